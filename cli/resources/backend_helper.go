@@ -54,28 +54,31 @@ const (
 
 // BackendConfiguration backend config
 type BackendConfiguration struct {
-	Name                string                   `json:"name,omitempty" yaml:"name"`
-	NameSpace           string                   `json:"namespace,omitempty" yaml:"namespace"`
-	Storage             string                   `json:"storage,omitempty" yaml:"storage"`
-	VstoreName          string                   `json:"vstoreName,omitempty" yaml:"vstoreName"`
-	AccountName         string                   `json:"accountName,omitempty" yaml:"accountName"`
-	Urls                []string                 `json:"urls,omitempty" yaml:"urls"`
-	StorageDeviceSN     string                   `json:"storageDeviceSN,omitempty" yaml:"storageDeviceSN"`
-	Pools               []string                 `json:"pools,omitempty" yaml:"pools"`
-	MetrovStorePairID   string                   `json:"metrovStorePairID,omitempty" yaml:"metrovStorePairID"`
-	MetroBackend        string                   `json:"metroBackend,omitempty" yaml:"metroBackend"`
-	SupportedTopologies []map[string]interface{} `json:"supportedTopologies,omitempty" yaml:"supportedTopologies"`
-	MaxClientThreads    string                   `json:"maxClientThreads,omitempty" yaml:"maxClientThreads"`
-	Configured          bool                     `json:"-" yaml:"configured"`
-	Provisioner         string                   `json:"provisioner,omitempty" yaml:"provisioner"`
-	AuthenticationMode  string                   `json:"-" yaml:"authenticationMode"`
-	Parameters          struct {
-		Protocol   string                            `json:"protocol,omitempty" yaml:"protocol"`
-		ParentName string                            `json:"parentname,omitempty" yaml:"parentname"`
-		DeviceWWN  string                            `json:"deviceWWN,omitempty" yaml:"deviceWWN"`
-		Portals    interface{}                       `json:"portals,omitempty" yaml:"portals"`
-		IscsiLinks string                            `json:"iscsiLinks,omitempty" yaml:"iscsiLinks"`
-		Alua       map[string]map[string]interface{} `json:"ALUA,omitempty" yaml:"ALUA"`
+	Name                   string                   `json:"name,omitempty" yaml:"name"`
+	NameSpace              string                   `json:"namespace,omitempty" yaml:"namespace"`
+	Storage                string                   `json:"storage,omitempty" yaml:"storage"`
+	VstoreName             string                   `json:"vstoreName,omitempty" yaml:"vstoreName"`
+	AccountName            string                   `json:"accountName,omitempty" yaml:"accountName"`
+	Urls                   []string                 `json:"urls,omitempty" yaml:"urls"`
+	StorageDeviceSN        string                   `json:"storageDeviceSN,omitempty" yaml:"storageDeviceSN"`
+	Pools                  []string                 `json:"pools,omitempty" yaml:"pools"`
+	MetrovStorePairID      string                   `json:"metrovStorePairID,omitempty" yaml:"metrovStorePairID"`
+	MetroBackend           string                   `json:"metroBackend,omitempty" yaml:"metroBackend"`
+	SupportedTopologies    []map[string]interface{} `json:"supportedTopologies,omitempty" yaml:"supportedTopologies"`
+	MaxClientThreads       string                   `json:"maxClientThreads,omitempty" yaml:"maxClientThreads"`
+	MaxTenantClientThreads string                   `json:"maxTenantClientThreads,omitempty" yaml:"maxTenantClientThreads"`
+	Configured             bool                     `json:"-" yaml:"configured"`
+	Provisioner            string                   `json:"provisioner,omitempty" yaml:"provisioner"`
+	AuthenticationMode     string                   `json:"-" yaml:"authenticationMode"`
+	Parameters             struct {
+		Protocol         string                            `json:"protocol,omitempty" yaml:"protocol"`
+		ParentName       string                            `json:"parentname,omitempty" yaml:"parentname"`
+		DeviceWWN        string                            `json:"deviceWWN,omitempty" yaml:"deviceWWN"`
+		Portals          interface{}                       `json:"portals,omitempty" yaml:"portals"`
+		IscsiLinks       string                            `json:"iscsiLinks,omitempty" yaml:"iscsiLinks"`
+		Alua             map[string]map[string]interface{} `json:"ALUA,omitempty" yaml:"ALUA"`
+		UnmapWaitTimeout string                            `json:"unmapWaitTimeout,omitempty" yaml:"unmapWaitTimeout"`
+		VolumeName       string                            `json:"volumeName,omitempty" yaml:"volumeName"`
 	} `json:"parameters,omitempty" yaml:"parameters"`
 }
 
@@ -28,6 +28,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -458,6 +459,7 @@ func getSessionIdByDevice(devPath string) (string, error) {
 func WatchDMDevice(ctx context.Context, lunWWN string, expectPathNumber int) (DMDeviceInfo, error) {
 	log.AddContext(ctx).Infof("Watch DM Disk Generation. lunWWN: %s,expectPathNumber: %d", lunWWN, expectPathNumber)
 	var timeout = time.After(time.Second * time.Duration(app.GetGlobalConfig().ScanVolumeTimeout))
+	var interval = getWatchDeviceInterval()
 	var dm DMDeviceInfo
 	var err = errors.New(VolumeNotFound)
 	for {
@@ -465,7 +467,7 @@ func WatchDMDevice(ctx context.Context, lunWWN string, expectPathNumber int) (DM
 		case <-timeout:
 			return dm, err
 		default:
-			time.Sleep(watchDeviceInterval)
+			time.Sleep(interval)
 		}
 
 		dm, err = findDMDeviceByWWN(ctx, lunWWN)
@@ -483,6 +485,16 @@ func WatchDMDevice(ctx context.Context, lunWWN string, expectPathNumber int) (DM
 	}
 }
 
+// getWatchDeviceInterval returns the configured interval for polling the DM device,
+// the default interval is used if it is not configured
+func getWatchDeviceInterval() time.Duration {
+	if interval := app.GetGlobalConfig().ScanVolumeInterval; interval > 0 {
+		return time.Duration(interval) * time.Millisecond
+	}
+
+	return watchDeviceInterval
+}
+
 func findDMDeviceByWWN(ctx context.Context, lunWWN string) (dm DMDeviceInfo, err error) {
 	var output string
 	output, err = utils.ExecShellCmd(ctx, "multipathd show maps")
@@ -1351,13 +1363,15 @@ func VerifyDeviceAvailableOfDM(ctx context.Context, tgtLunWWN string, expectPath
 		return dev, nil
 	}
 
+	discoveryErr := newDMDiscoveryError(err, tgtLunWWN, expectPathNumber, foundDevices, dm.Devices,
+		time.Now().Sub(start))
 	if err.Error() == VolumePathIncomplete {
 		_, rmErr := removeMultiPathDevice(ctx, dm.Sysfs, dm.Devices)
 		if rmErr != nil {
 			log.AddContext(ctx).Warningf("Failed to clear the DM disk. "+
 				"Sysfs:%s , devs: %v ,error:%v", dm.Sysfs, dm.Devices, rmErr)
 		}
-		return "", err
+		return "", discoveryErr
 	}
 
 	// No DM disk is found. Delete the corresponding SD disk. Otherwise, residual physical drive letters may occur.
@@ -1365,7 +1379,23 @@ func VerifyDeviceAvailableOfDM(ctx context.Context, tgtLunWWN string, expectPath
 	if rmErr := removeDevices(ctx, foundDevices); rmErr != nil {
 		log.AddContext(ctx).Errorf("clear devices %v error %v", foundDevices, rmErr)
 	}
-	return "", err
+	return "", discoveryErr
+}
+
+// newDMDiscoveryError returns the error of waiting for the DM device, the paths aggregated into the DM device
+// and the found paths which are not aggregated are listed, the reason of the error is kept as the prefix
+func newDMDiscoveryError(err error, tgtLunWWN string, expectPathNumber int,
+	foundDevices, dmDevices []string, elapsed time.Duration) error {
+	var missing []string
+	for _, device := range foundDevices {
+		if !slices.Contains(dmDevices, device) {
+			missing = append(missing, device)
+		}
+	}
+
+	return fmt.Errorf("%w: waited %s for the DM device of lun %s, expected %d paths, "+
+		"appeared paths %v, missing paths %v", err, elapsed.Round(time.Millisecond), tgtLunWWN,
+		expectPathNumber, dmDevices, missing)
 }
 
 // RemoveDevices is used to remove devices
@@ -1920,6 +1950,43 @@ func ReadMountPoints(ctx context.Context) (map[string]string, error) {
 	return mountMap, nil
 }
 
+// MountPointInfo is the information of a mount point in /proc/mounts
+type MountPointInfo struct {
+	Source  string
+	Target  string
+	FsType  string
+	Options []string
+}
+
+// GetMountPointInfo read mount file and return the mount point information of the target path.
+// nil will be returned if the target path is not mounted.
+var GetMountPointInfo = func(ctx context.Context, targetPath string) (*MountPointInfo, error) {
+	data, err := ConsistentRead(procMountsPath, maxListTries)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Read the mount file error: %v", err)
+		return nil, err
+	}
+
+	const mountFieldsLength = 4
+	var info *MountPointInfo
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < mountFieldsLength || fields[0] == "#" || fields[1] != targetPath {
+			continue
+		}
+
+		// the last matched one is the mount point that currently takes effect
+		info = &MountPointInfo{
+			Source:  fields[0],
+			Target:  fields[1],
+			FsType:  fields[2],
+			Options: strings.Split(fields[3], ","),
+		}
+	}
+
+	return info, nil
+}
+
 // ConsistentRead repeatedly reads a file until it gets the same content twice.
 // This is useful when reading files in /proc/mount that are larger than page size
 // and kernel may modify them between individual read() syscalls
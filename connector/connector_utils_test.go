@@ -30,6 +30,8 @@ import (
 	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app"
+	cfg "github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app/config"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 )
 
@@ -726,3 +728,33 @@ func TestRemoveWwnType(t *testing.T) {
 		})
 	}
 }
+
+func TestGetWatchDeviceInterval(t *testing.T) {
+	// arrange
+	unconfigured := cfg.MockCompletedConfig()
+	unconfigured.ScanVolumeInterval = 0
+
+	// action
+	configured := getWatchDeviceInterval()
+	stubs := gostub.StubFunc(&app.GetGlobalConfig, unconfigured)
+	fallback := getWatchDeviceInterval()
+	stubs.Reset()
+
+	// assert
+	assert.Equal(t, 100*time.Millisecond, configured)
+	assert.Equal(t, watchDeviceInterval, fallback)
+}
+
+func TestNewDMDiscoveryError(t *testing.T) {
+	// arrange
+	reason := errors.New(VolumePathIncomplete)
+
+	// action
+	err := newDMDiscoveryError(reason, "6582575100bc510f12345678000103e8", 3,
+		[]string{"sdb", "sdc", "sdd"}, []string{"sdb", "sdc"}, 1500*time.Millisecond)
+
+	// assert
+	assert.ErrorIs(t, err, reason)
+	assert.Equal(t, "VolumePathIncomplete: waited 1.5s for the DM device of lun 6582575100bc510f12345678000103e8, "+
+		"expected 3 paths, appeared paths [sdb sdc], missing paths [sdd]", err.Error())
+}
@@ -99,3 +99,67 @@ func Test_findDiskOfUltraPath_Success(t *testing.T) {
 	// assert
 	assert.Equal(t, wantDiskName, gotDiskName)
 }
+
+func Test_parseISCSIInfo_WithChap(t *testing.T) {
+	// arrange
+	connectionProperties := map[string]interface{}{
+		"tgtLunWWN":          "test_wwn",
+		"tgtPortals":         []string{"127.0.0.1"},
+		"tgtIQNs":            []string{"iqn.test"},
+		"tgtHostLUNs":        []string{"1"},
+		"volumeUseMultiPath": true,
+		"multiPathType":      connector.DMMultiPath,
+		"authUserName":       "chap-user",
+		"authPassword":       "chap-password",
+		"authMethod":         "CHAP",
+	}
+	var updated []string
+
+	// mock
+	patches := gomonkey.ApplyFunc(runISCSIAdmin,
+		func(_ context.Context, _, _, iscsiCMD string, _ []string) error {
+			updated = append(updated, iscsiCMD)
+			return nil
+		})
+	defer patches.Reset()
+
+	// act
+	info, err := parseISCSIInfo(context.Background(), connectionProperties)
+	updateErr := updateChapInfo(context.Background(), "127.0.0.1", "iqn.test", info.tgtChapInfo)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, updateErr)
+	assert.Equal(t, []string{
+		"--op update -n node.session.auth.authmethod -v CHAP",
+		"--op update -n node.session.auth.username -v chap-user",
+		"--op update -n node.session.auth.password -v chap-password",
+	}, updated)
+}
+
+func Test_parseISCSIInfo_WithoutChap(t *testing.T) {
+	// arrange
+	connectionProperties := map[string]interface{}{
+		"tgtLunWWN":          "test_wwn",
+		"tgtPortals":         []string{"127.0.0.1"},
+		"tgtIQNs":            []string{"iqn.test"},
+		"tgtHostLUNs":        []string{"1"},
+		"volumeUseMultiPath": true,
+		"multiPathType":      connector.DMMultiPath,
+		"authUserName":       "",
+		"authPassword":       "",
+		"authMethod":         "",
+	}
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(runISCSIAdmin, errors.New("should not update chap"))
+	defer patches.Reset()
+
+	// act
+	info, err := parseISCSIInfo(context.Background(), connectionProperties)
+	updateErr := updateChapInfo(context.Background(), "127.0.0.1", "iqn.test", info.tgtChapInfo)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, updateErr)
+}
@@ -0,0 +1,157 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package nfs
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+const (
+	// fsGroupChangePolicyAlways changes the ownership of the whole volume at every mount, it is the default
+	fsGroupChangePolicyAlways = "Always"
+	// fsGroupChangePolicyOnRootMismatch skips the recursive change if the root of the volume already matches
+	fsGroupChangePolicyOnRootMismatch = "OnRootMismatch"
+
+	// the group permissions granted to the files and the directories, which is the same as the kubelet
+	fsGroupFilePerm fs.FileMode = 0660
+	fsGroupDirPerm              = 0770 | fs.ModeSetgid
+)
+
+// parseNFSFSGroup validates the fsGroup and the fsGroupChangePolicy in the connection properties
+func parseNFSFSGroup(con *connectorInfo, connectionProperties map[string]interface{}) error {
+	value, _ := connectionProperties["fsGroup"].(string)
+	value = strings.TrimSpace(value)
+	policy, _ := connectionProperties["fsGroupChangePolicy"].(string)
+	policy = strings.TrimSpace(policy)
+	if value == "" {
+		return nil
+	}
+
+	fsGroup, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || fsGroup < 0 {
+		return fmt.Errorf("fsGroup %s is invalid, it must be a non-negative integer", value)
+	}
+
+	if policy == "" {
+		policy = fsGroupChangePolicyAlways
+	}
+
+	if policy != fsGroupChangePolicyAlways && policy != fsGroupChangePolicyOnRootMismatch {
+		return fmt.Errorf("fsGroupChangePolicy %s is invalid, it must be %s or %s",
+			policy, fsGroupChangePolicyAlways, fsGroupChangePolicyOnRootMismatch)
+	}
+
+	con.fsGroup = &fsGroup
+	con.fsGroupChangePolicy = policy
+	return nil
+}
+
+// applyFSGroup recursively applies the group ownership of the fsGroup to the mounted target path,
+// the recursive walk is skipped if the policy is OnRootMismatch and the root already matches
+func applyFSGroup(ctx context.Context, conn *connectorInfo) error {
+	if conn.fsGroup == nil {
+		return nil
+	}
+
+	if slices.Contains(strings.Split(conn.mntFlags.DashO, ","), readOnlyMountOption) {
+		log.AddContext(ctx).Infof("%s is mounted read-only, skip changing the ownership to fsGroup %d",
+			conn.targetPath, *conn.fsGroup)
+		return nil
+	}
+
+	gid := int(*conn.fsGroup)
+	if conn.fsGroupChangePolicy == fsGroupChangePolicyOnRootMismatch {
+		matched, err := isFSGroupRootMatched(conn.targetPath, gid)
+		if err != nil {
+			return err
+		}
+
+		if matched {
+			log.AddContext(ctx).Infof("the root of %s already matches fsGroup %d, skip the recursive change",
+				conn.targetPath, gid)
+			return nil
+		}
+	}
+
+	log.AddContext(ctx).Infof("start to change the ownership of %s to fsGroup %d", conn.targetPath, gid)
+	err := filepath.WalkDir(conn.targetPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		return changeFSGroup(path, entry, gid)
+	})
+	if err != nil {
+		return fmt.Errorf("change the ownership of %s to fsGroup %d failed, error: %w", conn.targetPath, gid, err)
+	}
+
+	log.AddContext(ctx).Infof("finish changing the ownership of %s to fsGroup %d", conn.targetPath, gid)
+	return nil
+}
+
+func changeFSGroup(path string, entry fs.DirEntry, gid int) error {
+	if err := os.Lchown(path, -1, gid); err != nil {
+		return err
+	}
+
+	// the permissions of the symbolic link are meaningless, and chmod follows the link
+	if entry.Type()&fs.ModeSymlink != 0 {
+		return nil
+	}
+
+	info, err := entry.Info()
+	if err != nil {
+		return err
+	}
+
+	perm := fsGroupFilePerm
+	if entry.IsDir() {
+		perm = fsGroupDirPerm
+	}
+
+	mode := info.Mode()
+	if mode&perm == perm {
+		return nil
+	}
+
+	return os.Chmod(path, mode|perm)
+}
+
+// isFSGroupRootMatched checks whether the root is owned by the gid with the group permissions of the kubelet
+func isFSGroupRootMatched(root string, gid int) (bool, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return false, err
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return false, fmt.Errorf("get the owner of %s failed", root)
+	}
+
+	return int(stat.Gid) == gid && info.Mode()&fsGroupDirPerm == fsGroupDirPerm, nil
+}
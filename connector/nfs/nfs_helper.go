@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -30,15 +32,20 @@ import (
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/connector"
 	connUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/connector/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
 const (
-	fsInfoSegment             = 2
-	targetMountPathPermission = 0750
-	unformattedFsCode         = 2
+	fsInfoSegment     = 2
+	unformattedFsCode = 2
+
+	readOnlyMountOption  = "ro"
+	readWriteMountOption = "rw"
+	bindMountOption      = "bind"
+	nfsFsTypePrefix      = "nfs"
 )
 
 type connectorInfo struct {
@@ -48,6 +55,23 @@ type connectorInfo struct {
 	fsType     string
 	mntFlags   connUtils.MountParam
 	accessMode csi.VolumeCapability_AccessMode_Mode
+
+	// subPath is the directory under the export that is mounted to the target path, the sourcePath contains it
+	subPath string
+
+	// xprtSec is the transport layer security policy of NFS over TLS, empty means cleartext
+	xprtSec string
+	// tlsServerName is the hostname that the server certificate is verified against
+	tlsServerName string
+	// nconnect is the number of TCP connections of the NFS mount, 0 means the default of the kernel
+	nconnect int
+	// fsGroup is the group that owns the mounted volume, nil means the ownership is not changed
+	fsGroup *int64
+	// fsGroupChangePolicy decides whether the ownership is changed when the root already matches the fsGroup
+	fsGroupChangePolicy string
+	// skipResize skips the resize when the device is already mounted to the target path, e.g. a repeated stage,
+	// the filesystem is grown when the device is mounted at the first time and by NodeExpandVolume afterwards
+	skipResize bool
 }
 
 func parseNFSInfo(ctx context.Context,
@@ -60,11 +84,10 @@ func parseNFSInfo(ctx context.Context,
 		return nil, errors.New(msg)
 	}
 
-	sourcePath, srcPathExist := connectionProperties["sourcePath"].(string)
-	if !srcPathExist || sourcePath == "" {
-		msg := "there are no source path in the connection info"
-		log.AddContext(ctx).Errorln(msg)
-		return nil, errors.New(msg)
+	sourcePath, subPath, err := parseNFSSourcePath(srcType, connectionProperties)
+	if err != nil {
+		log.AddContext(ctx).Errorln(err)
+		return nil, err
 	}
 
 	targetPath, tgtPathExist := connectionProperties["targetPath"].(string)
@@ -81,7 +104,21 @@ func parseNFSInfo(ctx context.Context,
 
 	accessMode, _ := connectionProperties["accessMode"].(csi.VolumeCapability_AccessMode_Mode)
 	mntDashO, _ := connectionProperties["mountFlags"].(string)
+	mntDashO = enforceReadOnlyMountOption(srcType, accessMode, strings.TrimSpace(mntDashO))
+	err = connUtils.ValidateMountOptions(mntDashO, strings.Split(app.GetGlobalConfig().DeniedMountFlags, ","))
+	if err != nil {
+		log.AddContext(ctx).Errorln(err)
+		return nil, err
+	}
+
 	protocol, _ := connectionProperties["protocol"].(string)
+	mountPermission, _ := connectionProperties["mountPermission"].(string)
+	permission, err := connUtils.ParseMountPermission(mountPermission)
+	if err != nil {
+		log.AddContext(ctx).Errorln(err)
+		return nil, err
+	}
+
 	var mntDashT string
 	if protocol == constants.ProtocolDpc {
 		mntDashT = constants.ProtocolDpc
@@ -93,20 +130,65 @@ func parseNFSInfo(ctx context.Context,
 
 	con.srcType = srcType
 	con.sourcePath = sourcePath
+	con.subPath = subPath
 	con.targetPath = targetPath
 	con.fsType = fsType
 	con.accessMode = accessMode
-	con.mntFlags = connUtils.MountParam{DashO: strings.TrimSpace(mntDashO), DashT: mntDashT}
+	con.skipResize, _ = connectionProperties["skipResize"].(bool)
+	con.mntFlags = connUtils.MountParam{DashO: mntDashO, DashT: mntDashT,
+		TargetPermission: permission}
+	if err = parseNFSTLSInfo(ctx, &con, connectionProperties); err != nil {
+		log.AddContext(ctx).Errorln(err)
+		return nil, err
+	}
+
+	if err = parseNFSNconnect(ctx, &con, connectionProperties); err != nil {
+		log.AddContext(ctx).Errorln(err)
+		return nil, err
+	}
+
+	if err = parseNFSFSGroup(&con, connectionProperties); err != nil {
+		log.AddContext(ctx).Errorln(err)
+		return nil, err
+	}
 
 	return &con, nil
 }
 
+// enforceReadOnlyMountOption mounts the filesystem of the read-only access mode with ro, so that the mount is
+// read-only on the client side instead of relying on the server. The node stage already appends ro to the
+// mount flags of the read-only access mode, so a user-supplied rw is dropped here to let ro win.
+func enforceReadOnlyMountOption(srcType string, accessMode csi.VolumeCapability_AccessMode_Mode,
+	mntDashO string) string {
+	if srcType != connector.MountFSType || utils.GetAccessModeType(accessMode) != "ReadOnly" {
+		return mntDashO
+	}
+
+	var options []string
+	for _, option := range strings.Split(mntDashO, ",") {
+		if option != "" && option != readWriteMountOption && option != readOnlyMountOption {
+			options = append(options, option)
+		}
+	}
+
+	return strings.Join(append(options, readOnlyMountOption), ",")
+}
+
 func tryConnectVolume(ctx context.Context, connMap map[string]interface{}) (string, error) {
 	conn, err := parseNFSInfo(ctx, connMap)
 	if err != nil {
 		return "", err
 	}
 
+	if isParallelFS(conn) {
+		return "", connectParallelFS(ctx, conn)
+	}
+
+	mounted, err := isExpectedMountExist(ctx, conn)
+	if err != nil {
+		return "", err
+	}
+
 	switch conn.srcType {
 	case "block":
 		_, err = connector.ReadDevice(ctx, conn.sourcePath)
@@ -114,22 +196,136 @@ func tryConnectVolume(ctx context.Context, connMap map[string]interface{}) (stri
 			return "", err
 		}
 
-		err = mountDisk(ctx, conn)
+		if mounted && conn.skipResize {
+			log.AddContext(ctx).Infof("Skip resizing mount path %s, the device is already mounted",
+				conn.targetPath)
+		} else if mounted {
+			err = resizeMountedDisk(ctx, conn)
+		} else {
+			err = mountDisk(ctx, conn)
+		}
 		if err != nil {
 			return "", err
 		}
 	case "fs":
-		err = mountFS(ctx, conn.sourcePath, conn.targetPath, conn.mntFlags)
+		if mounted {
+			break
+		}
+
+		err = mountNFSSource(ctx, conn)
 		if err != nil {
-			return "", err
+			return "", wrapNconnectMountError(conn, wrapNFSTLSMountError(conn, err))
 		}
 	default:
 		return "", errors.New("not support source type")
 	}
-	return "", nil
+	return "", applyFSGroup(ctx, conn)
+}
+
+func isParallelFS(conn *connectorInfo) bool {
+	return conn.mntFlags.DashT == constants.ProtocolDpc || conn.mntFlags.DashT == constants.ProtocolDtfs
+}
+
+// connectParallelFS mounts the DPC or DTFS filesystem by its own client. The fsType is ignored since the
+// parallel filesystem is never formatted on the host.
+func connectParallelFS(ctx context.Context, conn *connectorInfo) error {
+	if conn.srcType != connector.MountFSType {
+		msg := fmt.Sprintf("source type %s is not supported by protocol %s", conn.srcType, conn.mntFlags.DashT)
+		log.AddContext(ctx).Errorln(msg)
+		return errors.New(msg)
+	}
+
+	mounted, err := isExpectedMountExist(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	if mounted {
+		return nil
+	}
+
+	return mountNFSSource(ctx, conn)
+}
+
+// isExpectedMountExist checks whether the source is already mounted to the target with the expected type
+// and read-only option, so a retried stage can return early. An error will be returned if the target is
+// mounted by a different source or type.
+func isExpectedMountExist(ctx context.Context, conn *connectorInfo) (bool, error) {
+	var options []string
+	if conn.mntFlags.DashO != "" {
+		options = strings.Split(conn.mntFlags.DashO, ",")
+	}
+
+	// the source of a bind mount in /proc/mounts is the original device, let the mount compare it
+	if slices.Contains(options, bindMountOption) {
+		return false, nil
+	}
+
+	info, err := connector.GetMountPointInfo(ctx, conn.targetPath)
+	if err != nil {
+		return false, err
+	}
+
+	if info == nil {
+		return false, nil
+	}
+
+	if !isSameMountSource(conn, info.Source) {
+		return false, fmt.Errorf("target path %s is already mounted by source %s, but expected source is %s",
+			conn.targetPath, info.Source, conn.sourcePath)
+	}
+
+	if !isSameMountType(conn, info.FsType) {
+		return false, fmt.Errorf("target path %s is already mounted with type %s, which is not expected",
+			conn.targetPath, info.FsType)
+	}
+
+	if slices.Contains(options, readOnlyMountOption) != slices.Contains(info.Options, readOnlyMountOption) {
+		return false, fmt.Errorf("target path %s is already mounted with options %v, but expected options are %v",
+			conn.targetPath, info.Options, options)
+	}
+
+	log.AddContext(ctx).Infof("%s is already mounted to %s with type %s, skip mounting",
+		conn.sourcePath, conn.targetPath, info.FsType)
+	return true, nil
 }
 
-func preMount(sourcePath, targetPath string, checkSourcePath bool) error {
+func isSameMountSource(conn *connectorInfo, mountSource string) bool {
+	if mountSource == conn.sourcePath {
+		return true
+	}
+
+	if conn.srcType != connector.MountBlockType {
+		return false
+	}
+
+	// the mount source is like /dev/mapper/mpath<x>, but the source path is like /dev/dm-<n>
+	mountRealPath, err := filepath.EvalSymlinks(mountSource)
+	if err != nil {
+		return false
+	}
+
+	sourceRealPath, err := filepath.EvalSymlinks(conn.sourcePath)
+	if err != nil {
+		return false
+	}
+
+	return mountRealPath == sourceRealPath
+}
+
+func isSameMountType(conn *connectorInfo, mountType string) bool {
+	if conn.srcType == connector.MountBlockType {
+		return mountType == conn.fsType
+	}
+
+	if conn.mntFlags.DashT != "" {
+		return mountType == conn.mntFlags.DashT
+	}
+
+	return strings.HasPrefix(mountType, nfsFsTypePrefix)
+}
+
+func preMount(sourcePath, targetPath string, checkSourcePath bool, permission os.FileMode) error {
 	if checkSourcePath {
 		if _, err := os.Stat(sourcePath); err != nil && os.IsNotExist(err) {
 			return errors.New("source path does not exist")
@@ -137,7 +333,7 @@ func preMount(sourcePath, targetPath string, checkSourcePath bool) error {
 	}
 
 	if _, err := os.Stat(targetPath); err != nil && os.IsNotExist(err) {
-		if err := os.MkdirAll(targetPath, targetMountPathPermission); err != nil {
+		if err := os.MkdirAll(targetPath, permission); err != nil {
 			return errors.New("can not create a target path")
 		}
 	}
@@ -276,26 +472,39 @@ func mountDisk(ctx context.Context, conn *connectorInfo) error {
 			return err
 		}
 	} else {
+		if existFsType != conn.fsType {
+			msg := fmt.Sprintf("the existing filesystem type %s of device %s mismatches the requested "+
+				"filesystem type %s, refuse to mount it to %s", existFsType, conn.sourcePath, conn.fsType,
+				conn.targetPath)
+			log.AddContext(ctx).Errorln(msg)
+			return errors.New(msg)
+		}
+
 		err = connUtils.MountToDir(ctx, conn.sourcePath, conn.targetPath, conn.mntFlags, true)
 		if err != nil {
 			return err
 		}
 
-		if conn.accessMode == csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER {
-			log.AddContext(ctx).Infoln("PVC accessMode is ReadWriteMany, not support to expend filesystem")
-			return nil
-		}
+		return resizeMountedDisk(ctx, conn)
+	}
+	return nil
+}
 
-		if conn.accessMode == csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY {
-			log.AddContext(ctx).Infoln("PVC accessMode is ReadOnlyMany, no need to expend filesystem")
-			return nil
-		}
+func resizeMountedDisk(ctx context.Context, conn *connectorInfo) error {
+	if conn.accessMode == csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER {
+		log.AddContext(ctx).Infoln("PVC accessMode is ReadWriteMany, not support to expend filesystem")
+		return nil
+	}
 
-		err = connector.ResizeMountPath(ctx, conn.targetPath)
-		if err != nil {
-			log.AddContext(ctx).Errorf("Resize mount path %s err %s", conn.targetPath, err)
-			return err
-		}
+	if conn.accessMode == csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY {
+		log.AddContext(ctx).Infoln("PVC accessMode is ReadOnlyMany, no need to expend filesystem")
+		return nil
+	}
+
+	err := connector.ResizeMountPath(ctx, conn.targetPath)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Resize mount path %s err %s", conn.targetPath, err)
+		return err
 	}
 	return nil
 }
@@ -323,7 +532,15 @@ func removeTargetPath(targetPath string) error {
 func tryDisConnectVolume(ctx context.Context, targetPath string) error {
 	err := connUtils.Unmount(ctx, targetPath)
 	if err != nil {
-		return err
+		if !connUtils.IsUnmountBusyOrTimeout(err) || !app.GetGlobalConfig().EnableLazyUnmount {
+			return err
+		}
+
+		log.AddContext(ctx).Warningf("Unmount %s failed, error: %v, fall back to lazy unmount", targetPath, err)
+		if err = connUtils.LazyUnmount(ctx, targetPath); err != nil {
+			return err
+		}
+		log.AddContext(ctx).Infof("Lazy unmount %s success", targetPath)
 	}
 
 	return removeTargetPath(targetPath)
@@ -0,0 +1,144 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package nfs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/connector"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+const (
+	minNconnect = 1
+	maxNconnect = 16
+
+	// the nconnect mount option is supported since linux 5.3
+	minNconnectKernelMajor = 5
+	minNconnectKernelMinor = 3
+)
+
+// unsupportedMountOptionKeywords are the messages of mount.nfs and the kernel when a mount option is not supported
+var unsupportedMountOptionKeywords = []string{"incorrect mount option", "invalid argument"}
+
+// getKernelMaxNconnect returns the maximum nconnect supported by the kernel of the host, 0 means not supported
+var getKernelMaxNconnect = func(ctx context.Context) (int, error) {
+	_, major, minor, err := getKernelVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if major < minNconnectKernelMajor || (major == minNconnectKernelMajor && minor < minNconnectKernelMinor) {
+		return 0, nil
+	}
+
+	return maxNconnect, nil
+}
+
+// parseNFSNconnect validates the nconnect in the connection properties and merges it into the mount options
+func parseNFSNconnect(ctx context.Context, con *connectorInfo, connectionProperties map[string]interface{}) error {
+	value, _ := connectionProperties["nconnect"].(string)
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	nconnect, err := strconv.Atoi(value)
+	if err != nil || nconnect < minNconnect || nconnect > maxNconnect {
+		return fmt.Errorf("nconnect %s is invalid, it must be an integer between %d and %d",
+			value, minNconnect, maxNconnect)
+	}
+
+	if con.srcType != connector.MountFSType || con.mntFlags.DashT != "" {
+		return fmt.Errorf("nconnect %d is only supported by NFS", nconnect)
+	}
+
+	if _, exist := getMountOption(con.mntFlags.DashO, "nconnect"); exist {
+		return fmt.Errorf("nconnect %d conflicts with the nconnect in mountFlags %s", nconnect, con.mntFlags.DashO)
+	}
+
+	if version := getNFSVersion(con.mntFlags.DashO); version == "2" || version == "4.0" {
+		return fmt.Errorf("nconnect %d is incompatible with NFS version %s, NFSv3 or NFSv4.1 and later is required",
+			nconnect, version)
+	}
+
+	kernelMax, err := getKernelMaxNconnect(ctx)
+	if err != nil {
+		log.AddContext(ctx).Warningf("Get the maximum nconnect supported by the kernel failed, error: %v", err)
+	} else if nconnect > kernelMax {
+		log.AddContext(ctx).Warningf("nconnect %d exceeds the maximum %d supported by the kernel, "+
+			"the mount may fail or the nconnect may be ignored", nconnect, kernelMax)
+	}
+
+	con.nconnect = nconnect
+	nconnectOption := "nconnect=" + value
+	if con.mntFlags.DashO == "" {
+		con.mntFlags.DashO = nconnectOption
+	} else {
+		con.mntFlags.DashO += "," + nconnectOption
+	}
+
+	return nil
+}
+
+// getNFSVersion returns the NFS version in the mount options, empty means the version is negotiated
+func getNFSVersion(options string) string {
+	version, exist := getMountOption(options, "vers")
+	if !exist {
+		version, _ = getMountOption(options, "nfsvers")
+	}
+
+	return version
+}
+
+func getMountOption(options, key string) (string, bool) {
+	for _, option := range strings.Split(options, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		if name == key {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// isUnsupportedMountOptionError checks whether the mount failed because the server or the kernel rejected an option
+func isUnsupportedMountOptionError(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, keyword := range unsupportedMountOptionKeywords {
+		if strings.Contains(message, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wrapNconnectMountError hints the NFS version only if the version is negotiated and the mount options are rejected,
+// because the kernel refuses nconnect when NFSv4.0 is negotiated
+func wrapNconnectMountError(conn *connectorInfo, err error) error {
+	if err == nil || conn.nconnect == 0 || getNFSVersion(conn.mntFlags.DashO) != "" ||
+		!isUnsupportedMountOptionError(err) {
+		return err
+	}
+
+	return fmt.Errorf("mount %s with nconnect=%d failed, nconnect is incompatible with NFSv4.0, "+
+		"please check the NFS version negotiated with the server, error: %w", conn.sourcePath, conn.nconnect, err)
+}
@@ -0,0 +1,153 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package nfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/connector"
+	connUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/connector/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+// exportMountPattern is the pattern of the temporary directory that the export root is mounted to
+const exportMountPattern = ".export-"
+
+// parseNFSSourcePath returns the path to mount, which is the sourcePath, or the subPath under the exportPath
+// if the exportPath is specified, so that many volumes can be carved out of the subdirectories of one filesystem.
+// The cleaned subPath is returned as well, so that it can be resolved inside the export when mounting.
+func parseNFSSourcePath(srcType string, connectionProperties map[string]interface{}) (string, string, error) {
+	sourcePath, _ := connectionProperties["sourcePath"].(string)
+	exportPath, _ := connectionProperties["exportPath"].(string)
+	exportPath = strings.TrimSpace(exportPath)
+	if exportPath == "" {
+		if sourcePath == "" {
+			return "", "", errors.New("there are no source path in the connection info")
+		}
+
+		return sourcePath, "", nil
+	}
+
+	if sourcePath != "" {
+		return "", "", fmt.Errorf("sourcePath %s conflicts with exportPath %s", sourcePath, exportPath)
+	}
+
+	if srcType != connector.MountFSType {
+		return "", "", fmt.Errorf("exportPath %s is only supported by the filesystem", exportPath)
+	}
+
+	subPath, _ := connectionProperties["subPath"].(string)
+	subPath = strings.TrimSpace(subPath)
+	if subPath == "" {
+		return exportPath, "", nil
+	}
+
+	// the subPath must stay inside the export, e.g. "../other" or "/other" are refused
+	if !filepath.IsLocal(subPath) {
+		return "", "", fmt.Errorf("subPath %s is invalid, it must be a relative path inside the export", subPath)
+	}
+
+	subPath = path.Clean(subPath)
+	return strings.TrimSuffix(exportPath, "/") + "/" + subPath, subPath, nil
+}
+
+// mountNFSSource mounts the source path to the target path. The subPath is not mounted by its path directly,
+// since a symlink in the subPath would be followed out of the export, which filepath.IsLocal can not detect.
+func mountNFSSource(ctx context.Context, conn *connectorInfo) error {
+	if conn.subPath == "" {
+		return mountFS(ctx, conn.sourcePath, conn.targetPath, conn.mntFlags)
+	}
+
+	return mountNFSSubPath(ctx, conn)
+}
+
+// mountNFSSubPath mounts the export root to a temporary directory, opens the subPath inside it without following
+// any symlink out of the export, and bind mounts the opened directory to the target path
+func mountNFSSubPath(ctx context.Context, conn *connectorInfo) error {
+	exportRoot := strings.TrimSuffix(conn.sourcePath, "/"+conn.subPath)
+	exportMount, err := os.MkdirTemp(filepath.Dir(conn.targetPath), exportMountPattern)
+	if err != nil {
+		return fmt.Errorf("create the temporary mount path of export %s failed, error: %w", exportRoot, err)
+	}
+	defer func() {
+		if err := os.Remove(exportMount); err != nil {
+			log.AddContext(ctx).Warningf("Remove the temporary mount path %s failed, error: %v", exportMount, err)
+		}
+	}()
+
+	if err = mountFS(ctx, exportRoot, exportMount, conn.mntFlags); err != nil {
+		return err
+	}
+	defer func() {
+		// the bind mount keeps the export mounted, only the temporary mount point is removed
+		if err := connUtils.Unmount(ctx, exportMount); err != nil {
+			log.AddContext(ctx).Warningf("Unmount the temporary mount path %s failed, error: %v", exportMount, err)
+		}
+	}()
+
+	subDir, err := openSubPath(exportMount, conn.subPath)
+	if err != nil {
+		return fmt.Errorf("open subPath %s of export %s failed, error: %w", conn.subPath, exportRoot, err)
+	}
+	defer subDir.Close()
+
+	bindOptions := []string{bindMountOption}
+	if slices.Contains(strings.Split(conn.mntFlags.DashO, ","), readOnlyMountOption) {
+		bindOptions = append(bindOptions, readOnlyMountOption)
+	}
+
+	// the mount command runs in another process, so the opened directory is referred by the fd of this process
+	openedPath := fmt.Sprintf("/proc/%d/fd/%d", os.Getpid(), subDir.Fd())
+	return mountFS(ctx, openedPath, conn.targetPath, connUtils.MountParam{
+		DashO:            strings.Join(bindOptions, ","),
+		TargetPermission: conn.mntFlags.TargetPermission,
+	})
+}
+
+// openSubPath opens the directory of the subPath under the root, the symlinks are only followed inside the root
+func openSubPath(root, subPath string) (*os.File, error) {
+	exportRoot, err := os.OpenRoot(root)
+	if err != nil {
+		return nil, err
+	}
+	defer exportRoot.Close()
+
+	subDir, err := exportRoot.Open(subPath)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := subDir.Stat()
+	if err != nil {
+		subDir.Close()
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		subDir.Close()
+		return nil, fmt.Errorf("%s is not a directory", subPath)
+	}
+
+	return subDir, nil
+}
@@ -21,12 +21,21 @@ import (
 	"errors"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/agiledragon/gomonkey/v2"
+	"github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/prashantv/gostub"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/connector"
+	connUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/connector/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app"
+	cfg "github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app/config"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
@@ -37,7 +46,7 @@ const (
 
 func testExecShellCmd(_ context.Context, format string, args ...interface{}) (string, error) {
 	if format == "blkid -o udev %s" {
-		return "ID_FS_TYPE=xxx\n", nil
+		return "ID_FS_TYPE=ext4\n", nil
 	}
 
 	if args[0] == "err-targetPath" {
@@ -55,7 +64,10 @@ func TestConnectVolume(t *testing.T) {
 	defer utils.RemoveDir("test-sourcePath", "test-sourcePath")
 	defer utils.RemoveDir("test-targetPath", "test-targetPath")
 
-	stubs := gostub.StubFunc(&connector.ReadDevice, []byte{}, nil)
+	config := cfg.MockCompletedConfig()
+	config.DeniedMountFlags = "suid"
+	stubs := gostub.StubFunc(&app.GetGlobalConfig, config)
+	stubs.StubFunc(&connector.ReadDevice, []byte{}, nil)
 	stubs.StubFunc(&utils.PathExist, true, nil)
 	stubs.StubFunc(&connector.ResizeMountPath, nil)
 	stubs.StubFunc(&connector.IsInFormatting, false, nil)
@@ -98,6 +110,8 @@ func getConnectVolumeTests() []struct {
 	var ctx = context.TODO()
 	var blockConnMap = map[string]any{"srcType": "block", "sourcePath": "test-sourcePath",
 		"targetPath": "test-targetPath", "fsType": "", "mountFlags": ""}
+	var fsTypeMismatchMap = map[string]any{"srcType": "block", "sourcePath": "test-sourcePath",
+		"targetPath": "test-targetPath", "fsType": "xfs", "mountFlags": ""}
 	var existFsTypeIsEmptyMap = map[string]any{"srcType": "block", "sourcePath": "sourcePath",
 		"targetPath": "test-targetPath", "fsType": "", "mountFlags": ""}
 	var fsConnMap = map[string]any{"srcType": "fs", "sourcePath": "test-sourcePath",
@@ -106,6 +120,20 @@ func getConnectVolumeTests() []struct {
 	var emptySrcTypeMap = map[string]any{"srcType": ""}
 	var emptySourcePathMap = map[string]any{"srcType": "block", "sourcePath": ""}
 	var emptyTargetPathMap = map[string]any{"srcType": "fs", "sourcePath": "testSourcePath", "targetPath": ""}
+	var invalidPermissionMap = map[string]any{"srcType": "fs", "sourcePath": "test-sourcePath",
+		"targetPath": "test", "mountPermission": "0999"}
+	var outOfRangePermissionMap = map[string]any{"srcType": "fs", "sourcePath": "test-sourcePath",
+		"targetPath": "test", "mountPermission": "10755"}
+	var customPermissionMap = map[string]any{"srcType": "fs", "sourcePath": "test-sourcePath",
+		"targetPath": "test", "mountPermission": "0755"}
+	var emptyMountFlagMap = map[string]any{"srcType": "fs", "sourcePath": "test-sourcePath",
+		"targetPath": "test", "mountFlags": "nfsvers=3,,noexec"}
+	var deniedMountFlagMap = map[string]any{"srcType": "fs", "sourcePath": "test-sourcePath",
+		"targetPath": "test", "mountFlags": "nfsvers=3,suid"}
+	var conflictMountFlagMap = map[string]any{"srcType": "fs", "sourcePath": "test-sourcePath",
+		"targetPath": "test", "mountFlags": "exec,noexec"}
+	var illegalMountFlagMap = map[string]any{"srcType": "fs", "sourcePath": "test-sourcePath",
+		"targetPath": "test", "mountFlags": "nfsvers=3;reboot"}
 
 	return []struct {
 		name    string
@@ -119,7 +147,15 @@ func getConnectVolumeTests() []struct {
 		{"SrcTypeIsOther", args{ctx, otherSrcTypeMap}, "", true},
 		{"SrcTypeIsFS", args{ctx, fsConnMap}, "", false},
 		{"SrcTypeIsBlock", args{ctx, blockConnMap}, "", false},
+		{"FsTypeMismatch", args{ctx, fsTypeMismatchMap}, "", true},
 		{"ExistFsTypeIsEmpty", args{ctx, existFsTypeIsEmptyMap}, "", true},
+		{"InvalidMountPermission", args{ctx, invalidPermissionMap}, "", true},
+		{"OutOfRangeMountPermission", args{ctx, outOfRangePermissionMap}, "", true},
+		{"CustomMountPermission", args{ctx, customPermissionMap}, "", false},
+		{"EmptyMountFlag", args{ctx, emptyMountFlagMap}, "", true},
+		{"DeniedMountFlag", args{ctx, deniedMountFlagMap}, "", true},
+		{"ConflictMountFlag", args{ctx, conflictMountFlagMap}, "", true},
+		{"IllegalMountFlag", args{ctx, illegalMountFlagMap}, "", true},
 	}
 }
 
@@ -164,9 +200,510 @@ func TestDisConnectVolume(t *testing.T) {
 	}
 }
 
+func TestConnectVolumeAlreadyMounted(t *testing.T) {
+	var ctx = context.TODO()
+	var fsConnMap = map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+		"targetPath": "test-targetPath", "mountFlags": "ro"}
+	tests := []struct {
+		name    string
+		info    *connector.MountPointInfo
+		wantErr bool
+	}{
+		{"SameSourceAndType", &connector.MountPointInfo{Source: "127.0.0.1:/share", Target: "test-targetPath",
+			FsType: "nfs4", Options: []string{"ro", "vers=4.1"}}, false},
+		{"DifferentSource", &connector.MountPointInfo{Source: "127.0.0.1:/other", Target: "test-targetPath",
+			FsType: "nfs4", Options: []string{"ro"}}, true},
+		{"DifferentType", &connector.MountPointInfo{Source: "127.0.0.1:/share", Target: "test-targetPath",
+			FsType: "ext4", Options: []string{"ro"}}, true},
+		{"DifferentReadOnlyOption", &connector.MountPointInfo{Source: "127.0.0.1:/share",
+			Target: "test-targetPath", FsType: "nfs", Options: []string{"rw"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubs := gostub.StubFunc(&connector.GetMountPointInfo, tt.info, nil)
+			defer stubs.Reset()
+			stubs.StubFunc(&app.GetGlobalConfig, cfg.MockCompletedConfig())
+			stubs.Stub(&utils.ExecShellCmd, func(_ context.Context, format string, _ ...interface{}) (string, error) {
+				t.Errorf("unexpected command %s is executed", format)
+				return "", nil
+			})
+
+			nfs := &Connector{}
+			if _, err := nfs.ConnectVolume(ctx, fsConnMap); (err != nil) != tt.wantErr {
+				t.Errorf("ConnectVolume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConnectVolumeParallelFS(t *testing.T) {
+	var ctx = context.TODO()
+	tests := []struct {
+		name      string
+		conn      map[string]any
+		wantMount bool
+		wantErr   bool
+	}{
+		{"DpcIgnoreFsType", map[string]any{"srcType": "fs", "sourcePath": "/share", "targetPath": "test-targetPath",
+			"fsType": "xfs", "protocol": constants.ProtocolDpc}, true, false},
+		{"DtfsIgnoreFsType", map[string]any{"srcType": "fs", "sourcePath": "/share", "targetPath": "test-targetPath",
+			"fsType": "ext4", "protocol": constants.ProtocolDtfs}, true, false},
+		{"DpcBlockNotSupported", map[string]any{"srcType": "block", "sourcePath": "/dev/sdb",
+			"targetPath": "test-targetPath", "fsType": "xfs", "protocol": constants.ProtocolDpc}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mounted bool
+			stubs := gostub.StubFunc(&connector.GetMountPointInfo, (*connector.MountPointInfo)(nil), nil)
+			defer stubs.Reset()
+			stubs.StubFunc(&app.GetGlobalConfig, cfg.MockCompletedConfig())
+			stubs.Stub(&utils.ExecShellCmd, func(_ context.Context, format string, _ ...interface{}) (string, error) {
+				if strings.HasPrefix(format, "mkfs") || strings.HasPrefix(format, "blkid") {
+					t.Errorf("unexpected command %s is executed", format)
+				}
+				return "", nil
+			})
+			patches := gomonkey.ApplyFunc(connUtils.MountToDir, func(_ context.Context, _, _ string,
+				flags connUtils.MountParam, _ bool) error {
+				mounted = flags.DashT == tt.conn["protocol"]
+				return nil
+			})
+			defer patches.Reset()
+
+			nfs := &Connector{}
+			if _, err := nfs.ConnectVolume(ctx, tt.conn); (err != nil) != tt.wantErr {
+				t.Errorf("ConnectVolume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if mounted != tt.wantMount {
+				t.Errorf("ConnectVolume() mounted = %v, want %v", mounted, tt.wantMount)
+			}
+		})
+	}
+}
+
+func TestConnectVolumeNFSTLS(t *testing.T) {
+	var ctx = context.TODO()
+	tests := []struct {
+		name       string
+		conn       map[string]any
+		supportErr error
+		wantSource string
+		wantOption string
+		wantErr    bool
+	}{
+		{"TLSWithServerName", map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+			"targetPath": "test-targetPath", "mountFlags": "nfsvers=4.2", "tlsServerName": "nfs.example.com"},
+			nil, "nfs.example.com:/share", "nfsvers=4.2,xprtsec=tls", false},
+		{"MTLSWithoutServerName", map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+			"targetPath": "test-targetPath", "xprtsec": "mtls"}, nil, "127.0.0.1:/share", "xprtsec=mtls", false},
+		{"InvalidXprtSec", map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+			"targetPath": "test-targetPath", "xprtsec": "ssl"}, nil, "", "", true},
+		{"TLSNotSupported", map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+			"targetPath": "test-targetPath", "xprtsec": "tls"}, errors.New("old kernel"), "", "", true},
+		{"TLSWithDpc", map[string]any{"srcType": "fs", "sourcePath": "/share", "targetPath": "test-targetPath",
+			"xprtsec": "tls", "protocol": constants.ProtocolDpc}, nil, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSource, gotOption string
+			stubs := gostub.StubFunc(&connector.GetMountPointInfo, (*connector.MountPointInfo)(nil), nil)
+			defer stubs.Reset()
+			stubs.StubFunc(&app.GetGlobalConfig, cfg.MockCompletedConfig())
+			stubs.StubFunc(&checkNFSTLSSupport, tt.supportErr)
+			patches := gomonkey.ApplyFunc(connUtils.MountToDir, func(_ context.Context, sourcePath, _ string,
+				flags connUtils.MountParam, _ bool) error {
+				gotSource, gotOption = sourcePath, flags.DashO
+				return nil
+			})
+			defer patches.Reset()
+
+			nfs := &Connector{}
+			if _, err := nfs.ConnectVolume(ctx, tt.conn); (err != nil) != tt.wantErr {
+				t.Errorf("ConnectVolume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotSource != tt.wantSource || gotOption != tt.wantOption {
+				t.Errorf("ConnectVolume() mount %s with %s, want %s with %s",
+					gotSource, gotOption, tt.wantSource, tt.wantOption)
+			}
+		})
+	}
+}
+
+func TestConnectVolumeNconnect(t *testing.T) {
+	var ctx = context.TODO()
+	tests := []struct {
+		name       string
+		conn       map[string]any
+		wantOption string
+		wantErr    bool
+	}{
+		{"NconnectWithVersion", map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+			"targetPath": "test-targetPath", "mountFlags": "nfsvers=4.1", "nconnect": "8"},
+			"nfsvers=4.1,nconnect=8", false},
+		{"NconnectWithoutMountFlags", map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+			"targetPath": "test-targetPath", "nconnect": "16"}, "nconnect=16", false},
+		{"NconnectOutOfRange", map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+			"targetPath": "test-targetPath", "nconnect": "17"}, "", true},
+		{"NconnectNotInteger", map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+			"targetPath": "test-targetPath", "nconnect": "four"}, "", true},
+		{"NconnectWithNFSv40", map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+			"targetPath": "test-targetPath", "mountFlags": "vers=4.0", "nconnect": "4"}, "", true},
+		{"NconnectConflictsWithMountFlags", map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+			"targetPath": "test-targetPath", "mountFlags": "nconnect=2", "nconnect": "4"}, "", true},
+		{"NconnectWithDpc", map[string]any{"srcType": "fs", "sourcePath": "/share", "targetPath": "test-targetPath",
+			"nconnect": "4", "protocol": constants.ProtocolDpc}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOption string
+			stubs := gostub.StubFunc(&connector.GetMountPointInfo, (*connector.MountPointInfo)(nil), nil)
+			defer stubs.Reset()
+			stubs.StubFunc(&app.GetGlobalConfig, cfg.MockCompletedConfig())
+			stubs.StubFunc(&getKernelMaxNconnect, maxNconnect, nil)
+			patches := gomonkey.ApplyFunc(connUtils.MountToDir, func(_ context.Context, _, _ string,
+				flags connUtils.MountParam, _ bool) error {
+				gotOption = flags.DashO
+				return nil
+			})
+			defer patches.Reset()
+
+			nfs := &Connector{}
+			if _, err := nfs.ConnectVolume(ctx, tt.conn); (err != nil) != tt.wantErr {
+				t.Errorf("ConnectVolume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotOption != tt.wantOption {
+				t.Errorf("ConnectVolume() mount with %s, want %s", gotOption, tt.wantOption)
+			}
+		})
+	}
+}
+
+func TestConnectVolumeReadOnly(t *testing.T) {
+	var ctx = context.TODO()
+	tests := []struct {
+		name       string
+		accessMode csi.VolumeCapability_AccessMode_Mode
+		mountFlags string
+		wantOption string
+		wantErr    bool
+	}{
+		{"ReadOnlyMany", csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY, "", "ro", false},
+		{"ReadOnlyManyWithMountFlags", csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY,
+			"nfsvers=3", "nfsvers=3,ro", false},
+		{"ReadOnlyManyWithReadOnlyMountFlag", csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY,
+			"ro,nfsvers=3", "nfsvers=3,ro", false},
+		{"ReadOnlyManyWithReadWriteMountFlag", csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY,
+			"rw,nfsvers=3", "nfsvers=3,ro", false},
+		{"ReadOnlyManyWithNodeStageMountFlags", csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY,
+			"rw,nfsvers=3,ro", "nfsvers=3,ro", false},
+		{"ReadWriteMany", csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER, "nfsvers=3", "nfsvers=3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOption string
+			stubs := gostub.StubFunc(&connector.GetMountPointInfo, (*connector.MountPointInfo)(nil), nil)
+			defer stubs.Reset()
+			stubs.StubFunc(&app.GetGlobalConfig, cfg.MockCompletedConfig())
+			patches := gomonkey.ApplyFunc(connUtils.MountToDir, func(_ context.Context, _, _ string,
+				flags connUtils.MountParam, _ bool) error {
+				gotOption = flags.DashO
+				return nil
+			})
+			defer patches.Reset()
+
+			nfs := &Connector{}
+			_, err := nfs.ConnectVolume(ctx, map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+				"targetPath": "test-targetPath", "mountFlags": tt.mountFlags, "accessMode": tt.accessMode})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConnectVolume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotOption != tt.wantOption {
+				t.Errorf("ConnectVolume() mount with %s, want %s", gotOption, tt.wantOption)
+			}
+		})
+	}
+}
+
+func TestConnectVolumeSubPath(t *testing.T) {
+	var ctx = context.TODO()
+	tests := []struct {
+		name        string
+		conn        map[string]any
+		subDir      string
+		wantSources []string
+		wantErr     bool
+	}{
+		{"ExportPathWithSubPath", map[string]any{"srcType": "fs", "exportPath": "127.0.0.1:/share/",
+			"subPath": "pvc-1/data/"}, "pvc-1/data", []string{"127.0.0.1:/share", bindMountOption}, false},
+		{"ExportPathWithoutSubPath", map[string]any{"srcType": "fs", "exportPath": "127.0.0.1:/share"}, "",
+			[]string{"127.0.0.1:/share"}, false},
+		{"SubPathNotExist", map[string]any{"srcType": "fs", "exportPath": "127.0.0.1:/share",
+			"subPath": "pvc-1"}, "", []string{"127.0.0.1:/share"}, true},
+		{"SubPathEscapesExport", map[string]any{"srcType": "fs", "exportPath": "127.0.0.1:/share",
+			"subPath": "pvc-1/../../other"}, "", nil, true},
+		{"AbsoluteSubPath", map[string]any{"srcType": "fs", "exportPath": "127.0.0.1:/share",
+			"subPath": "/other"}, "", nil, true},
+		{"ExportPathConflictsWithSourcePath", map[string]any{"srcType": "fs", "exportPath": "127.0.0.1:/share",
+			"sourcePath": "127.0.0.1:/share"}, "", nil, true},
+		{"ExportPathWithBlock", map[string]any{"srcType": "block", "exportPath": "127.0.0.1:/share"}, "",
+			nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSources []string
+			tt.conn["targetPath"] = filepath.Join(t.TempDir(), "globalmount")
+			stubs := gostub.StubFunc(&connector.GetMountPointInfo, (*connector.MountPointInfo)(nil), nil)
+			defer stubs.Reset()
+			stubs.StubFunc(&app.GetGlobalConfig, cfg.MockCompletedConfig())
+			patches := gomonkey.ApplyFunc(connUtils.MountToDir, func(_ context.Context, sourcePath, targetPath string,
+				_ connUtils.MountParam, _ bool) error {
+				if strings.HasPrefix(sourcePath, "/proc/") {
+					gotSources = append(gotSources, bindMountOption)
+					return nil
+				}
+				gotSources = append(gotSources, sourcePath)
+				return os.MkdirAll(filepath.Join(targetPath, tt.subDir), 0750)
+			})
+			defer patches.Reset()
+			patches.ApplyFuncReturn(connUtils.Unmount, nil)
+
+			nfs := &Connector{}
+			if _, err := nfs.ConnectVolume(ctx, tt.conn); (err != nil) != tt.wantErr {
+				t.Errorf("ConnectVolume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(gotSources, tt.wantSources) {
+				t.Errorf("ConnectVolume() mount %v, want %v", gotSources, tt.wantSources)
+			}
+		})
+	}
+}
+
+func TestConnectVolumeSubPathSymlinkEscape(t *testing.T) {
+	// arrange
+	targetPath := filepath.Join(t.TempDir(), "globalmount")
+	conn := map[string]any{"srcType": "fs", "exportPath": "127.0.0.1:/share", "subPath": "pvc-1",
+		"targetPath": targetPath}
+	var bound bool
+
+	// mock
+	stubs := gostub.StubFunc(&connector.GetMountPointInfo, (*connector.MountPointInfo)(nil), nil)
+	defer stubs.Reset()
+	stubs.StubFunc(&app.GetGlobalConfig, cfg.MockCompletedConfig())
+	patches := gomonkey.ApplyFunc(connUtils.MountToDir, func(_ context.Context, sourcePath, targetPath string,
+		_ connUtils.MountParam, _ bool) error {
+		if strings.HasPrefix(sourcePath, "/proc/") {
+			bound = true
+			return nil
+		}
+		return os.Symlink("/", filepath.Join(targetPath, "pvc-1"))
+	})
+	defer patches.Reset()
+	patches.ApplyFuncReturn(connUtils.Unmount, nil)
+
+	// action
+	_, err := (&Connector{}).ConnectVolume(context.TODO(), conn)
+
+	// assert
+	if err == nil || bound {
+		t.Errorf("ConnectVolume() error = %v, bound = %v, want the symlink out of the export refused", err, bound)
+	}
+}
+
+func TestConnectVolumeFSGroup(t *testing.T) {
+	var ctx = context.TODO()
+	gid := strconv.Itoa(os.Getgid())
+	tests := []struct {
+		name     string
+		fsGroup  string
+		policy   string
+		rootMode os.FileMode
+		wantPerm os.FileMode
+		wantErr  bool
+	}{
+		{"Always", gid, "", 0750 | os.ModeSetgid, 0660, false},
+		{"OnRootMismatchWithMatchedRoot", gid, "OnRootMismatch", 0770 | os.ModeSetgid, 0600, false},
+		{"OnRootMismatchWithMismatchedRoot", gid, "OnRootMismatch", 0750, 0660, false},
+		{"WithoutFSGroup", "", "", 0750, 0600, false},
+		{"InvalidFSGroup", "-1", "", 0750, 0600, true},
+		{"InvalidPolicy", gid, "Never", 0750, 0600, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetPath := t.TempDir()
+			filePath := filepath.Join(targetPath, "data")
+			if err := os.WriteFile(filePath, nil, 0600); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chmod(targetPath, tt.rootMode); err != nil {
+				t.Fatal(err)
+			}
+			stubs := gostub.StubFunc(&connector.GetMountPointInfo, (*connector.MountPointInfo)(nil), nil)
+			defer stubs.Reset()
+			stubs.StubFunc(&app.GetGlobalConfig, cfg.MockCompletedConfig())
+			patches := gomonkey.ApplyFuncReturn(connUtils.MountToDir, nil)
+			defer patches.Reset()
+
+			nfs := &Connector{}
+			_, err := nfs.ConnectVolume(ctx, map[string]any{"srcType": "fs", "sourcePath": "127.0.0.1:/share",
+				"targetPath": targetPath, "fsGroup": tt.fsGroup, "fsGroupChangePolicy": tt.policy})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConnectVolume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			info, err := os.Stat(filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != tt.wantPerm {
+				t.Errorf("ConnectVolume() file permission = %v, want %v", info.Mode().Perm(), tt.wantPerm)
+			}
+		})
+	}
+}
+
+func TestDisConnectVolumeLazyUnmount(t *testing.T) {
+	var ctx = context.TODO()
+	tests := []struct {
+		name       string
+		enableLazy bool
+		output     string
+		wantLazy   bool
+		wantErr    bool
+	}{
+		{"LazyUnmountWhenBusy", true, "umount.nfs4: /test: device is busy", true, false},
+		{"StrictWhenBusy", false, "umount.nfs4: /test: device is busy", false, true},
+		{"NoLazyUnmountWhenOtherError", true, "umount: /test: permission denied", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := cfg.MockCompletedConfig()
+			config.EnableLazyUnmount = tt.enableLazy
+			var lazyUnmounted bool
+			stubs := gostub.StubFunc(&app.GetGlobalConfig, config)
+			defer stubs.Reset()
+			stubs.Stub(&utils.ExecShellCmd, func(_ context.Context, format string, _ ...interface{}) (string, error) {
+				if format == "umount -l %s" {
+					lazyUnmounted = true
+					return "", nil
+				}
+				return tt.output, errors.New("exit status 32")
+			})
+			patches := gomonkey.ApplyFuncReturn(connector.MountPathIsExist, true, nil)
+			defer patches.Reset()
+
+			err := tryDisConnectVolume(ctx, "lazy-targetPath")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("tryDisConnectVolume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if lazyUnmounted != tt.wantLazy {
+				t.Errorf("tryDisConnectVolume() lazy unmounted = %v, want %v", lazyUnmounted, tt.wantLazy)
+			}
+		})
+	}
+}
+
 func TestMain(m *testing.M) {
 	log.MockInitLogging(logName)
 	defer log.MockStopLogging(logName)
 
 	m.Run()
 }
+
+func TestConnectVolumeBlockAlreadyMounted(t *testing.T) {
+	var ctx = context.TODO()
+	tests := []struct {
+		name        string
+		skipResize  bool
+		wantResized bool
+	}{
+		{"Resize", false, true},
+		{"SkipResize", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resized := false
+			stubs := gostub.Stub(&connector.ResizeMountPath, func(_ context.Context, _ string) error {
+				resized = true
+				return nil
+			})
+			defer stubs.Reset()
+			stubs.StubFunc(&connector.GetMountPointInfo, &connector.MountPointInfo{Source: "/dev/dm-1",
+				Target: "test-targetPath", FsType: "ext4", Options: []string{"rw"}}, nil)
+			stubs.StubFunc(&connector.ReadDevice, []byte{}, nil)
+			stubs.StubFunc(&app.GetGlobalConfig, cfg.MockCompletedConfig())
+
+			nfs := &Connector{}
+			_, err := nfs.ConnectVolume(ctx, map[string]any{"srcType": "block", "sourcePath": "/dev/dm-1",
+				"targetPath": "test-targetPath", "fsType": "ext4", "skipResize": tt.skipResize})
+			if err != nil {
+				t.Errorf("ConnectVolume() error = %v", err)
+			}
+			if resized != tt.wantResized {
+				t.Errorf("ConnectVolume() resized = %v, want %v", resized, tt.wantResized)
+			}
+		})
+	}
+}
+
+func TestWrapNconnectMountError(t *testing.T) {
+	mountErr := errors.New("mount.nfs: an incorrect mount option was specified")
+	tests := []struct {
+		name     string
+		conn     *connectorInfo
+		err      error
+		wantHint bool
+	}{
+		{"NegotiatedVersionUnsupportedOption", &connectorInfo{nconnect: 4}, mountErr, true},
+		{"ExplicitVersion", &connectorInfo{nconnect: 4, mntFlags: connUtils.MountParam{DashO: "nfsvers=4.1"}},
+			mountErr, false},
+		{"OtherError", &connectorInfo{nconnect: 4}, errors.New("mount.nfs: Connection timed out"), false},
+		{"WithoutNconnect", &connectorInfo{}, mountErr, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := wrapNconnectMountError(tt.conn, tt.err)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("wrapNconnectMountError() error = %v, want wrapping %v", err, tt.err)
+			}
+			if gotHint := strings.Contains(err.Error(), "NFSv4.0"); gotHint != tt.wantHint {
+				t.Errorf("wrapNconnectMountError() error = %v, want hint %v", err, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestWrapNFSTLSMountError(t *testing.T) {
+	mountErr := errors.New("mount.nfs: Connection reset by peer")
+	tests := []struct {
+		name       string
+		conn       *connectorInfo
+		wantServer string
+	}{
+		{"SourceHost", &connectorInfo{sourcePath: "127.0.0.1:/share", xprtSec: xprtSecTLS}, "127.0.0.1"},
+		{"TLSServerName", &connectorInfo{sourcePath: "nfs.example.com:/share", xprtSec: xprtSecTLS,
+			tlsServerName: "nfs.example.com"}, "nfs.example.com"},
+		{"SourceWithoutExport", &connectorInfo{sourcePath: "127.0.0.1", xprtSec: xprtSecMTLS}, "127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := wrapNFSTLSMountError(tt.conn, mountErr)
+			if !errors.Is(err, mountErr) {
+				t.Errorf("wrapNFSTLSMountError() error = %v, want wrapping %v", err, mountErr)
+			}
+			if !strings.Contains(err.Error(), "matches "+tt.wantServer+",") {
+				t.Errorf("wrapNFSTLSMountError() error = %v, want server name %s", err, tt.wantServer)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package nfs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/connector"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+const (
+	xprtSecNone = "none"
+	xprtSecTLS  = "tls"
+	xprtSecMTLS = "mtls"
+
+	// the xprtsec mount option of RPC-with-TLS is supported since linux 6.5
+	minTLSKernelMajor = 6
+	minTLSKernelMinor = 5
+)
+
+// checkNFSTLSSupport checks whether the kernel of the host supports the xprtsec mount option
+var checkNFSTLSSupport = func(ctx context.Context) error {
+	release, major, minor, err := getKernelVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if major < minTLSKernelMajor || (major == minTLSKernelMajor && minor < minTLSKernelMinor) {
+		return fmt.Errorf("kernel %s does not support NFS over TLS, %d.%d or later is required",
+			release, minTLSKernelMajor, minTLSKernelMinor)
+	}
+
+	return nil
+}
+
+// getKernelVersion returns the release and the major and minor version of the kernel of the host
+func getKernelVersion(ctx context.Context) (string, int, int, error) {
+	output, err := utils.ExecShellCmd(ctx, "uname -r")
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("get kernel version failed, error: %v", err)
+	}
+
+	release := strings.TrimSpace(output)
+	versions := strings.SplitN(release, ".", 3)
+	if len(versions) < 2 {
+		return "", 0, 0, fmt.Errorf("kernel version %s is invalid", release)
+	}
+
+	major, majorErr := strconv.Atoi(versions[0])
+	minor, minorErr := strconv.Atoi(versions[1])
+	if majorErr != nil || minorErr != nil {
+		return "", 0, 0, fmt.Errorf("kernel version %s is invalid", release)
+	}
+
+	return release, major, minor, nil
+}
+
+// parseNFSTLSInfo sets the xprtsec mount option and replaces the host of the source path with the tlsServerName,
+// so that the server certificate is verified against the configured hostname instead of the raw ip
+func parseNFSTLSInfo(ctx context.Context, con *connectorInfo, connectionProperties map[string]interface{}) error {
+	xprtSec, _ := connectionProperties["xprtsec"].(string)
+	tlsServerName, _ := connectionProperties["tlsServerName"].(string)
+	xprtSec = strings.TrimSpace(xprtSec)
+	tlsServerName = strings.TrimSpace(tlsServerName)
+	if xprtSec == "" && tlsServerName != "" {
+		xprtSec = xprtSecTLS
+	}
+
+	switch xprtSec {
+	case "", xprtSecNone:
+		return nil
+	case xprtSecTLS, xprtSecMTLS:
+	default:
+		return fmt.Errorf("xprtsec %s is invalid, only %s, %s and %s are supported",
+			xprtSec, xprtSecNone, xprtSecTLS, xprtSecMTLS)
+	}
+
+	if con.srcType != connector.MountFSType || con.mntFlags.DashT != "" {
+		return fmt.Errorf("xprtsec %s is only supported by NFS", xprtSec)
+	}
+
+	if err := checkNFSTLSSupport(ctx); err != nil {
+		return fmt.Errorf("NFS over TLS is requested but not supported by the host: %v", err)
+	}
+
+	if tlsServerName != "" {
+		sourcePath, err := replaceSourceHost(con.sourcePath, tlsServerName)
+		if err != nil {
+			return err
+		}
+		con.sourcePath = sourcePath
+	}
+
+	con.xprtSec = xprtSec
+	con.tlsServerName = tlsServerName
+	xprtSecOption := "xprtsec=" + xprtSec
+	if con.mntFlags.DashO == "" {
+		con.mntFlags.DashO = xprtSecOption
+	} else {
+		con.mntFlags.DashO += "," + xprtSecOption
+	}
+
+	log.AddContext(ctx).Infof("Mount %s to %s with xprtsec %s", con.sourcePath, con.targetPath, xprtSec)
+	return nil
+}
+
+// replaceSourceHost replaces the host of the nfs source path like 127.0.0.1:/share or [::1]:/share
+func replaceSourceHost(sourcePath, host string) (string, error) {
+	index := strings.LastIndex(sourcePath, ":/")
+	if index <= 0 {
+		return "", fmt.Errorf("source path %s is not a nfs export", sourcePath)
+	}
+
+	return host + sourcePath[index:], nil
+}
+
+func wrapNFSTLSMountError(conn *connectorInfo, err error) error {
+	if err == nil || conn.xprtSec == "" {
+		return err
+	}
+
+	serverName := conn.tlsServerName
+	if serverName == "" {
+		serverName = conn.sourcePath
+		if index := strings.LastIndex(conn.sourcePath, ":/"); index > 0 {
+			serverName = conn.sourcePath[:index]
+		}
+	}
+
+	return fmt.Errorf("mount %s with xprtsec=%s failed, please check that mount.nfs supports xprtsec, "+
+		"tlshd is running and the server certificate matches %s, error: %w",
+		conn.sourcePath, conn.xprtSec, serverName, err)
+}
@@ -23,9 +23,11 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/connector"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
@@ -34,10 +36,83 @@ const (
 	targetMountPathPermission = 0750
 )
 
+// ErrTargetBusy is returned when the target path can not be unmounted because it is busy
+var ErrTargetBusy = errors.New("target is busy")
+
 // MountParam is the parameters for mount
 type MountParam struct {
 	DashT string
 	DashO string
+	// TargetPermission is the permission of the created target path, 0750 will be used if it is zero
+	TargetPermission os.FileMode
+}
+
+// ParseMountPermission parses the octal permission string such as "0755" to a file mode.
+// The default permission 0750 will be returned if the permission is empty.
+func ParseMountPermission(permission string) (os.FileMode, error) {
+	permission = strings.TrimSpace(permission)
+	if permission == "" {
+		return targetMountPathPermission, nil
+	}
+
+	mode, err := strconv.ParseUint(permission, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("mount permission %s is not a valid octal number: %w", permission, err)
+	}
+
+	if mode == 0 || os.FileMode(mode)&^os.ModePerm != 0 {
+		return 0, fmt.Errorf("mount permission %s is not a legal file mode", permission)
+	}
+
+	return os.FileMode(mode), nil
+}
+
+// conflictMountOptions are the mount options that can not be specified together
+var conflictMountOptions = map[string]string{
+	"exec": "noexec", "noexec": "exec",
+	"suid": "nosuid", "nosuid": "suid",
+	"dev": "nodev", "nodev": "dev",
+	"ro": "rw", "rw": "ro",
+	"sync": "async", "async": "sync",
+}
+
+// ValidateMountOptions validates the comma separated mount options, the options must not be empty,
+// must not contain shell special characters, must not be in the denylist and must not conflict with each other.
+func ValidateMountOptions(options string, denylist []string) error {
+	if options == "" {
+		return nil
+	}
+
+	denied := make(map[string]bool, len(denylist))
+	for _, option := range denylist {
+		if option = strings.TrimSpace(option); option != "" {
+			denied[option] = true
+		}
+	}
+
+	specified := make(map[string]bool)
+	for _, option := range strings.Split(options, ",") {
+		if option == "" {
+			return fmt.Errorf("mount options %q contain an empty option", options)
+		}
+
+		if strings.ContainsAny(option, " \t\r\n;|&$`<>()'\"\\") {
+			return fmt.Errorf("mount option %q contains illegal characters", option)
+		}
+
+		name, _, _ := strings.Cut(option, "=")
+		if denied[name] {
+			return fmt.Errorf("mount option %q is not allowed", option)
+		}
+
+		if conflict, ok := conflictMountOptions[name]; ok && specified[conflict] {
+			return fmt.Errorf("mount option %q conflicts with option %q", option, conflict)
+		}
+
+		specified[name] = true
+	}
+
+	return nil
 }
 
 // BindMountRawBlockDevice mounts the raw block device to target path
@@ -95,7 +170,7 @@ func BindMountRawBlockDevice(ctx context.Context, sourcePath, targetPath string,
 
 // MountToDir mounts source to target which is a directory.
 func MountToDir(ctx context.Context, sourcePath, targetPath string, flags MountParam, checkSourcePath bool) error {
-	err := preMount(sourcePath, targetPath, checkSourcePath)
+	err := preMount(sourcePath, targetPath, checkSourcePath, flags.TargetPermission)
 	if err != nil {
 		return err
 	}
@@ -166,12 +241,33 @@ func Unmount(ctx context.Context, targetPath string) error {
 	if err != nil && !(strings.Contains(output, "not mounted") ||
 		strings.Contains(output, "not found")) {
 		log.AddContext(ctx).Errorf("Unmount %s error: %s", targetPath, output)
+		if strings.Contains(output, "busy") {
+			return fmt.Errorf("unmount %s failed, %w: %w", targetPath, ErrTargetBusy, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// LazyUnmount detaches the target path from the file system hierarchy immediately,
+// and cleans up all references to it as soon as it is not busy anymore.
+func LazyUnmount(ctx context.Context, targetPath string) error {
+	output, err := utils.ExecShellCmd(ctx, "umount -l %s", targetPath)
+	if err != nil && !(strings.Contains(output, "not mounted") ||
+		strings.Contains(output, "not found")) {
+		log.AddContext(ctx).Errorf("Lazy unmount %s error: %s", targetPath, output)
 		return err
 	}
 
 	return nil
 }
 
+// IsUnmountBusyOrTimeout checks whether the unmount error is caused by busy target or command timeout
+func IsUnmountBusyOrTimeout(err error) bool {
+	return errors.Is(err, ErrTargetBusy) || errors.Is(err, constants.ErrTimeout)
+}
+
 // ContainSourceDevice used to check whether target path referenced source device is equal to sourceDev
 func ContainSourceDevice(ctx context.Context, targetPath, sourceDev string) bool {
 	for _, value := range findSourceDevice(ctx, targetPath) {
@@ -199,16 +295,20 @@ func createMountTargetFile(targetPath string) error {
 	return nil
 }
 
-func preMount(sourcePath, targetPath string, checkSourcePath bool) error {
+func preMount(sourcePath, targetPath string, checkSourcePath bool, permission os.FileMode) error {
 	if checkSourcePath {
 		if _, err := os.Stat(sourcePath); err != nil && os.IsNotExist(err) {
 			return errors.New("source path does not exist")
 		}
 	}
 
+	if permission == 0 {
+		permission = targetMountPathPermission
+	}
+
 	if _, err := os.Stat(targetPath); err != nil && os.IsNotExist(err) {
-		log.Infof("target path %s does not exist, create it", targetPath)
-		if err := os.MkdirAll(targetPath, targetMountPathPermission); err != nil {
+		log.Infof("target path %s does not exist, create it with permission %o", targetPath, permission)
+		if err := os.MkdirAll(targetPath, permission); err != nil {
 			return errors.New("can not create a target path")
 		}
 	}
@@ -37,6 +37,8 @@ type loggingConfig struct {
 type serviceConfig struct {
 	Controller           bool
 	EnableLeaderElection bool
+	// StrictResponseDecode rejects the unknown fields when decoding storage responses
+	StrictResponseDecode bool
 
 	Endpoint         string
 	DrEndpoint       string
@@ -72,10 +74,13 @@ type connectorConfig struct {
 	NvmeMultiPathType    string
 	DeviceCleanupTimeout int
 	ScanVolumeTimeout    int
+	ScanVolumeInterval   int
 	ConnectorThreads     int
 	AllPathOnline        bool
 	ExecCommandTimeout   int
 	EnableRoCEConnect    bool
+	EnableLazyUnmount    bool
+	DeniedMountFlags     string
 }
 
 type k8sConfig struct {
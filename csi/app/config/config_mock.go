@@ -60,6 +60,7 @@ func mockServiceConfig() serviceConfig {
 	return serviceConfig{
 		Controller:           false,
 		EnableLeaderElection: false,
+		StrictResponseDecode: false,
 
 		Endpoint:         "",
 		DrEndpoint:       "",
@@ -85,9 +86,12 @@ func mockConnectorConfig() connectorConfig {
 		NvmeMultiPathType:    "HW-UltraPath-NVMe",
 		DeviceCleanupTimeout: 5,
 		ScanVolumeTimeout:    5,
+		ScanVolumeInterval:   100,
 		ConnectorThreads:     5,
 		AllPathOnline:        true,
 		EnableRoCEConnect:    true,
+		EnableLazyUnmount:    false,
+		DeniedMountFlags:     "",
 	}
 }
 
@@ -31,6 +31,7 @@ const (
 
 	defaultCleanupTimeout     = 240
 	defaultScanVolumeTimeout  = 3
+	defaultScanVolumeInterval = 100
 	defaultConnectorThreads   = 4
 	defaultExecCommandTimeout = 30
 
@@ -40,6 +41,9 @@ const (
 	minScanVolumeTimeout = 1
 	maxScanVolumeTimeout = 600
 
+	minScanVolumeInterval = 10
+	maxScanVolumeInterval = 10000
+
 	minExecCommandTimeout = 1
 	maxExecCommandTimeout = 600
 )
@@ -50,10 +54,13 @@ type connectorOptions struct {
 	nvmeMultiPathType    string
 	deviceCleanupTimeout int
 	scanVolumeTimeout    int
+	scanVolumeInterval   int
 	connectorThreads     int
 	allPathOnline        bool
 	execCommandTimeout   int
 	enableRoCEConnect    bool
+	enableLazyUnmount    bool
+	deniedMountFlags     string
 }
 
 // NewConnectorOptions returns connector configurations
@@ -64,9 +71,11 @@ func NewConnectorOptions() *connectorOptions {
 		nvmeMultiPathType:    hwUltraPathNVMe,
 		deviceCleanupTimeout: defaultCleanupTimeout,
 		scanVolumeTimeout:    defaultScanVolumeTimeout,
+		scanVolumeInterval:   defaultScanVolumeInterval,
 		connectorThreads:     defaultConnectorThreads,
 		allPathOnline:        false,
 		enableRoCEConnect:    true,
+		enableLazyUnmount:    false,
 	}
 }
 
@@ -87,6 +96,9 @@ func (opt *connectorOptions) AddFlags(ff *flag.FlagSet) {
 	ff.IntVar(&opt.scanVolumeTimeout, "scan-volume-timeout",
 		defaultScanVolumeTimeout,
 		"The timeout for waiting for multipath aggregation when DM-multipath is used on the host")
+	ff.IntVar(&opt.scanVolumeInterval, "scan-volume-interval",
+		defaultScanVolumeInterval,
+		"The interval in milliseconds for polling the multipath aggregation when DM-multipath is used on the host")
 	ff.IntVar(&opt.connectorThreads, "connector-threads",
 		defaultConnectorThreads,
 		"The concurrency supported during disk operations.")
@@ -98,6 +110,10 @@ func (opt *connectorOptions) AddFlags(ff *flag.FlagSet) {
 		"The timeout for running command on host")
 	ff.BoolVar(&opt.enableRoCEConnect, "enable-roce-connect", true,
 		"Whether to enable automatic CSI disk scanning when RoCE is used, default is true")
+	ff.BoolVar(&opt.enableLazyUnmount, "enable-lazy-unmount", false,
+		"Whether to fall back to lazy unmount when unmounting a busy or unreachable nfs volume, default is false")
+	ff.StringVar(&opt.deniedMountFlags, "denied-mount-flags", "",
+		"Comma separated mount options which are not allowed in the mountFlags of StorageClass")
 }
 
 // ApplyFlags assign the connector flags
@@ -107,10 +123,13 @@ func (opt *connectorOptions) ApplyFlags(cfg *config.AppConfig) {
 	cfg.NvmeMultiPathType = opt.nvmeMultiPathType
 	cfg.DeviceCleanupTimeout = opt.deviceCleanupTimeout
 	cfg.ScanVolumeTimeout = opt.scanVolumeTimeout
+	cfg.ScanVolumeInterval = opt.scanVolumeInterval
 	cfg.ConnectorThreads = opt.connectorThreads
 	cfg.AllPathOnline = opt.allPathOnline
 	cfg.ExecCommandTimeout = opt.execCommandTimeout
 	cfg.EnableRoCEConnect = opt.enableRoCEConnect
+	cfg.EnableLazyUnmount = opt.enableLazyUnmount
+	cfg.DeniedMountFlags = opt.deniedMountFlags
 }
 
 // ValidateFlags validate the connector flags
@@ -131,6 +150,11 @@ func (opt *connectorOptions) ValidateFlags() []error {
 		errs = append(errs, err)
 	}
 
+	err = opt.validateScanVolumeInterval()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
 	return errs
 }
 
@@ -160,6 +184,14 @@ func (opt *connectorOptions) validateScanVolumeTimeout() error {
 	return nil
 }
 
+func (opt *connectorOptions) validateScanVolumeInterval() error {
+	if opt.scanVolumeInterval < minScanVolumeInterval || opt.scanVolumeInterval > maxScanVolumeInterval {
+		return fmt.Errorf("the value of scanVolumeInterval ranges from %d to %d, current is: %d",
+			minScanVolumeInterval, maxScanVolumeInterval, opt.scanVolumeInterval)
+	}
+	return nil
+}
+
 func (opt *connectorOptions) validateConnectorThreads() error {
 	if opt.connectorThreads < minThreads || opt.connectorThreads > maxThreads {
 		return fmt.Errorf("the connector-threads %d should be %d~%d",
@@ -88,6 +88,7 @@ func compareConnectorOptions(envCfg *config.AppConfig) error {
 		nvmeMultiPathType:    hwUltraPathNVMe,
 		deviceCleanupTimeout: defaultCleanupTimeout,
 		scanVolumeTimeout:    defaultScanVolumeTimeout,
+		scanVolumeInterval:   defaultScanVolumeInterval,
 		connectorThreads:     defaultConnectorThreads,
 		allPathOnline:        false,
 		execCommandTimeout:   0,
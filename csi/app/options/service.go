@@ -41,6 +41,7 @@ const (
 type serviceOptions struct {
 	controller           bool
 	enableLeaderElection bool
+	strictResponseDecode bool
 
 	driverName       string
 	endpoint         string
@@ -119,6 +120,8 @@ func (opt *serviceOptions) AddFlags(ff *flag.FlagSet) {
 		"The port of exported csi server")
 	ff.StringVar(&opt.exportCsiServerAddress, "export-csi-service-address", "",
 		"The address of exported csi server")
+	ff.BoolVar(&opt.strictResponseDecode, "strict-response-decode", false,
+		"Reject the unknown fields when decoding storage responses to detect schema drift")
 }
 
 // ApplyFlags assign the service flags
@@ -136,6 +139,7 @@ func (opt *serviceOptions) ApplyFlags(cfg *config.AppConfig) {
 	cfg.WebHookPort = opt.webHookPort
 	cfg.WebHookAddress = opt.webHookAddress
 	cfg.EnableLeaderElection = opt.enableLeaderElection
+	cfg.StrictResponseDecode = opt.strictResponseDecode
 	cfg.LeaderRetryPeriod = opt.leaderRetryPeriod
 	cfg.LeaderLeaseDuration = opt.leaderLeaseDuration
 	cfg.LeaderRenewDeadline = opt.leaderRenewDeadline
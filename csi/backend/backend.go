@@ -69,6 +69,8 @@ var (
 		{"sourceVolumeName", filterBySupportClone},
 		{"sourceSnapshotName", filterBySupportClone},
 		{"nfsProtocol", filterByNFSProtocol},
+		{"dedupe", filterBySupportDedupe},
+		{"compression", filterBySupportCompression},
 	}
 
 	// SecondaryFilterFuncs secondary filters' function map
@@ -81,6 +83,16 @@ var (
 	}
 )
 
+// protocolServicePorts maps the protocol configured in the backend to the protocol of the service ports serving it
+var protocolServicePorts = map[string]string{
+	constants.ProtocolIscsi:   constants.ProtocolIscsi,
+	"fc":                      "fc",
+	"fc-nvme":                 "fc",
+	"roce":                    "roce",
+	constants.ProtocolNfs:     constants.ProtocolNfs,
+	constants.ProtocolNfsPlus: constants.ProtocolNfs,
+}
+
 // AccessibleTopology represents selected node topology
 type AccessibleTopology struct {
 	RequisiteTopologies []map[string]string
@@ -354,6 +366,12 @@ func FilterStoragePool(ctx context.Context, requestSize int64, parameters map[st
 			" please check your storage class", err)
 	}
 
+	// filter the storage pools whose service ports of the backend protocol are offline
+	filterPools, err = filterByProtocolOnline(ctx, filterPools)
+	if err != nil {
+		return nil, err
+	}
+
 	// filter the storage by topology
 	filterPools, err = FilterByTopology(parameters, filterPools)
 	if err != nil {
@@ -522,8 +540,12 @@ func filterByVolumeType(ctx context.Context, volumeType string, candidatePools [
 
 func filterByAllocType(ctx context.Context, allocType string, candidatePools []*model.StoragePool) (
 	[]*model.StoragePool, error) {
-	var filterPools []*model.StoragePool
+	if allocType != "" && allocType != "thin" && allocType != "thick" {
+		return nil, fmt.Errorf("allocType %s is invalid, only thin and thick are supported", allocType)
+	}
 
+	var filterPools []*model.StoragePool
+	var unsupportedPools []string
 	for _, pool := range candidatePools {
 		valid := false
 
@@ -547,9 +569,16 @@ func filterByAllocType(ctx context.Context, allocType string, candidatePools []*
 
 		if valid {
 			filterPools = append(filterPools, pool)
+		} else {
+			unsupportedPools = append(unsupportedPools, pool.Parent+":"+pool.Name)
 		}
 	}
 
+	if len(filterPools) == 0 && len(unsupportedPools) != 0 {
+		return nil, fmt.Errorf("allocType %s is not supported by the candidate pools %v, "+
+			"e.g. the Dorado storage only supports thin", allocType, unsupportedPools)
+	}
+
 	return filterPools, nil
 }
 
@@ -700,6 +729,21 @@ func isTopologySupportedByBackend(backend *model.Backend, topology map[string]st
 	return requisiteFound
 }
 
+// IsBackendAccessible returns whether the backend supports the volumeType and can create volumes accessible by the
+// given topology, the backend not configured with supported topologies is accessible by any topology
+func IsBackendAccessible(ctx context.Context, backend *model.Backend, volumeType string,
+	topology map[string]string) bool {
+	if filterPools, _ := filterByVolumeType(ctx, volumeType, backend.Pools); len(filterPools) == 0 {
+		return false
+	}
+
+	if len(topology) == 0 || len(backend.SupportedTopologies) == 0 {
+		return true
+	}
+
+	return isTopologySupportedByBackend(backend, topology)
+}
+
 func extractProtocolTopology(topology, protocolTopology map[string]string) map[string]string {
 	remainingTopology := make(map[string]string, 0)
 
@@ -832,6 +876,49 @@ func FilterByCapability(ctx context.Context, parameters map[string]interface{},
 	return candidatePools, nil
 }
 
+// filterByProtocolOnline filters out the pools whose backend reports that the service ports of its protocol are
+// offline, the pools are kept if the backend does not report the capability of its protocol
+func filterByProtocolOnline(ctx context.Context, candidatePools []*model.StoragePool) ([]*model.StoragePool, error) {
+	var filterPools []*model.StoragePool
+	var offlinePools []string
+	for _, pool := range candidatePools {
+		if isProtocolOffline(pool) {
+			offlinePools = append(offlinePools, pool.Parent+":"+pool.Name)
+			continue
+		}
+		filterPools = append(filterPools, pool)
+	}
+
+	if len(offlinePools) == 0 {
+		return candidatePools, nil
+	}
+
+	if len(filterPools) == 0 {
+		return nil, fmt.Errorf("failed to select pool, the service ports of the backend protocol of the "+
+			"candidate pools %v are offline", offlinePools)
+	}
+
+	log.AddContext(ctx).Infof("Skip the pools %v, the service ports of their backend protocol are offline",
+		offlinePools)
+	return filterPools, nil
+}
+
+func isProtocolOffline(pool *model.StoragePool) bool {
+	backend, exists := cache.BackendCacheProvider.Load(pool.Parent)
+	if !exists {
+		return false
+	}
+
+	protocol, _ := backend.Parameters["protocol"].(string)
+	servicePorts, ok := protocolServicePorts[protocol]
+	if !ok {
+		return false
+	}
+
+	online, exist := pool.Capabilities[constants.SupportProtocolPrefix+strings.ToUpper(servicePorts)]
+	return exist && !online
+}
+
 func filterByNFSProtocol(ctx context.Context, nfsProtocol string, candidatePools []*model.StoragePool) (
 	[]*model.StoragePool, error) {
 	if nfsProtocol == "" {
@@ -872,6 +959,31 @@ func filterBySupportClone(ctx context.Context, cloneSource string, candidatePool
 	return filterPools, nil
 }
 
+func filterBySupportDedupe(ctx context.Context, dedupe string, candidatePools []*model.StoragePool) (
+	[]*model.StoragePool, error) {
+	return filterByBoolCapability(ctx, dedupe, string(constants.SupportDedupe), candidatePools), nil
+}
+
+func filterBySupportCompression(ctx context.Context, compression string, candidatePools []*model.StoragePool) (
+	[]*model.StoragePool, error) {
+	return filterByBoolCapability(ctx, compression, string(constants.SupportCompression), candidatePools), nil
+}
+
+func filterByBoolCapability(ctx context.Context, required, capability string,
+	candidatePools []*model.StoragePool) []*model.StoragePool {
+	if len(required) == 0 || !utils.StrToBool(ctx, required) {
+		return candidatePools
+	}
+
+	var filterPools []*model.StoragePool
+	for _, pool := range candidatePools {
+		if pool.Capabilities[capability] {
+			filterPools = append(filterPools, pool)
+		}
+	}
+	return filterPools
+}
+
 // FilterByCapacity filter backend by capacity
 func FilterByCapacity(requestSize int64, allocType string, candidatePools []*model.StoragePool) []*model.StoragePool {
 	var filterPools []*model.StoragePool
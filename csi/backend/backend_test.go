@@ -338,6 +338,17 @@ func TestFilterByAllocType(t *testing.T) {
 	}
 }
 
+func TestFilterByAllocType_Error(t *testing.T) {
+	doradoPool := &model.StoragePool{Name: "pool1", Parent: "dorado",
+		Capabilities: map[string]bool{"SupportThin": true, "SupportThick": false}}
+
+	_, err := filterByAllocType(ctx, "Thick", []*model.StoragePool{doradoPool})
+	require.ErrorContains(t, err, "allocType Thick is invalid")
+
+	_, err = filterByAllocType(ctx, "thick", []*model.StoragePool{doradoPool})
+	require.ErrorContains(t, err, "allocType thick is not supported by the candidate pools [dorado:pool1]")
+}
+
 func TestFilterByMetroNormal(t *testing.T) {
 	load := gomonkey.ApplyMethod(reflect.TypeOf(&cache.BackendCache{}), "Load",
 		func(_ *cache.BackendCache, backendName string) (model.Backend, bool) {
@@ -578,6 +589,27 @@ func TestFilterByNFSProtocol(t *testing.T) {
 	}
 }
 
+func TestFilterByProtocolOnline(t *testing.T) {
+	load := gomonkey.ApplyMethod(reflect.TypeOf(&cache.BackendCache{}), "Load",
+		func(_ *cache.BackendCache, backendName string) (model.Backend, bool) {
+			return model.Backend{Name: backendName, Parameters: map[string]interface{}{"protocol": "iscsi"}}, true
+		})
+	defer load.Reset()
+
+	online := &model.StoragePool{Name: "pool1", Parent: "backend1",
+		Capabilities: map[string]bool{"SupportProtocolISCSI": true}}
+	offline := &model.StoragePool{Name: "pool2", Parent: "backend2",
+		Capabilities: map[string]bool{"SupportProtocolISCSI": false}}
+	unreported := &model.StoragePool{Name: "pool3", Parent: "backend3", Capabilities: map[string]bool{}}
+
+	got, err := filterByProtocolOnline(ctx, []*model.StoragePool{online, offline, unreported})
+	require.NoError(t, err)
+	require.Equal(t, []*model.StoragePool{online, unreported}, got)
+
+	_, err = filterByProtocolOnline(ctx, []*model.StoragePool{offline})
+	require.ErrorContains(t, err, "backend2:pool2")
+}
+
 func TestFilterBySupportClone(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -869,3 +901,40 @@ func TestUpdateSelectPool(t *testing.T) {
 	// assert
 	require.Equal(t, expectedCapacity, selectPool.Capacities["FreeCapacity"])
 }
+
+func TestFilterBySupportDedupe(t *testing.T) {
+	// arrange
+	candidatePools := []*model.StoragePool{
+		{Name: "pool1", Capabilities: map[string]bool{"SupportDedupe": true}},
+		{Name: "pool2", Capabilities: map[string]bool{"SupportDedupe": false}},
+		{Name: "pool3", Capabilities: map[string]bool{}},
+	}
+
+	// act
+	required, requiredErr := filterBySupportDedupe(ctx, "true", candidatePools)
+	notRequired, notRequiredErr := filterBySupportDedupe(ctx, "", candidatePools)
+
+	// assert
+	require.NoError(t, requiredErr)
+	require.NoError(t, notRequiredErr)
+	require.Equal(t, candidatePools[:1], required)
+	require.Equal(t, candidatePools, notRequired)
+}
+
+func TestFilterBySupportCompression(t *testing.T) {
+	// arrange
+	candidatePools := []*model.StoragePool{
+		{Name: "pool1", Capabilities: map[string]bool{"SupportCompression": false}},
+		{Name: "pool2", Capabilities: map[string]bool{"SupportCompression": true}},
+	}
+
+	// act
+	required, requiredErr := filterBySupportCompression(ctx, "true", candidatePools)
+	notRequired, notRequiredErr := filterBySupportCompression(ctx, "false", candidatePools)
+
+	// assert
+	require.NoError(t, requiredErr)
+	require.NoError(t, notRequiredErr)
+	require.Equal(t, candidatePools[1:], required)
+	require.Equal(t, candidatePools, notRequired)
+}
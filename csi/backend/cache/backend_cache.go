@@ -95,7 +95,7 @@ func (b *BackendCache) Delete(ctx context.Context, backendName string) {
 	defer b.PrintCacheContent(ctx)
 	bk, ok := b.backends[backendName]
 	if ok && bk.Plugin != nil {
-		bk.Plugin.Logout(ctx)
+		bk.Plugin.Close(ctx)
 	}
 	log.AddContext(ctx).Debugf("delete backend cache, backendName: [%v]", backendName)
 	delete(b.backends, backendName)
@@ -108,7 +108,7 @@ func (b *BackendCache) Clear(ctx context.Context) {
 	defer b.PrintCacheContent(ctx)
 	for name, bk := range b.backends {
 		if bk.Plugin != nil {
-			bk.Plugin.Logout(ctx)
+			bk.Plugin.Close(ctx)
 		}
 		delete(b.backends, name)
 	}
@@ -18,14 +18,22 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strconv"
+	"sync"
 
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/backend/model"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/lib/drcsi"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
+// refreshBackendParallelNum limits the backends refreshed at the same time
+const refreshBackendParallelNum = 10
+
 // StorageBackendDetails backend details
 type StorageBackendDetails struct {
 	Capabilities   map[string]bool
@@ -36,6 +44,15 @@ type StorageBackendDetails struct {
 // StorageServiceInterface query backend operation set
 type StorageServiceInterface interface {
 	GetBackendDetails(ctx context.Context, name, contentName string) (StorageBackendDetails, error)
+	RefreshAllBackendDetails(ctx context.Context) []*BackendDetailsResult
+}
+
+// BackendDetailsResult holds the result of refreshing the details of a backend,
+// the Details is only valid if the Err is nil
+type BackendDetailsResult struct {
+	Name    string
+	Details StorageBackendDetails
+	Err     error
 }
 
 // StorageHandler backend query handler
@@ -63,6 +80,53 @@ func (s *StorageHandler) GetBackendDetails(ctx context.Context,
 		return StorageBackendDetails{}, err
 	}
 
+	return s.queryBackendDetails(ctx, bk)
+}
+
+// RefreshAllBackendDetails queries the details of all the cached backends concurrently, the failure of a backend
+// does not affect the others, the results are sorted by the backend name
+func (s *StorageHandler) RefreshAllBackendDetails(ctx context.Context) []*BackendDetailsResult {
+	backends := s.cacheHandler.List(ctx)
+	results := make([]*BackendDetailsResult, len(backends))
+	sem := utils.NewSemaphore(refreshBackendParallelNum)
+	var wg sync.WaitGroup
+	for i := range backends {
+		if backends[i].Plugin == nil {
+			results[i] = &BackendDetailsResult{Name: backends[i].Name,
+				Err: fmt.Errorf("plugin of backend %s is not initialized", backends[i].Name)}
+			continue
+		}
+
+		wg.Add(1)
+		sem.Acquire()
+		go func(index int, bk *model.Backend) {
+			defer func() {
+				sem.Release()
+				wg.Done()
+			}()
+			details, err := s.queryBackendDetails(ctx, bk)
+			results[index] = &BackendDetailsResult{Name: bk.Name, Details: details, Err: err}
+		}(i, &backends[i])
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	var failed int
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	log.AddContext(ctx).Infof("Refresh details of %d backends, %d succeeded, %d failed",
+		len(results), len(results)-failed, failed)
+	return results
+}
+
+func (s *StorageHandler) queryBackendDetails(ctx context.Context, bk *model.Backend) (StorageBackendDetails, error) {
+	name := bk.Name
 	capabilities, specifications, err := bk.Plugin.UpdateBackendCapabilities(ctx)
 	if err != nil {
 		log.AddContext(ctx).Warningf("query backend %s capabilities failed, error: %v", name, err)
@@ -0,0 +1,73 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/backend/cache"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/backend/model"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/backend/plugin"
+)
+
+type fakeCapabilityPlugin struct {
+	plugin.StoragePlugin
+	err error
+}
+
+func (p *fakeCapabilityPlugin) UpdateBackendCapabilities(context.Context) (map[string]interface{},
+	map[string]interface{}, error) {
+	if p.err != nil {
+		return nil, nil, p.err
+	}
+
+	return map[string]interface{}{"SupportThin": true}, map[string]interface{}{"LocalDeviceSN": "sn"}, nil
+}
+
+func (p *fakeCapabilityPlugin) UpdatePoolCapabilities(context.Context, []string) (map[string]interface{}, error) {
+	return map[string]interface{}{"pool": map[string]interface{}{"FreeCapacity": int64(1024)}}, nil
+}
+
+func TestStorageHandler_RefreshAllBackendDetails(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	backendCache := cache.NewBackendCache()
+	unreachable := errors.New("backend is unreachable")
+	backendCache.Store(ctx, "healthy", model.Backend{Name: "healthy", Plugin: &fakeCapabilityPlugin{},
+		Pools: []*model.StoragePool{{Name: "pool"}}})
+	backendCache.Store(ctx, "failed", model.Backend{Name: "failed", Plugin: &fakeCapabilityPlugin{err: unreachable}})
+	backendCache.Store(ctx, "uninitialized", model.Backend{Name: "uninitialized"})
+	handler := &StorageHandler{cacheHandler: &CacheWrapper{BackendCacheInterface: backendCache}}
+
+	// action
+	results := handler.RefreshAllBackendDetails(ctx)
+
+	// assert
+	require.Len(t, results, 3)
+	require.Equal(t, "failed", results[0].Name)
+	require.ErrorIs(t, results[0].Err, unreachable)
+	require.Equal(t, "healthy", results[1].Name)
+	require.NoError(t, results[1].Err)
+	require.Equal(t, map[string]bool{"SupportThin": true}, results[1].Details.Capabilities)
+	require.Equal(t, map[string]string{"FreeCapacity": "1024"}, results[1].Details.Pools[0].Capacities)
+	require.Equal(t, "uninitialized", results[2].Name)
+	require.Error(t, results[2].Err)
+}
@@ -169,6 +169,13 @@ func (p *FusionStoragePlugin) Logout(ctx context.Context) {
 	}
 }
 
+// Close logs out the storage session and releases the resources of the client, it is called when the backend is removed
+func (p *FusionStoragePlugin) Close(ctx context.Context) {
+	if p.cli != nil {
+		p.cli.Close(ctx)
+	}
+}
+
 // ReLogin will refresh the user session of storage
 func (p *FusionStoragePlugin) ReLogin(ctx context.Context) error {
 	if p.cli == nil {
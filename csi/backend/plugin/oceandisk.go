@@ -125,6 +125,13 @@ func (p *OceandiskPlugin) Logout(ctx context.Context) {
 	}
 }
 
+// Close logs out the storage session and releases the resources of the client, it is called when the backend is removed
+func (p *OceandiskPlugin) Close(ctx context.Context) {
+	if p.cli != nil {
+		p.cli.Close(ctx)
+	}
+}
+
 // ReLogin will refresh the user session of storage
 func (p *OceandiskPlugin) ReLogin(ctx context.Context) error {
 	if p.cli == nil {
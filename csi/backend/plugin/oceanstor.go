@@ -18,14 +18,24 @@ package plugin
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
+	xuanwuV1 "github.com/Huawei/eSDK_K8S_Plugin/v4/client/apis/xuanwu/v1"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/client"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/clientv6"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/smartx"
@@ -37,6 +47,10 @@ import (
 const (
 	// DoradoV6PoolUsageType defines pool usage type of dorado v6
 	DoradoV6PoolUsageType = "0"
+	// SanPoolUsageType defines pool usage type of block storage
+	SanPoolUsageType = "1"
+	// NasPoolUsageType defines pool usage type of file storage
+	NasPoolUsageType = "2"
 
 	// ProtocolNfs defines protocol type nfs
 	ProtocolNfs = "nfs"
@@ -47,20 +61,35 @@ const (
 	SystemVStore = "0"
 
 	volumeNameSuffix = "-{{.PVCUid}}"
+
+	// maxVolumeNameLength is the name length limit used by the plugins which do not query it from the storage
+	maxVolumeNameLength  = client.MaxLunNameLengthOfDoradoV6
+	volumeNameHashLength = 8
+
+	remoteDeviceQueryParallelNum = 8
 )
 
+// frontEndProtocols are the protocols whose capabilities are reported by the online service ports
+var frontEndProtocols = []string{constants.ProtocolIscsi, "fc", "roce", constants.ProtocolNfs}
+
 // OceanstorPlugin provides oceanstor plugin base operations
 type OceanstorPlugin struct {
 	basePlugin
 
 	vStoreId string
 
+	volumeNameTpl string
+	alarmInError  bool
+
 	cli          client.OceanstorClientInterface
 	product      constants.OceanstorVersion
 	capabilities map[string]interface{}
 }
 
-func (p *OceanstorPlugin) init(ctx context.Context, config map[string]interface{}, keepLogin bool) error {
+// init initializes the client of the plugin. If validateOnly is true, only the credentials are validated,
+// the session is logged out immediately and the client of the plugin is not set.
+func (p *OceanstorPlugin) init(ctx context.Context, config map[string]interface{},
+	keepLogin, validateOnly bool) error {
 	backendClientConfig, err := formatOceanstorInitParam(config)
 	if err != nil {
 		return err
@@ -71,8 +100,14 @@ func (p *OceanstorPlugin) init(ctx context.Context, config map[string]interface{
 		return err
 	}
 
+	p.alarmInError, _ = config[constants.AlarmInErrorKey].(bool)
+	if validateOnly {
+		return p.validateLogin(ctx, cli, backendClientConfig.Name)
+	}
+
 	if err = cli.Login(ctx); err != nil {
-		log.AddContext(ctx).Errorf("plugin init login failed, err: %v", err)
+		log.AddContext(ctx).Errorf("plugin init login failed, failure: %s, err: %v",
+			storage.ClassifyLoginFailure(err), err)
 		return err
 	}
 
@@ -84,6 +119,7 @@ func (p *OceanstorPlugin) init(ctx context.Context, config map[string]interface{
 
 	p.name = backendClientConfig.Name
 	p.product = cli.Product
+	log.AddContext(ctx).Infof("Init client of backend %s with config: %s", p.name, cli.DescribeConfig())
 
 	if p.product.IsDoradoV6OrV7() {
 		clientV6, err := clientv6.NewClientV6(ctx, backendClientConfig)
@@ -109,53 +145,15 @@ func (p *OceanstorPlugin) init(ctx context.Context, config map[string]interface{
 	return nil
 }
 
-func (p *OceanstorPlugin) formatInitParam(config map[string]interface{}) (res *client.NewClientConfig, err error) {
-	res = &client.NewClientConfig{}
-
-	configUrls, exist := config["urls"].([]interface{})
-	if !exist || len(configUrls) <= 0 {
-		err = errors.New("urls must be provided")
-		return
-	}
-	for _, i := range configUrls {
-		res.Urls = append(res.Urls, i.(string))
-	}
-	res.User, exist = config["user"].(string)
-	if !exist {
-		err = errors.New("user must be provided")
-		return
-	}
-	res.SecretName, exist = config["secretName"].(string)
-	if !exist {
-		err = errors.New("SecretName must be provided")
-		return
-	}
-	res.SecretNamespace, exist = config["secretNamespace"].(string)
-	if !exist {
-		err = errors.New("SecretNamespace must be provided")
-		return
-	}
-	res.BackendID, exist = config["backendID"].(string)
-	if !exist {
-		err = errors.New("backendID must be provided")
-		return
-	}
-	res.VstoreName, _ = config["vstoreName"].(string)
-	res.ParallelNum, _ = config["maxClientThreads"].(string)
-
-	res.UseCert, _ = config["useCert"].(bool)
-	res.CertSecretMeta, _ = config["certSecret"].(string)
-
-	res.Storage, exist = config["storage"].(string)
-	if !exist {
-		return nil, errors.New("storage type must be configured for backend")
+func (p *OceanstorPlugin) validateLogin(ctx context.Context, cli client.OceanstorClientInterface, name string) error {
+	if err := cli.ValidateLogin(ctx); err != nil {
+		log.AddContext(ctx).Errorf("plugin validate login failed, err: %v", err)
+		return err
 	}
 
-	res.Name, exist = config["name"].(string)
-	if !exist {
-		return nil, errors.New("storage name must be configured for backend")
-	}
-	return
+	cli.Logout(ctx)
+	p.name = name
+	return nil
 }
 
 func (p *OceanstorPlugin) updateBackendCapabilities(ctx context.Context) (map[string]interface{}, error) {
@@ -168,13 +166,15 @@ func (p *OceanstorPlugin) updateBackendCapabilities(ctx context.Context) (map[st
 	log.AddContext(ctx).Debugf("Get license feature: %v", features)
 
 	supportThin := utils.IsSupportFeature(features, "SmartThin")
-	supportThick := !p.product.IsDorado() && !p.product.IsDoradoV6OrV7()
+	supportThick := p.supportThick()
 	supportQoS := utils.IsSupportFeature(features, "SmartQoS")
 	supportMetro := utils.IsSupportFeature(features, "HyperMetro")
 	supportMetroNAS := utils.IsSupportFeature(features, "HyperMetroNAS")
 	supportReplication := utils.IsSupportFeature(features, "HyperReplication")
 	supportClone := utils.IsSupportFeature(features, "HyperClone") || utils.IsSupportFeature(features, "HyperCopy")
 	supportApplicationType := p.product.IsDoradoV6OrV7()
+	supportDedupe := utils.IsSupportFeature(features, "SmartDedupe")
+	supportCompression := utils.IsSupportFeature(features, "SmartCompression")
 
 	log.AddContext(ctx).Debugf("storageVersion: %v", p.cli.GetStorageVersion())
 
@@ -187,18 +187,44 @@ func (p *OceanstorPlugin) updateBackendCapabilities(ctx context.Context) (map[st
 		"SupportApplicationType": supportApplicationType,
 		"SupportClone":           supportClone,
 		"SupportMetroNAS":        supportMetroNAS,
+		"SupportDedupe":          supportDedupe,
+		"SupportCompression":     supportCompression,
 	}
 
+	p.updateProtocolCapabilities(ctx, capabilities)
 	return capabilities, nil
 }
 
-func (p *OceanstorPlugin) getRemoteDevices(ctx context.Context) (string, error) {
+// updateProtocolCapabilities sets whether the service ports of each front-end protocol are online,
+// the capabilities are left unset if the protocols can not be queried, so that the backend is not excluded
+func (p *OceanstorPlugin) updateProtocolCapabilities(ctx context.Context, capabilities map[string]interface{}) {
+	protocols, err := p.cli.GetSupportedProtocols(ctx)
+	if err != nil {
+		log.AddContext(ctx).Warningf("Get supported protocols of backend %s error: %v", p.name, err)
+		return
+	}
+
+	log.AddContext(ctx).Debugf("Get supported protocols: %v", protocols)
+	for _, protocol := range frontEndProtocols {
+		capabilities[constants.SupportProtocolPrefix+strings.ToUpper(protocol)] = slices.Contains(protocols, protocol)
+	}
+}
+
+// remoteDeviceQuery queries the details of a remote device, such as the reachability,
+// the device is excluded from the result if the query returns error
+type remoteDeviceQuery func(ctx context.Context, device map[string]interface{}) error
+
+func (p *OceanstorPlugin) getRemoteDevices(ctx context.Context, query remoteDeviceQuery) (string, error) {
 	devices, err := p.cli.GetAllRemoteDevices(ctx)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Get remote devices error: %v", err)
 		return "", err
 	}
 
+	if query != nil {
+		devices = queryRemoteDevices(ctx, devices, query)
+	}
+
 	var devicesSN []string
 	for _, dev := range devices {
 		deviceSN, ok := dev["SN"].(string)
@@ -210,8 +236,39 @@ func (p *OceanstorPlugin) getRemoteDevices(ctx context.Context) (string, error)
 	return strings.Join(devicesSN, ";"), nil
 }
 
+// queryRemoteDevices queries the devices concurrently, the order of the devices queried successfully is preserved
+func queryRemoteDevices(ctx context.Context, devices []map[string]interface{},
+	query remoteDeviceQuery) []map[string]interface{} {
+	errs := make([]error, len(devices))
+	sem := utils.NewSemaphore(remoteDeviceQueryParallelNum)
+	var wg sync.WaitGroup
+	for i, dev := range devices {
+		wg.Add(1)
+		sem.Acquire()
+		go func(index int, device map[string]interface{}) {
+			defer func() {
+				sem.Release()
+				wg.Done()
+			}()
+			errs[index] = query(ctx, device)
+		}(i, dev)
+	}
+	wg.Wait()
+
+	var result []map[string]interface{}
+	for i, dev := range devices {
+		if errs[i] != nil {
+			log.AddContext(ctx).Warningf("Query remote device %v error: %v", dev["SN"], errs[i])
+			continue
+		}
+		result = append(result, dev)
+	}
+
+	return result
+}
+
 func (p *OceanstorPlugin) updateBackendSpecifications(ctx context.Context) (map[string]interface{}, error) {
-	devicesSN, err := p.getRemoteDevices(ctx)
+	devicesSN, err := p.getRemoteDevices(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -221,10 +278,55 @@ func (p *OceanstorPlugin) updateBackendSpecifications(ctx context.Context) (map[
 		"RemoteDevicesSN": devicesSN,
 		"VStoreID":        p.cli.GetvStoreID(),
 		"VStoreName":      p.cli.GetvStoreName(),
+		"ActiveURL":       p.cli.GetActiveURL(),
 	}
+
+	system, err := p.cli.GetSystemInfo(ctx)
+	if err != nil {
+		log.AddContext(ctx).Warningf("get system info of backend %s failed, error: %v", p.name, err)
+	} else {
+		specifications["ProductMode"] = system.ProductMode
+		specifications["PointRelease"] = system.PointRelease
+	}
+
+	skew, err := base.CheckClockSkew(ctx, p.cli, base.DefaultClockSkewThreshold)
+	if err != nil {
+		log.AddContext(ctx).Warningf("check clock skew of backend %s failed, error: %v", p.name, err)
+	} else {
+		// the skew is rounded so that the specifications do not change with the jitter of the requests
+		specifications["ClockSkew"] = skew.Round(time.Second).String()
+	}
+
+	p.updateLicenseExpiry(ctx, specifications)
 	return specifications, nil
 }
 
+// updateLicenseExpiry sets the expiry dates of the temporary licenses as LicenseExpiry.<feature>, and the features
+// expiring soon as LicenseExpiringSoon, since the capabilities provided by them are removed silently after they expire
+func (p *OceanstorPlugin) updateLicenseExpiry(ctx context.Context, specifications map[string]interface{}) {
+	expiries, err := p.cli.GetLicenseExpiry(ctx)
+	if err != nil {
+		log.AddContext(ctx).Warningf("get license expiry of backend %s failed, error: %v", p.name, err)
+		return
+	}
+
+	var expiringSoon []string
+	for feature := range expiries {
+		soon, expiry := base.IsFeatureExpiringSoon(expiries, feature, base.DefaultLicenseExpiryWarning)
+		specifications[constants.LicenseExpiryKeyPrefix+feature] = expiry.UTC().Format(time.RFC3339)
+		if soon {
+			log.AddContext(ctx).Warningf("license of feature %s of backend %s expires at %s, the capabilities "+
+				"provided by it will be removed after then", feature, p.name, expiry.UTC().Format(time.RFC3339))
+			expiringSoon = append(expiringSoon, feature)
+		}
+	}
+
+	if len(expiringSoon) != 0 {
+		slices.Sort(expiringSoon)
+		specifications[constants.LicenseExpiringSoonKey] = strings.Join(expiringSoon, ",")
+	}
+}
+
 // updateVStorePair update vStore pair info
 func (p *OceanstorPlugin) updateVStorePair(ctx context.Context, specifications map[string]interface{}) {
 	if specifications == nil {
@@ -309,6 +411,25 @@ func getParams(ctx context.Context, name string,
 	return params
 }
 
+// supportThick checks whether the storage supports the thick allocation, the Dorado storage only allocates
+// the space of the volumes on demand
+func (p *OceanstorPlugin) supportThick() bool {
+	return !p.product.IsDorado() && !p.product.IsDoradoV6OrV7()
+}
+
+// checkDryRun returns an error with the masked params if the dryRun parameter is true,
+// so that the assembled params can be checked without creating the volume on storage
+func checkDryRun(ctx context.Context, parameters, params map[string]interface{}) error {
+	dryRun, ok := utils.GetValue[string](parameters, constants.DryRunKey)
+	if !ok || !utils.StrToBool(ctx, dryRun) {
+		return nil
+	}
+
+	maskedParams := base.MaskRequestData(params)
+	log.AddContext(ctx).Infof("Dry run of creating volume %v, params: %v", params["name"], maskedParams)
+	return fmt.Errorf("dry run is enabled, volume %v is not created, params: %v", params["name"], maskedParams)
+}
+
 // resetParams process need reset param
 func resetParams(source, target map[string]interface{}) {
 	if source == nil || target == nil {
@@ -332,6 +453,7 @@ func processBoolParams(ctx context.Context, source, target map[string]interface{
 	for _, i := range []string{
 		"replication",
 		"hyperMetro",
+		"sharedQoS",
 	} {
 		if v, exist := source[i].(string); exist && v != "" {
 			target[strings.ToLower(i)] = utils.StrToBool(ctx, v)
@@ -372,6 +494,8 @@ func toLowerParams(source, target map[string]interface{}) {
 		"accesskrb5p",
 		"fileSystemMode",
 		"metroPairSyncSpeed",
+		"tieringPolicy",
+		"preferredController",
 	} {
 		if v, exist := source[key]; exist && v != "" {
 			target[strings.ToLower(key)] = v
@@ -381,6 +505,69 @@ func toLowerParams(source, target map[string]interface{}) {
 
 func (p *OceanstorPlugin) updatePoolCapabilities(ctx context.Context, poolNames []string,
 	vStoreQuotaMap map[string]interface{}, usageType string) (map[string]interface{}, error) {
+	validPools, err := p.getValidPools(ctx, poolNames, usageType)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := analyzePoolsCapacity(ctx, validPools, vStoreQuotaMap)
+	return capabilities, nil
+}
+
+// getVStoreQuota returns the capacity quotas of the vstore of the backend,
+// nil is returned if the storage does not support the vstore quota or the backend is not in a vstore
+func (p *OceanstorPlugin) getVStoreQuota(ctx context.Context) (*client.VStoreQuota, error) {
+	// only Dorado V6 6.1.5 and later versions need to get vStore's capacity.
+	if !p.product.IsDoradoV6OrV7() ||
+		(p.product.IsDoradoV6() && version.CompareVersions(p.cli.GetStorageVersion(), constants.DoradoV615) == -1) ||
+		p.cli.GetvStoreName() == "" {
+		return nil, nil
+	}
+
+	quota, err := p.cli.GetVStoreQuota(ctx, p.cli.GetvStoreID())
+	if err != nil {
+		return nil, err
+	}
+
+	log.AddContext(ctx).Debugf("get quota of vstore %s, san: %+v, nas: %+v", p.cli.GetvStoreName(),
+		quota.SAN, quota.NAS)
+	return quota, nil
+}
+
+// aggregateFreeCapacity returns the total free bytes of the pools for the usage type, the vStore quota limits
+// the whole vStore instead of each pool, so the total is capped by the free capacity of the quota once
+func (p *OceanstorPlugin) aggregateFreeCapacity(ctx context.Context, poolNames []string,
+	vStoreQuotaMap map[string]interface{}, usageType string) (int64, error) {
+	validPools, err := p.getValidPools(ctx, poolNames, usageType)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, pool := range validPools {
+		freeStr, ok := pool["USERFREECAPACITY"].(string)
+		if !ok {
+			log.AddContext(ctx).Warningf("Free capacity of pool %v does not exist", pool["NAME"])
+			continue
+		}
+
+		free, err := strconv.ParseInt(freeStr, constants.DefaultIntBase, constants.DefaultIntBitSize)
+		if err != nil {
+			return 0, fmt.Errorf("parse free capacity %s of pool %v failed, error: %v", freeStr, pool["NAME"], err)
+		}
+		total += free * constants.AllocationUnitBytes
+	}
+
+	if quotaFree, ok := vStoreQuotaMap[string(xuanwuV1.FreeCapacity)].(int64); ok && quotaFree < total {
+		log.AddContext(ctx).Debugf("free capacity %d of pools is capped by vstore quota %d", total, quotaFree)
+		return quotaFree, nil
+	}
+
+	return total, nil
+}
+
+func (p *OceanstorPlugin) getValidPools(ctx context.Context, poolNames []string,
+	usageType string) ([]map[string]interface{}, error) {
 	pools, err := p.cli.GetAllPools(ctx)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Get all pools error: %v", err)
@@ -404,8 +591,7 @@ func (p *OceanstorPlugin) updatePoolCapabilities(ctx context.Context, poolNames
 		}
 	}
 
-	capabilities := analyzePoolsCapacity(ctx, validPools, vStoreQuotaMap)
-	return capabilities, nil
+	return validPools, nil
 }
 
 // SupportQoSParameters checks requested QoS parameters support by Oceanstor plugin
@@ -420,6 +606,13 @@ func (p *OceanstorPlugin) Logout(ctx context.Context) {
 	}
 }
 
+// Close logs out the storage session and releases the resources of the client, it is called when the backend is removed
+func (p *OceanstorPlugin) Close(ctx context.Context) {
+	if p.cli != nil {
+		p.cli.Close(ctx)
+	}
+}
+
 // ReLogin will refresh the user session of storage
 func (p *OceanstorPlugin) ReLogin(ctx context.Context) error {
 	if p.cli == nil {
@@ -429,6 +622,15 @@ func (p *OceanstorPlugin) ReLogin(ctx context.Context) error {
 	return p.cli.ReLogin(ctx)
 }
 
+// GetDeviceSN returns the SN of the storage device connected by the plugin
+func (p *OceanstorPlugin) GetDeviceSN() string {
+	if p.cli == nil {
+		return ""
+	}
+
+	return p.cli.GetDeviceSN()
+}
+
 // GetSectorSize get sector size of plugin
 func (p *OceanstorPlugin) GetSectorSize() int64 {
 	return SectorSize
@@ -457,17 +659,124 @@ func (p *OceanstorPlugin) switchClient(ctx context.Context, newClient client.Oce
 var (
 	pvcNamespaceRe = regexp.MustCompile(`\{\{\s*\.PVCNamespace\s*\}\}`)
 	pvcNameRe      = regexp.MustCompile(`\{\{\s*\.PVCName\s*\}\}`)
+	pvcUidRe       = regexp.MustCompile(`\{\{\s*\.PVCUid\s*\}\}`)
+	pvNameRe       = regexp.MustCompile(`\{\{\s*\.PVName\s*\}\}`)
 )
 
+// isUniqueVolumeName checks whether the volume name template resolves to a unique name by itself,
+// the volumeNameSuffix is not appended to such template
+func isUniqueVolumeName(volumeNameTpl string) bool {
+	return pvcUidRe.MatchString(volumeNameTpl) || pvNameRe.MatchString(volumeNameTpl)
+}
+
 func validateVolumeName(volumeNameTpl string) error {
+	if isUniqueVolumeName(volumeNameTpl) {
+		return nil
+	}
+
 	if !pvcNamespaceRe.MatchString(volumeNameTpl) || !pvcNameRe.MatchString(volumeNameTpl) {
-		return errors.New("{{.PVCNamespace}} or {{." +
-			"PVCName}} must be configured in the volumeName parameter at the same time")
+		return errors.New("{{.PVCUid}}, {{.PVName}} or both {{.PVCNamespace}} and {{." +
+			"PVCName}} must be configured in the volumeName parameter")
 	}
 
 	return nil
 }
 
+// truncateVolumeName truncates the volume name to the given name length limit deterministically,
+// the hash of the full name is kept at the end so that the truncated names do not collide,
+// the name is cut on a rune boundary so that a multibyte character is not split
+func truncateVolumeName(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+
+	cut := maxLength - volumeNameHashLength - 1
+	for cut > 0 && !utf8.RuneStart(name[cut]) {
+		cut--
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])[:volumeNameHashLength]
+	return name[:cut] + "-" + hash
+}
+
+// setVolumeNameTpl sets the volume name template of the backend,
+// it is used if the volumeName parameter is not configured in StorageClass
+func (p *OceanstorPlugin) setVolumeNameTpl(parameters map[string]interface{}) error {
+	volumeNameTpl, _ := utils.GetValue[string](parameters, constants.ScVolumeNameKey)
+	if volumeNameTpl == "" {
+		return nil
+	}
+
+	if err := validateVolumeName(volumeNameTpl); err != nil {
+		return fmt.Errorf("invalid volumeName parameter of backend: %w", err)
+	}
+
+	p.volumeNameTpl = volumeNameTpl
+	return nil
+}
+
+// getVolumeName renders the volume name by the template configured in StorageClass or backend,
+// the name is not truncated here since the name length limit depends on the object type on the storage
+func (p *OceanstorPlugin) getVolumeName(pvName string, parameters map[string]any) (string, error) {
+	volumeNameTpl, _ := utils.GetValue[string](parameters, constants.ScVolumeNameKey)
+	if volumeNameTpl == "" {
+		volumeNameTpl = p.volumeNameTpl
+	}
+
+	return renderVolumeName(pvName, volumeNameTpl, parameters)
+}
+
+// fitVolumeName truncates the rendered volume name to the name length limit of the object type on the storage,
+// an error is returned only if the limit is too short to hold even the hash of the name
+func (p *OceanstorPlugin) fitVolumeName(name, objType string) (string, error) {
+	maxLength, err := p.cli.GetMaxNameLength(objType)
+	if err != nil {
+		return "", err
+	}
+
+	if len(name) > maxLength && maxLength <= volumeNameHashLength+1 {
+		return "", fmt.Errorf("the length %d of volume name %s exceeds the maximum name length %d of %s "+
+			"on backend %s, which is too short to truncate the name", len(name), name, maxLength, objType, p.name)
+	}
+
+	return truncateVolumeName(name, maxLength), nil
+}
+
+// withActiveAlarm appends the most severe relevant active alarm of the storage to the error of a failed creation
+// if alarmInError is enabled, so that the environmental cause such as a degraded pool is surfaced in the events of
+// the pvc. All the alarms are relevant to an error returned by the storage, otherwise only the ones located at the pool
+func (p *OceanstorPlugin) withActiveAlarm(ctx context.Context, err error, pool string) error {
+	if !p.alarmInError {
+		return err
+	}
+
+	var storageErr *utils.StorageError
+	storageSide := errors.As(err, &storageErr) || errors.Is(err, base.ErrStorageBusy)
+	if !storageSide && pool == "" {
+		return err
+	}
+
+	alarms, alarmErr := p.cli.GetActiveAlarms(ctx)
+	if alarmErr != nil {
+		log.AddContext(ctx).Warningf("get active alarms of backend %s failed, error: %v", p.name, alarmErr)
+		return err
+	}
+
+	if !storageSide {
+		alarms = slices.DeleteFunc(alarms, func(alarm client.AlarmInfo) bool {
+			return !strings.Contains(alarm.Location, pool)
+		})
+	}
+
+	alarm := client.MostSevereAlarm(alarms, client.AlarmSeverityMajor)
+	if alarm == nil {
+		return err
+	}
+
+	return fmt.Errorf("%w, the most severe active alarm of backend %s is %s", err, p.name, alarm)
+}
+
 func newExtraCreateMetadataFromParameters(parameters map[string]any) (map[string]string, error) {
 	for _, key := range []string{constants.PVCNamespaceKey, constants.PVCNameKey, constants.PVNameKey} {
 		if _, exist := parameters[key]; !exist {
@@ -534,13 +843,14 @@ func checkClientConfig(param map[string]interface{}, data *client.NewClientConfi
 		return fmt.Errorf("verify user: [%v] failed. user must be provided", data.User)
 	}
 
+	data.CredentialsPath, _ = utils.GetValue[string](param, constants.CredentialsPathKey)
 	data.SecretName, ok = utils.GetValue[string](param, "secretName")
-	if !ok {
+	if !ok && data.CredentialsPath == "" {
 		return fmt.Errorf("verify SecretName: [%v] failed. SecretName must be provided", data.SecretName)
 	}
 
 	data.SecretNamespace, ok = utils.GetValue[string](param, "secretNamespace")
-	if !ok {
+	if !ok && data.CredentialsPath == "" {
 		return fmt.Errorf("verify SecretNamespace: [%v] failed. SecretNamespace must be provided", data.SecretNamespace)
 	}
 
@@ -559,7 +869,16 @@ func checkClientConfig(param map[string]interface{}, data *client.NewClientConfi
 
 	data.VstoreName, _ = utils.GetValue[string](param, "vstoreName")
 	data.ParallelNum, _ = utils.GetValue[string](param, "maxClientThreads")
+	data.TenantParallelNum, _ = utils.GetValue[string](param, "maxTenantClientThreads")
 	data.UseCert, _ = utils.GetValue[bool](param, "useCert")
 	data.CertSecretMeta, _ = utils.GetValue[string](param, "certSecret")
-	return nil
+	data.CASecretMeta, _ = utils.GetValue[string](param, constants.CASecretKey)
+	data.LoginScope, _ = utils.GetValue[string](param, constants.LoginScopeKey)
+	data.ProxyURL, _ = utils.GetValue[string](param, constants.ProxyURLKey)
+	data.Headers = getCustomHeaders(param)
+	if _, err := storage.NewProxyFunc(data.ProxyURL); err != nil {
+		return fmt.Errorf("verify proxyURL failed, err: %w", err)
+	}
+
+	return pkgUtils.CheckLoginScope(data.LoginScope)
 }
@@ -280,11 +280,14 @@ func (p *OceanstorASeriesPlugin) getBackendCapabilities(ctx context.Context) (ma
 }
 
 func (p *OceanstorASeriesPlugin) getBackendSpecifications() map[string]interface{} {
+	systemInfo := p.cli.GetSystemInfo()
 	specifications := map[string]interface{}{
-		"LocalDeviceSN": p.cli.GetDeviceSN(),
-		"VStoreID":      p.cli.GetvStoreID(),
-		"VStoreName":    p.cli.GetvStoreName(),
-		"DeviceWWN":     p.cli.GetDeviceWWN(),
+		"LocalDeviceSN":  p.cli.GetDeviceSN(),
+		"VStoreID":       p.cli.GetvStoreID(),
+		"VStoreName":     p.cli.GetvStoreName(),
+		"DeviceWWN":      p.cli.GetDeviceWWN(),
+		"DeviceModel":    systemInfo.Model,
+		"ProductVersion": systemInfo.ProductVersion,
 	}
 	return specifications
 }
@@ -337,6 +340,13 @@ func (p *OceanstorASeriesPlugin) Logout(ctx context.Context) {
 	}
 }
 
+// Close logs out the storage session and releases the resources of the client, it is called when the backend is removed
+func (p *OceanstorASeriesPlugin) Close(ctx context.Context) {
+	if p.cli != nil {
+		p.cli.Close(ctx)
+	}
+}
+
 // GetSectorSize gets the sector size of plugin
 func (p *OceanstorASeriesPlugin) GetSectorSize() int64 {
 	return SectorSize
@@ -346,10 +346,12 @@ func TestOceanstorASeriesPlugin_UpdateBackendCapabilities(t *testing.T) {
 		"SupportNFS42":              false,
 	}
 	wantSpecifications := map[string]interface{}{
-		"LocalDeviceSN": "test-sn",
-		"VStoreID":      "test-vstore-id",
-		"VStoreName":    "test-vstore-name",
-		"DeviceWWN":     "test-wwn",
+		"LocalDeviceSN":  "test-sn",
+		"VStoreID":       "test-vstore-id",
+		"VStoreName":     "test-vstore-name",
+		"DeviceWWN":      "test-wwn",
+		"DeviceModel":    "A800",
+		"ProductVersion": "V100R001C00",
 	}
 
 	// mock
@@ -359,6 +361,7 @@ func TestOceanstorASeriesPlugin_UpdateBackendCapabilities(t *testing.T) {
 	cli.EXPECT().GetvStoreID().Return("test-vstore-id")
 	cli.EXPECT().GetvStoreName().Return("test-vstore-name")
 	cli.EXPECT().GetDeviceWWN().Return("test-wwn")
+	cli.EXPECT().GetSystemInfo().Return(client.ASeriesSystem{Model: "A800", ProductVersion: "V100R001C00"})
 
 	// act
 	gotCapabilities, gotSpecifications, gotErr := p.UpdateBackendCapabilities(ctx)
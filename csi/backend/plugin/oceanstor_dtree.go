@@ -66,7 +66,11 @@ func (p *OceanstorDTreePlugin) Init(ctx context.Context, config map[string]inter
 		return err
 	}
 
-	err = p.init(ctx, config, keepLogin)
+	if err = p.setVolumeNameTpl(parameters); err != nil {
+		return err
+	}
+
+	err = p.init(ctx, config, keepLogin, false)
 	if err != nil {
 		log.AddContext(ctx).Errorf("init dtree plugin failed, data:")
 		return err
@@ -91,10 +95,13 @@ func (p *OceanstorDTreePlugin) CreateVolume(ctx context.Context, name string, pa
 
 	var err error
 	if p.product.IsDoradoV6OrV7() {
-		name, err = getVolumeNameFromPVNameOrParameters(name, parameters)
+		name, err = p.getVolumeName(name, parameters)
 		if err != nil {
 			return nil, err
 		}
+		if name, err = p.fitVolumeName(name, client.ObjectTypeDTree); err != nil {
+			return nil, err
+		}
 	}
 
 	parentname := p.parentName
@@ -107,6 +114,9 @@ func (p *OceanstorDTreePlugin) CreateVolume(ctx context.Context, name string, pa
 	parameters["vstoreId"] = p.vStoreId
 	parameters["parentname"] = parentname
 	params := getParams(ctx, name, parameters)
+	if err = checkDryRun(ctx, parameters, params); err != nil {
+		return nil, err
+	}
 
 	volObj, err := p.getDTreeObj().Create(ctx, params)
 	if err != nil {
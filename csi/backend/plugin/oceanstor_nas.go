@@ -23,10 +23,8 @@ import (
 	"errors"
 	"fmt"
 	"net"
-	"strconv"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/cli/helper"
-	xuanwuV1 "github.com/Huawei/eSDK_K8S_Plugin/v4/client/apis/xuanwu/v1"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
 	pkgVolume "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/volume"
@@ -92,7 +90,11 @@ func (p *OceanstorNasPlugin) Init(ctx context.Context, config map[string]interfa
 		return err
 	}
 
-	err = p.init(ctx, config, keepLogin)
+	if err = p.setVolumeNameTpl(parameters); err != nil {
+		return err
+	}
+
+	err = p.init(ctx, config, keepLogin, false)
 	if err != nil {
 		log.AddContext(ctx).Errorf("init oceanstor nas failed, config: %+v, parameters: %+v err: %v",
 			config, parameters, err)
@@ -154,21 +156,30 @@ func (p *OceanstorNasPlugin) CreateVolume(ctx context.Context, name string, para
 	volumeName := name
 	var err error
 	if p.product.IsDoradoV6OrV7() {
-		volumeName, err = getVolumeNameFromPVNameOrParameters(name, parameters)
+		volumeName, err = p.getVolumeName(name, parameters)
 		if err != nil {
 			return nil, err
 		}
+		if volumeName, err = p.fitVolumeName(volumeName, client.ObjectTypeFilesystem); err != nil {
+			return nil, err
+		}
 	}
 
 	params := getParams(ctx, volumeName, parameters)
 	params["metroDomainID"] = p.metroDomainID
 	params["pvName"] = name
+	if err = checkDryRun(ctx, parameters, params); err != nil {
+		return nil, err
+	}
+
 	nas := p.getNasObj()
 	volObj, err := nas.Create(ctx, params)
 	if err != nil {
-		return nil, err
+		pool, _ := utils.GetValue[string](params, "storagepool")
+		return nil, p.withActiveAlarm(ctx, err, pool)
 	}
 
+	p.cli.InvalidatePoolCache()
 	return volObj, nil
 }
 
@@ -196,7 +207,12 @@ func (p *OceanstorNasPlugin) DeleteVolume(ctx context.Context, name string) erro
 		}
 	}
 	nas := p.getNasObj()
-	return nas.Delete(ctx, name)
+	if err := nas.Delete(ctx, name); err != nil {
+		return err
+	}
+
+	p.cli.InvalidatePoolCache()
+	return nil
 }
 
 // ExpandVolume used to expand volume
@@ -207,7 +223,12 @@ func (p *OceanstorNasPlugin) ExpandVolume(ctx context.Context, name string, size
 		}
 	}
 	nas := p.getNasObj()
-	return false, nas.Expand(ctx, name, size)
+	if err := nas.Expand(ctx, name, size); err != nil {
+		return false, err
+	}
+
+	p.cli.InvalidatePoolCache()
+	return false, nil
 }
 
 // UpdatePoolCapabilities used to update pool capabilities
@@ -222,46 +243,29 @@ func (p *OceanstorNasPlugin) UpdatePoolCapabilities(ctx context.Context,
 	return p.updatePoolCapabilities(ctx, poolNames, vStoreQuotaMap, "2")
 }
 
-func (p *OceanstorNasPlugin) getVstoreCapacity(ctx context.Context) (map[string]interface{}, error) {
-	// only Dorado V6 6.1.5 and later versions need to get vStore's capacity.
-	if !p.product.IsDoradoV6OrV7() ||
-		(p.product.IsDoradoV6() && version.CompareVersions(p.cli.GetStorageVersion(), constants.DoradoV615) == -1) ||
-		p.cli.GetvStoreName() == "" {
-		return map[string]interface{}{}, nil
-	}
-	vStore, err := p.cli.GetvStoreByName(ctx, p.cli.GetvStoreName())
+// AggregateFreeCapacity returns the total free bytes of the pools for the usage type capped by the vstore quota,
+// used for CSI GetCapacity
+func (p *OceanstorNasPlugin) AggregateFreeCapacity(ctx context.Context, poolNames []string,
+	usageType string) (int64, error) {
+	vStoreQuotaMap, err := p.getVstoreCapacity(ctx)
 	if err != nil {
-		return nil, err
-	}
-	if vStore == nil {
-		return nil, fmt.Errorf("not find vstore by name, name: %s", p.cli.GetvStoreName())
+		log.AddContext(ctx).Debugf("get vstore capacity failed, err: %v", err)
+		vStoreQuotaMap = map[string]interface{}{}
 	}
 
-	var nasCapacityQuota, nasFreeCapacityQuota int64
+	return p.aggregateFreeCapacity(ctx, poolNames, vStoreQuotaMap, usageType)
+}
 
-	if totalStr, ok := vStore["nasCapacityQuota"].(string); ok {
-		nasCapacityQuota, err = strconv.ParseInt(totalStr, constants.DefaultIntBase, constants.DefaultIntBitSize)
-	}
-	if freeStr, ok := vStore["nasFreeCapacityQuota"].(string); ok {
-		nasFreeCapacityQuota, err = strconv.ParseInt(freeStr, constants.DefaultIntBase, constants.DefaultIntBitSize)
-	}
+func (p *OceanstorNasPlugin) getVstoreCapacity(ctx context.Context) (map[string]interface{}, error) {
+	quota, err := p.getVStoreQuota(ctx)
 	if err != nil {
-		log.AddContext(ctx).Warningf("parse vstore quota failed, error: %v", err)
 		return nil, err
 	}
-
-	log.AddContext(ctx).Debugf("nasFreeCapacityQuota %v,nasCapacityQuota %v",
-		nasFreeCapacityQuota, nasCapacityQuota)
-	// if not set quota, nasCapacityQuota is 0, nasFreeCapacityQuota is -1
-	if nasCapacityQuota == 0 || nasFreeCapacityQuota == -1 {
+	if quota == nil {
 		return map[string]interface{}{}, nil
 	}
 
-	return map[string]interface{}{
-		string(xuanwuV1.FreeCapacity):  nasFreeCapacityQuota * constants.AllocationUnitBytes,
-		string(xuanwuV1.TotalCapacity): nasCapacityQuota * constants.AllocationUnitBytes,
-		string(xuanwuV1.UsedCapacity):  (nasCapacityQuota - nasFreeCapacityQuota) * constants.AllocationUnitBytes,
-	}, nil
+	return capacityQuotaToMap(quota.NAS), nil
 }
 
 // UpdateMetroRemotePlugin used to convert metroRemotePlugin to OceanstorSanPlugin
@@ -38,21 +38,21 @@ func TestInit(t *testing.T) {
 		wantErr    bool
 	}{
 		{"Normal",
-			map[string]interface{}{"urls": []interface{}{"*.*.*.*"}, "backendID": "mock-backendID",
+			map[string]interface{}{"urls": []interface{}{"https://*.*.*.*:8088"}, "backendID": "mock-backendID",
 				"user": "testUser", "secretName": "mock-secretname", "secretNamespace": "mock-namespace",
 				"keyText": "0NuSPbY4r6rANmmAipqPTMRpSlz3OULX", "storage": "oceanstor-nas", "name": "test"},
 			map[string]interface{}{"protocol": "nfs", "portals": []interface{}{"*.*.*.*"}},
 			false, false,
 		},
 		{"ProtocolErr",
-			map[string]interface{}{"urls": []interface{}{"*.*.*.*"}, "backendID": "mock-backendID",
+			map[string]interface{}{"urls": []interface{}{"https://*.*.*.*:8088"}, "backendID": "mock-backendID",
 				"user": "testUser", "secretName": "mock-secretname", "secretNamespace": "mock-namespace",
 				"keyText": "0NuSPbY4r6rANmmAipqPTMRpSlz3OULX", "storage": "oceanstor-nas", "name": "test"},
 			map[string]interface{}{"protocol": "wrong", "portals": []interface{}{"*.*.*.1"}},
 			false, true,
 		},
 		{"PortNotUnique",
-			map[string]interface{}{"urls": []interface{}{"*.*.*.*"}, "backendID": "mock-backendID",
+			map[string]interface{}{"urls": []interface{}{"https://*.*.*.*:8088"}, "backendID": "mock-backendID",
 				"user": "testUser", "secretName": "mock-secretname", "secretNamespace": "mock-namespace",
 				"keyText": "0NuSPbY4r6rANmmAipqPTMRpSlz3OULX", "storage": "oceanstor-nas", "name": "test"},
 			map[string]interface{}{"protocol": "wrong", "portals": []interface{}{"*.*.*.1", "*.*.*.2"}},
@@ -95,7 +95,7 @@ func TestValidate(t *testing.T) {
 			"protocol": "nfs",
 			"portals":  portals,
 		}
-		urls := []interface{}{"127.0.0.1"}
+		urls := []interface{}{"https://127.0.0.1:8088"}
 		config := map[string]interface{}{
 			"parameters":         parameters,
 			"urls":               urls,
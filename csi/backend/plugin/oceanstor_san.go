@@ -22,10 +22,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"strconv"
 	"sync"
+	"time"
 
-	xuanwuV1 "github.com/Huawei/eSDK_K8S_Plugin/v4/client/apis/xuanwu/v1"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	pkgVolume "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/volume"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/proto"
@@ -34,7 +33,6 @@ import (
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/volume"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
-	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/version"
 )
 
 const (
@@ -51,6 +49,10 @@ type OceanstorSanPlugin struct {
 	portals  []string
 	alua     map[string]interface{}
 
+	unmapWaitTimeout time.Duration
+	// chapSecret is the secret of the CHAP credentials of the iscsi initiators, the CHAP is not enabled if it is empty
+	chapSecret string
+
 	replicaRemotePlugin *OceanstorSanPlugin
 	metroRemotePlugin   *OceanstorSanPlugin
 	storageOnline       bool
@@ -86,6 +88,20 @@ func (p *OceanstorSanPlugin) Init(ctx context.Context, config map[string]interfa
 
 	p.alua, _ = parameters["ALUA"].(map[string]interface{})
 
+	if timeout, ok := parameters["unmapWaitTimeout"].(string); ok && timeout != "" {
+		unmapWaitTimeout, err := time.ParseDuration(timeout)
+		if err != nil {
+			return fmt.Errorf("unmapWaitTimeout %s of oceanstor-san backend is invalid: %w", timeout, err)
+		}
+		p.unmapWaitTimeout = unmapWaitTimeout
+	}
+
+	p.chapSecret, _ = parameters[constants.ChapSecretKey].(string)
+	if p.chapSecret != "" && protocol != "iscsi" {
+		return fmt.Errorf("%s is only supported by the iscsi protocol, but the protocol is %s",
+			constants.ChapSecretKey, protocol)
+	}
+
 	if protocol == "iscsi" || protocol == "roce" {
 		portals, exist := parameters["portals"].([]interface{})
 		if !exist {
@@ -100,7 +116,11 @@ func (p *OceanstorSanPlugin) Init(ctx context.Context, config map[string]interfa
 		p.portals = IPs
 	}
 
-	err := p.init(ctx, config, keepLogin)
+	if err := p.setVolumeNameTpl(parameters); err != nil {
+		return err
+	}
+
+	err := p.init(ctx, config, keepLogin, false)
 	if err != nil {
 		return err
 	}
@@ -130,7 +150,7 @@ func (p *OceanstorSanPlugin) getSanObj() *volume.SAN {
 		replicaRemoteCli = p.replicaRemotePlugin.cli
 	}
 
-	return volume.NewSAN(p.cli, metroRemoteCli, replicaRemoteCli, p.product)
+	return volume.NewSAN(p.cli, metroRemoteCli, replicaRemoteCli, p.product).WithUnmapWaitTimeout(p.unmapWaitTimeout)
 }
 
 // CreateVolume used to create volume
@@ -138,19 +158,29 @@ func (p *OceanstorSanPlugin) CreateVolume(ctx context.Context,
 	name string, parameters map[string]interface{}) (utils.Volume, error) {
 	var err error
 	if p.product.IsDoradoV6OrV7() {
-		name, err = getVolumeNameFromPVNameOrParameters(name, parameters)
+		name, err = p.getVolumeName(name, parameters)
 		if err != nil {
 			return nil, err
 		}
+		if name, err = p.fitVolumeName(name, client.ObjectTypeLun); err != nil {
+			return nil, err
+		}
 	}
 
 	params := getParams(ctx, name, parameters)
+	if err = checkDryRun(ctx, parameters, params); err != nil {
+		return nil, err
+	}
+
 	san := p.getSanObj()
 
 	volObj, err := san.Create(ctx, params)
 	if err != nil {
-		return nil, err
+		pool, _ := utils.GetValue[string](params, "storagepool")
+		return nil, p.withActiveAlarm(ctx, err, pool)
 	}
+
+	p.cli.InvalidatePoolCache()
 	return volObj, nil
 }
 
@@ -164,13 +194,24 @@ func (p *OceanstorSanPlugin) QueryVolume(ctx context.Context, name string, param
 // DeleteVolume used to delete volume
 func (p *OceanstorSanPlugin) DeleteVolume(ctx context.Context, name string) error {
 	san := p.getSanObj()
-	return san.Delete(ctx, name)
+	if err := san.Delete(ctx, name); err != nil {
+		return err
+	}
+
+	p.cli.InvalidatePoolCache()
+	return nil
 }
 
 // ExpandVolume used to expand volume
 func (p *OceanstorSanPlugin) ExpandVolume(ctx context.Context, name string, size int64) (bool, error) {
 	san := p.getSanObj()
-	return san.Expand(ctx, name, size)
+	needExpandFS, err := san.Expand(ctx, name, size)
+	if err != nil {
+		return false, err
+	}
+
+	p.cli.InvalidatePoolCache()
+	return needExpandFS, nil
 }
 
 func (p *OceanstorSanPlugin) isHyperMetro(ctx context.Context, lun map[string]interface{}) bool {
@@ -218,20 +259,22 @@ func (p *OceanstorSanPlugin) metroHandler(ctx context.Context, req handlerReques
 	}
 
 	localAttacher := attacher.NewAttacher(attacher.VolumeAttacherConfig{
-		Product:  p.product,
-		Cli:      req.localCli,
-		Protocol: p.protocol,
-		Invoker:  "csi",
-		Portals:  p.portals,
-		Alua:     p.alua,
+		Product:    p.product,
+		Cli:        req.localCli,
+		Protocol:   p.protocol,
+		Invoker:    "csi",
+		Portals:    p.portals,
+		Alua:       p.alua,
+		ChapSecret: p.chapSecret,
 	})
 	remoteAttacher := attacher.NewAttacher(attacher.VolumeAttacherConfig{
-		Product:  p.metroRemotePlugin.product,
-		Cli:      req.metroCli,
-		Protocol: p.metroRemotePlugin.protocol,
-		Invoker:  "csi",
-		Portals:  p.metroRemotePlugin.portals,
-		Alua:     p.metroRemotePlugin.alua,
+		Product:    p.metroRemotePlugin.product,
+		Cli:        req.metroCli,
+		Protocol:   p.metroRemotePlugin.protocol,
+		Invoker:    "csi",
+		Portals:    p.metroRemotePlugin.portals,
+		Alua:       p.metroRemotePlugin.alua,
+		ChapSecret: p.metroRemotePlugin.chapSecret,
 	})
 
 	metroAttacher := attacher.NewMetroAttacher(localAttacher, remoteAttacher, p.protocol)
@@ -247,12 +290,13 @@ func (p *OceanstorSanPlugin) metroHandler(ctx context.Context, req handlerReques
 func (p *OceanstorSanPlugin) commonHandler(ctx context.Context,
 	plugin *OceanstorSanPlugin, lun, parameters map[string]any, method string) ([]reflect.Value, error) {
 	commonAttacher := attacher.NewAttacher(attacher.VolumeAttacherConfig{
-		Product:  plugin.product,
-		Cli:      plugin.cli,
-		Protocol: plugin.protocol,
-		Invoker:  "csi",
-		Portals:  plugin.portals,
-		Alua:     plugin.alua,
+		Product:    plugin.product,
+		Cli:        plugin.cli,
+		Protocol:   plugin.protocol,
+		Invoker:    "csi",
+		Portals:    plugin.portals,
+		Alua:       plugin.alua,
+		ChapSecret: plugin.chapSecret,
 	})
 
 	lunName, ok := lun["NAME"].(string)
@@ -405,44 +449,29 @@ func (p *OceanstorSanPlugin) UpdatePoolCapabilities(ctx context.Context, poolNam
 	return p.updatePoolCapabilities(ctx, poolNames, vStoreQuotaMap, "1")
 }
 
-func (p *OceanstorSanPlugin) getVstoreCapacity(ctx context.Context) (map[string]interface{}, error) {
-	// only Dorado V6 6.1.5 and later versions need to get vStore's capacity.
-	if !p.product.IsDoradoV6OrV7() ||
-		(p.product.IsDoradoV6() && version.CompareVersions(p.cli.GetStorageVersion(), constants.DoradoV615) == -1) ||
-		p.cli.GetvStoreName() == "" {
-		return map[string]interface{}{}, nil
-	}
-	vStore, err := p.cli.GetvStoreByName(ctx, p.cli.GetvStoreName())
+// AggregateFreeCapacity returns the total free bytes of the pools for the usage type capped by the vstore quota,
+// used for CSI GetCapacity
+func (p *OceanstorSanPlugin) AggregateFreeCapacity(ctx context.Context, poolNames []string,
+	usageType string) (int64, error) {
+	vStoreQuotaMap, err := p.getVstoreCapacity(ctx)
 	if err != nil {
-		return nil, err
-	}
-	if vStore == nil {
-		return nil, fmt.Errorf("not find vstore by name, name: %s", p.cli.GetvStoreName())
+		log.AddContext(ctx).Debugf("get vstore capacity failed, err: %v", err)
+		vStoreQuotaMap = map[string]interface{}{}
 	}
 
-	var sanCapacityQuota, sanFreeCapacityQuota int64
+	return p.aggregateFreeCapacity(ctx, poolNames, vStoreQuotaMap, usageType)
+}
 
-	if totalStr, ok := vStore["sanCapacityQuota"].(string); ok {
-		sanCapacityQuota, err = strconv.ParseInt(totalStr, constants.DefaultIntBase, constants.DefaultIntBitSize)
-	}
-	if freeStr, ok := vStore["sanFreeCapacityQuota"].(string); ok {
-		sanFreeCapacityQuota, err = strconv.ParseInt(freeStr, constants.DefaultIntBase, constants.DefaultIntBitSize)
-	}
+func (p *OceanstorSanPlugin) getVstoreCapacity(ctx context.Context) (map[string]interface{}, error) {
+	quota, err := p.getVStoreQuota(ctx)
 	if err != nil {
-		log.AddContext(ctx).Warningf("parse vstore quota failed, error: %v", err)
 		return nil, err
 	}
-
-	// if not set quota, sanCapacityQuota is 0, sanFreeCapacityQuota is -1
-	if sanCapacityQuota == 0 || sanFreeCapacityQuota == -1 {
+	if quota == nil {
 		return map[string]interface{}{}, nil
 	}
 
-	return map[string]interface{}{
-		string(xuanwuV1.FreeCapacity):  sanFreeCapacityQuota * constants.AllocationUnitBytes,
-		string(xuanwuV1.TotalCapacity): sanCapacityQuota * constants.AllocationUnitBytes,
-		string(xuanwuV1.UsedCapacity):  (sanCapacityQuota - sanFreeCapacityQuota) * constants.AllocationUnitBytes,
-	}, nil
+	return capacityQuotaToMap(quota.SAN), nil
 }
 
 // UpdateMetroRemotePlugin used to convert metroRemotePlugin to OceanstorSanPlugin
@@ -17,12 +17,24 @@
 package plugin
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+	"unicode/utf8"
 
+	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/client"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/test/mocks/mock_client"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 )
 
 func Test_validateVolumeName(t *testing.T) {
@@ -37,6 +49,8 @@ func Test_validateVolumeName(t *testing.T) {
 			wantErr: false},
 		{name: "without PVCName", volumeNameTpl: "{{.PVCNamespace}}", wantErr: true},
 		{name: "without PVCNamespace", volumeNameTpl: "{{.PVCName}}", wantErr: true},
+		{name: "with PVCUid only", volumeNameTpl: "prefix{{ .PVCUid }}", wantErr: false},
+		{name: "with PVName only", volumeNameTpl: "{{.PVName}}", wantErr: false},
 		{name: "empty", volumeNameTpl: "", wantErr: true},
 	}
 	for _, tt := range tests {
@@ -95,3 +109,703 @@ func Test_newExtraCreateMetadataFromParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestOceanstorPlugin_updateBackendSpecifications(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli}
+
+	// mock
+	cli.EXPECT().GetAllRemoteDevices(ctx).Return([]map[string]interface{}{{"SN": "remote-sn"}}, nil)
+	cli.EXPECT().GetDeviceSN().Return("local-sn")
+	cli.EXPECT().GetvStoreID().Return("0")
+	cli.EXPECT().GetvStoreName().Return("System_vStore")
+	cli.EXPECT().GetActiveURL().Return("https://127.0.0.1:8088/deviceManager/rest")
+	cli.EXPECT().GetSystemInfo(ctx).Return(&client.StorageSystemInfo{ProductMode: "820", PointRelease: "6.1.8"}, nil)
+	cli.EXPECT().GetStorageTime(ctx).Return(time.Now().Add(time.Minute), nil)
+	cli.EXPECT().GetLicenseExpiry(ctx).Return(map[string]time.Time{"HyperMetro": time.Unix(1767225600, 0)}, nil)
+
+	// action
+	got, err := p.updateBackendSpecifications(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"LocalDeviceSN":            "local-sn",
+		"RemoteDevicesSN":          "remote-sn",
+		"VStoreID":                 "0",
+		"VStoreName":               "System_vStore",
+		"ActiveURL":                "https://127.0.0.1:8088/deviceManager/rest",
+		"ProductMode":              "820",
+		"PointRelease":             "6.1.8",
+		"ClockSkew":                "1m0s",
+		"LicenseExpiry.HyperMetro": "2026-01-01T00:00:00Z",
+		"LicenseExpiringSoon":      "HyperMetro",
+	}, got)
+}
+
+func TestOceanstorPlugin_updateBackendSpecifications_GetStorageTimeFailed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli}
+
+	// mock
+	cli.EXPECT().GetAllRemoteDevices(ctx).Return(nil, nil)
+	cli.EXPECT().GetDeviceSN().Return("local-sn")
+	cli.EXPECT().GetvStoreID().Return("0")
+	cli.EXPECT().GetvStoreName().Return("System_vStore")
+	cli.EXPECT().GetActiveURL().Return("https://127.0.0.1:8088/deviceManager/rest")
+	cli.EXPECT().GetSystemInfo(ctx).Return(nil, errors.New("get system error"))
+	cli.EXPECT().GetStorageTime(ctx).Return(time.Time{}, errors.New("get storage time error"))
+	cli.EXPECT().GetLicenseExpiry(ctx).Return(nil, errors.New("get license expiry error"))
+
+	// action
+	got, err := p.updateBackendSpecifications(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.NotContains(t, got, "ClockSkew")
+	require.NotContains(t, got, "ProductMode")
+	require.NotContains(t, got, "LicenseExpiry.HyperMetro")
+	require.NotContains(t, got, "LicenseExpiringSoon")
+}
+
+func TestOceanstorSanPlugin_UpdatePoolCapabilities_VStoreQuota(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorSanPlugin{OceanstorPlugin: OceanstorPlugin{cli: cli, product: constants.OceanStorDoradoV7}}
+	pools := map[string]interface{}{
+		"pool1": map[string]interface{}{"NAME": "pool1", "USAGETYPE": "0",
+			"USERFREECAPACITY": "4194304", "USERTOTALCAPACITY": "8388608"},
+	}
+
+	// mock
+	cli.EXPECT().GetvStoreName().Return("vstore").AnyTimes()
+	cli.EXPECT().GetvStoreID().Return("1")
+	cli.EXPECT().GetVStoreQuota(ctx, "1").Return(&client.VStoreQuota{
+		SAN: &client.CapacityQuota{Total: 1 << 31, Free: 1 << 30},
+	}, nil)
+	cli.EXPECT().GetAllPools(ctx).Return(pools, nil)
+
+	// action
+	got, err := p.UpdatePoolCapabilities(ctx, []string{"pool1"})
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"pool1": map[string]interface{}{
+			"FreeCapacity":  int64(1 << 30),
+			"TotalCapacity": int64(1 << 31),
+			"UsedCapacity":  int64(1 << 30),
+		},
+	}, got)
+}
+
+func getValidateOnlyInitConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"urls":            []interface{}{"https://127.0.0.1:8088"},
+		"user":            "user",
+		"secretName":      "secret-name",
+		"secretNamespace": "secret-namespace",
+		"backendID":       "backend-id",
+		"storage":         constants.OceanStorSan,
+		"name":            "backend-name",
+	}
+}
+
+func TestOceanstorPlugin_init_ValidateOnly(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	p := &OceanstorPlugin{}
+	cli := &client.OceanstorClient{RestClient: &client.RestClient{}}
+	var loginCalled, setSystemInfoCalled, logoutCalled bool
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(client.NewClient, cli, nil).
+		ApplyMethodReturn(cli.RestClient, "ValidateLogin", nil).
+		ApplyMethod(cli.RestClient, "Login", func(_ *client.RestClient, _ context.Context) error {
+			loginCalled = true
+			return nil
+		}).
+		ApplyMethod(cli.RestClient, "SetSystemInfo", func(_ *client.RestClient, _ context.Context) error {
+			setSystemInfoCalled = true
+			return nil
+		}).
+		ApplyMethod(cli.RestClient, "Logout", func(_ *client.RestClient, _ context.Context) {
+			logoutCalled = true
+		})
+	defer patches.Reset()
+
+	// action
+	err := p.init(ctx, getValidateOnlyInitConfig(), true, true)
+
+	// assert
+	require.NoError(t, err)
+	require.False(t, loginCalled)
+	require.False(t, setSystemInfoCalled)
+	require.True(t, logoutCalled)
+	require.Equal(t, "backend-name", p.name)
+	require.Nil(t, p.cli)
+}
+
+func TestOceanstorPlugin_init_ValidateOnlyFailed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	p := &OceanstorPlugin{}
+	cli := &client.OceanstorClient{RestClient: &client.RestClient{}}
+	wantErr := errors.New("invalid credentials")
+	var logoutCalled bool
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(client.NewClient, cli, nil).
+		ApplyMethodReturn(cli.RestClient, "ValidateLogin", wantErr).
+		ApplyMethod(cli.RestClient, "Logout", func(_ *client.RestClient, _ context.Context) {
+			logoutCalled = true
+		})
+	defer patches.Reset()
+
+	// action
+	err := p.init(ctx, getValidateOnlyInitConfig(), false, true)
+
+	// assert
+	require.ErrorIs(t, err, wantErr)
+	require.False(t, logoutCalled)
+	require.Empty(t, p.name)
+}
+
+func TestOceanstorPlugin_init_WithRetryBudget(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	p := &OceanstorPlugin{}
+	cli := &client.OceanstorClient{RestClient: &client.RestClient{}}
+	config := getValidateOnlyInitConfig()
+	config[constants.RetryBudgetKey] = "10"
+	var gotConfig *client.NewClientConfig
+
+	// mock
+	patches := gomonkey.ApplyFunc(client.NewClient,
+		func(_ context.Context, param *client.NewClientConfig) (*client.OceanstorClient, error) {
+			gotConfig = param
+			return cli, nil
+		}).
+		ApplyMethodReturn(cli.RestClient, "ValidateLogin", nil).
+		ApplyMethodReturn(cli.RestClient, "Logout")
+	defer patches.Reset()
+
+	// action
+	err := p.init(ctx, config, false, true)
+
+	// assert
+	require.NoError(t, err)
+	require.NotNil(t, gotConfig)
+	require.Equal(t, 10, gotConfig.RetryBudget)
+}
+
+func TestOceanstorPlugin_init_InvalidRetryBudget(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	p := &OceanstorPlugin{}
+	config := getValidateOnlyInitConfig()
+	config[constants.RetryBudgetKey] = "-1"
+
+	// action
+	err := p.init(ctx, config, false, true)
+
+	// assert
+	require.ErrorContains(t, err, constants.RetryBudgetKey)
+}
+
+func TestOceanstorPlugin_init_WithSessionTimeout(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	p := &OceanstorPlugin{}
+	cli := &client.OceanstorClient{RestClient: &client.RestClient{}}
+	config := getValidateOnlyInitConfig()
+	config[constants.SessionTimeoutKey] = "30m"
+	var gotConfig *client.NewClientConfig
+
+	// mock
+	patches := gomonkey.ApplyFunc(client.NewClient,
+		func(_ context.Context, param *client.NewClientConfig) (*client.OceanstorClient, error) {
+			gotConfig = param
+			return cli, nil
+		}).
+		ApplyMethodReturn(cli.RestClient, "ValidateLogin", nil).
+		ApplyMethodReturn(cli.RestClient, "Logout")
+	defer patches.Reset()
+
+	// action
+	err := p.init(ctx, config, false, true)
+
+	// assert
+	require.NoError(t, err)
+	require.NotNil(t, gotConfig)
+	require.Equal(t, 30*time.Minute, gotConfig.SessionTimeout)
+}
+
+func TestOceanstorPlugin_init_WithMaxTenantClientThreads(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	p := &OceanstorPlugin{}
+	cli := &client.OceanstorClient{RestClient: &client.RestClient{}}
+	config := getValidateOnlyInitConfig()
+	config["maxClientThreads"] = "30"
+	config["maxTenantClientThreads"] = "5"
+	var gotConfig *client.NewClientConfig
+
+	// mock
+	patches := gomonkey.ApplyFunc(client.NewClient,
+		func(_ context.Context, param *client.NewClientConfig) (*client.OceanstorClient, error) {
+			gotConfig = param
+			return cli, nil
+		}).
+		ApplyMethodReturn(cli.RestClient, "ValidateLogin", nil).
+		ApplyMethodReturn(cli.RestClient, "Logout")
+	defer patches.Reset()
+
+	// action
+	err := p.init(ctx, config, false, true)
+
+	// assert
+	require.NoError(t, err)
+	require.NotNil(t, gotConfig)
+	require.Equal(t, "30", gotConfig.ParallelNum)
+	require.Equal(t, "5", gotConfig.TenantParallelNum)
+}
+
+func TestOceanstorPlugin_getRemoteDevices_WithoutQuery(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli}
+
+	// mock
+	cli.EXPECT().GetAllRemoteDevices(ctx).Return([]map[string]interface{}{{"SN": "sn-1"}, {}, {"SN": "sn-2"}}, nil)
+
+	// action
+	got, err := p.getRemoteDevices(ctx, nil)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "sn-1;sn-2", got)
+}
+
+func TestOceanstorPlugin_getRemoteDevices_BoundedQuery(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli}
+	var devices []map[string]interface{}
+	var wantSN []string
+	for i := 0; i < remoteDeviceQueryParallelNum*3; i++ {
+		sn := fmt.Sprintf("sn-%d", i)
+		devices = append(devices, map[string]interface{}{"SN": sn})
+		if i != 1 {
+			wantSN = append(wantSN, sn)
+		}
+	}
+	var running, maxRunning int32
+	query := func(ctx context.Context, device map[string]interface{}) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			old := atomic.LoadInt32(&maxRunning)
+			if current <= old || atomic.CompareAndSwapInt32(&maxRunning, old, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if device["SN"] == "sn-1" {
+			return errors.New("unreachable")
+		}
+		return nil
+	}
+
+	// mock
+	cli.EXPECT().GetAllRemoteDevices(ctx).Return(devices, nil)
+
+	// action
+	got, err := p.getRemoteDevices(ctx, query)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, strings.Join(wantSN, ";"), got)
+	require.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(remoteDeviceQueryParallelNum))
+}
+
+func TestOceanstorPlugin_updateBackendCapabilities_SpaceEfficiency(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli, product: constants.OceanStorDoradoV6}
+	features := map[string]int{"SmartThin": 1, "SmartDedupe": 1, "SmartCompression": 0}
+
+	// mock
+	cli.EXPECT().GetLicenseFeature(ctx).Return(features, nil)
+	cli.EXPECT().GetStorageVersion().Return("6.1.8")
+	cli.EXPECT().GetSupportedProtocols(ctx).Return([]string{"iscsi"}, nil)
+
+	// action
+	got, err := p.updateBackendCapabilities(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, true, got["SupportDedupe"])
+	require.Equal(t, false, got["SupportCompression"])
+}
+
+func TestOceanstorPlugin_updateProtocolCapabilities(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli}
+	capabilities := map[string]interface{}{}
+
+	// mock
+	cli.EXPECT().GetSupportedProtocols(ctx).Return([]string{"fc", "iscsi"}, nil)
+
+	// action
+	p.updateProtocolCapabilities(ctx, capabilities)
+
+	// assert
+	require.Equal(t, map[string]interface{}{
+		"SupportProtocolISCSI": true,
+		"SupportProtocolFC":    true,
+		"SupportProtocolROCE":  false,
+		"SupportProtocolNFS":   false,
+	}, capabilities)
+}
+
+func TestOceanstorPlugin_updateProtocolCapabilities_QueryFailed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli}
+	capabilities := map[string]interface{}{}
+
+	// mock
+	cli.EXPECT().GetSupportedProtocols(ctx).Return(nil, errors.New("query failed"))
+
+	// action
+	p.updateProtocolCapabilities(ctx, capabilities)
+
+	// assert
+	require.Empty(t, capabilities)
+}
+
+func TestOceanstorPlugin_fitVolumeName(t *testing.T) {
+	// arrange
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli}
+	p.name = "backend"
+	longName := strings.Repeat("a", 256)
+
+	// mock
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeLun).Return(255, nil).Times(2)
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeFilesystem).Return(64, nil)
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeDTree).Return(volumeNameHashLength+1, nil)
+
+	// action
+	fit, fitErr := p.fitVolumeName(strings.Repeat("a", 255), client.ObjectTypeLun)
+	lun, lunErr := p.fitVolumeName(longName, client.ObjectTypeLun)
+	fs, fsErr := p.fitVolumeName(longName, client.ObjectTypeFilesystem)
+	_, shortErr := p.fitVolumeName(longName, client.ObjectTypeDTree)
+
+	// assert
+	require.NoError(t, fitErr)
+	require.Equal(t, strings.Repeat("a", 255), fit)
+	require.NoError(t, lunErr)
+	require.Equal(t, truncateVolumeName(longName, 255), lun)
+	require.NoError(t, fsErr)
+	require.Len(t, fs, 64)
+	require.ErrorContains(t, shortErr, "the length 256 of volume name")
+	require.ErrorContains(t, shortErr, "maximum name length 9 of dtree on backend backend")
+}
+
+func TestOceanstorPlugin_withActiveAlarm_StorageError(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli, alarmInError: true}
+	p.name = "backend"
+	createErr := fmt.Errorf("create lun error: %w", &utils.StorageError{Code: 1077949061})
+	alarms := []client.AlarmInfo{
+		{Name: "Disk Is Removed", Severity: client.AlarmSeverityWarning},
+		{Name: "Storage Pool Is Degraded", Severity: client.AlarmSeverityCritical, Description: "disk failure"},
+		{Name: "Controller Fault", Severity: client.AlarmSeverityMajor},
+	}
+
+	// mock
+	cli.EXPECT().GetActiveAlarms(ctx).Return(alarms, nil)
+	cli.EXPECT().GetActiveAlarms(ctx).Return(nil, errors.New("query error"))
+
+	// action
+	alarmErr := p.withActiveAlarm(ctx, createErr, "")
+	queryErr := p.withActiveAlarm(ctx, createErr, "")
+
+	// assert
+	require.ErrorIs(t, alarmErr, createErr)
+	require.ErrorContains(t, alarmErr, "[critical] Storage Pool Is Degraded: disk failure")
+	require.Equal(t, createErr, queryErr)
+}
+
+func TestOceanstorPlugin_withActiveAlarm_PoolLocation(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli, alarmInError: true}
+	p.name = "backend"
+	createErr := errors.New("get pool error")
+	alarms := []client.AlarmInfo{
+		{Name: "Controller Fault", Severity: client.AlarmSeverityCritical, Location: "CTE0.A"},
+		{Name: "Storage Pool Is Degraded", Severity: client.AlarmSeverityMajor, Location: "StoragePool001"},
+	}
+
+	// mock
+	cli.EXPECT().GetActiveAlarms(ctx).Return(alarms, nil)
+
+	// action
+	poolErr := p.withActiveAlarm(ctx, createErr, "StoragePool001")
+	noPoolErr := p.withActiveAlarm(ctx, createErr, "")
+
+	// assert
+	require.ErrorContains(t, poolErr, "[major] Storage Pool Is Degraded")
+	require.NotContains(t, poolErr.Error(), "Controller Fault")
+	require.Equal(t, createErr, noPoolErr)
+}
+
+func TestOceanstorPlugin_withActiveAlarm_Disabled(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	p := &OceanstorPlugin{}
+	createErr := &utils.StorageError{Code: 1077949061}
+
+	// action
+	err := p.withActiveAlarm(ctx, createErr, "StoragePool001")
+
+	// assert
+	require.Equal(t, error(createErr), err)
+}
+
+func TestOceanstorSanPlugin_CreateVolume_VolumeNameTooLong(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorSanPlugin{OceanstorPlugin: OceanstorPlugin{cli: cli, product: constants.OceanStorDoradoV7}}
+	parameters := map[string]any{"volumeName": strings.Repeat("a", 300) + "-{{.PVName}}",
+		constants.PVCNameKey: "test-pvc", constants.PVCNamespaceKey: "test-namespace", constants.PVNameKey: "pvc-test"}
+
+	// mock
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeLun).Return(volumeNameHashLength, nil)
+
+	// action
+	_, err := p.CreateVolume(ctx, "pvc-test", parameters)
+
+	// assert
+	require.ErrorContains(t, err, "the length 309 of volume name")
+	require.ErrorContains(t, err, "maximum name length 8 of lun")
+}
+
+func TestOceanstorDTreePlugin_CreateVolume_VolumeNameTooLong(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorDTreePlugin{OceanstorPlugin: OceanstorPlugin{cli: cli, product: constants.OceanStorDoradoV7}}
+	parameters := map[string]any{"volumeName": strings.Repeat("a", 300) + "-{{.PVName}}",
+		constants.PVCNameKey: "test-pvc", constants.PVCNamespaceKey: "test-namespace", constants.PVNameKey: "pvc-test"}
+
+	// mock
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeDTree).Return(volumeNameHashLength, nil)
+
+	// action
+	_, err := p.CreateVolume(ctx, "pvc-test", parameters)
+
+	// assert
+	require.ErrorContains(t, err, "the length 309 of volume name")
+	require.ErrorContains(t, err, "maximum name length 8 of dtree")
+}
+
+func Test_truncateVolumeName(t *testing.T) {
+	// arrange
+	shortName := strings.Repeat("a", maxVolumeNameLength)
+	longName := strings.Repeat("a", maxVolumeNameLength+1)
+	otherLongName := strings.Repeat("a", maxVolumeNameLength) + "b"
+
+	// action
+	gotShort := truncateVolumeName(shortName, maxVolumeNameLength)
+	gotLong := truncateVolumeName(longName, maxVolumeNameLength)
+	gotOtherLong := truncateVolumeName(otherLongName, maxVolumeNameLength)
+
+	// assert
+	require.Equal(t, shortName, gotShort)
+	require.Len(t, gotLong, maxVolumeNameLength)
+	require.Len(t, gotOtherLong, maxVolumeNameLength)
+	require.Equal(t, gotLong, truncateVolumeName(longName, maxVolumeNameLength))
+	require.NotEqual(t, gotLong, gotOtherLong)
+}
+
+func Test_truncateVolumeName_Multibyte(t *testing.T) {
+	// arrange
+	name := strings.Repeat("卷", maxVolumeNameLength)
+
+	// action
+	got := truncateVolumeName(name, maxVolumeNameLength)
+
+	// assert
+	require.True(t, utf8.ValidString(got))
+	require.LessOrEqual(t, len(got), maxVolumeNameLength)
+	require.True(t, strings.HasPrefix(name, got[:len(got)-volumeNameHashLength-1]))
+}
+
+func TestOceanstorPlugin_getVolumeName_BackendTemplate(t *testing.T) {
+	// arrange
+	uid := "c2fd3f46-bf17-4a7d-b88e-2e3232bae434"
+	p := &OceanstorPlugin{}
+	app.GetGlobalConfig().VolumeNamePrefix = "pvc"
+	parameters := map[string]any{constants.PVCNameKey: "test-pvc", constants.PVCNamespaceKey: "test-namespace",
+		constants.PVNameKey: "pvc-" + uid}
+
+	// action
+	setErr := p.setVolumeNameTpl(map[string]any{"volumeName": "{{.PVCUid}}"})
+	backendName, backendErr := p.getVolumeName("pvc-"+uid, parameters)
+	parameters["volumeName"] = "{{.PVCNamespace}}-{{.PVCName}}"
+	scName, scErr := p.getVolumeName("pvc-"+uid, parameters)
+
+	// assert
+	require.NoError(t, setErr)
+	require.NoError(t, backendErr)
+	require.NoError(t, scErr)
+	require.Equal(t, strings.Replace(uid, "-", "", -1), backendName)
+	require.Equal(t, "test-namespace-test-pvc-"+strings.Replace(uid, "-", "", -1), scName)
+}
+
+func TestOceanstorPlugin_setVolumeNameTpl_MayCollide(t *testing.T) {
+	// arrange
+	p := &OceanstorPlugin{}
+
+	// action
+	err := p.setVolumeNameTpl(map[string]any{"volumeName": "{{.PVCName}}"})
+
+	// assert
+	require.ErrorContains(t, err, "invalid volumeName parameter of backend")
+	require.Empty(t, p.volumeNameTpl)
+}
+
+func TestOceanstorPlugin_Close(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli}
+
+	// mock
+	cli.EXPECT().Close(ctx).Times(1)
+
+	// act
+	p.Close(ctx)
+}
+
+func TestOceanstorPlugin_AggregateFreeCapacity(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli}
+	pools := map[string]interface{}{
+		"san-pool":     map[string]interface{}{"NAME": "san-pool", "USAGETYPE": "1", "USERFREECAPACITY": "2"},
+		"nas-pool":     map[string]interface{}{"NAME": "nas-pool", "USAGETYPE": "2", "USERFREECAPACITY": "4"},
+		"v6-pool":      map[string]interface{}{"NAME": "v6-pool", "USAGETYPE": "0", "USERFREECAPACITY": "8"},
+		"v6-new-pool":  map[string]interface{}{"NAME": "v6-new-pool", "NEWUSAGETYPE": "0", "USERFREECAPACITY": "16"},
+		"no-free-pool": map[string]interface{}{"NAME": "no-free-pool", "USAGETYPE": "1"},
+	}
+
+	// mock
+	cli.EXPECT().GetAllPools(ctx).Return(pools, nil)
+
+	// action
+	got, err := p.aggregateFreeCapacity(ctx,
+		[]string{"san-pool", "nas-pool", "v6-pool", "v6-new-pool", "no-free-pool", "not-exist-pool"},
+		map[string]interface{}{}, "1")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, int64(2+8+16)*constants.AllocationUnitBytes, got)
+}
+
+func TestOceanstorPlugin_AggregateFreeCapacity_ParseFailed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli}
+	pools := map[string]interface{}{
+		"san-pool": map[string]interface{}{"NAME": "san-pool", "USAGETYPE": "1", "USERFREECAPACITY": "abc"},
+	}
+
+	// mock
+	cli.EXPECT().GetAllPools(ctx).Return(pools, nil)
+
+	// action
+	_, err := p.aggregateFreeCapacity(ctx, []string{"san-pool"}, map[string]interface{}{}, "1")
+
+	// assert
+	require.ErrorContains(t, err, "parse free capacity abc of pool san-pool failed")
+}
+
+func TestOceanstorPlugin_AggregateFreeCapacity_CappedByVStoreQuota(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	p := &OceanstorPlugin{cli: cli}
+	pools := map[string]interface{}{
+		"pool1": map[string]interface{}{"NAME": "pool1", "USAGETYPE": "1", "USERFREECAPACITY": "2"},
+		"pool2": map[string]interface{}{"NAME": "pool2", "USAGETYPE": "1", "USERFREECAPACITY": "2"},
+	}
+	quota := capacityQuotaToMap(&client.CapacityQuota{Total: 8 * constants.AllocationUnitBytes,
+		Free: 3 * constants.AllocationUnitBytes})
+
+	// mock
+	cli.EXPECT().GetAllPools(ctx).Return(pools, nil)
+
+	// action
+	got, err := p.aggregateFreeCapacity(ctx, []string{"pool1", "pool2"}, quota, "1")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, int64(3)*constants.AllocationUnitBytes, got)
+}
+
+func Test_checkDryRun(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	params := map[string]interface{}{"name": "pvc-test", "storagepool": "pool", "user": "admin"}
+
+	// act
+	disabledErr := checkDryRun(ctx, map[string]interface{}{}, params)
+	falseErr := checkDryRun(ctx, map[string]interface{}{constants.DryRunKey: "false"}, params)
+	dryRunErr := checkDryRun(ctx, map[string]interface{}{constants.DryRunKey: "true"}, params)
+
+	// assert
+	require.NoError(t, disabledErr)
+	require.NoError(t, falseErr)
+	require.ErrorContains(t, dryRunErr, "dry run is enabled, volume pvc-test is not created")
+	require.ErrorContains(t, dryRunErr, "storagepool:pool")
+	require.NotContains(t, dryRunErr.Error(), "admin")
+}
@@ -44,6 +44,8 @@ type StoragePlugin interface {
 	DeleteSnapshot(context.Context, string, string) error
 	SmartXQoSQuery
 	Logout(context.Context)
+	// Close logs out the storage session and releases the resources of the client when the backend is removed
+	Close(context.Context)
 	ReLogin(ctx context.Context) error
 	// Validate used to check parameters, include login verification
 	Validate(context.Context, map[string]interface{}) error
@@ -25,6 +25,7 @@ import (
 	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	xuanwuV1 "github.com/Huawei/eSDK_K8S_Plugin/v4/client/apis/xuanwu/v1"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
@@ -111,13 +112,14 @@ func formatOceanstorInitParam(config map[string]interface{}) (res *oceanstor.New
 		err = errors.New("user must be provided")
 		return
 	}
+	res.CredentialsPath, _ = config[constants.CredentialsPathKey].(string)
 	res.SecretName, exist = config["secretName"].(string)
-	if !exist {
+	if !exist && res.CredentialsPath == "" {
 		err = errors.New("SecretName must be provided")
 		return
 	}
 	res.SecretNamespace, exist = config["secretNamespace"].(string)
-	if !exist {
+	if !exist && res.CredentialsPath == "" {
 		err = errors.New("SecretNamespace must be provided")
 		return
 	}
@@ -128,9 +130,43 @@ func formatOceanstorInitParam(config map[string]interface{}) (res *oceanstor.New
 	}
 	res.VstoreName, _ = config["vstoreName"].(string)
 	res.ParallelNum, _ = config["maxClientThreads"].(string)
+	res.TenantParallelNum, _ = config["maxTenantClientThreads"].(string)
 
 	res.UseCert, _ = config["useCert"].(bool)
 	res.CertSecretMeta, _ = config["certSecret"].(string)
+	res.CASecretMeta, _ = config[constants.CASecretKey].(string)
+	res.LoginScope, _ = config[constants.LoginScopeKey].(string)
+	res.ProxyURL, _ = config[constants.ProxyURLKey].(string)
+	res.VerboseLog, _ = config[constants.VerboseLogKey].(bool)
+	res.Headers = getCustomHeaders(config)
+	res.PoolCacheTTL, err = getPoolCacheTTL(config)
+	if err != nil {
+		return nil, err
+	}
+	res.SystemCacheTTL, err = getSystemCacheTTL(config)
+	if err != nil {
+		return nil, err
+	}
+	res.RateLimit, res.RateBurst, err = getRateLimit(config)
+	if err != nil {
+		return nil, err
+	}
+	res.RetryBudget, err = getNonNegativeInt(config, constants.RetryBudgetKey)
+	if err != nil {
+		return nil, err
+	}
+	res.Transport, err = getTransportConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	res.LoginTimeout, err = getLoginTimeout(config)
+	if err != nil {
+		return nil, err
+	}
+	res.SessionTimeout, err = getSessionTimeout(config)
+	if err != nil {
+		return nil, err
+	}
 
 	res.Storage, exist = config["storage"].(string)
 	if !exist {
@@ -164,13 +200,14 @@ func formatBaseClientConfig(config map[string]interface{}) (*storage.NewClientCo
 		return nil, fmt.Errorf("user is not provided in config, or it is invalid, config: %v", config)
 	}
 
+	res.CredentialsPath, _ = utils.GetValue[string](config, constants.CredentialsPathKey)
 	res.SecretName, ok = utils.GetValue[string](config, "secretName")
-	if !ok {
+	if !ok && res.CredentialsPath == "" {
 		return nil, fmt.Errorf("secretName is not provided in config, or it is invalid, config: %v", config)
 	}
 
 	res.SecretNamespace, ok = utils.GetValue[string](config, "secretNamespace")
-	if !ok {
+	if !ok && res.CredentialsPath == "" {
 		return nil, fmt.Errorf("secretNamespace is not provided in config, or it is invalid, config: %v", config)
 	}
 
@@ -182,6 +219,20 @@ func formatBaseClientConfig(config map[string]interface{}) (*storage.NewClientCo
 	res.ParallelNum, _ = utils.GetValue[string](config, "maxClientThreads")
 	res.UseCert, _ = utils.GetValue[bool](config, "useCert")
 	res.CertSecretMeta, _ = utils.GetValue[string](config, "certSecret")
+	res.CASecretMeta, _ = utils.GetValue[string](config, constants.CASecretKey)
+	res.LoginScope, _ = utils.GetValue[string](config, constants.LoginScopeKey)
+	res.ProxyURL, _ = utils.GetValue[string](config, constants.ProxyURLKey)
+	res.VerboseLog, _ = utils.GetValue[bool](config, constants.VerboseLogKey)
+	res.Headers = getCustomHeaders(config)
+	transport, err := getTransportConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	res.Transport = transport
+	res.LoginTimeout, err = getLoginTimeout(config)
+	if err != nil {
+		return nil, err
+	}
 
 	res.Storage, ok = utils.GetValue[string](config, "storage")
 	if !ok {
@@ -238,6 +289,19 @@ func analyzePoolsCapacity(ctx context.Context, pools []map[string]interface{},
 	return capacities
 }
 
+// capacityQuotaToMap converts the vstore quota to the capacity map, the map is empty if the quota is not set
+func capacityQuotaToMap(quota *oceanstor.CapacityQuota) map[string]interface{} {
+	if quota == nil {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		string(xuanwuV1.FreeCapacity):  quota.Free,
+		string(xuanwuV1.TotalCapacity): quota.Total,
+		string(xuanwuV1.UsedCapacity):  quota.Used(),
+	}
+}
+
 func verifyDTreeParam(ctx context.Context, config map[string]any, storageType string) error {
 	// verify storage
 	storage, exist := utils.ToStringWithFlag(config["storage"])
@@ -335,6 +399,15 @@ func updateCapabilityByNfsServiceSetting(capabilities map[string]interface{}, nf
 
 func getVolumeNameFromPVNameOrParameters(pvName string, parameters map[string]any) (string, error) {
 	volumeNameTpl, _ := utils.GetValue[string](parameters, constants.ScVolumeNameKey)
+	volumeName, err := renderVolumeName(pvName, volumeNameTpl, parameters)
+	if err != nil {
+		return "", err
+	}
+
+	return truncateVolumeName(volumeName, maxVolumeNameLength), nil
+}
+
+func renderVolumeName(pvName, volumeNameTpl string, parameters map[string]any) (string, error) {
 	if volumeNameTpl == "" {
 		return pvName, nil
 	}
@@ -348,7 +421,11 @@ func getVolumeNameFromPVNameOrParameters(pvName string, parameters map[string]an
 		return "", err
 	}
 
-	tpl, err := template.New(constants.ScVolumeNameKey).Parse(volumeNameTpl + volumeNameSuffix)
+	if !isUniqueVolumeName(volumeNameTpl) {
+		volumeNameTpl += volumeNameSuffix
+	}
+
+	tpl, err := template.New(constants.ScVolumeNameKey).Parse(volumeNameTpl)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse volume name template %s: %w", volumeNameTpl, err)
 	}
@@ -360,3 +437,185 @@ func getVolumeNameFromPVNameOrParameters(pvName string, parameters map[string]an
 
 	return volumeName.String(), nil
 }
+
+// getCustomHeaders returns the static headers of the backend, the header whose value is not a string is ignored
+func getCustomHeaders(config map[string]interface{}) map[string]string {
+	if headers, ok := config[constants.CustomHeadersKey].(map[string]string); ok {
+		return headers
+	}
+
+	rawHeaders, ok := config[constants.CustomHeadersKey].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	headers := make(map[string]string, len(rawHeaders))
+	for key, rawValue := range rawHeaders {
+		if value, ok := rawValue.(string); ok {
+			headers[key] = value
+		}
+	}
+	return headers
+}
+
+// getPoolCacheTTL returns the cache duration of the pools, zero is returned if the cache is not enabled
+func getPoolCacheTTL(config map[string]interface{}) (time.Duration, error) {
+	value, ok := config[constants.PoolCacheTTLKey].(string)
+	if !ok || value == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("%s %s is invalid, it must be a non-negative duration such as 30s",
+			constants.PoolCacheTTLKey, value)
+	}
+
+	return ttl, nil
+}
+
+// getSystemCacheTTL returns the cache duration of the system info, zero is returned if the default is used
+func getSystemCacheTTL(config map[string]interface{}) (time.Duration, error) {
+	value, ok := config[constants.SystemCacheTTLKey].(string)
+	if !ok || value == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s %s is invalid, it must be a duration such as 5s, or a negative one to disable it",
+			constants.SystemCacheTTLKey, value)
+	}
+
+	return ttl, nil
+}
+
+// getLoginTimeout returns the total time of the login across the urls, zero is returned if it is not bounded
+func getLoginTimeout(config map[string]interface{}) (time.Duration, error) {
+	value, ok := config[constants.LoginTimeoutKey].(string)
+	if !ok || value == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("%s %s is invalid, it must be a non-negative duration such as 60s",
+			constants.LoginTimeoutKey, value)
+	}
+
+	return timeout, nil
+}
+
+// getSessionTimeout returns the idle timeout of the login session requested from the storage,
+// zero is returned if the default of the storage is used
+func getSessionTimeout(config map[string]interface{}) (time.Duration, error) {
+	value, ok := config[constants.SessionTimeoutKey].(string)
+	if !ok || value == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("%s %s is invalid, it must be a non-negative duration such as 30m",
+			constants.SessionTimeoutKey, value)
+	}
+
+	return timeout, nil
+}
+
+// getRateLimit returns the requests per second and the burst of the rate limit, zero is returned if it is not enabled
+func getRateLimit(config map[string]interface{}) (float64, int, error) {
+	var rate float64
+	var burst int
+	var err error
+	if value, ok := config[constants.RateLimitKey].(string); ok && value != "" {
+		rate, err = strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 {
+			return 0, 0, fmt.Errorf("%s %s is invalid, it must be a non-negative number",
+				constants.RateLimitKey, value)
+		}
+	}
+
+	if value, ok := config[constants.RateBurstKey].(string); ok && value != "" {
+		burst, err = strconv.Atoi(value)
+		if err != nil || burst < 0 {
+			return 0, 0, fmt.Errorf("%s %s is invalid, it must be a non-negative integer",
+				constants.RateBurstKey, value)
+		}
+	}
+
+	return rate, burst, nil
+}
+
+// getTransportConfig returns the idle connection and keepalive settings of the http transport,
+// zero is returned if it is not set
+func getTransportConfig(config map[string]interface{}) (storage.TransportConfig, error) {
+	var res storage.TransportConfig
+	var err error
+	res.MaxIdleConns, err = getNonNegativeInt(config, constants.MaxIdleConnsKey)
+	if err != nil {
+		return storage.TransportConfig{}, err
+	}
+
+	res.MaxIdleConnsPerHost, err = getNonNegativeInt(config, constants.MaxIdleConnsPerHostKey)
+	if err != nil {
+		return storage.TransportConfig{}, err
+	}
+
+	if value, ok := config[constants.IdleConnTimeoutKey].(string); ok && value != "" {
+		res.IdleConnTimeout, err = time.ParseDuration(value)
+		if err != nil || res.IdleConnTimeout < 0 {
+			return storage.TransportConfig{}, fmt.Errorf("%s %s is invalid, it must be a non-negative duration "+
+				"such as 90s", constants.IdleConnTimeoutKey, value)
+		}
+	}
+
+	if value, ok := config[constants.KeepAliveKey].(string); ok && value != "" {
+		res.KeepAlive, err = time.ParseDuration(value)
+		if err != nil {
+			return storage.TransportConfig{}, fmt.Errorf("%s %s is invalid, it must be a duration such as 30s",
+				constants.KeepAliveKey, value)
+		}
+	}
+
+	res.HostOverrides, err = getHostOverrides(config)
+	if err != nil {
+		return storage.TransportConfig{}, err
+	}
+
+	return res, nil
+}
+
+// getHostOverrides returns the IPs that the host names of the urls are dialed with, nil is returned if it is not set
+func getHostOverrides(config map[string]interface{}) (map[string]string, error) {
+	rawOverrides, ok := config[constants.HostOverridesKey].(map[string]interface{})
+	if !ok || len(rawOverrides) == 0 {
+		return nil, nil
+	}
+
+	overrides := make(map[string]string, len(rawOverrides))
+	for host, rawIP := range rawOverrides {
+		ip, ok := rawIP.(string)
+		if host == "" || !ok || net.ParseIP(ip) == nil {
+			return nil, fmt.Errorf("%s %s: %v is invalid, it must map a host name to an IP",
+				constants.HostOverridesKey, host, rawIP)
+		}
+		overrides[strings.ToLower(host)] = ip
+	}
+
+	return overrides, nil
+}
+
+func getNonNegativeInt(config map[string]interface{}, key string) (int, error) {
+	value, ok := config[key].(string)
+	if !ok || value == "" {
+		return 0, nil
+	}
+
+	res, err := strconv.Atoi(value)
+	if err != nil || res < 0 {
+		return 0, fmt.Errorf("%s %s is invalid, it must be a non-negative integer", key, value)
+	}
+
+	return res, nil
+}
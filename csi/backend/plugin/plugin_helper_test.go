@@ -20,12 +20,14 @@ package plugin
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
 )
 
 func Test_formatBaseClientConfig_UrlsMissing(t *testing.T) {
@@ -209,6 +211,25 @@ func Test_formatBaseClientConfig_Success(t *testing.T) {
 	require.NotNil(t, got)
 }
 
+func Test_formatBaseClientConfig_CredentialsPath(t *testing.T) {
+	// arrange
+	config := map[string]interface{}{
+		"urls":                       []interface{}{"test"},
+		"user":                       "test",
+		constants.CredentialsPathKey: "/etc/huawei/credentials",
+		"backendID":                  "id",
+		"storage":                    "s3",
+		"name":                       "test",
+	}
+
+	// act
+	got, gotErr := formatBaseClientConfig(config)
+
+	// assert
+	require.NoError(t, gotErr)
+	require.Equal(t, "/etc/huawei/credentials", got.CredentialsPath)
+}
+
 func Test_getVolumeNameFromPVNameOrParameters(t *testing.T) {
 	// arrange
 	uid := "c2fd3f46-bf17-4a7d-b88e-2e3232bae434"
@@ -231,8 +252,8 @@ func Test_getVolumeNameFromPVNameOrParameters(t *testing.T) {
 		{name: "validate volume name failed",
 			args: args{volumePrefix: "pvc", pvName: "pvc-" + uid,
 				parameters: map[string]any{"volumeName": "{{.PVCNamespace}}"}}, want: "" + uid,
-			wantErrMsg: "{{.PVCNamespace}} or {{." +
-				"PVCName}} must be configured in the volumeName parameter at the same time"},
+			wantErrMsg: "{{.PVCUid}}, {{.PVName}} or both {{.PVCNamespace}} and {{." +
+				"PVCName}} must be configured in the volumeName parameter"},
 		{name: "metadata key not found",
 			args: args{volumePrefix: "pvc", pvName: "pvc-" + uid,
 				parameters: map[string]any{"volumeName": "{{.PVCNamespace}}{{.PVCName}}"}}, want: "" + uid,
@@ -241,6 +262,15 @@ func Test_getVolumeNameFromPVNameOrParameters(t *testing.T) {
 			parameters: map[string]any{"volumeName": "{{.PVCNamespace}}-{{.PVCName}}", constants.PVCNameKey: "test-pvc",
 				constants.PVCNamespaceKey: "test-namespace", constants.PVNameKey: "pvc-" + uid}},
 			want: "test-namespace-test-pvc-" + strings.Replace(uid, "-", "", -1), wantErrMsg: ""},
+		{name: "flat name with PVCUid only", args: args{volumePrefix: "pvc", pvName: "pvc-" + uid,
+			parameters: map[string]any{"volumeName": "k8s{{.PVCUid}}", constants.PVCNameKey: "test-pvc",
+				constants.PVCNamespaceKey: "test-namespace", constants.PVNameKey: "pvc-" + uid}},
+			want: "k8s" + strings.Replace(uid, "-", "", -1), wantErrMsg: ""},
+		{name: "truncate long name", args: args{volumePrefix: "pvc", pvName: "pvc-" + uid,
+			parameters: map[string]any{"volumeName": strings.Repeat("a", 300) + "-{{.PVName}}",
+				constants.PVCNameKey: "test-pvc", constants.PVCNamespaceKey: "test-namespace",
+				constants.PVNameKey: "pvc-" + uid}},
+			want: truncateVolumeName(strings.Repeat("a", 300)+"-pvc-"+uid, maxVolumeNameLength), wantErrMsg: ""},
 	}
 
 	for _, tt := range tests {
@@ -262,3 +292,109 @@ func Test_getVolumeNameFromPVNameOrParameters(t *testing.T) {
 	}
 
 }
+
+func Test_getRateLimit(t *testing.T) {
+	// arrange
+	cases := []struct {
+		name      string
+		config    map[string]interface{}
+		wantRate  float64
+		wantBurst int
+		wantErr   bool
+	}{
+		{"NotSet", map[string]interface{}{}, 0, 0, false},
+		{"RateAndBurst", map[string]interface{}{constants.RateLimitKey: "2.5", constants.RateBurstKey: "5"},
+			2.5, 5, false},
+		{"InvalidRate", map[string]interface{}{constants.RateLimitKey: "fast"}, 0, 0, true},
+		{"NegativeBurst", map[string]interface{}{constants.RateLimitKey: "1", constants.RateBurstKey: "-1"},
+			0, 0, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			rate, burst, err := getRateLimit(c.config)
+
+			// assert
+			require.Equal(t, c.wantErr, err != nil)
+			require.Equal(t, c.wantRate, rate)
+			require.Equal(t, c.wantBurst, burst)
+		})
+	}
+}
+
+func Test_getTransportConfig(t *testing.T) {
+	// arrange
+	cases := []struct {
+		name    string
+		config  map[string]interface{}
+		want    storage.TransportConfig
+		wantErr bool
+	}{
+		{"NotSet", map[string]interface{}{}, storage.TransportConfig{}, false},
+		{"AllSet", map[string]interface{}{constants.MaxIdleConnsKey: "100", constants.MaxIdleConnsPerHostKey: "10",
+			constants.IdleConnTimeoutKey: "90s"},
+			storage.TransportConfig{MaxIdleConns: 100, MaxIdleConnsPerHost: 10, IdleConnTimeout: 90 * time.Second},
+			false},
+		{"InvalidMaxIdleConns", map[string]interface{}{constants.MaxIdleConnsKey: "many"},
+			storage.TransportConfig{}, true},
+		{"NegativeMaxIdleConnsPerHost", map[string]interface{}{constants.MaxIdleConnsPerHostKey: "-1"},
+			storage.TransportConfig{}, true},
+		{"InvalidIdleConnTimeout", map[string]interface{}{constants.IdleConnTimeoutKey: "90"},
+			storage.TransportConfig{}, true},
+		{"KeepAlive", map[string]interface{}{constants.IdleConnTimeoutKey: "90s", constants.KeepAliveKey: "30s"},
+			storage.TransportConfig{IdleConnTimeout: 90 * time.Second, KeepAlive: 30 * time.Second}, false},
+		{"KeepAliveDisabled", map[string]interface{}{constants.KeepAliveKey: "-1s"},
+			storage.TransportConfig{KeepAlive: -time.Second}, false},
+		{"InvalidKeepAlive", map[string]interface{}{constants.KeepAliveKey: "30"},
+			storage.TransportConfig{}, true},
+		{"HostOverrides", map[string]interface{}{constants.HostOverridesKey: map[string]interface{}{
+			"Storage.Example.com": "192.168.1.10"}},
+			storage.TransportConfig{HostOverrides: map[string]string{"storage.example.com": "192.168.1.10"}}, false},
+		{"InvalidHostOverrides", map[string]interface{}{constants.HostOverridesKey: map[string]interface{}{
+			"storage.example.com": "storage"}},
+			storage.TransportConfig{}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			got, err := getTransportConfig(c.config)
+
+			// assert
+			require.Equal(t, c.wantErr, err != nil)
+			require.Equal(t, c.want, got)
+		})
+	}
+}
+
+func Test_getSystemCacheTTL(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		want    time.Duration
+		wantErr string
+	}{
+		{name: "not configured", config: map[string]interface{}{}, want: 0},
+		{name: "configured", config: map[string]interface{}{constants.SystemCacheTTLKey: "10s"}, want: 10 * time.Second},
+		{name: "disabled", config: map[string]interface{}{constants.SystemCacheTTLKey: "-1s"}, want: -time.Second},
+		{name: "invalid", config: map[string]interface{}{constants.SystemCacheTTLKey: "ten"},
+			wantErr: "systemCacheTTL ten is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			got, err := getSystemCacheTTL(tt.config)
+
+			// assert
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -21,9 +21,11 @@ import (
 	"testing"
 
 	"github.com/prashantv/gostub"
+	"go.uber.org/mock/gomock"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app"
 	cfg "github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app/config"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/test/mocks/mock_client"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
@@ -48,3 +50,36 @@ func TestMain(m *testing.M) {
 
 	m.Run()
 }
+
+func TestStoragePlugin_Close(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	fusionCli := mock_client.NewMockIRestClient(mockCtrl)
+	oceandiskCli := mock_client.NewMockOceandiskClientInterface(mockCtrl)
+	aSeriesCli := mock_client.NewMockOceanASeriesClientInterface(mockCtrl)
+	dmeCli := mock_client.NewMockDMEASeriesClientInterface(mockCtrl)
+	tests := []struct {
+		name   string
+		plugin interface{ Close(context.Context) }
+		mock   func()
+	}{
+		{name: "fusionstorage", plugin: &FusionStoragePlugin{cli: fusionCli},
+			mock: func() { fusionCli.EXPECT().Close(ctx).Times(1) }},
+		{name: "oceandisk", plugin: &OceandiskPlugin{cli: oceandiskCli},
+			mock: func() { oceandiskCli.EXPECT().Close(ctx).Times(1) }},
+		{name: "oceanstor a-series", plugin: &OceanstorASeriesPlugin{cli: aSeriesCli},
+			mock: func() { aSeriesCli.EXPECT().Close(ctx).Times(1) }},
+		{name: "dme a-series", plugin: &DMEASeriesPlugin{cli: dmeCli},
+			mock: func() { dmeCli.EXPECT().Close(ctx).Times(1) }},
+		{name: "client not initialized", plugin: &FusionStoragePlugin{}, mock: func() {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// mock
+			tt.mock()
+
+			// act
+			tt.plugin.Close(ctx)
+		})
+	}
+}
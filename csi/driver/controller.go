@@ -28,6 +28,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/backend/handler"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
@@ -43,6 +44,9 @@ func (d *CsiDriver) CreateVolume(ctx context.Context, req *csi.CreateVolumeReque
 		return nil, err
 	}
 
+	// tag the requests with the pvc namespace, so the storage client can limit the concurrency of each tenant
+	ctx = utils.WithTenant(ctx, req.GetParameters()[constants.PVCNamespaceKey])
+
 	annotations, err := app.GetGlobalConfig().K8sUtils.GetVolumeConfiguration(ctx, req.GetName())
 	if err != nil {
 		log.AddContext(ctx).Errorf("get pvc info failed, error: %v", err)
@@ -95,7 +99,7 @@ func (d *CsiDriver) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeReque
 	}
 	if err != nil {
 		log.AddContext(ctx).Errorf("Delete volume %s error: %v", volumeId, err)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(storageErrorCode(err), err.Error())
 	}
 
 	log.AddContext(ctx).Infof("Volume %s is deleted", volumeId)
@@ -148,7 +152,7 @@ func (d *CsiDriver) ControllerExpandVolume(ctx context.Context, req *csi.Control
 	}
 	if err != nil {
 		log.AddContext(ctx).Errorf("Expand volume %s error: %v", volumeId, err)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(storageErrorCode(err), err.Error())
 	}
 
 	log.AddContext(ctx).Infof("Volume %s is expanded to %d, nodeExpansionRequired %t",
@@ -254,7 +258,16 @@ func (d *CsiDriver) ListVolumes(ctx context.Context, req *csi.ListVolumesRequest
 
 // GetCapacity used to get volume capacity
 func (d *CsiDriver) GetCapacity(ctx context.Context, req *csi.GetCapacityRequest) (*csi.GetCapacityResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "Not implemented")
+	defer utils.RecoverPanic(ctx)
+
+	total, err := getAvailableCapacity(ctx, handler.NewCacheWrapper().List(ctx), req.GetParameters(),
+		req.GetAccessibleTopology().GetSegments())
+	if err != nil {
+		log.AddContext(ctx).Errorf("get capacity with parameters %v failed, error: %v", req.GetParameters(), err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &csi.GetCapacityResponse{AvailableCapacity: total}, nil
 }
 
 // ControllerGetCapabilities used to controller get capabilities
@@ -299,6 +312,13 @@ func (d *CsiDriver) ControllerGetCapabilities(ctx context.Context, req *csi.Cont
 					},
 				},
 			},
+			{
+				Type: &csi.ControllerServiceCapability_Rpc{
+					Rpc: &csi.ControllerServiceCapability_RPC{
+						Type: csi.ControllerServiceCapability_RPC_GET_CAPACITY,
+					},
+				},
+			},
 		},
 	}, nil
 }
@@ -36,6 +36,7 @@ import (
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/backend/model"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/backend/plugin"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
@@ -305,6 +306,11 @@ func getAccessibleTopologies(ctx context.Context, req *csi.CreateVolumeRequest,
 	return accessibleTopologies
 }
 
+// mountParameterKeys are the StorageClass parameters only used by the node when mounting the volume,
+// they are passed to NodeStageVolume through the volume context
+var mountParameterKeys = []string{"xprtsec", "tlsServerName", "nconnect", "subPath",
+	"mountPermission", "fsGroup", "fsGroupChangePolicy"}
+
 func getAttributes(req *csi.CreateVolumeRequest, vol utils.Volume, backendName string) map[string]string {
 	attributes := map[string]string{
 		"backend":                          backendName,
@@ -314,6 +320,12 @@ func getAttributes(req *csi.CreateVolumeRequest, vol utils.Volume, backendName s
 		constants.DisableVerifyCapacityKey: req.Parameters[constants.DisableVerifyCapacityKey],
 	}
 
+	for _, key := range mountParameterKeys {
+		if value, exist := req.Parameters[key]; exist && value != "" {
+			attributes[key] = value
+		}
+	}
+
 	if lunWWN, err := vol.GetLunWWN(); err == nil {
 		attributes["lunWWN"] = lunWWN
 	}
@@ -543,7 +555,7 @@ func (d *CsiDriver) createVolume(ctx context.Context, req *csi.CreateVolumeReque
 	vol, err := storagePoolPair.Local.Plugin.CreateVolume(ctx, req.GetName(), parameters)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Create volume %s error: %v", req.GetName(), err)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(storageErrorCode(err), err.Error())
 	}
 
 	recordCapacityChanged(ctx, req.GetCapacityRange().GetRequiredBytes(),
@@ -557,6 +569,16 @@ func (d *CsiDriver) createVolume(ctx context.Context, req *csi.CreateVolumeReque
 	return res, nil
 }
 
+// storageErrorCode returns Unavailable if the storage is temporarily busy so that the request is retried with backoff,
+// otherwise Internal is returned
+func storageErrorCode(err error) codes.Code {
+	if errors.Is(err, base.ErrStorageBusy) {
+		return codes.Unavailable
+	}
+
+	return codes.Internal
+}
+
 func recordCapacityChanged(ctx context.Context, required, actual, sectorSize int64) {
 	if required < actual {
 		log.AddContext(ctx).Infof("Required capacity is %d, actual capacity is %d, "+
@@ -668,3 +690,85 @@ func getBackendFilesystemMode(ctx context.Context, bk *model.Backend, volName st
 	}
 	return ""
 }
+
+// poolUsageTypes maps the volumeType of the StorageClass to the usage type of the pools to aggregate
+var poolUsageTypes = map[string]string{
+	"":                   plugin.SanPoolUsageType,
+	volumeTypeLun:        plugin.SanPoolUsageType,
+	volumeTypeFileSystem: plugin.NasPoolUsageType,
+}
+
+// freeCapacityAggregator is implemented by the plugins which are able to aggregate the free capacity of pools
+type freeCapacityAggregator interface {
+	GetDeviceSN() string
+	AggregateFreeCapacity(ctx context.Context, poolNames []string, usageType string) (int64, error)
+}
+
+// getAvailableCapacity returns the total free bytes of the online backends matched the backend, storagepool,
+// volumeType and protocol parameters of the StorageClass and accessible by the topology, the pools shared by
+// several backends of the same storage device are counted once, the backends not supporting the aggregation are
+// skipped
+func getAvailableCapacity(ctx context.Context, backends []model.Backend, parameters map[string]string,
+	topology map[string]string) (int64, error) {
+	usageType, ok := poolUsageTypes[parameters["volumeType"]]
+	if !ok {
+		log.AddContext(ctx).Debugf("volumeType %s does not support aggregating free capacity",
+			parameters["volumeType"])
+		return 0, nil
+	}
+
+	var total int64
+	countedPools := make(map[string]bool)
+	for i := range backends {
+		bk := &backends[i]
+		if !isCapacityBackend(ctx, bk, parameters, topology) {
+			continue
+		}
+
+		aggregator, ok := bk.Plugin.(freeCapacityAggregator)
+		if !ok {
+			log.AddContext(ctx).Debugf("backend %s does not support aggregating free capacity", bk.Name)
+			continue
+		}
+
+		var poolNames []string
+		for _, pool := range bk.Pools {
+			poolKey := aggregator.GetDeviceSN() + "/" + pool.Name
+			if (parameters["storagepool"] != "" && pool.Name != parameters["storagepool"]) || countedPools[poolKey] {
+				continue
+			}
+			countedPools[poolKey] = true
+			poolNames = append(poolNames, pool.Name)
+		}
+		if len(poolNames) == 0 {
+			continue
+		}
+
+		free, err := aggregator.AggregateFreeCapacity(ctx, poolNames, usageType)
+		if err != nil {
+			return 0, fmt.Errorf("aggregate free capacity of backend %s failed: %w", bk.Name, err)
+		}
+		total += free
+	}
+
+	return total, nil
+}
+
+// isCapacityBackend returns whether the free capacity of the backend is counted by the GetCapacity request
+func isCapacityBackend(ctx context.Context, bk *model.Backend, parameters map[string]string,
+	topology map[string]string) bool {
+	if !bk.Available {
+		return false
+	}
+
+	if backendName := parameters["backend"]; backendName != "" && bk.Name != helper.GetBackendName(backendName) {
+		return false
+	}
+
+	if protocol, ok := bk.Parameters["protocol"].(string); ok && parameters["protocol"] != "" &&
+		protocol != parameters["protocol"] {
+		return false
+	}
+
+	return backend.IsBackendAccessible(ctx, bk, parameters["volumeType"], topology)
+}
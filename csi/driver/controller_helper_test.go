@@ -19,6 +19,7 @@ package driver
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -27,13 +28,16 @@ import (
 	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app"
 	cfg "github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app/config"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/backend/handler"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/backend/model"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/backend/plugin"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/manage"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/k8sutils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
@@ -320,3 +324,184 @@ func Test_isSupportExpandVolume_NasSuccess(t *testing.T) {
 		t.Errorf("Test_isSupportExpandVolume_NasSuccess failed, wantRes = %v, gotRes = %v", true, res)
 	}
 }
+
+func Test_storageErrorCode(t *testing.T) {
+	// arrange
+	busyErr := fmt.Errorf("create volume error: %w", base.ErrStorageBusy)
+
+	// action
+	busyCode := storageErrorCode(busyErr)
+	otherCode := storageErrorCode(errors.New("create volume error"))
+
+	// assert
+	require.Equal(t, codes.Unavailable, busyCode)
+	require.Equal(t, codes.Internal, otherCode)
+}
+
+func Test_getAttributes_MountParameters(t *testing.T) {
+	// arrange
+	req := &csi.CreateVolumeRequest{Parameters: map[string]string{
+		"xprtsec":       "tls",
+		"tlsServerName": "nfs.example.com",
+		"nconnect":      "4",
+		"subPath":       "data",
+		"volumeType":    "fs",
+	}}
+
+	// action
+	attributes := getAttributes(req, utils.NewVolume("fake-nfs"), "fake-backend")
+
+	// assert
+	require.Equal(t, "tls", attributes["xprtsec"])
+	require.Equal(t, "nfs.example.com", attributes["tlsServerName"])
+	require.Equal(t, "4", attributes["nconnect"])
+	require.Equal(t, "data", attributes["subPath"])
+	require.NotContains(t, attributes, "volumeType")
+}
+
+func Test_getAttributes_MountParametersReachNodeStage(t *testing.T) {
+	// arrange
+	createReq := &csi.CreateVolumeRequest{Parameters: map[string]string{
+		"mountPermission":     "755",
+		"fsGroup":             "2000",
+		"fsGroupChangePolicy": "OnRootMismatch",
+	}}
+	stageReq := &csi.NodeStageVolumeRequest{
+		VolumeId:          "fake-backend.fake-nfs",
+		StagingTargetPath: "/staging",
+		VolumeCapability: &csi.VolumeCapability{
+			AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{}},
+			AccessMode: &csi.VolumeCapability_AccessMode{
+				Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+			},
+		},
+	}
+
+	// action
+	stageReq.VolumeContext = getAttributes(createReq, utils.NewVolume("fake-nfs"), "fake-backend")
+	parameters, err := manage.BuildParameters(manage.WithVolumeCapability(context.Background(), stageReq))
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "755", parameters["mountPermission"])
+	require.Equal(t, "2000", parameters["fsGroup"])
+	require.Equal(t, "OnRootMismatch", parameters["fsGroupChangePolicy"])
+}
+
+type fakeCapacityPlugin struct {
+	plugin.StoragePlugin
+	sn   string
+	free map[string]int64
+	err  error
+}
+
+func (p *fakeCapacityPlugin) GetDeviceSN() string {
+	return p.sn
+}
+
+func (p *fakeCapacityPlugin) AggregateFreeCapacity(_ context.Context, poolNames []string,
+	usageType string) (int64, error) {
+	var total int64
+	for _, name := range poolNames {
+		total += p.free[usageType+"/"+name]
+	}
+	return total, p.err
+}
+
+func newCapacityPools(storage string) []*model.StoragePool {
+	return []*model.StoragePool{{Name: "pool1", Storage: storage}, {Name: "pool2", Storage: storage}}
+}
+
+func Test_getAvailableCapacity(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	backends := []model.Backend{
+		{Name: "san", Available: true, Pools: newCapacityPools(constants.OceanStorSan),
+			Plugin: &fakeCapacityPlugin{sn: "sn1", free: map[string]int64{"1/pool1": 1, "1/pool2": 2}}},
+		{Name: "san2", Available: true, Pools: newCapacityPools(constants.OceanStorSan),
+			Plugin: &fakeCapacityPlugin{sn: "sn2", free: map[string]int64{"1/pool1": 4, "1/pool2": 8}}},
+		{Name: "nas", Available: true, Pools: newCapacityPools(constants.OceanStorNas),
+			Plugin: &fakeCapacityPlugin{sn: "sn1", free: map[string]int64{"2/pool1": 16, "2/pool2": 32}}},
+		{Name: "offline", Available: false, Pools: newCapacityPools(constants.OceanStorSan),
+			Plugin: &fakeCapacityPlugin{sn: "sn3", free: map[string]int64{"1/pool1": 64}}},
+		{Name: "unsupported", Available: true, Pools: newCapacityPools(constants.OceanStorSan),
+			Plugin: &struct{ plugin.StoragePlugin }{}},
+	}
+
+	// action
+	all, allErr := getAvailableCapacity(ctx, backends, map[string]string{}, nil)
+	byBackend, byBackendErr := getAvailableCapacity(ctx, backends, map[string]string{"backend": "san2"}, nil)
+	byPool, byPoolErr := getAvailableCapacity(ctx, backends, map[string]string{"storagepool": "pool2"}, nil)
+	byFs, byFsErr := getAvailableCapacity(ctx, backends, map[string]string{"volumeType": "fs"}, nil)
+
+	// assert
+	require.NoError(t, allErr)
+	require.Equal(t, int64(1+2+4+8), all)
+	require.NoError(t, byBackendErr)
+	require.Equal(t, int64(4+8), byBackend)
+	require.NoError(t, byPoolErr)
+	require.Equal(t, int64(2+8), byPool)
+	require.NoError(t, byFsErr)
+	require.Equal(t, int64(16+32), byFs)
+}
+
+func Test_getAvailableCapacity_SharedPoolsCountedOnce(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	free := map[string]int64{"1/pool1": 1, "1/pool2": 2}
+	backends := []model.Backend{
+		{Name: "vstore1", Available: true, Pools: newCapacityPools(constants.OceanStorSan),
+			Plugin: &fakeCapacityPlugin{sn: "sn1", free: free}},
+		{Name: "vstore2", Available: true, Pools: newCapacityPools(constants.OceanStorSan),
+			Plugin: &fakeCapacityPlugin{sn: "sn1", free: free}},
+	}
+
+	// action
+	total, err := getAvailableCapacity(ctx, backends, map[string]string{}, nil)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, int64(1+2), total)
+}
+
+func Test_getAvailableCapacity_FilterByTopologyAndProtocol(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	backends := []model.Backend{
+		{Name: "zone1", Available: true, Pools: newCapacityPools(constants.OceanStorSan),
+			Parameters:          map[string]interface{}{"protocol": "iscsi"},
+			SupportedTopologies: []map[string]string{{"topology.kubernetes.io/zone": "zone1"}},
+			Plugin:              &fakeCapacityPlugin{sn: "sn1", free: map[string]int64{"1/pool1": 1}}},
+		{Name: "zone2", Available: true, Pools: newCapacityPools(constants.OceanStorSan),
+			Parameters:          map[string]interface{}{"protocol": "iscsi"},
+			SupportedTopologies: []map[string]string{{"topology.kubernetes.io/zone": "zone2"}},
+			Plugin:              &fakeCapacityPlugin{sn: "sn2", free: map[string]int64{"1/pool1": 2}}},
+		{Name: "fc", Available: true, Pools: newCapacityPools(constants.OceanStorSan),
+			Parameters: map[string]interface{}{"protocol": "fc"},
+			Plugin:     &fakeCapacityPlugin{sn: "sn3", free: map[string]int64{"1/pool1": 4}}},
+	}
+
+	// action
+	byZone, byZoneErr := getAvailableCapacity(ctx, backends, map[string]string{},
+		map[string]string{"topology.kubernetes.io/zone": "zone1"})
+	byProtocol, byProtocolErr := getAvailableCapacity(ctx, backends, map[string]string{"protocol": "iscsi"}, nil)
+
+	// assert
+	require.NoError(t, byZoneErr)
+	require.Equal(t, int64(1+4), byZone)
+	require.NoError(t, byProtocolErr)
+	require.Equal(t, int64(1+2), byProtocol)
+}
+
+func Test_getAvailableCapacity_Failed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	backends := []model.Backend{{Name: "san", Available: true, Pools: newCapacityPools(constants.OceanStorSan),
+		Plugin: &fakeCapacityPlugin{err: errors.New("get pools failed")}}}
+
+	// action
+	_, err := getAvailableCapacity(ctx, backends, map[string]string{}, nil)
+
+	// assert
+	require.ErrorContains(t, err, "aggregate free capacity of backend san failed: get pools failed")
+}
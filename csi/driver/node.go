@@ -227,6 +227,14 @@ func (d *CsiDriver) NodeGetCapabilities(ctx context.Context,
 					},
 				},
 			},
+			{
+				// the kubelet passes the fsGroup of the pod in NodeStageVolume and leaves the ownership to the driver
+				Type: &csi.NodeServiceCapability_Rpc{
+					Rpc: &csi.NodeServiceCapability_RPC{
+						Type: csi.NodeServiceCapability_RPC_VOLUME_MOUNT_GROUP,
+					},
+				},
+			},
 		},
 	}, nil
 }
@@ -40,6 +40,7 @@ import (
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/provider"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/lib/drcsi"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/cert"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/iputils"
@@ -96,6 +97,8 @@ func runCSIController(ctx context.Context, csiDriver *driver.CsiDriver) {
 
 	app.GetGlobalConfig().K8sUtils.Activate()
 
+	base.SetStrictDecode(app.GetGlobalConfig().StrictResponseDecode)
+
 	// Clean up before exiting
 	go exitClean(true)
 
@@ -343,7 +346,7 @@ func exitClean(isController bool) {
 }
 
 func clean(isController bool) {
-	ctx := context.TODO()
+	ctx := log.EnsureRequestID(context.Background())
 	// flush log
 	ensureRuntimePanicLogging(ctx)
 	if isController {
@@ -172,6 +172,13 @@ func WithVolumeCapability(ctx context.Context, req *csi.NodeStageVolumeRequest)
 			parameters["mountFlags"] = strings.Join(opts, ",")
 			parameters["accessMode"] = volumeAccessMode
 			parameters["fsPermission"] = req.VolumeContext["fsPermission"]
+			parameters["mountPermission"] = req.VolumeContext["mountPermission"]
+			parameters["xprtsec"] = req.VolumeContext["xprtsec"]
+			parameters["tlsServerName"] = req.VolumeContext["tlsServerName"]
+			parameters["nconnect"] = req.VolumeContext["nconnect"]
+			parameters["subPath"] = req.VolumeContext["subPath"]
+			parameters["fsGroup"] = getVolumeMountGroup(req)
+			parameters["fsGroupChangePolicy"] = req.VolumeContext["fsGroupChangePolicy"]
 		default:
 			return errors.New("invalid volume capability")
 		}
@@ -179,6 +186,15 @@ func WithVolumeCapability(ctx context.Context, req *csi.NodeStageVolumeRequest)
 	}
 }
 
+// getVolumeMountGroup returns the fsGroup of the pod passed by the kubelet, or the fsGroup in the volume context
+func getVolumeMountGroup(req *csi.NodeStageVolumeRequest) string {
+	if group := req.GetVolumeCapability().GetMount().GetVolumeMountGroup(); group != "" {
+		return group
+	}
+
+	return req.VolumeContext["fsGroup"]
+}
+
 // CheckParam check node stage volume request parameters
 func CheckParam(ctx context.Context, req *csi.NodeStageVolumeRequest) error {
 	switch req.VolumeCapability.GetAccessType().(type) {
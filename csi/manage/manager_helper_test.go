@@ -227,6 +227,7 @@ func TestControllerPublishInfoReflectToMap(t *testing.T) {
 		"portWWNList": []nvme.PortWWNPair{
 			{InitiatorPortWWN: "mock_initiator_port_wwn_1", TargetPortWWN: "mock_target_port_wwn_1"},
 		},
+		"chapSecret": "",
 	}
 
 	if got := mockControllerPublishInfo().ReflectToMap(); !reflect.DeepEqual(got, want) {
@@ -98,12 +98,27 @@ func (m *NasManager) StageVolume(ctx context.Context, req *csi.NodeStageVolumeRe
 	sourcePath := sourcePathPrefix + volumeName
 
 	connectInfo := map[string]interface{}{
-		"srcType":    connector.MountFSType,
-		"sourcePath": sourcePath,
-		"targetPath": parameters["targetPath"],
-		"mountFlags": parameters["mountFlags"],
-		"protocol":   parameters["protocol"],
-		"portals":    parameters["portals"],
+		"srcType":         connector.MountFSType,
+		"sourcePath":      sourcePath,
+		"targetPath":      parameters["targetPath"],
+		"mountFlags":      parameters["mountFlags"],
+		"protocol":        parameters["protocol"],
+		"portals":         parameters["portals"],
+		"mountPermission": parameters["mountPermission"],
+		"xprtsec":         parameters["xprtsec"],
+		"tlsServerName":   parameters["tlsServerName"],
+		"nconnect":        parameters["nconnect"],
+		"accessMode":      parameters["accessMode"],
+
+		"fsGroup":             parameters["fsGroup"],
+		"fsGroupChangePolicy": parameters["fsGroupChangePolicy"],
+	}
+
+	// the filesystem of the volume is the export, only the subPath under it is mounted
+	if subPath, _ := parameters["subPath"].(string); subPath != "" {
+		delete(connectInfo, "sourcePath")
+		connectInfo["exportPath"] = sourcePath
+		connectInfo["subPath"] = subPath
 	}
 
 	return Mount(ctx, connectInfo)
@@ -49,11 +49,18 @@ func mockNasStageVolumeRequest() *csi.NodeStageVolumeRequest {
 
 func mockExpectedConnectInfo() map[string]interface{} {
 	return map[string]interface{}{
-		"srcType":    connector.MountFSType,
-		"sourcePath": "127.0.0.1:/pvc-nas-xxx",
-		"targetPath": "/test_staging_target_path",
-		"mountFlags": "bound",
-		"protocol":   "nfs",
+		"srcType":             connector.MountFSType,
+		"sourcePath":          "127.0.0.1:/pvc-nas-xxx",
+		"targetPath":          "/test_staging_target_path",
+		"mountFlags":          "bound",
+		"protocol":            "nfs",
+		"mountPermission":     "",
+		"xprtsec":             "",
+		"tlsServerName":       "",
+		"nconnect":            "",
+		"accessMode":          csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+		"fsGroup":             "",
+		"fsGroupChangePolicy": "",
 	}
 }
 
@@ -81,6 +88,40 @@ func TestNasManagerStageNfsVolume(t *testing.T) {
 	}
 }
 
+func TestNasManagerStageNfsVolumeSubPath(t *testing.T) {
+	// arrange
+	manager := &NasManager{
+		protocol: "nfs",
+		portals:  []string{"127.0.0.1"},
+		Conn:     connector.GetConnector(context.Background(), connector.NFSDriver),
+	}
+	req := mockNasStageVolumeRequest()
+	req.VolumeContext = map[string]string{"subPath": "data"}
+
+	// mock
+	mockMountShare := gomonkey.ApplyFunc(Mount, func(ctx context.Context, parameters map[string]interface{}) error {
+		expectedConnectInfo := mockExpectedConnectInfo()
+		delete(expectedConnectInfo, "sourcePath")
+		expectedConnectInfo["exportPath"] = "127.0.0.1:/pvc-nas-xxx"
+		expectedConnectInfo["subPath"] = "data"
+		expectedConnectInfo["portals"] = []string{"127.0.0.1"}
+		if !reflect.DeepEqual(parameters, expectedConnectInfo) {
+			return fmt.Errorf("stage nfs volume error parameter: %+v expectConnectInfo: %+v", parameters,
+				expectedConnectInfo)
+		}
+		return nil
+	})
+	defer mockMountShare.Reset()
+
+	// action
+	err := manager.StageVolume(context.Background(), req)
+
+	// assert
+	if err != nil {
+		t.Errorf("TestNasManagerStageNfsVolumeSubPath() want error = nil, got error = %v", err)
+	}
+}
+
 func TestNasManagerStageDpcVolume(t *testing.T) {
 	manager := &NasManager{
 		protocol: "dpc",
@@ -25,11 +25,15 @@ import (
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/connector"
 	connUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/connector/utils"
+	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/flow"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
+// chapAuthMethod is the value of node.session.auth.authmethod used by the node to log in the targets
+const chapAuthMethod = "CHAP"
+
 // SanManager implements VolumeManager interface
 type SanManager struct {
 	Conn     connector.VolumeConnector
@@ -243,6 +247,10 @@ func connectVolume(ctx context.Context, parameters map[string]interface{}) error
 	}
 
 	connectionParams := publishInfo.ReflectToMap()
+	if err := setChapCredentials(ctx, publishInfo.ChapSecret, connectionParams); err != nil {
+		return err
+	}
+
 	conn, exist := parameters["connector"].(connector.VolumeConnector)
 	if !exist {
 		return errors.New("connector doesn't exist while connect volume")
@@ -257,17 +265,42 @@ func connectVolume(ctx context.Context, parameters map[string]interface{}) error
 	return nil
 }
 
+// setChapCredentials sets the CHAP credentials in the CHAP secret to the connection params,
+// so that the node logs in the targets with the same credentials as the initiator on the storage
+func setChapCredentials(ctx context.Context, chapSecret string, connectionParams map[string]interface{}) error {
+	if chapSecret == "" {
+		return nil
+	}
+
+	user, password, err := pkgUtils.GetChapCredentials(ctx, chapSecret)
+	if err != nil {
+		log.AddContext(ctx).Errorf("get CHAP credentials from secret %s failed, error: %v", chapSecret, err)
+		return err
+	}
+
+	connectionParams["authUserName"] = user
+	connectionParams["authPassword"] = password
+	connectionParams["authMethod"] = chapAuthMethod
+	return nil
+}
+
 // stageForMount when AccessType is csi.VolumeCapability_Mount, this function will be called to mount share path
 func stageForMount(ctx context.Context, parameters map[string]interface{}) error {
 	log.AddContext(ctx).Infoln("the request to stage filesystem device")
 
 	connectInfo := map[string]interface{}{
-		"fsType":     parameters["fsType"],
-		"srcType":    connector.MountBlockType,
-		"sourcePath": parameters["devPath"],
-		"targetPath": parameters["targetPath"],
-		"mountFlags": parameters["mountFlags"],
-		"accessMode": parameters["accessMode"],
+		"fsType":          parameters["fsType"],
+		"srcType":         connector.MountBlockType,
+		"sourcePath":      parameters["devPath"],
+		"targetPath":      parameters["targetPath"],
+		"mountFlags":      parameters["mountFlags"],
+		"accessMode":      parameters["accessMode"],
+		"mountPermission": parameters["mountPermission"],
+
+		"fsGroup":             parameters["fsGroup"],
+		"fsGroupChangePolicy": parameters["fsGroupChangePolicy"],
+		// a repeated stage only remounts, the volume expanded after the first stage is grown by NodeExpandVolume
+		"skipResize": true,
 	}
 	err := Mount(ctx, connectInfo)
 	if err != nil {
@@ -35,6 +35,7 @@ import (
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/connector/roce"
 	connUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/connector/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app"
+	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
@@ -289,6 +290,39 @@ func mockSanStageVolumeRequest(t *testing.T, volumeType string) *csi.NodeStageVo
 	}
 }
 
+func TestConnectVolume_ChapSecret(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	conn := connector.GetConnector(ctx, connector.ISCSIDriver)
+	parameters := map[string]interface{}{
+		"connector": conn,
+		"publishInfo": &ControllerPublishInfo{TgtLunWWN: "mock_tgt_lun_wwn_1",
+			ChapSecret: "huawei-csi/chap-secret"},
+	}
+	var gotParams map[string]interface{}
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(pkgUtils.GetChapCredentials, "chap-user", "chap-password", nil).
+		ApplyMethod(reflect.TypeOf(conn), "ConnectVolume",
+			func(_ *iscsi.Connector, _ context.Context, params map[string]interface{}) (string, error) {
+				gotParams = params
+				return "test_dev_path", nil
+			})
+	defer patches.Reset()
+
+	// action
+	err := connectVolume(ctx, parameters)
+
+	// assert
+	if err != nil {
+		t.Fatalf("TestConnectVolume_ChapSecret() want error = nil, got error = %v", err)
+	}
+	if gotParams["authUserName"] != "chap-user" || gotParams["authPassword"] != "chap-password" ||
+		gotParams["authMethod"] != chapAuthMethod {
+		t.Errorf("TestConnectVolume_ChapSecret() want CHAP user %s, got %v", "chap-user", gotParams["authUserName"])
+	}
+}
+
 // checkTargetMapContainsSourceMap is a helper function called from multiple test cases
 func checkTargetMapContainsSourceMap(source, target map[string]interface{}) bool {
 	for key, sourceValue := range source {
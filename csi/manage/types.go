@@ -34,7 +34,8 @@ type VolumeManager interface {
 
 // ControllerPublishInfo context passed by ControllerPublishVolume
 // VolumeUseMultiPath is required, and if it is equal true, then MultiPathType is required
-// iscsi protocol: TgtPortals, TgtIQNs, TgtHostLUNs, TgtLunWWN is required
+// iscsi protocol: TgtPortals, TgtIQNs, TgtHostLUNs, TgtLunWWN is required,
+// and ChapSecret, the namespace/name of the CHAP secret read by the node, is set if the CHAP authentication is enabled
 // fc protocol: TgtLunWWN, TgtWWNs, TgtHostLUNs is required
 // fc-nvme protocol: PortWWNList, TgtLunGuid is required
 // roce protocol: TgtPortals, TgtLunGuid is required
@@ -49,6 +50,7 @@ type ControllerPublishInfo struct {
 	PortWWNList        []nvme.PortWWNPair `json:"portWWNList"`
 	VolumeUseMultiPath bool               `json:"volumeUseMultiPath"`
 	MultiPathType      string             `json:"multiPathType"`
+	ChapSecret         string             `json:"chapSecret"`
 }
 
 // DTreePublishInfo records dtree parent name and pass by ControllerPublishVolume
@@ -27,6 +27,7 @@ import (
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/lib/drcsi"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
@@ -130,6 +131,9 @@ func (p *StorageProvider) GetBackendStats(ctx context.Context, req *drcsi.GetBac
 		return nil, err
 	}
 
+	stats := storage.GetConnectionStats(req.BackendId)
+	log.AddContext(ctx).Infof("connections of backend %s, new: %d, reused: %d", backendName, stats.New, stats.Reused)
+
 	response := &drcsi.GetBackendStatsResponse{
 		VendorName:      constants.ProviderVendorName,
 		ProviderName:    app.GetGlobalConfig().DriverName,
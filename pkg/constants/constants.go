@@ -104,9 +104,17 @@ const (
 	DisableVerifyCapacityKey = "disableVerifyCapacity"
 	// AdvancedOptionsKey is the key of advanced volume options parameter in StorageClass
 	AdvancedOptionsKey = "advancedOptions"
+	// DryRunKey is the key of dryRun parameter in StorageClass to assemble the create params without creating
+	DryRunKey = "dryRun"
 	// ScVolumeNameKey is the key of volumeName in StorageClass
 	ScVolumeNameKey = "volumeName"
 
+	// LicenseExpiryKeyPrefix is the prefix of the keys of the license expiry dates in the backend specifications
+	LicenseExpiryKeyPrefix = "LicenseExpiry."
+	// LicenseExpiringSoonKey is the key of the comma separated features whose licenses expire soon
+	// in the backend specifications
+	LicenseExpiringSoonKey = "LicenseExpiringSoon"
+
 	// PVCNameKey is the key of PVC name in CreateVolumeRequest parameters
 	PVCNameKey = "csi.storage.k8s.io/pvc/name"
 	// PVCNamespaceKey is the key of PVC namespace in CreateVolumeRequest parameters
@@ -124,6 +132,54 @@ const (
 	AuthModeScopeLocal = "0"
 	// AuthModeScopeLDAP is the login backend scope param
 	AuthModeScopeLDAP = "1"
+	// LoginScopeKey is the param of backend to override the login scope of the secret
+	LoginScopeKey = "loginScope"
+	// ProxyURLKey is the param of backend to route the storage requests through the proxy
+	ProxyURLKey = "proxyURL"
+	// CustomHeadersKey is the param of backend to add the static headers to the storage requests
+	CustomHeadersKey = "customHeaders"
+	// PoolCacheTTLKey is the param of backend to enable the cache of the pools, e.g. 30s
+	PoolCacheTTLKey = "poolCacheTTL"
+	// SystemCacheTTLKey is the param of backend of the cache duration of the system info, e.g. 5s,
+	// a negative duration disables the cache
+	SystemCacheTTLKey = "systemCacheTTL"
+	// CredentialsPathKey is the param of backend to read the credentials from the mounted secret files
+	CredentialsPathKey = "credentialsPath"
+	// RetryBudgetKey is the param of backend to limit the relogin and resend in a burst, e.g. 10
+	RetryBudgetKey = "retryBudget"
+	// RateLimitKey is the param of backend to limit the requests per second to the storage, e.g. 10
+	RateLimitKey = "rateLimit"
+	// RateBurstKey is the param of backend to allow the requests at once when the rate is limited
+	RateBurstKey = "rateBurst"
+	// CASecretKey is the param of backend to verify the storage certificate against the CA bundle of the secret
+	CASecretKey = "caSecret"
+	// MaxIdleConnsKey is the param of backend to limit the idle connections of the http transport
+	MaxIdleConnsKey = "maxIdleConns"
+	// MaxIdleConnsPerHostKey is the param of backend to limit the idle connections to each storage url
+	MaxIdleConnsPerHostKey = "maxIdleConnsPerHost"
+	// IdleConnTimeoutKey is the param of backend to close the idle connections after the duration, e.g. 90s
+	IdleConnTimeoutKey = "idleConnTimeout"
+	// KeepAliveKey is the param of backend to probe the connections by TCP keepalive after they are idle for
+	// the duration, e.g. 30s, it should be shorter than the idleConnTimeout, and a negative duration disables it
+	KeepAliveKey = "keepAlive"
+	// HostOverridesKey is the param of backend to dial the host names of the urls with the static IPs instead of
+	// the ones resolved by the DNS, e.g. {"storage.example.com": "192.168.1.10"}
+	HostOverridesKey = "hostOverrides"
+	// ChapSecretKey is the param of iscsi backend to enable the CHAP of the initiators with the credentials
+	// in the secret, in the format of namespace/name
+	ChapSecretKey = "chapSecret"
+	// ChapUserKey and ChapPasswordKey are the keys of the CHAP credentials in the CHAP secret,
+	// they are the same as the ones of the iscsi volume of kubernetes
+	ChapUserKey     = "node.session.auth.username"
+	ChapPasswordKey = "node.session.auth.password"
+	// LoginTimeoutKey is the param of backend to bound the total time of the login across the urls, e.g. 60s
+	LoginTimeoutKey = "loginTimeout"
+	// SessionTimeoutKey is the param of backend to request the idle timeout of the login session, e.g. 30m
+	SessionTimeoutKey = "sessionTimeout"
+	// VerboseLogKey is the param of backend to log the bodies of all the storage requests and responses
+	VerboseLogKey = "verboseLog"
+	// AlarmInErrorKey is the param of backend to append the relevant active alarm to the error of a failed creation
+	AlarmInErrorKey = "alarmInError"
 )
 
 var (
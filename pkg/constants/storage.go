@@ -43,6 +43,12 @@ func (ver OceanstorVersion) IsDoradoV7() bool {
 	return ver == OceanStorDoradoV7
 }
 
+// IsSupportSmartTier checks whether the version supports SmartTier, only the hybrid flash storage has
+// multiple storage tiers to relocate data between
+func (ver OceanstorVersion) IsSupportSmartTier() bool {
+	return ver == OceanStorV3 || ver == OceanStorV5
+}
+
 const (
 	// OceanStorDoradoV7 is dorado v7
 	OceanStorDoradoV7 OceanstorVersion = "DoradoV7"
@@ -162,6 +168,16 @@ var SupportApplicationType BackendCapability = "SupportApplicationType"
 // SupportMetroNAS defines backend capability SupportMetroNAS
 var SupportMetroNAS BackendCapability = "SupportMetroNAS"
 
+// SupportDedupe defines backend capability SupportDedupe
+var SupportDedupe BackendCapability = "SupportDedupe"
+
+// SupportCompression defines backend capability SupportCompression
+var SupportCompression BackendCapability = "SupportCompression"
+
+// SupportProtocolPrefix is the prefix of the backend capabilities of the front-end protocols,
+// e.g. SupportProtocolISCSI is true if the iscsi service ports of the storage are online
+const SupportProtocolPrefix = "SupportProtocol"
+
 // SupportNFS3 defines backend capability SupportNFS3
 const SupportNFS3 = "SupportNFS3"
 
@@ -684,15 +684,8 @@ func checkMetroPairSyncSpeed(value string, params map[string]string) error {
 			"parameter metroPairSyncSpeed can be configured only when hyperMetro is set to true")
 	}
 
-	speed, err := strconv.Atoi(value)
-	if err != nil {
-		return fmt.Errorf("check spec failed: paramter metroPairSyncSpeed can only be an integer")
-	}
-
-	if speed < client.MetroPairSyncSpeedLow || speed > client.MetroPairSyncSpeedHighest {
-		return fmt.Errorf(
-			"check spec failed: paramter metroPairSyncSpeed must be between %d and %d, but got [%d]",
-			client.MetroPairSyncSpeedLow, client.MetroPairSyncSpeedHighest, speed)
+	if _, err := client.ParseMetroPairSyncSpeed(value); err != nil {
+		return fmt.Errorf("check spec failed: %w", err)
 	}
 
 	return nil
@@ -24,6 +24,7 @@ import (
 
 	xuanwuv1 "github.com/Huawei/eSDK_K8S_Plugin/v4/client/apis/xuanwu/v1"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/lib/drcsi"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
@@ -166,6 +167,7 @@ func (ctrl *backendController) shouldUpdateContentStatus(ctx context.Context, co
 	}
 
 	if !reflect.DeepEqual(content.Status.Specification, status.Specifications) {
+		ctrl.warnLicenseExpiringSoon(content, status.Specifications)
 		content.Status.Specification = status.Specifications
 	}
 
@@ -183,6 +185,20 @@ func (ctrl *backendController) shouldUpdateContentStatus(ctx context.Context, co
 	return true
 }
 
+// warnLicenseExpiringSoon records a warning event once the features whose licenses expire soon are changed,
+// since the capabilities provided by them are removed silently after they expire
+func (ctrl *backendController) warnLicenseExpiringSoon(content *xuanwuv1.StorageBackendContent,
+	specifications map[string]string) {
+	expiringSoon := specifications[constants.LicenseExpiringSoonKey]
+	if expiringSoon == "" || expiringSoon == content.Status.Specification[constants.LicenseExpiringSoonKey] {
+		return
+	}
+
+	ctrl.eventRecorder.Eventf(content, coreV1.EventTypeWarning, "LicenseExpiringSoon",
+		"The licenses of features %s of the storage expire soon, the capabilities provided by them "+
+			"will be removed after then", expiringSoon)
+}
+
 func (ctrl *backendController) getContentStats(ctx context.Context, content *xuanwuv1.StorageBackendContent) (
 	*xuanwuv1.StorageBackendContent, error) {
 
@@ -0,0 +1,133 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+const (
+	authFileUser     = "user"
+	authFilePassword = "password"
+)
+
+// AuthSource defines where the authentication information of the backend is read from
+type AuthSource interface {
+	// GetAuthInfo reads the latest authentication information, it is called on every login
+	GetAuthInfo(ctx context.Context) (*BackendAuthInfo, error)
+}
+
+type secretAuthSource struct {
+	name      string
+	namespace string
+}
+
+// NewSecretAuthSource returns the source that reads the k8s Secret by the Secret API
+func NewSecretAuthSource(name, namespace string) AuthSource {
+	return &secretAuthSource{name: name, namespace: namespace}
+}
+
+// GetAuthInfo reads the authentication information from the k8s Secret
+func (s *secretAuthSource) GetAuthInfo(ctx context.Context) (*BackendAuthInfo, error) {
+	return GetAuthInfoFromSecret(ctx, s.name, s.namespace)
+}
+
+type backendAuthSource struct {
+	backendID string
+}
+
+// NewBackendAuthSource returns the source that reads the k8s Secret referenced by the backend
+func NewBackendAuthSource(backendID string) AuthSource {
+	return &backendAuthSource{backendID: backendID}
+}
+
+// GetAuthInfo reads the authentication information from the k8s Secret referenced by the backend
+func (s *backendAuthSource) GetAuthInfo(ctx context.Context) (*BackendAuthInfo, error) {
+	return GetAuthInfoFromBackendID(ctx, s.backendID)
+}
+
+// SelectAuthSource returns the Secret mounted as files in the credentialsPath as the auth source if it is configured,
+// otherwise the secretSource is returned
+func SelectAuthSource(credentialsPath string, secretSource AuthSource) AuthSource {
+	if credentialsPath != "" {
+		return NewFileAuthSource(credentialsPath)
+	}
+
+	return secretSource
+}
+
+type fileAuthSource struct {
+	path string
+}
+
+// NewFileAuthSource returns the source that reads the Secret mounted as files in the directory,
+// the files are read on every login so that the rotated credentials are picked up by the next relogin
+func NewFileAuthSource(path string) AuthSource {
+	return &fileAuthSource{path: path}
+}
+
+// GetAuthInfo reads the authentication information from the mounted files
+func (s *fileAuthSource) GetAuthInfo(ctx context.Context) (*BackendAuthInfo, error) {
+	log.AddContext(ctx).Debugf("Get authentication information from file: %s", s.path)
+	user, err := readAuthFile(s.path, authFileUser)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := readAuthFile(s.path, authFilePassword)
+	if err != nil {
+		return nil, err
+	}
+
+	loginParams := &BackendAuthInfo{
+		User:     user,
+		Password: password,
+		Scope:    constants.AuthModeScopeLocal,
+	}
+
+	authenticationMode, err := readAuthFile(s.path, constants.AuthenticationModeKey)
+	if err == nil {
+		loginParams.Scope = authenticationMode
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return loginParams, nil
+}
+
+func readAuthFile(dir, name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", fmt.Errorf("read the %q field of the credentials in %s failed, error: %w", name, dir, err)
+	}
+
+	// the trailing newline is usually added when the file is created by an editor or echo
+	value := strings.TrimRight(string(data), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("the %q field of the credentials in %s is empty", name, dir)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+)
+
+func writeAuthFile(t *testing.T, dir, name, value string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(value), 0600))
+}
+
+func TestFileAuthSource_GetAuthInfo_Rotated(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeAuthFile(t, dir, authFileUser, "admin\n")
+	writeAuthFile(t, dir, authFilePassword, "old-password")
+	source := NewFileAuthSource(dir)
+
+	// act
+	before, beforeErr := source.GetAuthInfo(ctx)
+	writeAuthFile(t, dir, authFilePassword, "new-password")
+	writeAuthFile(t, dir, constants.AuthenticationModeKey, constants.AuthModeScopeLDAP)
+	after, afterErr := source.GetAuthInfo(ctx)
+
+	// assert
+	require.NoError(t, beforeErr)
+	require.NoError(t, afterErr)
+	require.Equal(t, &BackendAuthInfo{User: "admin", Password: "old-password",
+		Scope: constants.AuthModeScopeLocal}, before)
+	require.Equal(t, &BackendAuthInfo{User: "admin", Password: "new-password",
+		Scope: constants.AuthModeScopeLDAP}, after)
+}
+
+func TestFileAuthSource_GetAuthInfo_PasswordMissing(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeAuthFile(t, dir, authFileUser, "admin")
+
+	// act
+	_, err := NewFileAuthSource(dir).GetAuthInfo(ctx)
+
+	// assert
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileAuthSource_GetAuthInfo_UserEmpty(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeAuthFile(t, dir, authFileUser, "\n")
+	writeAuthFile(t, dir, authFilePassword, "password")
+
+	// act
+	_, err := NewFileAuthSource(dir).GetAuthInfo(ctx)
+
+	// assert
+	require.ErrorContains(t, err, `the "user" field of the credentials`)
+}
+
+func TestSelectAuthSource(t *testing.T) {
+	// arrange
+	secretSource := NewSecretAuthSource("secret", "default")
+
+	// act
+	fileSource := SelectAuthSource("/etc/huawei/credentials", secretSource)
+	defaultSource := SelectAuthSource("", secretSource)
+
+	// assert
+	require.Equal(t, NewFileAuthSource("/etc/huawei/credentials"), fileSource)
+	require.Equal(t, secretSource, defaultSource)
+}
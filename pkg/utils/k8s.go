@@ -41,6 +41,9 @@ import (
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
+// caBundleKey is the key of the PEM encoded CA bundle in the CA secret
+const caBundleKey = "ca.crt"
+
 // BackendAuthInfo for login backend
 type BackendAuthInfo struct {
 	// User is the account used for connecting to storage
@@ -122,6 +125,46 @@ func GetCertPool(ctx context.Context, useCert bool, secretMeta string) (bool, *x
 	return true, certPool, nil
 }
 
+// GetCAPool appends the CA bundle in the ca.crt of the secret to the cert pool, a new pool is created if it is nil
+func GetCAPool(ctx context.Context, secretMeta string, certPool *x509.CertPool) (*x509.CertPool, error) {
+	log.AddContext(ctx).Infof("Start get CA bundle from secret %s", secretMeta)
+	secret, err := GetBackendSecret(ctx, secretMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == nil || len(secret.Data[caBundleKey]) == 0 {
+		return nil, fmt.Errorf("the %q field in the CA secret %s does not exist or is empty", caBundleKey, secretMeta)
+	}
+
+	if certPool == nil {
+		certPool = x509.NewCertPool()
+	}
+
+	if !certPool.AppendCertsFromPEM(secret.Data[caBundleKey]) {
+		return nil, fmt.Errorf("no usable PEM certificate is found in the %q field of the CA secret %s",
+			caBundleKey, secretMeta)
+	}
+
+	return certPool, nil
+}
+
+// GetChapCredentials returns the CHAP username and password in the CHAP secret
+func GetChapCredentials(ctx context.Context, secretMeta string) (string, string, error) {
+	secret, err := GetBackendSecret(ctx, secretMeta)
+	if err != nil {
+		return "", "", err
+	}
+
+	if secret == nil || len(secret.Data[constants.ChapUserKey]) == 0 ||
+		len(secret.Data[constants.ChapPasswordKey]) == 0 {
+		return "", "", fmt.Errorf("the %q and %q fields in the CHAP secret %s must not be empty",
+			constants.ChapUserKey, constants.ChapPasswordKey, secretMeta)
+	}
+
+	return string(secret.Data[constants.ChapUserKey]), string(secret.Data[constants.ChapPasswordKey]), nil
+}
+
 func GetBackendConfigmapByClaimName(ctx context.Context, claimNameMeta string) (*coreV1.ConfigMap, error) {
 	log.AddContext(ctx).Infof("Get configmap meta data by claim meta: [%s]", claimNameMeta)
 	configmapMeta, _, err := GetConfigMeta(ctx, claimNameMeta)
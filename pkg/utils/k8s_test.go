@@ -17,9 +17,17 @@ package utils
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
@@ -115,6 +123,62 @@ func TestGetPwdAndAuthModeFromSecret(t *testing.T) {
 	}
 }
 
+func TestGetCAPool(t *testing.T) {
+	// arrange
+	ctx := context.TODO()
+	caBundle := newTestCABundle(t)
+	cases := []struct {
+		name     string
+		data     map[string][]byte
+		certPool *x509.CertPool
+		hasError bool
+	}{
+		{name: "ca.crt does not exist case", data: map[string][]byte{}, hasError: true},
+		{name: "ca.crt has no PEM case", data: map[string][]byte{caBundleKey: []byte("mock-ca")}, hasError: true},
+		{name: "new cert pool case", data: map[string][]byte{caBundleKey: caBundle}},
+		{name: "append to cert pool case", data: map[string][]byte{caBundleKey: caBundle},
+			certPool: x509.NewCertPool()},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// mock
+			m := mockGetSecret(c.data, nil)
+			defer m.Reset()
+
+			// action
+			certPool, err := GetCAPool(ctx, "mock-ns/mock-ca", c.certPool)
+
+			// assert
+			if c.hasError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, certPool)
+			if c.certPool != nil {
+				assert.Same(t, c.certPool, certPool)
+			}
+		})
+	}
+}
+
+func newTestCABundle(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "mock-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
 func mockGetSecret(data map[string][]byte, err error) *gomonkey.Patches {
 	return gomonkey.ApplyMethod(reflect.TypeOf(app.GetGlobalConfig().K8sUtils),
 		"GetSecret",
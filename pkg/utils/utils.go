@@ -350,6 +350,16 @@ func CheckAuthenticationMode(authMode string) error {
 	return nil
 }
 
+// CheckLoginScope used to check whether the login scope is accepted by the storage, empty scope is allowed
+func CheckLoginScope(scope string) error {
+	scopes := []string{constants.AuthModeScopeLocal, constants.AuthModeScopeLDAP}
+	if len(scope) > 0 && !slices.Contains(scopes, scope) {
+		return fmt.Errorf("loginScope must be one of %v, the actual value is %s", scopes, scope)
+	}
+
+	return nil
+}
+
 // ConvertAuthenticationToScope used to convert authentication to scope
 func ConvertAuthenticationToScope(authMode string) string {
 	authMode = strings.ToLower(strings.TrimSpace(authMode))
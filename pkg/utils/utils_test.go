@@ -370,6 +370,35 @@ func TestCheckAuthenticationMode(t *testing.T) {
 	}
 }
 
+func TestCheckLoginScope(t *testing.T) {
+	//arrange
+	type testCase struct {
+		name     string
+		input    string
+		checkRes bool
+	}
+
+	testCases := []testCase{
+		{name: "Test local scope case", input: "0", checkRes: true},
+		{name: "Test ldap scope case", input: "1", checkRes: true},
+		{name: "Test no value case", input: "", checkRes: true},
+		{name: "Test error case", input: "2", checkRes: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			//active
+			gotErr := CheckLoginScope(tc.input)
+			//assert
+			if tc.checkRes {
+				assert.Nil(t, gotErr)
+			} else {
+				assert.ErrorContains(t, gotErr, "must be one of")
+			}
+		})
+	}
+}
+
 func TestConvertAuthenticationToScope(t *testing.T) {
 	//arrange
 	type testCase struct {
@@ -163,7 +163,7 @@ func (c *Controller) getRequestBody(ctx context.Context, w http.ResponseWriter,
 func (c *Controller) serve(w http.ResponseWriter, r *http.Request, admit admitHandler) {
 	var body []byte
 	var err error
-	ctx := context.Background()
+	ctx := log.EnsureRequestID(context.Background())
 	log.AddContext(ctx).Infof("Start to handle request: %v", r)
 	if body, err = c.getRequestBody(ctx, w, r); err != nil {
 		return
@@ -493,8 +493,8 @@ func validateStorageBackendClaim(ctx context.Context, operation admissionV1.Oper
 }
 
 func admitStorageBackendClaim(ar admissionV1.AdmissionReview) *admissionV1.AdmissionResponse {
-	log.Infoln("Start admit StorageBackendClaim.")
-	ctx := context.Background()
+	ctx := log.EnsureRequestID(context.Background())
+	log.AddContext(ctx).Infoln("Start admit StorageBackendClaim.")
 	newClaim, oldClaim, err := getStorageBackendClaim(ctx, ar.Request.Operation, ar.Request.OldObject.Raw,
 		ar.Request.Object.Raw)
 	if err != nil {
@@ -0,0 +1,90 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// connectionCounters stores the connection counters of each backend,
+	// it is accessed by multiple clients concurrently and must be guarded by connectionCountersMutex
+	connectionCounters      = map[string]*connectionCounter{}
+	connectionCountersMutex sync.RWMutex
+)
+
+// ConnectionStats is the snapshot of the connections that the requests of a backend got from the http transport
+type ConnectionStats struct {
+	// New is the number of the requests that dialed a new connection
+	New uint64 `json:"new"`
+	// Reused is the number of the requests that reused an idle keep-alive connection
+	Reused uint64 `json:"reused"`
+}
+
+type connectionCounter struct {
+	newConns    atomic.Uint64
+	reusedConns atomic.Uint64
+}
+
+// GetConnectionStats returns the connection stats of the backend, zero is returned if no request is traced
+func GetConnectionStats(backendID string) ConnectionStats {
+	connectionCountersMutex.RLock()
+	defer connectionCountersMutex.RUnlock()
+	counter, ok := connectionCounters[backendID]
+	if !ok {
+		return ConnectionStats{}
+	}
+
+	return ConnectionStats{New: counter.newConns.Load(), Reused: counter.reusedConns.Load()}
+}
+
+// TraceConnection returns the request that counts whether the connection it gets is new or reused for the backend
+func TraceConnection(req *http.Request, backendID string) *http.Request {
+	counter := getConnectionCounter(backendID)
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				counter.reusedConns.Add(1)
+			} else {
+				counter.newConns.Add(1)
+			}
+		},
+	}
+
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+func getConnectionCounter(backendID string) *connectionCounter {
+	connectionCountersMutex.RLock()
+	counter, ok := connectionCounters[backendID]
+	connectionCountersMutex.RUnlock()
+	if ok {
+		return counter
+	}
+
+	connectionCountersMutex.Lock()
+	defer connectionCountersMutex.Unlock()
+	if counter, ok = connectionCounters[backendID]; !ok {
+		counter = &connectionCounter{}
+		connectionCounters[backendID] = counter
+	}
+
+	return counter
+}
@@ -0,0 +1,56 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceConnection_CountsNewAndReusedConnections(t *testing.T) {
+	// arrange
+	backendID := "backend-connection-stats"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: &http.Transport{}}
+	defer client.CloseIdleConnections()
+
+	// act
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(TraceConnection(req, backendID))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	// assert
+	require.Equal(t, ConnectionStats{New: 1, Reused: 2}, GetConnectionStats(backendID))
+}
+
+func TestGetConnectionStats_NotTraced(t *testing.T) {
+	// act
+	stats := GetConnectionStats("backend-not-traced")
+
+	// assert
+	require.Equal(t, ConnectionStats{}, stats)
+}
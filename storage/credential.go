@@ -0,0 +1,76 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// LoginPasswordKey is the key of the password in the login request body
+const LoginPasswordKey = "password"
+
+// CredentialDecrypter decrypts or unwraps the password stored in the secret of the backend before login,
+// e.g. the password wrapped by an external KMS or a sealed secret
+type CredentialDecrypter interface {
+	Decrypt(ctx context.Context, backendID, password string) (string, error)
+}
+
+type passthroughDecrypter struct{}
+
+// Decrypt returns the password as it is
+func (passthroughDecrypter) Decrypt(_ context.Context, _, password string) (string, error) {
+	return password, nil
+}
+
+var (
+	credentialDecrypter      CredentialDecrypter = passthroughDecrypter{}
+	credentialDecrypterMutex sync.RWMutex
+)
+
+// RegisterCredentialDecrypter sets the decrypter of the login password,
+// the password is passed through if the decrypter is nil
+func RegisterCredentialDecrypter(decrypter CredentialDecrypter) {
+	credentialDecrypterMutex.Lock()
+	defer credentialDecrypterMutex.Unlock()
+	if decrypter == nil {
+		decrypter = passthroughDecrypter{}
+	}
+	credentialDecrypter = decrypter
+}
+
+// DecryptPassword decrypts the password of the backend by the registered decrypter
+func DecryptPassword(ctx context.Context, backendID, password string) (string, error) {
+	credentialDecrypterMutex.RLock()
+	decrypter := credentialDecrypter
+	credentialDecrypterMutex.RUnlock()
+
+	decrypted, err := decrypter.Decrypt(ctx, backendID, password)
+	if err != nil {
+		return "", fmt.Errorf("decrypt password of backend %s failed, error: %w", backendID, err)
+	}
+
+	return decrypted, nil
+}
+
+// ClearLoginPassword clears the password in the login request body after it is used
+func ClearLoginPassword(data map[string]any) {
+	if _, exist := data[LoginPasswordKey]; exist {
+		data[LoginPasswordKey] = ""
+	}
+}
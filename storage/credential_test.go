@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDecrypter struct {
+	err error
+}
+
+func (d fakeDecrypter) Decrypt(_ context.Context, backendID, password string) (string, error) {
+	if d.err != nil {
+		return "", d.err
+	}
+
+	return "decrypted-" + backendID + "-" + password, nil
+}
+
+func TestDecryptPassword_Passthrough(t *testing.T) {
+	// action
+	got, err := DecryptPassword(context.Background(), "backend-1", "pwd")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "pwd", got)
+}
+
+func TestDecryptPassword_RegisteredDecrypter(t *testing.T) {
+	// arrange
+	RegisterCredentialDecrypter(fakeDecrypter{})
+	defer RegisterCredentialDecrypter(nil)
+
+	// action
+	got, err := DecryptPassword(context.Background(), "backend-1", "wrapped")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "decrypted-backend-1-wrapped", got)
+}
+
+func TestDecryptPassword_DecryptFailed(t *testing.T) {
+	// arrange
+	RegisterCredentialDecrypter(fakeDecrypter{err: errors.New("kms unavailable")})
+	defer RegisterCredentialDecrypter(nil)
+
+	// action
+	got, err := DecryptPassword(context.Background(), "backend-1", "wrapped")
+
+	// assert
+	require.ErrorContains(t, err, "kms unavailable")
+	require.Empty(t, got)
+}
+
+func TestClearLoginPassword(t *testing.T) {
+	// arrange
+	data := map[string]any{"username": "user", LoginPasswordKey: "pwd"}
+
+	// action
+	ClearLoginPassword(data)
+
+	// assert
+	require.Equal(t, map[string]any{"username": "user", LoginPasswordKey: ""}, data)
+}
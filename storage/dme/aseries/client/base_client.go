@@ -93,6 +93,7 @@ type BaseClientInterface interface {
 	Call(ctx context.Context, method string, url string, data any) ([]byte, error)
 	Login(ctx context.Context) error
 	Logout(ctx context.Context)
+	Close(ctx context.Context)
 	ReLogin(ctx context.Context) error
 	SetSystemInfo(ctx context.Context, sn string) error
 	ValidateLogin(ctx context.Context) error
@@ -115,9 +116,18 @@ type BaseClient struct {
 	storageID       string
 	deviceSN        string
 	token           string
+	// credentialsPath is the directory of the mounted secret, the k8s Secret API is used if it is empty
+	credentialsPath string
+
+	// caSecretMeta, proxyURL and transport are kept to recreate the http client when login
+	caSecretMeta string
+	proxyURL     string
+	transport    storage.TransportConfig
 
 	reLoginMutex     sync.Mutex
 	requestSemaphore *utils.Semaphore
+	// reLoginBackoff delays the relogin after the DME is unreachable
+	reLoginBackoff storage.ReLoginBackoff
 }
 
 // LoginResponse is the response of get login request
@@ -213,7 +223,8 @@ func NewBaseClient(ctx context.Context, param *storage.NewClientConfig) (*BaseCl
 		parallelCount = DefaultParallelCount
 	}
 
-	httpClient, err := storage.NewHTTPClientByCertMeta(ctx, param.UseCert, param.CertSecretMeta)
+	httpClient, err := storage.NewHTTPClientByCertMeta(ctx, param.UseCert, param.CertSecretMeta, param.CASecretMeta,
+		param.ProxyURL, param.Transport)
 	if err != nil {
 		log.AddContext(ctx).Errorf("New http client by cert meta failed, err is %v", err)
 		return nil, err
@@ -226,10 +237,25 @@ func NewBaseClient(ctx context.Context, param *storage.NewClientConfig) (*BaseCl
 		secretNamespace:  param.SecretNamespace,
 		secretName:       param.SecretName,
 		backendID:        param.BackendID,
+		credentialsPath:  param.CredentialsPath,
+		caSecretMeta:     param.CASecretMeta,
+		proxyURL:         param.ProxyURL,
+		transport:        param.Transport,
 		requestSemaphore: utils.NewSemaphore(parallelCount),
 	}, nil
 }
 
+// newHTTPClient creates the http client by the latest cert secret of the backend,
+// the CA bundle and the proxy of the client are kept
+func (cli *BaseClient) newHTTPClient(ctx context.Context) (storage.HTTP, error) {
+	useCert, certMeta, err := pkgUtils.GetCertSecretFromBackendID(ctx, cli.backendID)
+	if err != nil {
+		return nil, fmt.Errorf("get cert secret from backend %s failed, error: %w", cli.backendID, err)
+	}
+
+	return storage.NewHTTPClientByCertMeta(ctx, useCert, certMeta, cli.caSecretMeta, cli.proxyURL, cli.transport)
+}
+
 // ReLogin logout and login again
 func (cli *BaseClient) ReLogin(ctx context.Context) error {
 	oldToken := cli.token
@@ -240,13 +266,20 @@ func (cli *BaseClient) ReLogin(ctx context.Context) error {
 	if cli.token != "" && oldToken != cli.token {
 		// Coming here indicates other thread had already done relogin, so no need to relogin again
 		return nil
-	} else if cli.token != "" {
+	}
+
+	if err := cli.reLoginBackoff.Allow(); err != nil {
+		log.AddContext(ctx).Warningf("Skip relogin of backend %s: %v", cli.backendID, err)
+		return err
+	}
+
+	if cli.token != "" {
 		cli.Logout(ctx)
 	}
 
 	err := cli.Login(ctx)
-	if err != nil {
-		log.AddContext(ctx).Errorf("Try to relogin error: %v", err)
+	if failure := cli.reLoginBackoff.Record(err); err != nil {
+		log.AddContext(ctx).Errorf("Try to relogin error, failure: %s, err: %v", failure, err)
 		return err
 	}
 
@@ -284,6 +317,7 @@ func (cli *BaseClient) doLogin(ctx context.Context, authInfo *pkgUtils.BackendAu
 		return fmt.Errorf("get reuqest failed while login, error : %v", err)
 	}
 
+	var unconnected int
 	for _, url := range cli.urls {
 		cli.url = url
 		log.AddContext(ctx).Infof("try to login %s", cli.url)
@@ -294,13 +328,39 @@ func (cli *BaseClient) doLogin(ctx context.Context, authInfo *pkgUtils.BackendAu
 		} else {
 			err = reqErr
 		}
+
+		if reqErr.Error() == storage.Unconnected {
+			unconnected++
+		}
 	}
+
+	return classifyLoginError(err, unconnected, len(cli.urls))
+}
+
+// classifyLoginError wraps the error of the login with storage.ErrLoginUnreachable if none of the urls could be
+// connected, or with storage.ErrLoginRejected if the DME rejected the login
+func classifyLoginError(err error, unconnected, urls int) error {
+	if err == nil {
+		return nil
+	}
+
+	if unconnected == urls {
+		return fmt.Errorf("%w after trying %d urls: %w", storage.ErrLoginUnreachable, urls, err)
+	}
+
+	var loginErr LoginError
+	var authErr AuthError
+	if errors.As(err, &loginErr) || errors.As(err, &authErr) {
+		return fmt.Errorf("%w: %w", storage.ErrLoginRejected, err)
+	}
+
 	return err
 }
 
 // ValidateLogin validates the login info
 func (cli *BaseClient) ValidateLogin(ctx context.Context) error {
-	authInfo, err := pkgUtils.GetAuthInfoFromSecret(ctx, cli.secretName, cli.secretNamespace)
+	authInfo, err := pkgUtils.SelectAuthSource(cli.credentialsPath,
+		pkgUtils.NewSecretAuthSource(cli.secretName, cli.secretNamespace)).GetAuthInfo(ctx)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Get auth info failed: %v", err)
 		return err
@@ -310,12 +370,13 @@ func (cli *BaseClient) ValidateLogin(ctx context.Context) error {
 
 // Login login and set data from response
 func (cli *BaseClient) Login(ctx context.Context) error {
-	client, err := storage.NewHTTPClientByBackendID(ctx, cli.backendID)
+	client, err := cli.newHTTPClient(ctx)
 	if err != nil {
 		return fmt.Errorf("new http client by backend %s failed, err is %v", cli.backendID, err)
 	}
 	cli.client = client
-	authInfo, err := pkgUtils.GetAuthInfoFromBackendID(ctx, cli.backendID)
+	authInfo, err := pkgUtils.SelectAuthSource(cli.credentialsPath, pkgUtils.NewBackendAuthSource(cli.backendID)).
+		GetAuthInfo(ctx)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Get auth info failed: %v", err)
 		return err
@@ -325,6 +386,9 @@ func (cli *BaseClient) Login(ctx context.Context) error {
 		return nil
 	}
 	cli.Logout(ctx)
+	if storage.ClassifyLoginFailure(err) != storage.LoginFailureAuth {
+		return err
+	}
 	if setErr := pkgUtils.SetStorageBackendContentOnlineStatus(ctx, cli.GetBackendID(), false); setErr != nil {
 		return fmt.Errorf("login failed: %w\nSetStorageBackendContentOffline [%s] failed. error: %v",
 			err, cli.GetBackendID(), setErr)
@@ -340,6 +404,13 @@ func (cli *BaseClient) Logout(ctx context.Context) {
 	}
 }
 
+// Close logs out the session and releases the idle connections of the client, it is called when the backend is removed
+func (cli *BaseClient) Close(ctx context.Context) {
+	cli.Logout(ctx)
+	storage.CloseIdleConnections(cli.client)
+	log.AddContext(ctx).Infof("Client of backend %s is closed", cli.GetBackendID())
+}
+
 // Call provides call for restful request
 func (cli *BaseClient) Call(ctx context.Context, method string, url string, data any) ([]byte, error) {
 	var (
@@ -377,13 +448,9 @@ func (cli *BaseClient) Call(ctx context.Context, method string, url string, data
 	cli.requestSemaphore.Acquire()
 	defer cli.requestSemaphore.Release()
 
-	key := storage.UninitializedStorage
-	if storage.RequestSemaphoreMap[cli.GetDeviceSN()] != nil {
-		key = cli.GetDeviceSN()
-	}
-
-	storage.RequestSemaphoreMap[key].Acquire()
-	defer storage.RequestSemaphoreMap[key].Release()
+	storageSemaphore := storage.GetRequestSemaphoreOrDefault(cli.GetDeviceSN())
+	storageSemaphore.Acquire()
+	defer storageSemaphore.Release()
 
 	resp, err := cli.client.Do(req)
 	if err != nil {
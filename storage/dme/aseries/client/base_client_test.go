@@ -20,8 +20,11 @@ package client
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/agiledragon/gomonkey/v2"
@@ -99,6 +102,29 @@ func TestBaseClient_ReLogin_Success(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestBaseClient_Login_CredentialsPath(t *testing.T) {
+	successResp := `
+		{
+			"accessSession": "xxx",
+			"roaRand": "yyy",
+			"expires": 60,
+			"additionalInfo": null
+		}
+	`
+	cli := getMockClient(200, successResp)
+	cli.credentialsPath = t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(cli.credentialsPath, "user"), []byte("file-user"), 0600))
+	assert.Nil(t, os.WriteFile(filepath.Join(cli.credentialsPath, "password"), []byte("file-password"), 0600))
+
+	patch := gomonkey.ApplyFuncReturn(pkgUtils.GetCertSecretFromBackendID, false, "", nil)
+	defer patch.Reset()
+	patch.ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, nil, errors.New("secret api is forbidden"))
+
+	err := cli.Login(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "file-user", cli.user)
+}
+
 func TestBaseClient_ReLogin_Fail(t *testing.T) {
 	cli := &BaseClient{urls: []string{sessionUrl}}
 	patch := gomonkey.NewPatches()
@@ -180,6 +206,34 @@ func TestBaseClient_Login_Fail(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestBaseClient_Login_KeepTransportSettings(t *testing.T) {
+	// arrange
+	transport := storage.TransportConfig{MaxIdleConns: 10}
+	cli := &BaseClient{urls: []string{sessionUrl}, caSecretMeta: "ns/ca", proxyURL: "http://proxy:3128",
+		transport: transport}
+	var gotCASecret, gotProxy string
+	var gotTransport storage.TransportConfig
+
+	// mock
+	patch := gomonkey.NewPatches()
+	defer patch.Reset()
+	patch.ApplyFuncReturn(pkgUtils.GetCertSecretFromBackendID, false, "", nil).
+		ApplyFunc(storage.NewHTTPClientByCertMeta, func(_ context.Context, _ bool, _, caSecretMeta, proxyURL string,
+			transportConfig storage.TransportConfig) (storage.HTTP, error) {
+			gotCASecret, gotProxy, gotTransport = caSecretMeta, proxyURL, transportConfig
+			return nil, errors.New("stop after the http client is created")
+		})
+
+	// action
+	err := cli.Login(context.Background())
+
+	// assert
+	assert.NotNil(t, err)
+	assert.Equal(t, "ns/ca", gotCASecret)
+	assert.Equal(t, "http://proxy:3128", gotProxy)
+	assert.Equal(t, transport, gotTransport)
+}
+
 func TestBaseClient_Call_Success(t *testing.T) {
 	cli := getMockClient(200, `{"accessSession": "xxx"}`)
 	_, err := cli.Call(context.Background(), "GET", sessionUrl, nil)
@@ -248,3 +302,55 @@ func TestBaseClient_GetBackendID_Success(t *testing.T) {
 	// assert
 	assert.Equal(t, wantBackendID, gotBackendID)
 }
+
+func Test_classifyLoginError(t *testing.T) {
+	// arrange
+	unconnected := errors.New(storage.Unconnected)
+	tests := []struct {
+		name        string
+		err         error
+		unconnected int
+		want        storage.LoginFailure
+	}{
+		{name: "success", err: nil, want: storage.LoginFailureNone},
+		{name: "all unconnected", err: unconnected, unconnected: 2, want: storage.LoginFailureConnectivity},
+		{name: "login error", err: LoginError{ExceptionId: "user.login.failed"}, unconnected: 1,
+			want: storage.LoginFailureAuth},
+		{name: "auth error", err: AuthError{Code: "1"}, want: storage.LoginFailureAuth},
+		{name: "other", err: errors.New("unmarshal failed"), want: storage.LoginFailureOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			got := classifyLoginError(tt.err, tt.unconnected, 2)
+
+			// assert
+			assert.Equal(t, tt.want, storage.ClassifyLoginFailure(got))
+		})
+	}
+}
+
+func TestBaseClient_Login_UnreachableKeepsOnline(t *testing.T) {
+	// arrange
+	cli := &BaseClient{urls: []string{sessionUrl}}
+	var offline bool
+
+	// mock
+	patch := gomonkey.NewPatches()
+	defer patch.Reset()
+	patch.ApplyFuncReturn(pkgUtils.GetCertSecretFromBackendID, false, "", nil).
+		ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, &pkgUtils.BackendAuthInfo{User: "1", Password: "1"}, nil).
+		ApplyMethodReturn(cli, "Call", nil, errors.New(storage.Unconnected)).
+		ApplyFunc(pkgUtils.SetStorageBackendContentOnlineStatus, func(_ context.Context, _ string, _ bool) error {
+			offline = true
+			return nil
+		})
+
+	// act
+	err := cli.Login(context.Background())
+
+	// assert
+	assert.ErrorIs(t, err, storage.ErrLoginUnreachable)
+	assert.False(t, offline)
+}
@@ -99,6 +99,7 @@ type IRestClient interface {
 	Login(ctx context.Context) error
 	SetAccountId(ctx context.Context) error
 	Logout(ctx context.Context)
+	Close(ctx context.Context)
 	ReLogin(ctx context.Context) error
 	KeepAlive(ctx context.Context)
 }
@@ -323,6 +324,16 @@ func (cli *RestClient) Logout(ctx context.Context) {
 	log.AddContext(ctx).Infof("Logout %s success.", cli.url)
 }
 
+// Close logs out the session and releases the idle connections of the client, it is called when the backend is removed
+func (cli *RestClient) Close(ctx context.Context) {
+	httpClient := cli.client
+	cli.Logout(ctx)
+	if httpClient != nil {
+		httpClient.CloseIdleConnections()
+	}
+	log.AddContext(ctx).Infof("Client of backend %s is closed", cli.backendID)
+}
+
 // KeepAlive used to keep connection token alive
 func (cli *RestClient) KeepAlive(ctx context.Context) {
 	_, err := cli.post(ctx, "/dsware/service/v1.3/sec/keepAlive", nil)
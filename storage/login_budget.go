@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrLoginTimeout indicates an error that the login does not succeed within the login timeout
+var ErrLoginTimeout = errors.New("login timed out")
+
+// loginAttemptKey marks the context of a login attempt, only such a context is bound to the http request
+type loginAttemptKey struct{}
+
+// LoginBudget bounds the total time of the login attempts across the urls,
+// each attempt gets a fair share of the remaining time so that a slow url does not consume all of it
+type LoginBudget struct {
+	timeout  time.Duration
+	deadline time.Time
+	attempts int
+}
+
+// NewLoginBudget returns a login budget starting from now, the login time is not bounded if timeout is not positive
+func NewLoginBudget(timeout time.Duration) *LoginBudget {
+	return &LoginBudget{timeout: timeout, deadline: time.Now().Add(timeout)}
+}
+
+// AttemptContext returns the context of the next login attempt, remaining is the number of the urls not tried yet.
+// The attempt is bounded by its share of the budget instead of the cancellation of ctx.
+// An error wrapping ErrLoginTimeout is returned if the budget is exhausted
+func (b *LoginBudget) AttemptContext(ctx context.Context, remaining int) (context.Context, context.CancelFunc, error) {
+	if b.timeout <= 0 {
+		return ctx, func() {}, nil
+	}
+
+	if err := b.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	b.attempts++
+	attemptCtx := context.WithValue(context.WithoutCancel(ctx), loginAttemptKey{}, struct{}{})
+	attemptCtx, cancel := context.WithTimeout(attemptCtx, time.Until(b.deadline)/time.Duration(max(remaining, 1)))
+	return attemptCtx, cancel, nil
+}
+
+// RequestContext returns the context bound to the http request of ctx. Only a login attempt is bounded by its
+// context, the other requests are not canceled together with the context of the caller
+func RequestContext(ctx context.Context) context.Context {
+	if ctx != nil && ctx.Value(loginAttemptKey{}) != nil {
+		return ctx
+	}
+
+	return context.Background()
+}
+
+// Err returns an error wrapping ErrLoginTimeout if the budget is exhausted, otherwise nil
+func (b *LoginBudget) Err() error {
+	if b.timeout <= 0 || time.Now().Before(b.deadline) {
+		return nil
+	}
+
+	return fmt.Errorf("%w after trying %d urls in %s", ErrLoginTimeout, b.attempts, b.timeout)
+}
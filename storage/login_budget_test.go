@@ -0,0 +1,88 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginBudget_AttemptContext_FairShare(t *testing.T) {
+	// arrange
+	budget := NewLoginBudget(time.Minute)
+
+	// act
+	ctx, cancel, err := budget.AttemptContext(context.Background(), 3)
+	require.NoError(t, err)
+	defer cancel()
+
+	// assert
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.InDelta(t, float64(20*time.Second), float64(time.Until(deadline)), float64(time.Second))
+}
+
+func TestLoginBudget_AttemptContext_Exhausted(t *testing.T) {
+	// arrange
+	budget := NewLoginBudget(time.Millisecond)
+	_, cancel, err := budget.AttemptContext(context.Background(), 1)
+	require.NoError(t, err)
+	cancel()
+	time.Sleep(2 * time.Millisecond)
+
+	// act
+	_, _, err = budget.AttemptContext(context.Background(), 1)
+
+	// assert
+	require.ErrorIs(t, err, ErrLoginTimeout)
+	require.ErrorContains(t, err, "after trying 1 urls")
+}
+
+func TestLoginBudget_AttemptContext_Unbounded(t *testing.T) {
+	// arrange
+	budget := NewLoginBudget(0)
+
+	// act
+	ctx, cancel, err := budget.AttemptContext(context.Background(), 1)
+	defer cancel()
+
+	// assert
+	require.NoError(t, err)
+	_, ok := ctx.Deadline()
+	require.False(t, ok)
+	require.NoError(t, budget.Err())
+}
+
+func TestRequestContext(t *testing.T) {
+	// arrange
+	callerCtx, callerCancel := context.WithCancel(context.Background())
+	budget := NewLoginBudget(time.Minute)
+	attemptCtx, cancel, err := budget.AttemptContext(callerCtx, 1)
+	require.NoError(t, err)
+	defer cancel()
+
+	// act
+	callerCancel()
+
+	// assert
+	require.Equal(t, context.Background(), RequestContext(callerCtx))
+	require.Same(t, attemptCtx, RequestContext(attemptCtx))
+	require.NoError(t, RequestContext(attemptCtx).Err())
+}
@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// reLoginInitialBackoff is the delay of the relogin after the first connectivity failure
+	reLoginInitialBackoff = 5 * time.Second
+	// reLoginMaxBackoff is the maximum delay of the relogin after the consecutive connectivity failures
+	reLoginMaxBackoff = 2 * time.Minute
+)
+
+var (
+	// ErrLoginUnreachable indicates the login failed because none of the urls could be connected
+	ErrLoginUnreachable = errors.New("all login urls are unreachable")
+	// ErrLoginRejected indicates the storage rejected the credentials, e.g. wrong password or locked account
+	ErrLoginRejected = errors.New("login rejected")
+	// ErrReLoginBackoff indicates the relogin is skipped because the storage was unreachable at the last relogin
+	ErrReLoginBackoff = errors.New("relogin is backing off")
+)
+
+// LoginFailure classifies the failure of login, so that the caller can retry with backoff when the storage is
+// unreachable but mark the backend offline and alert when the credentials are rejected
+type LoginFailure int
+
+const (
+	// LoginFailureNone means the login succeeded
+	LoginFailureNone LoginFailure = iota
+	// LoginFailureConnectivity means none of the urls could be connected within the login timeout
+	LoginFailureConnectivity
+	// LoginFailureAuth means the storage rejected the credentials
+	LoginFailureAuth
+	// LoginFailureOther means the login failed for other reasons, e.g. invalid config or unexpected response
+	LoginFailureOther
+)
+
+// String returns the name of the login failure
+func (f LoginFailure) String() string {
+	switch f {
+	case LoginFailureNone:
+		return "none"
+	case LoginFailureConnectivity:
+		return "connectivity"
+	case LoginFailureAuth:
+		return "auth"
+	default:
+		return "other"
+	}
+}
+
+// ClassifyLoginFailure returns the kind of the failure of the error returned by Login or ReLogin
+func ClassifyLoginFailure(err error) LoginFailure {
+	switch {
+	case err == nil:
+		return LoginFailureNone
+	case errors.Is(err, ErrLoginUnreachable), errors.Is(err, ErrLoginTimeout), errors.Is(err, ErrReLoginBackoff):
+		return LoginFailureConnectivity
+	case errors.Is(err, ErrLoginRejected):
+		return LoginFailureAuth
+	default:
+		return LoginFailureOther
+	}
+}
+
+// ReLoginBackoff delays the relogin after the connectivity failures exponentially, so that the requests to an
+// unreachable storage fail fast instead of trying all the urls again on every request
+type ReLoginBackoff struct {
+	mutex    sync.Mutex
+	failures int
+	next     time.Time
+}
+
+// Allow returns an error wrapping ErrReLoginBackoff if the relogin should still be delayed, otherwise nil
+func (b *ReLoginBackoff) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.failures == 0 || !time.Now().Before(b.next) {
+		return nil
+	}
+
+	return fmt.Errorf("%w until %s after %d connectivity failures",
+		ErrReLoginBackoff, b.next.Format(time.RFC3339), b.failures)
+}
+
+// Record records the result of the relogin and returns its failure, only the connectivity failures delay
+// the next relogin, the other results reset the delay
+func (b *ReLoginBackoff) Record(err error) LoginFailure {
+	failure := ClassifyLoginFailure(err)
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	switch {
+	case errors.Is(err, ErrReLoginBackoff):
+		// the relogin was not tried, the delay is kept
+	case failure == LoginFailureConnectivity:
+		delay := reLoginInitialBackoff
+		for i := 0; i < b.failures && delay < reLoginMaxBackoff; i++ {
+			delay *= 2
+		}
+		b.failures++
+		b.next = time.Now().Add(min(delay, reLoginMaxBackoff))
+	default:
+		b.failures = 0
+	}
+
+	return failure
+}
@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyLoginFailure(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name string
+		err  error
+		want LoginFailure
+	}{
+		{name: "success", err: nil, want: LoginFailureNone},
+		{name: "unreachable", err: fmt.Errorf("%w: unconnected", ErrLoginUnreachable),
+			want: LoginFailureConnectivity},
+		{name: "login timeout", err: fmt.Errorf("%w after trying 2 urls", ErrLoginTimeout),
+			want: LoginFailureConnectivity},
+		{name: "backoff", err: fmt.Errorf("%w until now", ErrReLoginBackoff), want: LoginFailureConnectivity},
+		{name: "rejected", err: fmt.Errorf("%w: wrong password", ErrLoginRejected), want: LoginFailureAuth},
+		{name: "other", err: errors.New("invalid url"), want: LoginFailureOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// action
+			got := ClassifyLoginFailure(tt.err)
+
+			// assert
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReLoginBackoff(t *testing.T) {
+	// arrange
+	backoff := &ReLoginBackoff{}
+
+	// act & assert
+	require.NoError(t, backoff.Allow())
+
+	require.Equal(t, LoginFailureConnectivity, backoff.Record(ErrLoginUnreachable))
+	require.ErrorIs(t, backoff.Allow(), ErrReLoginBackoff)
+	require.WithinDuration(t, time.Now().Add(reLoginInitialBackoff), backoff.next, time.Second)
+
+	require.Equal(t, LoginFailureConnectivity, backoff.Record(ErrLoginTimeout))
+	require.WithinDuration(t, time.Now().Add(2*reLoginInitialBackoff), backoff.next, time.Second)
+
+	next := backoff.next
+	require.Equal(t, LoginFailureConnectivity, backoff.Record(backoff.Allow()))
+	require.Equal(t, next, backoff.next)
+
+	require.Equal(t, LoginFailureAuth, backoff.Record(ErrLoginRejected))
+	require.NoError(t, backoff.Allow())
+}
+
+func TestReLoginBackoff_MaxBackoff(t *testing.T) {
+	// arrange
+	backoff := &ReLoginBackoff{failures: 100}
+
+	// act
+	backoff.Record(ErrLoginUnreachable)
+
+	// assert
+	require.WithinDuration(t, time.Now().Add(reLoginMaxBackoff), backoff.next, time.Second)
+}
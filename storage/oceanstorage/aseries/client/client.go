@@ -22,7 +22,6 @@ import (
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
-	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
@@ -39,6 +38,7 @@ type OceanASeriesClientInterface interface {
 	GetBackendID() string
 	GetDeviceSN() string
 	GetDeviceWWN() string
+	GetSystemInfo() ASeriesSystem
 	SetSystemInfo(ctx context.Context) error
 }
 
@@ -51,7 +51,8 @@ type OceanASeriesClient struct {
 	*base.FilesystemClient
 	*base.RestClient
 
-	deviceWWN string
+	deviceWWN  string
+	systemInfo ASeriesSystem
 }
 
 // NewClient inits a new client of oceanstor A-series client
@@ -78,16 +79,20 @@ func (cli *OceanASeriesClient) SetSystemInfo(ctx context.Context) error {
 		return err
 	}
 
-	wwn, ok := utils.GetValue[string](system, "wwn")
-	if ok {
-		cli.deviceWWN = wwn
-	}
+	cli.systemInfo = *parseASeriesSystem(system)
+	cli.deviceWWN = cli.systemInfo.Wwn
 
-	log.AddContext(ctx).Infof("backend type [%s], backend [%s], deviceWWN [%s]",
-		cli.Storage, cli.BackendID, cli.deviceWWN)
+	log.AddContext(ctx).Infof("backend type [%s], backend [%s], deviceWWN [%s], model [%s], version [%s %s]",
+		cli.Storage, cli.BackendID, cli.deviceWWN, cli.systemInfo.Model, cli.systemInfo.ProductVersion,
+		cli.systemInfo.PointRelease)
 	return nil
 }
 
+// GetSystemInfo used for get the A-series specific system info set by SetSystemInfo
+func (cli *OceanASeriesClient) GetSystemInfo() ASeriesSystem {
+	return cli.systemInfo
+}
+
 // GetDeviceWWN used for get device WWN
 func (cli *OceanASeriesClient) GetDeviceWWN() string {
 	return cli.deviceWWN
@@ -0,0 +1,69 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package client provides oceanstor A-series storage client
+package client
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
+)
+
+const fileSystemModeSeparator = ","
+
+// ASeriesSystem holds the system information specific to the A-series storage
+type ASeriesSystem struct {
+	// Model is the product model of the A-series storage, e.g. A800
+	Model string
+	// ProductVersion is the product version, e.g. V100R001C00
+	ProductVersion string
+	// PointRelease is the point release of the product version, e.g. 1.0.0
+	PointRelease string
+	// PatchVersion is the installed patch of the product version, empty if no patch is installed
+	PatchVersion string
+	// Wwn is the wwn of the storage device
+	Wwn string
+	// FileSystemModes are the filesystem modes supported by the storage, e.g. local and hypermetro
+	FileSystemModes []string
+}
+
+// SupportFileSystemMode checks whether the filesystem mode is supported by the A-series storage
+func (s *ASeriesSystem) SupportFileSystemMode(mode string) bool {
+	return slices.Contains(s.FileSystemModes, mode)
+}
+
+// parseASeriesSystem extracts the A-series specific fields from the response of /system,
+// the field that is absent in the response is left empty
+func parseASeriesSystem(system map[string]interface{}) *ASeriesSystem {
+	info := &ASeriesSystem{
+		Model:          utils.GetValueOrFallback(system, "productModeString", ""),
+		ProductVersion: utils.GetValueOrFallback(system, "PRODUCTVERSION", ""),
+		PointRelease:   utils.GetValueOrFallback(system, "pointRelease", ""),
+		PatchVersion:   utils.GetValueOrFallback(system, "patchVersion", ""),
+		Wwn:            utils.GetValueOrFallback(system, "wwn", ""),
+	}
+
+	modes := utils.GetValueOrFallback(system, "supportFileSystemMode", "")
+	for _, mode := range strings.Split(modes, fileSystemModeSeparator) {
+		if mode = strings.TrimSpace(mode); mode != "" {
+			info.FileSystemModes = append(info.FileSystemModes, mode)
+		}
+	}
+
+	return info
+}
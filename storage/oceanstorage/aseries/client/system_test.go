@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package client provides oceanstor A-series storage client
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
+)
+
+func TestOceanASeriesClient_SetSystemInfo_ASeriesFields(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	successBody := `{
+        "data": {
+            "ID": "2102355TJS10P3100001",
+            "NAME": "A800",
+            "PRODUCTVERSION": "V100R001C00",
+            "productModeString": "A800",
+            "pointRelease": "1.0.0",
+            "patchVersion": "SPH001",
+            "wwn": "2100a8d7b4e1c2f3",
+            "supportFileSystemMode": "0, 1"
+        },
+        "error": {
+            "code": 0,
+            "description": "success"
+        }
+    }`
+
+	// mock
+	mockClient := getMockClientWithResponse(200, successBody)
+
+	// action
+	err := mockClient.SetSystemInfo(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "2100a8d7b4e1c2f3", mockClient.GetDeviceWWN())
+	require.Equal(t, ASeriesSystem{
+		Model:           "A800",
+		ProductVersion:  "V100R001C00",
+		PointRelease:    "1.0.0",
+		PatchVersion:    "SPH001",
+		Wwn:             "2100a8d7b4e1c2f3",
+		FileSystemModes: []string{storage.LocalFilesystemMode, storage.HyperMetroFilesystemMode},
+	}, mockClient.GetSystemInfo())
+	systemInfo := mockClient.GetSystemInfo()
+	require.True(t, systemInfo.SupportFileSystemMode(storage.HyperMetroFilesystemMode))
+}
+
+func TestParseASeriesSystem_AbsentFields(t *testing.T) {
+	// arrange
+	system := map[string]interface{}{"ID": "2102355TJS10P3100001", "wwn": "2100a8d7b4e1c2f3"}
+
+	// action
+	info := parseASeriesSystem(system)
+
+	// assert
+	require.Equal(t, &ASeriesSystem{Wwn: "2100a8d7b4e1c2f3"}, info)
+	require.False(t, info.SupportFileSystemMode(storage.HyperMetroFilesystemMode))
+}
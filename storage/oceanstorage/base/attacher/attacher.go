@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/connector/nvme"
+	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/iputils"
@@ -52,6 +53,9 @@ type AttachmentManager struct {
 	Invoker  string
 	Portals  []string
 	Alua     map[string]interface{}
+	// ChapSecret is the secret of the CHAP credentials of the iscsi initiators in the format of namespace/name,
+	// the CHAP is not enabled if it is empty
+	ChapSecret string
 }
 
 // AttachmentManagerConfig defines the configurations of AttachmentManager
@@ -61,16 +65,19 @@ type AttachmentManagerConfig struct {
 	Invoker  string
 	Portals  []string
 	Alua     map[string]interface{}
+	// ChapSecret is the secret of the CHAP credentials of the iscsi initiators in the format of namespace/name
+	ChapSecret string
 }
 
 // NewAttachmentManager init a new AttachmentManager
 func NewAttachmentManager(config AttachmentManagerConfig) *AttachmentManager {
 	return &AttachmentManager{
-		Cli:      config.Cli,
-		Protocol: config.Protocol,
-		Invoker:  config.Invoker,
-		Portals:  config.Portals,
-		Alua:     config.Alua,
+		Cli:        config.Cli,
+		Protocol:   config.Protocol,
+		Invoker:    config.Invoker,
+		Portals:    config.Portals,
+		Alua:       config.Alua,
+		ChapSecret: config.ChapSecret,
 	}
 }
 
@@ -251,12 +258,32 @@ func (p *AttachmentManager) getISCSIProperties(ctx context.Context, wwn, hostLun
 		tgtHostLUNs = append(tgtHostLUNs, hostLunId)
 	}
 
-	return map[string]interface{}{
+	properties := map[string]interface{}{
 		"tgtPortals":  tgtPortals,
 		"tgtIQNs":     tgtIQNs,
 		"tgtHostLUNs": tgtHostLUNs,
 		"tgtLunWWN":   wwn,
-	}, nil
+	}
+
+	if p.ChapSecret != "" {
+		// only the reference of the CHAP secret is published, the publish context is stored in plaintext
+		// in the VolumeAttachment, so the node reads the credentials from the secret by itself
+		properties["chapSecret"] = p.ChapSecret
+	}
+
+	return properties, nil
+}
+
+// setISCSIChap enables the CHAP authentication of the iscsi initiator with the credentials of the CHAP secret,
+// the credentials are always pushed because the storage does not return the password to compare with,
+// so that a password rotated in the secret takes effect on the next publish
+func (p *AttachmentManager) setISCSIChap(ctx context.Context, name string) error {
+	user, password, err := pkgUtils.GetChapCredentials(ctx, p.ChapSecret)
+	if err != nil {
+		return err
+	}
+
+	return p.Cli.SetIscsiInitiatorChap(ctx, name, user, password)
 }
 
 func (p *AttachmentManager) getFCProperties(ctx context.Context, wwn, hostLunId string, parameters map[string]any) (
@@ -531,6 +558,13 @@ func (p *AttachmentManager) AttachISCSI(ctx context.Context,
 		return nil, errors.New(msg)
 	}
 
+	if p.ChapSecret != "" {
+		if err := p.setISCSIChap(ctx, name); err != nil {
+			log.AddContext(ctx).Errorf("Set CHAP of ISCSI initiator %s error: %v", name, err)
+			return nil, err
+		}
+	}
+
 	return initiator, nil
 }
 
@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package attacher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	coreV1 "k8s.io/api/core/v1"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/test/mocks/mock_client"
+)
+
+func TestAttachmentManager_AttachISCSI_Chap(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	manager := NewAttachmentManager(AttachmentManagerConfig{Cli: cli, Protocol: "iscsi",
+		ChapSecret: "huawei-csi/chap-secret"})
+	secret := &coreV1.Secret{Data: map[string][]byte{constants.ChapUserKey: []byte("chap-user"),
+		constants.ChapPasswordKey: []byte("chap-password")}}
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(GetSingleInitiator, "iqn.test", nil).
+		ApplyFuncReturn(pkgUtils.GetBackendSecret, secret, nil)
+	defer patches.Reset()
+	cli.EXPECT().GetIscsiInitiator(ctx, "iqn.test").Return(map[string]any{"ISFREE": "false", "PARENTID": "1"}, nil)
+	cli.EXPECT().SetIscsiInitiatorChap(ctx, "iqn.test", "chap-user", "chap-password").Return(nil)
+
+	// action
+	_, err := manager.AttachISCSI(ctx, "1", map[string]any{})
+
+	// assert
+	require.NoError(t, err)
+}
+
+func TestAttachmentManager_AttachISCSI_ChapSecretInvalid(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	manager := NewAttachmentManager(AttachmentManagerConfig{Cli: cli, Protocol: "iscsi",
+		ChapSecret: "huawei-csi/chap-secret"})
+	secret := &coreV1.Secret{Data: map[string][]byte{constants.ChapUserKey: []byte("chap-user")}}
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(GetSingleInitiator, "iqn.test", nil).
+		ApplyFuncReturn(pkgUtils.GetBackendSecret, secret, nil)
+	defer patches.Reset()
+	cli.EXPECT().GetIscsiInitiator(ctx, "iqn.test").Return(map[string]any{"ISFREE": "false", "PARENTID": "1"}, nil)
+
+	// action
+	_, err := manager.AttachISCSI(ctx, "1", map[string]any{})
+
+	// assert
+	require.ErrorContains(t, err, "must not be empty")
+}
+
+func TestAttachmentManager_AttachISCSI_ChapPasswordChanged(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	manager := NewAttachmentManager(AttachmentManagerConfig{Cli: cli, Protocol: "iscsi",
+		ChapSecret: "huawei-csi/chap-secret"})
+	secret := &coreV1.Secret{Data: map[string][]byte{constants.ChapUserKey: []byte("chap-user"),
+		constants.ChapPasswordKey: []byte("new-chap-password")}}
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(GetSingleInitiator, "iqn.test", nil).
+		ApplyFuncReturn(pkgUtils.GetBackendSecret, secret, nil)
+	defer patches.Reset()
+	cli.EXPECT().GetIscsiInitiator(ctx, "iqn.test").Return(map[string]any{"ISFREE": "false", "PARENTID": "1",
+		"USECHAP": "true", "CHAPNAME": "chap-user"}, nil)
+	cli.EXPECT().SetIscsiInitiatorChap(ctx, "iqn.test", "chap-user", "new-chap-password").Return(nil)
+
+	// action
+	_, err := manager.AttachISCSI(ctx, "1", map[string]any{})
+
+	// assert
+	require.NoError(t, err)
+}
+
+func TestAttachmentManager_GetMappingProperties_Chap(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	manager := NewAttachmentManager(AttachmentManagerConfig{Cli: cli, Protocol: "iscsi",
+		Portals: []string{"192.168.1.1"}, ChapSecret: "huawei-csi/chap-secret"})
+	port := map[string]any{"ID": "0+iqn.2006-08.com.huawei:oceanstor:21000022a1039d3b::1020001:192.168.1.1,t,0x0101"}
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(pkgUtils.GetBackendSecret, nil, errors.New("should not read secret"))
+	defer patches.Reset()
+	cli.EXPECT().GetIscsiTgtPort(ctx).Return([]any{port}, nil)
+
+	// action
+	properties, err := manager.GetMappingProperties(ctx, "test-wwn", "1", map[string]any{})
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "huawei-csi/chap-secret", properties["chapSecret"])
+	require.NotContains(t, properties, "authUserName")
+	require.NotContains(t, properties, "authPassword")
+}
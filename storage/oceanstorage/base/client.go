@@ -18,12 +18,18 @@
 package base
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
 	"slices"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
@@ -35,12 +41,65 @@ var (
 	WrongPasswordErrorCodes = []int64{1077987870, 1077949081, 1077949061}
 	// AccountBeenLocked account been locked
 	AccountBeenLocked = []int64{1077949070, 1077987871}
+	// StorageBusyErrorCodes storage is temporarily unavailable, such as the controller firmware is being upgraded
+	StorageBusyErrorCodes = []int64{storage.SystemBusy}
+
+	// ErrStorageBusy is returned when the storage is temporarily unavailable, the request can be retried later
+	ErrStorageBusy = errors.New("storage is busy")
+
+	// ErrSchemaDrift is returned in strict decode mode when the response contains unknown fields
+	ErrSchemaDrift = errors.New("response schema drift")
+
+	strictDecode atomic.Bool
 )
 
+func init() {
+	utils.RegisterErrorCodes("WrongPassword", "the user name or password is incorrect", WrongPasswordErrorCodes...)
+	utils.RegisterErrorCodes("AccountLocked", "the account has been locked", AccountBeenLocked...)
+	utils.RegisterErrorCodes("IPLocked", "the login ip has been locked", storage.IPLockErrorCode)
+	utils.RegisterErrorCodes("UserOffline", "the login session is offline", storage.UserOffline)
+	utils.RegisterErrorCodes("ObjectNameAlreadyExist", "the object name already exists", objectNameAlreadyExist)
+}
+
+// SetStrictDecode sets whether typed response decoding rejects the fields unknown to the target type,
+// which is off by default and is used to detect the schema drift of the storage response
+func SetStrictDecode(strict bool) {
+	strictDecode.Store(strict)
+}
+
+// OperationIDHeader is the response header that carries the operation id the storage assigns to a mutating request,
+// the same id is recorded in the audit log of the storage
+const OperationIDHeader = "X-Operation-ID"
+
 // Response defines response of request
 type Response struct {
 	Error map[string]interface{} `json:"error"`
 	Data  interface{}            `json:"data,omitempty"`
+
+	// OperationID is the operation id of the mutating request, empty if the storage does not return it
+	OperationID string `json:"-"`
+
+	// StatusCode is the http status code of the response, zero if no response is received
+	StatusCode int `json:"-"`
+}
+
+// RecordOperationID records the operation id of the mutating request from the response header,
+// and logs it if the request succeeds so that the request can be cross-referenced with the storage audit log
+func (resp *Response) RecordOperationID(ctx context.Context, method, url string, header http.Header) {
+	if method == http.MethodGet {
+		return
+	}
+
+	resp.OperationID = header.Get(OperationIDHeader)
+	if resp.OperationID == "" {
+		return
+	}
+
+	code, err := resp.getInt64Code()
+	if err == nil && code == storage.SuccessCode {
+		log.AddContext(ctx).Infof("Request method: %s, Url: %s succeeded, operation id: %s",
+			method, url, resp.OperationID)
+	}
 }
 
 // AssertErrorCode asserts if error code represents success
@@ -51,12 +110,30 @@ func (resp *Response) AssertErrorCode() error {
 	}
 
 	if code != storage.SuccessCode {
-		return fmt.Errorf("error code %d: [%v]", code, resp.Error["description"])
+		if err := StorageBusyError(code, resp.Error["description"]); err != nil {
+			return err
+		}
+
+		storageErr, err := utils.ParseRespErr(resp.Error)
+		if err != nil {
+			return fmt.Errorf("error code %s: [%v]", utils.FormatErrorCode(code), resp.Error["description"])
+		}
+		return storageErr
 	}
 
 	return nil
 }
 
+// StorageBusyError returns an error wrapping ErrStorageBusy if the code indicates the storage is busy,
+// otherwise nil is returned
+func StorageBusyError(code int64, description any) error {
+	if !slices.Contains(StorageBusyErrorCodes, code) {
+		return nil
+	}
+
+	return fmt.Errorf("%w, error code %d: [%v]", ErrStorageBusy, code, description)
+}
+
 // ResponseToleration defines the error code that can be tolerant and its reason
 type ResponseToleration struct {
 	Code   int64
@@ -79,6 +156,9 @@ func (resp *Response) AssertErrorWithTolerations(ctx context.Context, toleration
 			return nil
 		}
 
+		if err := StorageBusyError(code, resp.Error["description"]); err != nil {
+			return err
+		}
 		return fmt.Errorf("error code %d: [%v]", code, resp.Error["description"])
 	}
 
@@ -92,6 +172,10 @@ func (resp *Response) GetData(val any) error {
 		return fmt.Errorf("failed to marshal data, error %w", err)
 	}
 
+	if strictDecode.Load() {
+		return decodeStrict(data, val)
+	}
+
 	err = json.Unmarshal(data, &val)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal data as %T, error: %w", val, err)
@@ -100,6 +184,28 @@ func (resp *Response) GetData(val any) error {
 	return nil
 }
 
+func decodeStrict(data []byte, val any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(val)
+	if err == nil {
+		return nil
+	}
+
+	if strings.HasPrefix(err.Error(), "json: unknown field") {
+		log.Warningf("schema drift detected when unmarshal data as %T, error: %v", val, err)
+		return fmt.Errorf("%w: failed to unmarshal data as %T, error: %w", ErrSchemaDrift, val, err)
+	}
+
+	return fmt.Errorf("failed to unmarshal data as %T, error: %w", val, err)
+}
+
+// EndRequestSpan ends the span of the request with the error code of the response
+func EndRequestSpan(span trace.Span, resp Response, err error) {
+	code, codeErr := resp.getInt64Code()
+	storage.EndRequestSpan(span, code, codeErr == nil, err)
+}
+
 func (resp *Response) getInt64Code() (int64, error) {
 	val, exists := resp.Error["code"]
 	if !exists {
@@ -135,16 +241,21 @@ func MaskRequestData(data map[string]any) map[string]any {
 	return maskedData
 }
 
-// NeedReLogin determine if it is necessary to log in to the storage again
+// NeedReLogin determine if it is necessary to log in to the storage again, the http status code is checked as well
+// because the storage may return a non-json error page when the session expires
 func NeedReLogin(r Response, err error) bool {
 	var unconnected, unauthorized, offline bool
 	if err != nil && err.Error() == storage.Unconnected {
 		unconnected = true
 	}
 
+	if r.StatusCode == http.StatusUnauthorized {
+		unauthorized = true
+	}
+
 	if r.Error != nil {
 		if code, ok := r.Error["code"].(float64); ok {
-			unauthorized = int64(code) == storage.UserUnauthorized
+			unauthorized = unauthorized || int64(code) == storage.UserUnauthorized
 			offline = int64(code) == storage.UserOffline
 		}
 	}
@@ -32,6 +32,15 @@ import (
 
 const (
 	queryNfsSharePerPage int64 = 100
+
+	// NfsAllSquash indicates all_squash of the nfs share access
+	NfsAllSquash = 0
+	// NfsNoAllSquash indicates no_all_squash of the nfs share access
+	NfsNoAllSquash = 1
+	// NfsRootSquash indicates root_squash of the nfs share access
+	NfsRootSquash = 0
+	// NfsNoRootSquash indicates no_root_squash of the nfs share access
+	NfsNoRootSquash = 1
 )
 
 // Filesystem defines interfaces for file system operations
@@ -62,6 +71,10 @@ type Filesystem interface {
 	DeleteNfsShare(ctx context.Context, id, vStoreID string) error
 	// GetNFSServiceSetting used for get nfs service setting
 	GetNFSServiceSetting(ctx context.Context) (map[string]bool, error)
+	// GetShareSquashConfig used for get the squash config of nfs share access
+	GetShareSquashConfig(ctx context.Context, accessID, vStoreID string) (*NfsShareSquashConfig, error)
+	// SetShareSquashConfig used for reconcile the squash config of nfs share access
+	SetShareSquashConfig(ctx context.Context, accessID, vStoreID string, config *NfsShareSquashConfig) error
 }
 
 // FilesystemClient defines client implements the Filesystem interface
@@ -483,3 +496,122 @@ func (cli *FilesystemClient) GetNFSServiceSetting(ctx context.Context) (map[stri
 
 	return setting, nil
 }
+
+// NfsShareSquashConfig defines the squash config of nfs share access
+type NfsShareSquashConfig struct {
+	AllSquash  int
+	RootSquash int
+}
+
+// Validate checks whether the squash config is legal, the no_root_squash does not take effect with all_squash,
+// so the combination is rejected to avoid misunderstanding.
+func (config *NfsShareSquashConfig) Validate() error {
+	if config == nil {
+		return errors.New("nfs share squash config is nil")
+	}
+
+	if config.AllSquash != NfsAllSquash && config.AllSquash != NfsNoAllSquash {
+		return fmt.Errorf("allSquash %d must be %d or %d", config.AllSquash, NfsAllSquash, NfsNoAllSquash)
+	}
+
+	if config.RootSquash != NfsRootSquash && config.RootSquash != NfsNoRootSquash {
+		return fmt.Errorf("rootSquash %d must be %d or %d", config.RootSquash, NfsRootSquash, NfsNoRootSquash)
+	}
+
+	if config.AllSquash == NfsAllSquash && config.RootSquash == NfsNoRootSquash {
+		return errors.New("all_squash can not be configured with no_root_squash")
+	}
+
+	return nil
+}
+
+// GetShareSquashConfig used for get the squash config of nfs share access
+func (cli *FilesystemClient) GetShareSquashConfig(ctx context.Context,
+	accessID, vStoreID string) (*NfsShareSquashConfig, error) {
+	url := fmt.Sprintf("/NFS_SHARE_AUTH_CLIENT/%s", accessID)
+	var data = make(map[string]interface{})
+	if vStoreID != "" {
+		data["vstoreId"] = vStoreID
+	}
+
+	resp, err := cli.Get(ctx, url, data)
+	if err != nil {
+		return nil, err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return nil, fmt.Errorf("get nfs share access %s error: %d", accessID, code)
+	}
+
+	access, ok := resp.Data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("convert nfs share access %v to map failed", resp.Data)
+	}
+
+	allSquash, ok := access["ALLSQUASH"].(string)
+	if !ok {
+		return nil, fmt.Errorf("convert ALLSQUASH %v of nfs share access %s to string failed",
+			access["ALLSQUASH"], accessID)
+	}
+
+	rootSquash, ok := access["ROOTSQUASH"].(string)
+	if !ok {
+		return nil, fmt.Errorf("convert ROOTSQUASH %v of nfs share access %s to string failed",
+			access["ROOTSQUASH"], accessID)
+	}
+
+	config := &NfsShareSquashConfig{}
+	if config.AllSquash, err = strconv.Atoi(allSquash); err != nil {
+		return nil, fmt.Errorf("parse ALLSQUASH %s of nfs share access %s failed: %w", allSquash, accessID, err)
+	}
+
+	if config.RootSquash, err = strconv.Atoi(rootSquash); err != nil {
+		return nil, fmt.Errorf("parse ROOTSQUASH %s of nfs share access %s failed: %w", rootSquash, accessID, err)
+	}
+
+	return config, nil
+}
+
+// SetShareSquashConfig used for reconcile the squash config of nfs share access,
+// nothing will be updated if the current config is already as expected
+func (cli *FilesystemClient) SetShareSquashConfig(ctx context.Context,
+	accessID, vStoreID string, config *NfsShareSquashConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	current, err := cli.GetShareSquashConfig(ctx, accessID, vStoreID)
+	if err != nil {
+		return err
+	}
+
+	if *current == *config {
+		log.AddContext(ctx).Infof("squash config of nfs share access %s is already %+v, skip update",
+			accessID, *config)
+		return nil
+	}
+
+	url := fmt.Sprintf("/NFS_SHARE_AUTH_CLIENT/%s", accessID)
+	data := map[string]interface{}{
+		"ALLSQUASH":  config.AllSquash,
+		"ROOTSQUASH": config.RootSquash,
+	}
+	if vStoreID != "" {
+		data["vstoreId"] = vStoreID
+	}
+
+	resp, err := cli.Put(ctx, url, data)
+	if err != nil {
+		return err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return fmt.Errorf("update squash config of nfs share access %s error: %d", accessID, code)
+	}
+
+	log.AddContext(ctx).Infof("squash config of nfs share access %s is updated from %+v to %+v",
+		accessID, *current, *config)
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/require"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
@@ -124,3 +125,85 @@ func TestAllowNfsShareAccess_APIFailure(t *testing.T) {
 	require.ErrorContains(t, err, "allow nfs share")
 	require.Contains(t, err.Error(), "1077939726")
 }
+
+func TestGetShareSquashConfig_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	resp := `{"data": {"ID": "1", "ALLSQUASH": "1", "ROOTSQUASH": "0"}, "error": {"code": 0}}`
+
+	// Mock
+	mockClient := getMockClient(200, resp)
+
+	// Action
+	config, err := mockClient.GetShareSquashConfig(ctx, "1", "0")
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, &NfsShareSquashConfig{AllSquash: NfsNoAllSquash, RootSquash: NfsRootSquash}, config)
+}
+
+func TestSetShareSquashConfig_Changed(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockClient := getMockClient(200, "")
+	cli, _ := mockClient.RestClientInterface.(*RestClient)
+	var putData map[string]interface{}
+
+	// Mock
+	patches := gomonkey.ApplyMethodReturn(cli, "Get", Response{Error: map[string]interface{}{"code": float64(0)},
+		Data: map[string]interface{}{"ALLSQUASH": "1", "ROOTSQUASH": "0"}}, nil).
+		ApplyMethod(cli, "Put", func(_ *RestClient, _ context.Context, _ string,
+			data map[string]interface{}) (Response, error) {
+			putData = data
+			return Response{Error: map[string]interface{}{"code": float64(0)}}, nil
+		})
+	defer patches.Reset()
+
+	// Action
+	err := mockClient.SetShareSquashConfig(ctx, "1", "0",
+		&NfsShareSquashConfig{AllSquash: NfsNoAllSquash, RootSquash: NfsNoRootSquash})
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"ALLSQUASH": NfsNoAllSquash, "ROOTSQUASH": NfsNoRootSquash,
+		"vstoreId": "0"}, putData)
+}
+
+func TestSetShareSquashConfig_NoChange(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockClient := getMockClient(200, "")
+	cli, _ := mockClient.RestClientInterface.(*RestClient)
+	var putCalled bool
+
+	// Mock
+	patches := gomonkey.ApplyMethodReturn(cli, "Get", Response{Error: map[string]interface{}{"code": float64(0)},
+		Data: map[string]interface{}{"ALLSQUASH": "1", "ROOTSQUASH": "0"}}, nil).
+		ApplyMethod(cli, "Put", func(_ *RestClient, _ context.Context, _ string,
+			_ map[string]interface{}) (Response, error) {
+			putCalled = true
+			return Response{Error: map[string]interface{}{"code": float64(0)}}, nil
+		})
+	defer patches.Reset()
+
+	// Action
+	err := mockClient.SetShareSquashConfig(ctx, "1", "0",
+		&NfsShareSquashConfig{AllSquash: NfsNoAllSquash, RootSquash: NfsRootSquash})
+
+	// Assert
+	require.NoError(t, err)
+	require.False(t, putCalled)
+}
+
+func TestSetShareSquashConfig_InvalidCombination(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockClient := getMockClient(200, "")
+
+	// Action
+	err := mockClient.SetShareSquashConfig(ctx, "1", "0",
+		&NfsShareSquashConfig{AllSquash: NfsAllSquash, RootSquash: NfsNoRootSquash})
+
+	// Assert
+	require.ErrorContains(t, err, "no_root_squash")
+}
@@ -45,6 +45,8 @@ type Iscsi interface {
 	GetIscsiTgtPort(ctx context.Context) ([]interface{}, error)
 	// GetISCSIHostLink used for get iscsi host link
 	GetISCSIHostLink(ctx context.Context, hostID string) ([]interface{}, error)
+	// SetIscsiInitiatorChap used for enable the CHAP authentication of iscsi initiator
+	SetIscsiInitiatorChap(ctx context.Context, initiator, chapName, chapPassword string) error
 }
 
 // IscsiClient defines client implements the Iscsi interface
@@ -213,6 +215,38 @@ func (cli *IscsiClient) AddIscsiInitiatorToHost(ctx context.Context, initiator,
 	return nil
 }
 
+// SetIscsiInitiatorChap used for enable the CHAP authentication of iscsi initiator with the credentials,
+// and validate that the CHAP is enabled on the storage
+func (cli *IscsiClient) SetIscsiInitiatorChap(ctx context.Context, initiator, chapName, chapPassword string) error {
+	url := fmt.Sprintf("/iscsi_initiator/%s", initiator)
+	data := map[string]interface{}{
+		"USECHAP":      "true",
+		"CHAPNAME":     chapName,
+		"CHAPPASSWORD": chapPassword,
+	}
+	resp, err := cli.Put(ctx, url, data)
+	if err != nil {
+		return err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return fmt.Errorf("the storage rejected the CHAP credentials of iscsi initiator %s with error %d, "+
+			"please check the CHAP username and secret", initiator, code)
+	}
+
+	updated, err := cli.GetIscsiInitiator(ctx, initiator)
+	if err != nil {
+		return err
+	}
+	if updated == nil || updated["USECHAP"] != "true" {
+		return fmt.Errorf("CHAP is not enabled on iscsi initiator %s of the storage", initiator)
+	}
+
+	log.AddContext(ctx).Infof("CHAP is enabled on iscsi initiator %s", initiator)
+	return nil
+}
+
 // GetIscsiTgtPort used for get iscsi target port
 func (cli *IscsiClient) GetIscsiTgtPort(ctx context.Context) ([]interface{}, error) {
 	resp, err := cli.Get(ctx, "/iscsi_tgt_port", nil)
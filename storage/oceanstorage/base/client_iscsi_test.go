@@ -0,0 +1,70 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package base provide base operations for oceanstor base storage
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIscsiClient_SetIscsiInitiatorChap(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name            string
+		putCode         float64
+		useChap         string
+		wantErrContains string
+	}{
+		{name: "success", useChap: "true"},
+		{name: "credentials rejected", putCode: 1077949002, wantErrContains: "rejected the CHAP credentials"},
+		{name: "chap not enabled", useChap: "false", wantErrContains: "CHAP is not enabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restClient := &RestClient{}
+			cli := &IscsiClient{RestClientInterface: restClient}
+			var putData map[string]any
+
+			// mock
+			patches := gomonkey.ApplyMethod(restClient, "Put",
+				func(_ *RestClient, _ context.Context, _ string, data map[string]any) (Response, error) {
+					putData = data
+					return Response{Error: map[string]any{"code": tt.putCode}}, nil
+				}).
+				ApplyMethodReturn(restClient, "Get", Response{Error: map[string]any{"code": float64(0)},
+					Data: []any{map[string]any{"ID": "iqn.test", "USECHAP": tt.useChap}}}, nil)
+			defer patches.Reset()
+
+			// action
+			err := cli.SetIscsiInitiatorChap(context.Background(), "iqn.test", "chap-user", "chap-password")
+
+			// assert
+			require.Equal(t, map[string]any{"USECHAP": "true", "CHAPNAME": "chap-user",
+				"CHAPPASSWORD": "chap-password"}, putData)
+			if tt.wantErrContains != "" {
+				require.ErrorContains(t, err, tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
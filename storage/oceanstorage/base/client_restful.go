@@ -25,9 +25,11 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"maps"
 	"net/http"
 	"strconv"
 	"sync"
+	"time"
 
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
@@ -78,6 +80,12 @@ func isFilterLog(method, url string) bool {
 	return false
 }
 
+// isDebugLog returns whether the request and the response of the url are logged at debug level,
+// all of them are logged at info level if the verbose log of the client is enabled
+func (cli *RestClient) isDebugLog(method, url string) bool {
+	return !cli.VerboseLog && utils.IsDebugLog(method, url, debugLog, debugLogRegex)
+}
+
 // RestClientInterface defines interfaces for base restful call
 type RestClientInterface interface {
 	Call(ctx context.Context, method string, url string, data map[string]interface{}) (Response, error)
@@ -89,6 +97,7 @@ type RestClientInterface interface {
 	GetRequest(ctx context.Context, method string, url string, data map[string]interface{}) (*http.Request, error)
 	Login(ctx context.Context) error
 	Logout(ctx context.Context)
+	Close(ctx context.Context)
 	ReLogin(ctx context.Context) error
 	GetSystem(ctx context.Context) (map[string]interface{}, error)
 	ValidateLogin(ctx context.Context) error
@@ -110,10 +119,30 @@ type RestClient struct {
 	Storage         string
 	DeviceId        string
 	Token           string
+	// CredentialsPath is the directory of the mounted secret, the k8s Secret API is used if it is empty
+	CredentialsPath string
+	// LoginScope overrides the scope of the auth info in the secret when login if it is not empty
+	LoginScope string
+	// Headers are the static headers added to every request
+	Headers map[string]string
+	// CASecretMeta is the secret of the CA bundle that the server certificate is verified against
+	CASecretMeta string
+	// ProxyURL is the proxy the requests are routed through, no proxy is used if it is empty
+	ProxyURL string
+	// Transport is the idle connection and TCP keepalive settings of the http transport, it is kept when the client
+	// is recreated
+	Transport storage.TransportConfig
+	// LoginTimeout bounds the total time of the login attempts across the urls, it is not bounded if not positive
+	LoginTimeout time.Duration
+	// VerboseLog logs the bodies of all the requests and responses at info level, except the login ones
+	VerboseLog bool
 
 	SystemInfoRefreshing uint32
 	ReLoginMutex         sync.Mutex
 	RequestSemaphore     *utils.Semaphore
+	semaphoreRef         storage.RequestSemaphoreRef
+	// reLoginBackoff delays the relogin after the storage is unreachable
+	reLoginBackoff storage.ReLoginBackoff
 }
 
 // NewRestClient inits a new rest client
@@ -121,6 +150,16 @@ func NewRestClient(ctx context.Context, param *storage.NewClientConfig) (*RestCl
 	var err error
 	var parallelCount int
 
+	if err = storage.ValidateRestURLs(param.Urls); err != nil {
+		log.AddContext(ctx).Errorf("validate urls of backend %s failed, error: %v", param.BackendID, err)
+		return nil, err
+	}
+
+	if err = pkgUtils.CheckLoginScope(param.LoginScope); err != nil {
+		log.AddContext(ctx).Errorf("validate login scope of backend %s failed, error: %v", param.BackendID, err)
+		return nil, err
+	}
+
 	parallelCount, err = strconv.Atoi(param.ParallelNum)
 	if err != nil || parallelCount > MaxParallelCount || parallelCount < MinParallelCount {
 		log.Warningf("the config parallelNum %d is invalid, set it to the default value %d",
@@ -129,7 +168,8 @@ func NewRestClient(ctx context.Context, param *storage.NewClientConfig) (*RestCl
 	}
 
 	log.AddContext(ctx).Infof("Init parallel count is %d", parallelCount)
-	httpClient, err := storage.NewHTTPClientByCertMeta(ctx, param.UseCert, param.CertSecretMeta)
+	httpClient, err := storage.NewHTTPClientByCertMeta(ctx, param.UseCert, param.CertSecretMeta, param.CASecretMeta,
+		param.ProxyURL, param.Transport)
 	if err != nil {
 		log.AddContext(ctx).Errorf("new http client by cert meta failed, err is %v", err)
 		return nil, err
@@ -143,6 +183,14 @@ func NewRestClient(ctx context.Context, param *storage.NewClientConfig) (*RestCl
 		SecretNamespace:  param.SecretNamespace,
 		Client:           httpClient,
 		BackendID:        param.BackendID,
+		CredentialsPath:  param.CredentialsPath,
+		LoginScope:       param.LoginScope,
+		Headers:          maps.Clone(param.Headers),
+		CASecretMeta:     param.CASecretMeta,
+		ProxyURL:         param.ProxyURL,
+		Transport:        param.Transport,
+		LoginTimeout:     param.LoginTimeout,
+		VerboseLog:       param.VerboseLog,
 		RequestSemaphore: utils.NewSemaphore(parallelCount),
 	}, nil
 }
@@ -169,8 +217,16 @@ func (cli *RestClient) Call(ctx context.Context,
 	return cli.BaseCall(ctx, method, url, data)
 }
 
-// BaseCall provides base call for request
+// BaseCall provides base call for request, the request is traced in a span named by the method and the url
 func (cli *RestClient) BaseCall(ctx context.Context,
+	method string, url string, data map[string]interface{}) (Response, error) {
+	ctx, span := storage.StartRequestSpan(ctx, method, url, cli.BackendID, cli.GetDeviceSN())
+	r, err := cli.baseCall(ctx, method, url, data)
+	EndRequestSpan(span, r, err)
+	return r, err
+}
+
+func (cli *RestClient) baseCall(ctx context.Context,
 	method string, url string, data map[string]interface{}) (Response, error) {
 	var r Response
 	var req *http.Request
@@ -194,23 +250,27 @@ func (cli *RestClient) BaseCall(ctx context.Context,
 		return Response{}, err
 	}
 
-	log.FilteredLog(ctx, isFilterLog(method, url), utils.IsDebugLog(method, url, debugLog, debugLogRegex),
+	log.FilteredLog(ctx, isFilterLog(method, url), cli.isDebugLog(method, url),
 		fmt.Sprintf("Request method: %s, Url: %s, body: %v", method, req.URL, data))
 
 	if cli.RequestSemaphore == nil {
 		return Response{}, errors.New("request semaphore is nil")
 	}
 
-	cli.RequestSemaphore.Acquire()
+	if err = cli.RequestSemaphore.AcquireCtx(ctx); err != nil {
+		log.AddContext(ctx).Errorf("Acquire request semaphore of method: %s, Url: %s, error: %v",
+			method, req.URL, err)
+		return Response{}, err
+	}
 	defer cli.RequestSemaphore.Release()
 
-	if storage.RequestSemaphoreMap[cli.GetDeviceSN()] != nil {
-		storage.RequestSemaphoreMap[cli.GetDeviceSN()].Acquire()
-		defer storage.RequestSemaphoreMap[cli.GetDeviceSN()].Release()
-	} else {
-		storage.RequestSemaphoreMap[storage.UninitializedStorage].Acquire()
-		defer storage.RequestSemaphoreMap[storage.UninitializedStorage].Release()
+	storageSemaphore := storage.GetRequestSemaphoreOrDefault(cli.GetDeviceSN())
+	if err = storageSemaphore.AcquireCtx(ctx); err != nil {
+		log.AddContext(ctx).Errorf("Acquire storage semaphore of method: %s, Url: %s, error: %v",
+			method, req.URL, err)
+		return Response{}, err
 	}
+	defer storageSemaphore.Release()
 
 	resp, err := cli.Client.Do(req)
 	if err != nil {
@@ -225,15 +285,22 @@ func (cli *RestClient) BaseCall(ctx context.Context,
 		return Response{}, err
 	}
 
-	log.FilteredLog(ctx, isFilterLog(method, url), utils.IsDebugLog(method, url, debugLog, debugLogRegex),
+	log.FilteredLog(ctx, isFilterLog(method, url), cli.isDebugLog(method, url),
 		fmt.Sprintf("Response method: %s, Url: %s, body: %s", method, req.URL, body))
 
+	if err = storage.CheckJSONResponse(resp, body); err != nil {
+		log.AddContext(ctx).Errorf("Response method: %s, Url: %s error: %v", method, req.URL, err)
+		return Response{StatusCode: resp.StatusCode}, err
+	}
+
 	err = json.Unmarshal(body, &r)
 	if err != nil {
 		log.AddContext(ctx).Errorf("json.Unmarshal data %s error: %v", body, err)
-		return Response{}, err
+		return Response{StatusCode: resp.StatusCode}, err
 	}
 
+	r.StatusCode = resp.StatusCode
+	r.RecordOperationID(ctx, method, url, resp.Header)
 	return r, nil
 }
 
@@ -280,7 +347,7 @@ func (cli *RestClient) GetRequest(ctx context.Context,
 		reqBody = bytes.NewReader(reqBytes)
 	}
 
-	req, err = http.NewRequest(method, reqUrl, reqBody)
+	req, err = http.NewRequestWithContext(storage.RequestContext(ctx), method, reqUrl, reqBody)
 	if err != nil {
 		log.AddContext(ctx).Errorf("construct http request error: %v", err)
 		return nil, err
@@ -293,19 +360,33 @@ func (cli *RestClient) GetRequest(ctx context.Context,
 
 	req.Header.Set("Connection", "keep-alive")
 	req.Header.Set("Content-Type", "application/json")
+	req = storage.TraceConnection(req, cli.BackendID)
+	storage.InjectTraceContext(ctx, req.Header)
+	storage.SetCustomHeaders(ctx, req.Header, cli.Headers)
 
 	if cli.Token != "" {
-		req.Header.Set("iBaseToken", cli.Token)
+		req.Header.Set(storage.AuthTokenHeader, cli.Token)
 	}
 
 	return req, nil
 }
 
+// newHTTPClient creates the http client by the latest cert secret of the backend,
+// the CA bundle and the proxy of the client are kept
+func (cli *RestClient) newHTTPClient(ctx context.Context) (storage.HTTP, error) {
+	useCert, certMeta, err := pkgUtils.GetCertSecretFromBackendID(ctx, cli.BackendID)
+	if err != nil {
+		return nil, fmt.Errorf("get cert secret from backend %s failed, error: %w", cli.BackendID, err)
+	}
+
+	return storage.NewHTTPClientByCertMeta(ctx, useCert, certMeta, cli.CASecretMeta, cli.ProxyURL, cli.Transport)
+}
+
 // Login login and set data from response
 func (cli *RestClient) Login(ctx context.Context) error {
 	var err error
 
-	if cli.Client, err = storage.NewHTTPClientByBackendID(ctx, cli.BackendID); err != nil {
+	if cli.Client, err = cli.newHTTPClient(ctx); err != nil {
 		return pkgUtils.Errorln(ctx,
 			fmt.Sprintf("new http client by backend %s failed, err is %v", cli.BackendID, err))
 	}
@@ -314,23 +395,27 @@ func (cli *RestClient) Login(ctx context.Context) error {
 	if err != nil {
 		return pkgUtils.Errorln(ctx, fmt.Sprintf("get reuqest failed while login, error : %v", err))
 	}
+	defer storage.ClearLoginPassword(data)
 
 	cli.DeviceId, cli.Token = "", ""
 
 	resp, err := cli.loginCall(ctx, data)
 	if err != nil {
-		return pkgUtils.Errorln(ctx, fmt.Sprintf("request storage failed while login, error : %v", err))
+		log.AddContext(ctx).Errorf("request storage failed while login, error : %v", err)
+		return fmt.Errorf("request storage failed while login, error : %w", err)
 	}
 
 	code, _, err := utils.FormatRespErr(resp.Error)
 	if code != 0 {
-		msg := fmt.Sprintf("login %s error: %+v", cli.Url, resp)
+		msg := fmt.Sprintf("login %s error code %s: %+v", cli.Url, utils.FormatErrorCode(code), resp)
 		if utils.Contains(WrongPasswordErrorCodes, code) || utils.Contains(AccountBeenLocked, code) ||
 			code == storage.IPLockErrorCode {
 			if err := pkgUtils.SetStorageBackendContentOnlineStatus(ctx, cli.BackendID, false); err != nil {
 				msg = msg + fmt.Sprintf("\nsetStorageBackendContentOffline [%s] failed, "+
 					"error: %v", cli.BackendID, err)
 			}
+			log.AddContext(ctx).Errorln(msg)
+			return fmt.Errorf("%w: %s", storage.ErrLoginRejected, msg)
 		}
 		return pkgUtils.Errorln(ctx, msg)
 	}
@@ -350,11 +435,16 @@ func (cli *RestClient) Login(ctx context.Context) error {
 func (cli *RestClient) loginCall(ctx context.Context, data map[string]interface{}) (Response, error) {
 	var resp Response
 	var err error
+	budget := storage.NewLoginBudget(cli.LoginTimeout)
 	for i, url := range cli.Urls {
-		cli.Url = url + "/deviceManager/rest"
+		cli.Url, err = storage.JoinRestURL(url, storage.DeviceManagerRestPath)
+		if err != nil {
+			log.AddContext(ctx).Errorf("login %s error: %v", url, err)
+			break
+		}
 		log.AddContext(ctx).Infof("try to login %s", cli.Url)
 
-		resp, err = cli.BaseCall(ctx, "POST", "/xx/sessions", data)
+		resp, err = cli.loginAttempt(ctx, budget, len(cli.Urls)-i, data)
 		if err == nil {
 			/* Sort the login Url to the last slot of san addresses, so that
 			   if this connection error, next time will try other Url first. */
@@ -368,25 +458,50 @@ func (cli *RestClient) loginCall(ctx context.Context, data map[string]interface{
 		log.AddContext(ctx).Warningf("login %s error due to connection failure, gonna try another Url", cli.Url)
 	}
 
+	if err != nil && err.Error() == storage.Unconnected {
+		if budgetErr := budget.Err(); budgetErr != nil {
+			return Response{}, budgetErr
+		}
+		return Response{}, fmt.Errorf("%w after trying %d urls: %w", storage.ErrLoginUnreachable, len(cli.Urls), err)
+	}
+
 	if err != nil {
 		return Response{}, err
 	}
 	return resp, err
 }
 
+// loginAttempt sends the login request to the current url within its share of the login budget
+func (cli *RestClient) loginAttempt(ctx context.Context, budget *storage.LoginBudget, remaining int,
+	data map[string]interface{}) (Response, error) {
+	attemptCtx, cancel, err := budget.AttemptContext(ctx, remaining)
+	if err != nil {
+		return Response{}, err
+	}
+	defer cancel()
+
+	return cli.BaseCall(attemptCtx, "POST", "/xx/sessions", data)
+}
+
 func (cli *RestClient) getRequestParams(ctx context.Context, backendID string) (map[string]interface{}, error) {
-	authInfo, err := pkgUtils.GetAuthInfoFromBackendID(ctx, backendID)
+	authInfo, err := pkgUtils.SelectAuthSource(cli.CredentialsPath, pkgUtils.NewBackendAuthSource(backendID)).
+		GetAuthInfo(ctx)
 	if err != nil {
 		return nil, err
 	}
 	cli.User = authInfo.User
 
+	password, err := storage.DecryptPassword(ctx, backendID, authInfo.Password)
+	authInfo.Password = ""
+	if err != nil {
+		return nil, err
+	}
+
 	data := map[string]interface{}{
 		"username": authInfo.User,
-		"password": authInfo.Password,
-		"scope":    authInfo.Scope,
+		"password": password,
+		"scope":    cli.getLoginScope(authInfo.Scope),
 	}
-	authInfo.Password = ""
 
 	if len(cli.VStoreName) > 0 && cli.VStoreName != storage.DefaultVStore {
 		data["vstorename"] = cli.VStoreName
@@ -406,9 +521,7 @@ func (cli *RestClient) setDataFromRespData(ctx context.Context, resp Response) e
 		return fmt.Errorf("convert respData[\"deviceid\"]: [%T] to string failed", respData["deviceid"])
 	}
 
-	if storage.RequestSemaphoreMap[cli.DeviceId] == nil {
-		storage.RequestSemaphoreMap[cli.DeviceId] = utils.NewSemaphore(storage.MaxStorageThreads)
-	}
+	cli.semaphoreRef.Bind(cli.DeviceId)
 
 	cli.Token, ok = utils.GetValue[string](respData, "iBaseToken")
 	if !ok {
@@ -431,8 +544,21 @@ func (cli *RestClient) setDataFromRespData(ctx context.Context, resp Response) e
 	return nil
 }
 
-// Logout logout
+// Logout logout and release the reference to the request semaphore of the storage device
 func (cli *RestClient) Logout(ctx context.Context) {
+	defer cli.semaphoreRef.Release()
+	cli.logout(ctx)
+}
+
+// Close logs out the session and releases the resources held by the client, such as the idle connections
+// and the reference to the request semaphore of the device
+func (cli *RestClient) Close(ctx context.Context) {
+	cli.Logout(ctx)
+	storage.CloseIdleConnections(cli.Client)
+	log.AddContext(ctx).Infof("client of backend %s is closed", cli.BackendID)
+}
+
+func (cli *RestClient) logout(ctx context.Context) {
 	resp, err := cli.BaseCall(ctx, "DELETE", "/sessions", nil)
 	if err != nil {
 		log.AddContext(ctx).Warningf("logout %s error: %v", cli.Url, err)
@@ -458,13 +584,21 @@ func (cli *RestClient) ReLogin(ctx context.Context) error {
 	if cli.Token != "" && oldToken != cli.Token {
 		// Coming here indicates other thread had already done relogin, so no need to relogin again
 		return nil
-	} else if cli.Token != "" {
-		cli.Logout(ctx)
+	}
+
+	if err := cli.reLoginBackoff.Allow(); err != nil {
+		log.AddContext(ctx).Warningf("skip relogin of backend %s: %v", cli.BackendID, err)
+		return err
+	}
+
+	if cli.Token != "" {
+		// keep the reference to the request semaphore, the device is referenced again after login
+		cli.logout(ctx)
 	}
 
 	err := cli.Login(ctx)
-	if err != nil {
-		log.AddContext(ctx).Errorf("try to relogin error: %v", err)
+	if failure := cli.reLoginBackoff.Record(err); err != nil {
+		log.AddContext(ctx).Errorf("try to relogin error, failure: %s, err: %v", failure, err)
 		return err
 	}
 
@@ -510,42 +644,39 @@ func (cli *RestClient) GetSystem(ctx context.Context) (map[string]interface{}, e
 	return respData, nil
 }
 
+// getLoginScope returns the login scope configured for the backend if it is set, otherwise the scope of the secret
+func (cli *RestClient) getLoginScope(secretScope string) string {
+	if cli.LoginScope != "" {
+		return cli.LoginScope
+	}
+
+	return secretScope
+}
+
 // ValidateLogin validates the login info
 func (cli *RestClient) ValidateLogin(ctx context.Context) error {
-	var resp Response
-	var err error
+	params, err := pkgUtils.SelectAuthSource(cli.CredentialsPath,
+		pkgUtils.NewSecretAuthSource(cli.SecretName, cli.SecretNamespace)).GetAuthInfo(ctx)
+	if err != nil {
+		return err
+	}
 
-	params, err := pkgUtils.GetAuthInfoFromSecret(ctx, cli.SecretName, cli.SecretNamespace)
+	password, err := storage.DecryptPassword(ctx, cli.BackendID, params.Password)
+	params.Password = ""
 	if err != nil {
 		return err
 	}
 
 	data := map[string]interface{}{
 		"username": cli.User,
-		"password": params.Password,
-		"scope":    params.Scope,
+		"password": password,
+		"scope":    cli.getLoginScope(params.Scope),
 	}
-	params.Password = ""
+	defer storage.ClearLoginPassword(data)
 
 	cli.DeviceId = ""
 	cli.Token = ""
-	for i, url := range cli.Urls {
-		cli.Url = url + "/deviceManager/rest"
-		log.AddContext(ctx).Infof("try to login %s", cli.Url)
-		resp, err = cli.BaseCall(ctx, "POST", "/xx/sessions", data)
-		if err == nil {
-			/* Sort the login Url to the last slot of san addresses, so that
-			   if this connection error, next time will try other Url first. */
-			cli.Urls[i], cli.Urls[len(cli.Urls)-1] = cli.Urls[len(cli.Urls)-1], cli.Urls[i]
-			break
-		} else if err.Error() != storage.Unconnected {
-			log.AddContext(ctx).Errorf("login %s error", cli.Url)
-			break
-		}
-
-		log.AddContext(ctx).Warningf("login %s error due to connection failure, gonna try another Url", cli.Url)
-	}
-
+	resp, err := cli.loginCall(ctx, data)
 	if err != nil {
 		return err
 	}
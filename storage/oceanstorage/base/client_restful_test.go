@@ -27,6 +27,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -35,6 +36,13 @@ import (
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/prashantv/gostub"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app"
 	cfg "github.com/Huawei/eSDK_K8S_Plugin/v4/csi/app/config"
@@ -221,7 +229,8 @@ func TestBaseClient_GetRequest_newRequestFailed(t *testing.T) {
 
 	// mock
 	mock := gomonkey.NewPatches()
-	mock.ApplyFunc(http.NewRequest, func(method, url string, body io.Reader) (*http.Request, error) {
+	mock.ApplyFunc(http.NewRequestWithContext, func(_ context.Context, method, url string,
+		body io.Reader) (*http.Request, error) {
 		return nil, wantErr
 	})
 
@@ -254,6 +263,7 @@ func TestBaseClient_BaseCall_Success(t *testing.T) {
 	if err != nil {
 		return
 	}
+	wantResponse.StatusCode = http.StatusOK
 
 	// mock
 	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -318,7 +328,7 @@ func TestRestClient_BaseCall_Concurrency(t *testing.T) {
 	wg.Wait()
 
 	// assert
-	getAvailablePermits := storage.RequestSemaphoreMap[storage.UninitializedStorage].AvailablePermits()
+	getAvailablePermits := storage.GetRequestSemaphore(storage.UninitializedStorage).AvailablePermits()
 	if getAvailablePermits != wantAvailablePermits {
 		t.Errorf("TestRestClient_BaseCall_Concurrency failed, "+
 			"wantAvailablePermits = %d, getAvailablePermits = %d", wantAvailablePermits, getAvailablePermits)
@@ -690,6 +700,36 @@ func TestRestClient_loginCall_SuccessOnSecondUrl(t *testing.T) {
 	assert.Equal(t, expectedOrder, cli.Urls)
 }
 
+func TestRestClient_loginCall_LoginTimeout(t *testing.T) {
+	// arrange
+	release := make(chan struct{})
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slowServer.Close()
+	defer close(release)
+
+	timeout := 200 * time.Millisecond
+	cli, err := NewRestClient(context.Background(), &storage.NewClientConfig{
+		Urls:         []string{slowServer.URL, slowServer.URL},
+		LoginTimeout: timeout,
+	})
+	assert.NoError(t, err)
+
+	// act
+	start := time.Now()
+	gotResp, gotErr := cli.loginCall(context.Background(), map[string]interface{}{})
+
+	// assert
+	assert.ErrorIs(t, gotErr, storage.ErrLoginTimeout)
+	assert.ErrorContains(t, gotErr, "after trying 2 urls")
+	assert.Less(t, time.Since(start), 5*timeout)
+	assert.Empty(t, gotResp)
+}
+
 func TestRestClient_ValidateLogin_GetPasswordError(t *testing.T) {
 	// arrange
 	cli, _ := NewRestClient(context.Background(), &storage.NewClientConfig{})
@@ -830,3 +870,262 @@ func TestRestClient_getRequestParams(t *testing.T) {
 	assert.Equal(t, gotdata["scope"], scope)
 	assert.Equal(t, gotdata["vstorename"], vstore)
 }
+
+func TestRestClient_getRequestParams_LoginScopeOverride(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &storage.NewClientConfig{LoginScope: "1"})
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, &pkgUtils.BackendAuthInfo{Scope: "0"}, nil)
+
+	// act
+	data, err := cli.getRequestParams(context.Background(), "backend")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "1", data["scope"])
+}
+
+func TestNewRestClient_InvalidLoginScope(t *testing.T) {
+	// act
+	cli, err := NewRestClient(context.Background(), &storage.NewClientConfig{LoginScope: "ldap"})
+
+	// assert
+	assert.Nil(t, cli)
+	assert.ErrorContains(t, err, "loginScope must be one of")
+}
+
+func TestNewRestClient_MalformedUrl(t *testing.T) {
+	// arrange
+	param := &storage.NewClientConfig{Urls: []string{"https://[fe80::1]:8088", "https://fe80::2:8088"}}
+
+	// action
+	cli, err := NewRestClient(context.Background(), param)
+
+	// assert
+	assert.Nil(t, cli)
+	assert.ErrorContains(t, err, "IPv6 address must be enclosed in brackets")
+}
+
+func TestRestClient_loginCall_IPv6Url(t *testing.T) {
+	// arrange
+	cli := &RestClient{Urls: []string{"https://[fe80::1]:8088"}}
+	ctx := context.Background()
+
+	// mock
+	mock := gomonkey.ApplyMethodReturn(cli, "BaseCall", Response{}, nil)
+	defer mock.Reset()
+
+	// action
+	_, err := cli.loginCall(ctx, map[string]interface{}{})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "https://[fe80::1]:8088/deviceManager/rest", cli.Url)
+}
+
+func TestRestClient_BaseCall_RecordOperationID(t *testing.T) {
+	// arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(OperationIDHeader, "operation-1")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"error":{"code":0},"data":{}}`))
+	}))
+	defer server.Close()
+	cli, _ := NewRestClient(context.Background(), &storage.NewClientConfig{})
+	cli.Url = server.URL
+
+	// action
+	postResp, postErr := cli.BaseCall(context.Background(), "POST", "/lun", map[string]interface{}{})
+	getResp, getErr := cli.BaseCall(context.Background(), "GET", "/lun", nil)
+
+	// assert
+	assert.NoError(t, postErr)
+	assert.Equal(t, "operation-1", postResp.OperationID)
+	assert.NoError(t, getErr)
+	assert.Empty(t, getResp.OperationID)
+}
+
+func TestRestClient_BaseCall_TraceSpan(t *testing.T) {
+	// arrange
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	var traceParent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"error":{"code":1077936859,"description":"busy"},"data":{}}`))
+	}))
+	defer server.Close()
+	cli, _ := NewRestClient(context.Background(), &storage.NewClientConfig{BackendID: "backend-1"})
+	cli.Url = server.URL
+	cli.DeviceId = "sn-1"
+	parentCtx, parent := provider.Tracer("test").Start(context.Background(), "CreateVolume")
+
+	// action
+	_, err := cli.BaseCall(parentCtx, "DELETE", "/lun/12?vstoreId=0", nil)
+	parent.End()
+
+	// assert
+	assert.NoError(t, err)
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+	span := spans[0]
+	assert.Equal(t, "DELETE /lun/{id}", span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Contains(t, span.Attributes(), attribute.String(storage.SpanAttrBackendID, "backend-1"))
+	assert.Contains(t, span.Attributes(), attribute.String(storage.SpanAttrDeviceSN, "sn-1"))
+	assert.Contains(t, span.Attributes(), attribute.Int64(storage.SpanAttrErrorCode, 1077936859))
+	assert.Contains(t, traceParent, span.SpanContext().TraceID().String())
+	assert.Contains(t, traceParent, span.SpanContext().SpanID().String())
+}
+
+type fakeCredentialDecrypter struct{}
+
+func (fakeCredentialDecrypter) Decrypt(_ context.Context, _, password string) (string, error) {
+	return strings.TrimPrefix(password, "kms:"), nil
+}
+
+func TestRestClient_Login_DecryptedPassword(t *testing.T) {
+	// arrange
+	var loginBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&loginBody)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"error":{"code":0},"data":{"deviceid":"sn-1","iBaseToken":"token"}}`))
+	}))
+	defer server.Close()
+	cli, _ := NewRestClient(context.Background(), &storage.NewClientConfig{Urls: []string{server.URL}})
+	storage.RegisterCredentialDecrypter(fakeCredentialDecrypter{})
+	defer storage.RegisterCredentialDecrypter(nil)
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyFuncReturn(pkgUtils.GetCertSecretFromBackendID, false, "", nil).
+		ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, &pkgUtils.BackendAuthInfo{
+			User: "user", Password: "kms:pwd", Scope: "0"}, nil)
+
+	// action
+	err := cli.Login(context.Background())
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "pwd", loginBody["password"])
+	assert.Equal(t, "sn-1", cli.DeviceId)
+}
+
+func TestRestClient_BaseCall_UnauthorizedErrorPage(t *testing.T) {
+	// arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`<html><body>401 Unauthorized</body></html>`))
+	}))
+	defer server.Close()
+	cli, _ := NewRestClient(context.Background(), &storage.NewClientConfig{})
+	cli.Url = server.URL
+
+	// action
+	resp, err := cli.BaseCall(context.Background(), "GET", "/lun", nil)
+
+	// assert
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.True(t, NeedReLogin(resp, err))
+}
+
+func TestRestClient_Login_KeepTransportSettings(t *testing.T) {
+	// arrange
+	transport := storage.TransportConfig{MaxIdleConns: 10, HostOverrides: map[string]string{"array": "192.168.1.1"}}
+	cli := &RestClient{Urls: []string{"https://array:8088"}, CASecretMeta: "ns/ca", ProxyURL: "http://proxy:3128",
+		Transport: transport}
+	var gotCASecret, gotProxy string
+	var gotTransport storage.TransportConfig
+	wantErr := errors.New("stop after the http client is created")
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyFuncReturn(pkgUtils.GetCertSecretFromBackendID, false, "", nil).
+		ApplyFunc(storage.NewHTTPClientByCertMeta, func(_ context.Context, _ bool, _, caSecretMeta, proxyURL string,
+			transportConfig storage.TransportConfig) (storage.HTTP, error) {
+			gotCASecret, gotProxy, gotTransport = caSecretMeta, proxyURL, transportConfig
+			return nil, wantErr
+		})
+
+	// action
+	err := cli.Login(context.Background())
+
+	// assert
+	assert.ErrorContains(t, err, wantErr.Error())
+	assert.Equal(t, "ns/ca", gotCASecret)
+	assert.Equal(t, "http://proxy:3128", gotProxy)
+	assert.Equal(t, transport, gotTransport)
+}
+
+func TestRestClient_ReLogin_BackoffAfterUnreachable(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli, _ := NewRestClient(ctx, &storage.NewClientConfig{})
+	cli.Urls = []string{"https://127.0.0.1:8088", "https://127.0.0.2:8088"}
+	var attempts int
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(pkgUtils.GetCertSecretFromBackendID, false, "", nil).
+		ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, &pkgUtils.BackendAuthInfo{}, nil).
+		ApplyFuncReturn(storage.DecryptPassword, "password", nil).
+		ApplyMethod(cli, "BaseCall", func(_ *RestClient, _ context.Context, _ string, _ string,
+			_ map[string]interface{}) (Response, error) {
+			attempts++
+			return Response{}, errors.New(storage.Unconnected)
+		})
+	defer patches.Reset()
+
+	// act
+	firstErr := cli.ReLogin(ctx)
+	secondErr := cli.ReLogin(ctx)
+
+	// assert
+	assert.ErrorIs(t, firstErr, storage.ErrLoginUnreachable)
+	assert.ErrorIs(t, secondErr, storage.ErrReLoginBackoff)
+	assert.Equal(t, storage.LoginFailureConnectivity, storage.ClassifyLoginFailure(secondErr))
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRestClient_Login_WrongPassword(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli, _ := NewRestClient(ctx, &storage.NewClientConfig{})
+	cli.Urls = []string{"https://127.0.0.1:8088"}
+	var online *bool
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(pkgUtils.GetCertSecretFromBackendID, false, "", nil).
+		ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, &pkgUtils.BackendAuthInfo{}, nil).
+		ApplyFuncReturn(storage.DecryptPassword, "password", nil).
+		ApplyMethodReturn(cli, "BaseCall", Response{
+			Error: map[string]interface{}{"code": float64(WrongPasswordErrorCodes[0])}}, nil).
+		ApplyFunc(pkgUtils.SetStorageBackendContentOnlineStatus, func(_ context.Context, _ string, status bool) error {
+			online = &status
+			return nil
+		})
+	defer patches.Reset()
+
+	// act
+	err := cli.Login(ctx)
+
+	// assert
+	assert.Equal(t, storage.LoginFailureAuth, storage.ClassifyLoginFailure(err))
+	assert.NotNil(t, online)
+	assert.False(t, *online)
+}
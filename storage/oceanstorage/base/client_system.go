@@ -21,6 +21,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
@@ -38,8 +40,20 @@ type System interface {
 	GetRemoteDeviceBySN(ctx context.Context, sn string) (map[string]interface{}, error)
 	// GetAllRemoteDevices used for get all remote devices
 	GetAllRemoteDevices(ctx context.Context) ([]map[string]interface{}, error)
+	// GetStorageTime used for get the current time of the storage
+	GetStorageTime(ctx context.Context) (time.Time, error)
+	// GetLicenseExpiry used for get the expiry time of the license features
+	GetLicenseExpiry(ctx context.Context) (map[string]time.Time, error)
 }
 
+const (
+	// DefaultClockSkewThreshold defines the default clock skew between the storage and the host to be warned
+	DefaultClockSkewThreshold = 30 * time.Second
+
+	// DefaultLicenseExpiryWarning defines the default duration before the expiry of a license to be warned
+	DefaultLicenseExpiryWarning = 30 * 24 * time.Hour
+)
+
 // SystemClient defines client implements the System interface
 type SystemClient struct {
 	RestClientInterface
@@ -196,3 +210,127 @@ func (cli *SystemClient) GetRemoteDeviceBySN(ctx context.Context, sn string) (ma
 func (cli *SystemClient) GetAllRemoteDevices(ctx context.Context) ([]map[string]interface{}, error) {
 	return GetBatchObjs(ctx, cli.RestClientInterface, "/remote_device")
 }
+
+// GetStorageTime used for get the current time of the storage
+func (cli *SystemClient) GetStorageTime(ctx context.Context) (time.Time, error) {
+	resp, err := cli.Get(ctx, "/system_utc_time", nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return time.Time{}, fmt.Errorf("Get storage time error: %d", code)
+	}
+
+	respData, ok := resp.Data.(map[string]interface{})
+	if !ok {
+		return time.Time{}, pkgUtils.Errorf(ctx, "convert resp.Data to map failed, data: %v", resp.Data)
+	}
+
+	utcTime, ok := respData["CMO_SYS_UTC_TIME"].(string)
+	if !ok {
+		return time.Time{}, pkgUtils.Errorf(ctx, "convert CMO_SYS_UTC_TIME to string failed, data: %v",
+			respData["CMO_SYS_UTC_TIME"])
+	}
+
+	seconds, err := strconv.ParseInt(utcTime, 10, 64)
+	if err != nil {
+		return time.Time{}, pkgUtils.Errorf(ctx, "parse storage time %s failed, error: %v", utcTime, err)
+	}
+
+	return time.Unix(seconds, 0), nil
+}
+
+// GetLicenseExpiry used for get the expiry time of the license features, the permanent features are not included
+func (cli *SystemClient) GetLicenseExpiry(ctx context.Context) (map[string]time.Time, error) {
+	resp, err := cli.Get(ctx, "/license/activelicense", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return nil, fmt.Errorf("Get license expiry error: %d", code)
+	}
+
+	result := map[string]time.Time{}
+	if resp.Data == nil {
+		return result, nil
+	}
+
+	respData, ok := resp.Data.([]interface{})
+	if !ok {
+		return nil, pkgUtils.Errorf(ctx, "convert resp.Data to arr failed, data: %v", resp.Data)
+	}
+
+	permanent := map[string]bool{}
+	for _, i := range respData {
+		license, ok := i.(map[string]interface{})
+		if !ok {
+			log.AddContext(ctx).Warningf("convert license to map failed, data: %v", i)
+			continue
+		}
+
+		feature, _ := license["FEATURENAME"].(string)
+		expireDate, _ := license["EXPIREDATE"].(string)
+		if feature == "" {
+			continue
+		}
+		// the expire date of a permanent license is empty or 0
+		if expireDate == "" || expireDate == "0" {
+			permanent[feature] = true
+			continue
+		}
+
+		seconds, err := strconv.ParseInt(expireDate, 10, 64)
+		if err != nil {
+			log.AddContext(ctx).Warningf("parse expire date %s of license %s failed, error: %v",
+				expireDate, feature, err)
+			continue
+		}
+
+		expiry := time.Unix(seconds, 0)
+		// a feature may be covered by several licenses, it expires with the last one
+		if expiry.After(result[feature]) {
+			result[feature] = expiry
+		}
+	}
+
+	for feature := range permanent {
+		delete(result, feature)
+	}
+
+	return result, nil
+}
+
+// IsFeatureExpiringSoon returns whether the license of the feature in the expiries queried by GetLicenseExpiry
+// expires within the duration and the expiry time, false is returned if the feature is permanent or not licensed
+func IsFeatureExpiringSoon(expiries map[string]time.Time, feature string, within time.Duration) (bool, time.Time) {
+	expiry, ok := expiries[feature]
+	if !ok {
+		return false, time.Time{}
+	}
+
+	return time.Until(expiry) <= within, expiry
+}
+
+// CheckClockSkew returns the clock skew of the storage against the host, it is positive if the storage is ahead.
+// A warning is logged if the skew exceeds the threshold
+func CheckClockSkew(ctx context.Context, system System, threshold time.Duration) (time.Duration, error) {
+	before := time.Now()
+	storageTime, err := system.GetStorageTime(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// the storage time is compared with the middle of the request to exclude the round trip
+	hostTime := before.Add(time.Since(before) / 2)
+	skew := storageTime.Sub(hostTime)
+	if skew > threshold || skew < -threshold {
+		log.AddContext(ctx).Warningf("clock skew between the storage and the host is %s, exceeds %s, "+
+			"please check the NTP of them", skew, threshold)
+	}
+
+	return skew, nil
+}
@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package base provide base operations for oceanstor base storage
+package base
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemClient_GetStorageTime(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name    string
+		data    any
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "success", data: map[string]any{"CMO_SYS_UTC_TIME": "1700000000"}, want: time.Unix(1700000000, 0)},
+		{name: "time is not a number", data: map[string]any{"CMO_SYS_UTC_TIME": "now"}, wantErr: true},
+		{name: "time not exists", data: map[string]any{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restClient := &RestClient{}
+			cli := &SystemClient{RestClientInterface: restClient}
+
+			// mock
+			patches := gomonkey.ApplyMethodReturn(restClient, "Get",
+				Response{Error: map[string]any{"code": float64(0)}, Data: tt.data}, nil)
+			defer patches.Reset()
+
+			// action
+			got, err := cli.GetStorageTime(context.Background())
+
+			// assert
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(got))
+		})
+	}
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	// arrange
+	restClient := &RestClient{}
+	cli := &SystemClient{RestClientInterface: restClient}
+	storageTime := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(restClient, "Get",
+		Response{Error: map[string]any{"code": float64(0)}, Data: map[string]any{"CMO_SYS_UTC_TIME": storageTime}}, nil)
+	defer patches.Reset()
+
+	// action
+	skew, err := CheckClockSkew(context.Background(), cli, DefaultClockSkewThreshold)
+
+	// assert
+	require.NoError(t, err)
+	require.InDelta(t, float64(-time.Hour), float64(skew), float64(2*time.Second))
+}
+
+func TestSystemClient_GetLicenseExpiry(t *testing.T) {
+	// arrange
+	restClient := &RestClient{}
+	cli := &SystemClient{RestClientInterface: restClient}
+	licenses := []any{
+		map[string]any{"FEATURENAME": "HyperMetro", "EXPIREDATE": "1700000000"},
+		map[string]any{"FEATURENAME": "HyperMetro", "EXPIREDATE": "1800000000"},
+		map[string]any{"FEATURENAME": "SmartQoS", "EXPIREDATE": "1700000000"},
+		map[string]any{"FEATURENAME": "SmartQoS", "EXPIREDATE": "0"},
+		map[string]any{"FEATURENAME": "SmartThin", "EXPIREDATE": ""},
+	}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(restClient, "Get",
+		Response{Error: map[string]any{"code": float64(0)}, Data: licenses}, nil)
+	defer patches.Reset()
+
+	// action
+	got, err := cli.GetLicenseExpiry(context.Background())
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, map[string]time.Time{"HyperMetro": time.Unix(1800000000, 0)}, got)
+}
+
+func TestIsFeatureExpiringSoon(t *testing.T) {
+	// arrange
+	expiries := map[string]time.Time{"HyperMetro": time.Now().Add(24 * time.Hour)}
+
+	// action
+	soon, expiry := IsFeatureExpiringSoon(expiries, "HyperMetro", DefaultLicenseExpiryWarning)
+	notSoon, _ := IsFeatureExpiringSoon(expiries, "HyperMetro", time.Hour)
+	permanent, permanentExpiry := IsFeatureExpiringSoon(expiries, "SmartThin", time.Hour)
+
+	// assert
+	require.True(t, soon)
+	require.Equal(t, expiries["HyperMetro"], expiry)
+	require.False(t, notSoon)
+	require.False(t, permanent)
+	require.True(t, permanentExpiry.IsZero())
+}
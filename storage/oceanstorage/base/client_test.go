@@ -19,11 +19,17 @@ package base
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math"
+	"net/http"
 	"testing"
 
+	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
@@ -72,6 +78,10 @@ func TestResponse_AssertErrorCode(t *testing.T) {
 				"description": "test description"}},
 			wantErrContains: "test description"},
 		{name: "assert has error", resp: &Response{Error: map[string]any{"code": float64(0)}}, wantErrContains: ""},
+		{name: "assert error with suggestion",
+			resp: &Response{Error: map[string]any{"code": float64(1077949001),
+				"description": "test description", "suggestion": "test suggestion"}},
+			wantErrContains: "error code 1077949001: [test description], suggestion: [test suggestion]"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -130,3 +140,156 @@ func TestResponse_AssertErrorWithTolerantErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestResponse_GetData_UnknownFieldLenient(t *testing.T) {
+	// arrange
+	resp := &Response{Data: map[string]any{"ID": "1", "NAME": "system", "UNKNOWN": "value"}}
+	var system OceanstorSystem
+
+	// action
+	err := resp.GetData(&system)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "1", system.ID)
+	require.Equal(t, "system", system.Name)
+}
+
+func TestResponse_GetData_UnknownFieldStrict(t *testing.T) {
+	// arrange
+	resp := &Response{Data: map[string]any{"ID": "1", "NAME": "system", "UNKNOWN": "value"}}
+	var system OceanstorSystem
+	SetStrictDecode(true)
+	defer SetStrictDecode(false)
+
+	// action
+	err := resp.GetData(&system)
+
+	// assert
+	require.ErrorIs(t, err, ErrSchemaDrift)
+	require.ErrorContains(t, err, "UNKNOWN")
+}
+
+func TestResponse_GetData_KnownFieldStrict(t *testing.T) {
+	// arrange
+	resp := &Response{Data: map[string]any{"ID": "1", "NAME": "system"}}
+	var system OceanstorSystem
+	SetStrictDecode(true)
+	defer SetStrictDecode(false)
+
+	// action
+	err := resp.GetData(&system)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "1", system.ID)
+}
+
+type infoRecorder struct {
+	log.Logger
+	infos []string
+}
+
+func (r *infoRecorder) Infof(format string, args ...interface{}) {
+	r.infos = append(r.infos, fmt.Sprintf(format, args...))
+}
+
+func TestResponse_RecordOperationID(t *testing.T) {
+	// arrange
+	header := http.Header{}
+	header.Set(OperationIDHeader, "operation-1")
+	tests := []struct {
+		name            string
+		method          string
+		code            float64
+		wantOperationID string
+		wantLogged      bool
+	}{
+		{name: "mutating request succeeded", method: "POST", wantOperationID: "operation-1", wantLogged: true},
+		{name: "mutating request failed", method: "DELETE", code: 1077949061, wantOperationID: "operation-1"},
+		{name: "query request", method: "GET"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &infoRecorder{}
+			patches := gomonkey.ApplyFuncReturn(log.AddContext, recorder)
+			defer patches.Reset()
+			resp := &Response{Error: map[string]any{"code": tt.code}}
+
+			// action
+			resp.RecordOperationID(context.Background(), tt.method, "/lun", header)
+
+			// assert
+			require.Equal(t, tt.wantOperationID, resp.OperationID)
+			if tt.wantLogged {
+				require.Len(t, recorder.infos, 1)
+				require.Contains(t, recorder.infos[0], "operation id: operation-1")
+			} else {
+				require.Empty(t, recorder.infos)
+			}
+		})
+	}
+}
+
+func TestResponse_AssertErrorCode_StorageBusy(t *testing.T) {
+	// arrange
+	busy := &Response{Error: map[string]any{"code": float64(1077949006), "description": "The system is busy."}}
+	failed := &Response{Error: map[string]any{"code": float64(1077949061), "description": "failed"}}
+
+	// action
+	busyErr := busy.AssertErrorCode()
+	busyTolerationErr := busy.AssertErrorWithTolerations(context.Background())
+	failedErr := failed.AssertErrorCode()
+
+	// assert
+	require.ErrorIs(t, busyErr, ErrStorageBusy)
+	require.ErrorIs(t, busyTolerationErr, ErrStorageBusy)
+	require.Error(t, failedErr)
+	require.NotErrorIs(t, failedErr, ErrStorageBusy)
+}
+
+func TestNeedReLogin(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name string
+		resp Response
+		err  error
+		want bool
+	}{
+		{name: "unconnected", err: errors.New(storage.Unconnected), want: true},
+		{name: "unauthorized code", resp: Response{Error: map[string]interface{}{
+			"code": float64(storage.UserUnauthorized)}, StatusCode: http.StatusOK}, want: true},
+		{name: "offline code", resp: Response{Error: map[string]interface{}{
+			"code": float64(storage.UserOffline)}, StatusCode: http.StatusOK}, want: true},
+		{name: "unauthorized status with non-json body", resp: Response{StatusCode: http.StatusUnauthorized},
+			err: errors.New("invalid character '<' looking for beginning of value"), want: true},
+		{name: "unauthorized status with other code", resp: Response{Error: map[string]interface{}{
+			"code": float64(1077949061)}, StatusCode: http.StatusUnauthorized}, want: true},
+		{name: "server error status with non-json body", resp: Response{StatusCode: http.StatusInternalServerError},
+			err: errors.New("invalid character '<' looking for beginning of value"), want: false},
+		{name: "success", resp: Response{Error: map[string]interface{}{"code": float64(0)},
+			StatusCode: http.StatusOK}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// action
+			got := NeedReLogin(tt.resp, tt.err)
+
+			// assert
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRegisteredErrorCodes(t *testing.T) {
+	// act
+	wrongPassword := utils.FormatErrorCode(WrongPasswordErrorCodes[0])
+	ipLocked := utils.FormatErrorCode(storage.IPLockErrorCode)
+	unknown := utils.FormatErrorCode(1077949001)
+
+	// assert
+	require.Equal(t, fmt.Sprintf("%d (WrongPassword: the user name or password is incorrect)",
+		WrongPasswordErrorCodes[0]), wrongPassword)
+	require.Equal(t, fmt.Sprintf("%d (IPLocked: the login ip has been locked)", storage.IPLockErrorCode), ipLocked)
+	require.Equal(t, "1077949001", unknown)
+}
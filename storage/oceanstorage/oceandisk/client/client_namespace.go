@@ -20,8 +20,10 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/api"
@@ -43,6 +45,17 @@ const (
 	AssociateObjTypeNamespace = 11
 	// AssociateObjTypeNamespaceGroup Namespace group type
 	AssociateObjTypeNamespaceGroup = 256
+
+	// resizeCapacityTolerance is the capacity in sectors that the resized namespace may fall short of the requested
+	// one, because the storage aligns the capacity to its allocation granularity
+	resizeCapacityTolerance int64 = 2048
+)
+
+var (
+	// namespaceResizeConfirmInterval is the interval to re-read the namespace while confirming its new capacity
+	namespaceResizeConfirmInterval = time.Second
+	// namespaceResizeConfirmTimeout is the time to wait for the storage to apply the new capacity
+	namespaceResizeConfirmTimeout = 10 * time.Second
 )
 
 // Namespace defines interfaces for namespace operations
@@ -59,6 +72,8 @@ type Namespace interface {
 	DeleteNamespace(ctx context.Context, id string) error
 	// ExtendNamespace used for extend namespace
 	ExtendNamespace(ctx context.Context, namespaceID string, newCapacity int64) error
+	// ResizeNamespace used for extend namespace and confirm the storage has applied the new capacity
+	ResizeNamespace(ctx context.Context, namespaceID string, newCapacity int64) error
 	// CreateNamespace used for create namespace
 	CreateNamespace(ctx context.Context, params CreateNamespaceParams) (map[string]interface{}, error)
 	// GetHostNamespaceId used for get host namespace id
@@ -467,6 +482,50 @@ func (cli *OceandiskClient) ExtendNamespace(ctx context.Context, namespaceID str
 	return nil
 }
 
+// ResizeNamespace used for extend namespace to the new capacity in sectors, the namespace is re-read until
+// its capacity reaches the new capacity within the tolerance, an error is returned if the storage accepts
+// the extension but the capacity does not grow in time
+func (cli *OceandiskClient) ResizeNamespace(ctx context.Context, namespaceID string, newCapacity int64) error {
+	if err := cli.ExtendNamespace(ctx, namespaceID, newCapacity); err != nil {
+		return err
+	}
+
+	var capacity int64
+	err := utils.PollUntil(ctx, namespaceResizeConfirmInterval, namespaceResizeConfirmInterval,
+		namespaceResizeConfirmTimeout, func() (bool, error) {
+			namespace, err := cli.GetNamespaceByID(ctx, namespaceID)
+			if err != nil {
+				return false, err
+			}
+
+			capacity, err = parseNamespaceCapacity(namespace)
+			if err != nil {
+				return false, err
+			}
+
+			return capacity >= newCapacity-resizeCapacityTolerance, nil
+		})
+	if errors.Is(err, utils.ErrPollTimeout) {
+		return fmt.Errorf("namespace %s is not resized, the storage accepted capacity %d "+
+			"but the capacity is still %d: %w", namespaceID, newCapacity, capacity, err)
+	}
+	if err != nil {
+		return fmt.Errorf("confirm capacity of namespace %s failed: %w", namespaceID, err)
+	}
+
+	log.AddContext(ctx).Infof("Namespace %s is resized to capacity %d", namespaceID, capacity)
+	return nil
+}
+
+func parseNamespaceCapacity(namespace map[string]interface{}) (int64, error) {
+	capacityStr, ok := namespace["CAPACITY"].(string)
+	if !ok {
+		return 0, fmt.Errorf("convert capacity: %v to string failed", namespace["CAPACITY"])
+	}
+
+	return strconv.ParseInt(capacityStr, constants.DefaultIntBase, constants.DefaultIntBitSize)
+}
+
 // GetNamespaceCountOfMapping used for get namespace count of mapping by mapping id
 func (cli *OceandiskClient) GetNamespaceCountOfMapping(ctx context.Context, mappingID string) (int64, error) {
 	url := fmt.Sprintf(api.GetNamespaceCountOfMapping, mappingID)
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 
@@ -1512,3 +1513,78 @@ func TestBaseClient_UpdateNamespace_Success(t *testing.T) {
 		mock.Reset()
 	})
 }
+
+func TestBaseClient_ResizeNamespace_Success(t *testing.T) {
+	// arrange
+	namespaceID := "1"
+	capacity := int64(4194304)
+	client, err := NewClient(context.Background(), &storage.NewClientConfig{})
+	if err != nil {
+		return
+	}
+
+	putResponse := base.Response{
+		Error: map[string]interface{}{"code": float64(0), "description": "0"},
+	}
+	getResponse := base.Response{
+		Error: map[string]interface{}{"code": float64(0), "description": "0"},
+		Data:  map[string]interface{}{"ID": namespaceID, "CAPACITY": "4194304"},
+	}
+
+	// mock
+	mock := gomonkey.NewPatches()
+	mock.ApplyMethodReturn(&base.RestClient{}, "Put", putResponse, nil).
+		ApplyMethodReturn(&base.RestClient{}, "Get", getResponse, nil)
+
+	// action
+	getErr := client.ResizeNamespace(context.Background(), namespaceID, capacity)
+
+	// assert
+	if getErr != nil {
+		t.Errorf("TestBaseClient_ResizeNamespace_Success failed, wantErr = nil, gotErr = %v", getErr)
+	}
+
+	// cleanup
+	t.Cleanup(func() {
+		mock.Reset()
+	})
+}
+
+func TestBaseClient_ResizeNamespace_NotGrown(t *testing.T) {
+	// arrange
+	namespaceID := "1"
+	capacity := int64(4194304)
+	client, err := NewClient(context.Background(), &storage.NewClientConfig{})
+	if err != nil {
+		return
+	}
+
+	putResponse := base.Response{
+		Error: map[string]interface{}{"code": float64(0), "description": "0"},
+	}
+	getResponse := base.Response{
+		Error: map[string]interface{}{"code": float64(0), "description": "0"},
+		Data:  map[string]interface{}{"ID": namespaceID, "CAPACITY": "2097152"},
+	}
+
+	// mock
+	mock := gomonkey.NewPatches()
+	mock.ApplyMethodReturn(&base.RestClient{}, "Put", putResponse, nil).
+		ApplyMethodReturn(&base.RestClient{}, "Get", getResponse, nil).
+		ApplyGlobalVar(&namespaceResizeConfirmInterval, time.Millisecond).
+		ApplyGlobalVar(&namespaceResizeConfirmTimeout, 10*time.Millisecond)
+
+	// action
+	getErr := client.ResizeNamespace(context.Background(), namespaceID, capacity)
+
+	// assert
+	if !errors.Is(getErr, utils.ErrPollTimeout) {
+		t.Errorf("TestBaseClient_ResizeNamespace_NotGrown failed, wantErr = %v, gotErr = %v",
+			utils.ErrPollTimeout, getErr)
+	}
+
+	// cleanup
+	t.Cleanup(func() {
+		mock.Reset()
+	})
+}
@@ -317,5 +317,5 @@ func (p *SAN) expandLocalNamespace(ctx context.Context, params, taskResult map[s
 		return nil, fmt.Errorf("assert size: [%v] to int64 failed", params["size"])
 	}
 
-	return nil, p.cli.ExtendNamespace(ctx, namespaceID, newSize)
+	return nil, p.cli.ResizeNamespace(ctx, namespaceID, newSize)
 }
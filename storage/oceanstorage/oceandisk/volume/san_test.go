@@ -45,7 +45,7 @@ func TestSAN_Create_Success(t *testing.T) {
 	// arrange
 	ctx := context.Background()
 	clientConfig := storage.NewClientConfig{
-		Urls:            []string{"127.0.0.1"},
+		Urls:            []string{"https://127.0.0.1:8088"},
 		User:            "testUser",
 		SecretName:      "testSecretName",
 		SecretNamespace: "testSecretNamespace",
@@ -92,7 +92,7 @@ func TestSAN_Create_PrepareFailed(t *testing.T) {
 	// arrange
 	ctx := context.Background()
 	clientConfig := storage.NewClientConfig{
-		Urls:            []string{"127.0.0.1"},
+		Urls:            []string{"https://127.0.0.1:8088"},
 		User:            "testUser",
 		SecretName:      "testSecretName",
 		SecretNamespace: "testSecretNamespace",
@@ -135,7 +135,7 @@ func TestSAN_deleteNamespace_success(t *testing.T) {
 	// arrange
 	ctx := context.Background()
 	clientConfig := storage.NewClientConfig{
-		Urls:            []string{"127.0.0.1"},
+		Urls:            []string{"https://127.0.0.1:8088"},
 		User:            "testUser",
 		SecretName:      "testSecretName",
 		SecretNamespace: "testSecretNamespace",
@@ -171,7 +171,7 @@ func TestSAN_expandLocalNamespace_poolNotExist(t *testing.T) {
 	// arrange
 	ctx := context.Background()
 	clientConfig := storage.NewClientConfig{
-		Urls:            []string{"127.0.0.1"},
+		Urls:            []string{"https://127.0.0.1:8088"},
 		User:            "testUser",
 		SecretName:      "testSecretName",
 		SecretNamespace: "testSecretNamespace",
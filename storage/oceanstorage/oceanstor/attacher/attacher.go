@@ -53,6 +53,8 @@ type VolumeAttacherConfig struct {
 	Invoker  string
 	Portals  []string
 	Alua     map[string]interface{}
+	// ChapSecret is the secret of the CHAP credentials of the iscsi initiators in the format of namespace/name
+	ChapSecret string
 }
 
 // NewAttacher init a new attacher
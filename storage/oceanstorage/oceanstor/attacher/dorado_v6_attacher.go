@@ -37,11 +37,12 @@ const (
 
 func newDoradoV6OrV7Attacher(config VolumeAttacherConfig) VolumeAttacherPlugin {
 	baseAttacherConfig := attacher.AttachmentManagerConfig{
-		Cli:      config.Cli,
-		Protocol: config.Protocol,
-		Invoker:  config.Invoker,
-		Portals:  config.Portals,
-		Alua:     config.Alua,
+		Cli:        config.Cli,
+		Protocol:   config.Protocol,
+		Invoker:    config.Invoker,
+		Portals:    config.Portals,
+		Alua:       config.Alua,
+		ChapSecret: config.ChapSecret,
 	}
 	baseAttacher := attacher.NewAttachmentManager(baseAttacherConfig)
 
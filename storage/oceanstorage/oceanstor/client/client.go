@@ -22,10 +22,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
+	"maps"
 	"net/http"
 	"regexp"
+	"slices"
+	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
@@ -45,10 +48,40 @@ const (
 
 	// UrlNotFound defines error msg of url not found
 	UrlNotFound = "404_NotFound"
+
+	// DefaultSystemCacheTTL defines default cache duration of the system info
+	DefaultSystemCacheTTL = 5 * time.Second
+
+	// DefaultMaxResponseBodySize defines default maximum bytes of the response body
+	DefaultMaxResponseBodySize int64 = 8 * 1024 * 1024
+
+	// DefaultRetryBudgetRefillInterval defines default interval to refill a retry token
+	DefaultRetryBudgetRefillInterval = time.Second
+)
+
+var (
+	// ErrResponseTooLarge indicates an error that the response body exceeds the limit
+	ErrResponseTooLarge = errors.New("response too large")
+	// ErrRetryBudgetExhausted indicates an error that the retry budget of the backend is depleted
+	ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+	// ErrDeviceSNMismatch indicates a fatal error that the urls point to another device than the one of the first login
+	ErrDeviceSNMismatch = errors.New("device sn mismatch")
+	// ErrObjectNotFound indicates the object to operate does not exist on the storage
+	ErrObjectNotFound = errors.New("object not found")
+	// ErrLoginUnreachable indicates the login failed because none of the urls could be connected
+	ErrLoginUnreachable = storage.ErrLoginUnreachable
+	// ErrLoginRejected indicates the storage rejected the credentials, e.g. wrong password or locked account
+	ErrLoginRejected = storage.ErrLoginRejected
 )
 
 const (
 	description string = "Created from huawei-csi for Kubernetes"
+
+	// sessionTimeoutField is the field of the login request and response for the idle timeout of the session in seconds
+	sessionTimeoutField = "sessionTimeout"
+
+	// sessionRefreshDivisor makes the idle session refreshed in the last 1/sessionRefreshDivisor of its timeout
+	sessionRefreshDivisor = 10
 )
 
 // OceanstorClientInterface defines interfaces for base client operations
@@ -75,20 +108,46 @@ type OceanstorClientInterface interface {
 	DTree
 	OceanStorQuota
 	LIF
+	Session
+	Protocol
+	Alarm
 
 	SafeCall(ctx context.Context, method string, url string, data map[string]interface{}) (base.Response, error)
 	SafeBaseCall(ctx context.Context, method string, url string, data map[string]interface{}) (base.Response, error)
 	SafeDelete(ctx context.Context, url string, data map[string]interface{}) (base.Response, error)
+	CallStream(ctx context.Context, method string, url string, data map[string]interface{},
+		handler StreamHandler) (base.Response, error)
+	DeleteIfExists(ctx context.Context, url string, data map[string]interface{}) error
 	DuplicateClient() *OceanstorClient
 
 	GetBackendID() string
 	GetDeviceSN() string
 	GetStorageVersion() string
 	GetCurrentSiteWwn() string
+	GetActiveURL() string
 	SetSystemInfo(ctx context.Context) error
+	GetSystemInfo(ctx context.Context) (*StorageSystemInfo, error)
+	GetMaxNameLength(objType string) (int, error)
+	Canary(ctx context.Context, param *CanaryParam) *CanaryReport
+	InvalidatePoolCache()
+	IsTokenValid(ctx context.Context) (bool, error)
 }
 
 var (
+	objectNotExistCodes = map[int64]struct{}{
+		objectNotExist:      {},
+		lunNotExist:         {},
+		lunSnapshotNotExist: {},
+		filesystemNotExist:  {},
+		fsSnapshotNotExist:  {},
+		shareNotExist:       {},
+		clonePairNotExist:   {},
+		hyperMetroNotExist:  {},
+		lunCopyNotExist:     {},
+		replicationNotExist: {},
+		dtreeNotExist:       {},
+	}
+
 	filterLog = map[string]map[string]bool{
 		"POST": {
 			"/xx/sessions": true,
@@ -96,6 +155,10 @@ var (
 	}
 
 	filterLogRegex = map[string][]string{
+		// the CHAP credentials of the iscsi initiator are not logged
+		"PUT": {
+			`/iscsi_initiator/`,
+		},
 		"GET": {
 			`/vstore_pair\?filter=ID`,
 			`/FsHyperMetroDomain\?RUNNINGSTATUS=0`,
@@ -124,6 +187,11 @@ var (
 	}
 )
 
+func init() {
+	utils.RegisterErrorCodes("ObjectNotExist", "the object does not exist",
+		slices.Collect(maps.Keys(objectNotExistCodes))...)
+}
+
 func isFilterLog(method, url string) bool {
 	if filter, exist := filterLog[method]; exist && filter[url] {
 		return true
@@ -141,6 +209,12 @@ func isFilterLog(method, url string) bool {
 	return false
 }
 
+// isDebugLog returns whether the request and the response of the url are logged at debug level,
+// all of them are logged at info level if the verbose log of the client is enabled
+func (cli *RestClient) isDebugLog(method, url string) bool {
+	return !cli.VerboseLog && utils.IsDebugLog(method, url, debugLog, debugLogRegex)
+}
+
 // OceanstorClient implements OceanstorClientInterface
 type OceanstorClient struct {
 	*base.ApplicationTypeClient
@@ -165,12 +239,47 @@ type NewClientConfig struct {
 	SecretNamespace    string
 	VstoreName         string
 	ParallelNum        string
+	TenantParallelNum  string
 	BackendID          string
 	UseCert            bool
 	CertSecretMeta     string
 	Storage            string
 	Name               string
 	AuthenticationMode string
+	// LoginScope overrides the scope of the auth info in the secret when login if it is not empty
+	LoginScope string
+	// CredentialsPath is the directory of the mounted secret, the k8s Secret API is used if it is empty
+	CredentialsPath string
+	// CASecretMeta is the secret of the CA bundle that the server certificate is verified against
+	CASecretMeta string
+	// ProxyURL is the proxy the requests are routed through, no proxy is used if it is empty
+	ProxyURL string
+	// Headers are the static headers added to every request
+	Headers map[string]string
+	// Transport is the idle connection and TCP keepalive settings of the http transport
+	Transport storage.TransportConfig
+	// LoginTimeout bounds the total time of the login attempts across the urls, it is not bounded if not positive
+	LoginTimeout time.Duration
+	// SessionTimeout is the idle timeout of the login session requested from the storage,
+	// the default of the storage is used if it is not positive
+	SessionTimeout time.Duration
+	// VerboseLog logs the bodies of all the requests and responses at info level, except the login ones
+	VerboseLog bool
+	// SystemCacheTTL is the cache duration of the system info, DefaultSystemCacheTTL is used if it is zero
+	SystemCacheTTL time.Duration
+	// PoolCacheTTL is the cache duration of the pools, the cache is disabled if it is not positive
+	PoolCacheTTL time.Duration
+	// MaxResponseBodySize is the maximum bytes of the response body, DefaultMaxResponseBodySize is used if it is zero
+	MaxResponseBodySize int64
+	// RetryBudget is the retries allowed in a burst, the retries are not limited if it is not positive
+	RetryBudget int
+	// RetryBudgetRefillInterval is the interval to refill a retry token,
+	// DefaultRetryBudgetRefillInterval is used if it is not positive
+	RetryBudgetRefillInterval time.Duration
+	// RateLimit is the requests allowed per second, the rate is not limited if it is not positive
+	RateLimit float64
+	// RateBurst is the requests allowed at once when the rate is limited, 1 is used if it is not positive
+	RateBurst int
 }
 
 // NewClient inits a new oceanstor client
@@ -209,6 +318,12 @@ func (cli *OceanstorClient) SafeCall(ctx context.Context,
 
 	// Current connection fails, try to relogin to other Urls if exist,
 	// if relogin success, resend the request again.
+	if !cli.RetryBudget.TryAcquire() {
+		log.AddContext(ctx).Warningf("Retry budget of backend %s is exhausted, skip re-login and resend request "+
+			"method: %s, Url: %s", cli.BackendID, method, url)
+		return r, fmt.Errorf("%w, request method: %s, url: %s", ErrRetryBudgetExhausted, method, url)
+	}
+
 	log.AddContext(ctx).Infof("Try to re-login and resend request method: %s, Url: %s", method, url)
 	err = cli.ReLogin(ctx)
 	if err != nil {
@@ -224,8 +339,18 @@ func (cli *OceanstorClient) SafeCall(ctx context.Context,
 	return cli.SafeBaseCall(ctx, method, url, data)
 }
 
-// SafeBaseCall provides base call for request
+// SafeBaseCall provides base call for request, the request is traced in a span named by the method and the url
 func (cli *OceanstorClient) SafeBaseCall(ctx context.Context,
+	method string,
+	url string,
+	data map[string]interface{}) (base.Response, error) {
+	ctx, span := storage.StartRequestSpan(ctx, method, url, cli.BackendID, cli.GetDeviceSN())
+	r, err := cli.safeBaseCall(ctx, method, url, data)
+	base.EndRequestSpan(span, r, err)
+	return r, err
+}
+
+func (cli *OceanstorClient) safeBaseCall(ctx context.Context,
 	method string,
 	url string,
 	data map[string]interface{}) (base.Response, error) {
@@ -252,17 +377,27 @@ func (cli *OceanstorClient) SafeBaseCall(ctx context.Context,
 		return base.Response{}, fmt.Errorf("get request failed, error: %w", err)
 	}
 
-	log.FilteredLog(ctx, isFilterLog(method, url), utils.IsDebugLog(method, url, debugLog, debugLogRegex),
+	log.FilteredLog(ctx, isFilterLog(method, url), cli.isDebugLog(method, url),
 		fmt.Sprintf("Request method: %s, Url: %s, body: %v", method, req.URL, data))
 
+	tenant := utils.GetTenant(ctx)
+	if err = cli.TenantSemaphore.AcquireCtx(ctx, tenant); err != nil {
+		return base.Response{}, fmt.Errorf("acquire tenant semaphore failed, error: %w", err)
+	}
+	defer cli.TenantSemaphore.Release(tenant)
+
 	if cli.RequestSemaphore != nil {
-		cli.RequestSemaphore.Acquire()
+		if err = cli.RequestSemaphore.AcquireCtx(ctx); err != nil {
+			return base.Response{}, fmt.Errorf("acquire request semaphore failed, error: %w", err)
+		}
 		defer cli.RequestSemaphore.Release()
 	}
 
-	if storage.RequestSemaphoreMap[cli.GetDeviceSN()] != nil {
-		storage.RequestSemaphoreMap[cli.GetDeviceSN()].Acquire()
-		defer storage.RequestSemaphoreMap[cli.GetDeviceSN()].Release()
+	if storageSemaphore := storage.GetRequestSemaphore(cli.GetDeviceSN()); storageSemaphore != nil {
+		if err = storageSemaphore.AcquireCtx(ctx); err != nil {
+			return base.Response{}, fmt.Errorf("acquire storage semaphore failed, error: %w", err)
+		}
+		defer storageSemaphore.Release()
 	}
 
 	return cli.safeDoCall(ctx, method, url, req)
@@ -298,20 +433,25 @@ func (cli *OceanstorClient) safeDoCall(ctx context.Context,
 		}
 	}()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := cli.readResponseBody(resp.Body)
 	if err != nil {
 		return base.Response{}, fmt.Errorf("read response data error: %w", err)
 	}
 
-	log.FilteredLog(ctx, isFilterLog(method, url), utils.IsDebugLog(method, url, debugLog, debugLogRegex),
+	log.FilteredLog(ctx, isFilterLog(method, url), cli.isDebugLog(method, url),
 		fmt.Sprintf("base.Response method: %s, Url: %s, body: %s", method, req.URL, body))
 
+	if err = storage.CheckJSONResponse(resp, body); err != nil {
+		return base.Response{StatusCode: resp.StatusCode}, err
+	}
+
 	var r base.Response
 	err = json.Unmarshal(body, &r)
 	if err != nil {
-		return base.Response{}, fmt.Errorf("json.Unmarshal data %s error: %w", body, err)
+		return base.Response{StatusCode: resp.StatusCode}, fmt.Errorf("json.Unmarshal data %s error: %w", body, err)
 	}
 
+	r.StatusCode = resp.StatusCode
 	return r, nil
 }
 
@@ -321,6 +461,81 @@ func (cli *OceanstorClient) SafeDelete(ctx context.Context,
 	return cli.SafeCall(ctx, "DELETE", url, data)
 }
 
+// DeleteIfExists deletes the object of the url, the object which has already been deleted is treated as success
+func (cli *OceanstorClient) DeleteIfExists(ctx context.Context, url string, data map[string]interface{}) error {
+	resp, err := cli.SafeDelete(ctx, url, data)
+	if isObjectNotFound(resp) {
+		log.AddContext(ctx).Infof("Object %s does not exist while deleting", url)
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("delete %s error: %w", url, err)
+	}
+
+	code, ok := resp.Error["code"].(float64)
+	if !ok {
+		return fmt.Errorf("delete %s error: invalid response %v", url, resp.Error)
+	}
+
+	if int64(code) != 0 {
+		return utils.Errorf(ctx, "delete %s error: %d", url, int64(code))
+	}
+
+	return nil
+}
+
+// objectNamePattern is the charset of the object names accepted by the storage
+var objectNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// renameObject modifies the name of the object of the url, ErrObjectNotFound is returned if the object is gone
+func (cli *OceanstorClient) renameObject(ctx context.Context, url, newName string, maxNameLength int) error {
+	if len(newName) == 0 || len(newName) > maxNameLength {
+		return fmt.Errorf("the length of name %s must be between 1 and %d", newName, maxNameLength)
+	}
+	if !objectNamePattern.MatchString(newName) {
+		return fmt.Errorf("name %s can only contain letters, digits, underscores, hyphens and periods", newName)
+	}
+
+	resp, err := cli.Put(ctx, url, map[string]interface{}{"NAME": newName})
+	if err != nil {
+		return err
+	}
+
+	if isObjectNotFound(resp) {
+		return fmt.Errorf("rename %s to %s error: %w", url, newName, ErrObjectNotFound)
+	}
+
+	code, ok := resp.Error["code"].(float64)
+	if !ok {
+		return fmt.Errorf("rename %s to %s error: invalid response %v", url, newName, resp.Error)
+	}
+	if int64(code) != 0 {
+		return fmt.Errorf("rename %s to %s error: %d", url, newName, int64(code))
+	}
+
+	log.AddContext(ctx).Infof("Rename %s to %s successfully", url, newName)
+	return nil
+}
+
+func isObjectNotFound(resp base.Response) bool {
+	if resp.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	if description, ok := resp.Error["description"].(string); ok && strings.Contains(description, UrlNotFound) {
+		return true
+	}
+
+	code, ok := resp.Error["code"].(float64)
+	if !ok {
+		return false
+	}
+
+	_, exist := objectNotExistCodes[int64(code)]
+	return exist
+}
+
 // DuplicateClient clone a base client from origin client
 func (cli *OceanstorClient) DuplicateClient() *OceanstorClient {
 	dup := *cli
@@ -333,6 +548,35 @@ func (cli *OceanstorClient) DuplicateClient() *OceanstorClient {
 	return &dup
 }
 
+// IsTokenValid checks whether the token of the current session is still accepted by the storage,
+// neither re-login is triggered nor a new session is created
+func (cli *OceanstorClient) IsTokenValid(ctx context.Context) (bool, error) {
+	if cli.Token == "" {
+		return false, nil
+	}
+
+	resp, err := cli.SafeBaseCall(ctx, "GET", "/system/", nil)
+	if isTokenRejected(resp) {
+		log.AddContext(ctx).Infof("The token of backend %s is no longer accepted", cli.BackendID)
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("check token of backend %s failed, error: %w", cli.BackendID, err)
+	}
+
+	return true, nil
+}
+
+func isTokenRejected(resp base.Response) bool {
+	if resp.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+
+	code, ok := resp.Error["code"].(float64)
+	return ok && (int64(code) == storage.UserUnauthorized || int64(code) == storage.UserOffline)
+}
+
 func (cli *OceanstorClient) getResponseDataMap(ctx context.Context, data interface{}) (map[string]interface{}, error) {
 	respData, ok := data.(map[string]interface{})
 	if !ok {
@@ -378,3 +622,13 @@ func (cli *OceanstorClient) getObjByvStoreName(objList []interface{}) map[string
 func (cli *OceanstorClient) systemInfoRefreshing() bool {
 	return atomic.LoadUint32(&cli.SystemInfoRefreshing) == 1
 }
+
+// respStorageError returns the StorageError of the response, so that the suggestion of the storage is kept
+func respStorageError(resp base.Response) error {
+	storageErr, err := utils.ParseRespErr(resp.Error)
+	if err != nil {
+		return err
+	}
+
+	return storageErr
+}
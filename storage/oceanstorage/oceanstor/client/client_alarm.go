@@ -0,0 +1,153 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+// AlarmSeverity is the level of the alarm reported by the storage, a greater value is more severe
+type AlarmSeverity int
+
+const (
+	// AlarmSeverityInfo defines the informational alarm
+	AlarmSeverityInfo AlarmSeverity = 1
+	// AlarmSeverityWarning defines the warning alarm
+	AlarmSeverityWarning AlarmSeverity = 2
+	// AlarmSeverityMajor defines the major alarm
+	AlarmSeverityMajor AlarmSeverity = 3
+	// AlarmSeverityCritical defines the critical alarm
+	AlarmSeverityCritical AlarmSeverity = 4
+)
+
+var alarmSeverityNames = map[AlarmSeverity]string{
+	AlarmSeverityInfo:     "info",
+	AlarmSeverityWarning:  "warning",
+	AlarmSeverityMajor:    "major",
+	AlarmSeverityCritical: "critical",
+}
+
+// String returns the name of the severity
+func (s AlarmSeverity) String() string {
+	if name, ok := alarmSeverityNames[s]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// AlarmInfo holds the information of an active alarm of the storage
+type AlarmInfo struct {
+	ID          string
+	Name        string
+	Severity    AlarmSeverity
+	Description string
+	Location    string
+}
+
+// String returns the brief of the alarm, e.g. "[major] The storage pool is degraded: disk failure"
+func (a AlarmInfo) String() string {
+	if a.Description == "" {
+		return fmt.Sprintf("[%s] %s", a.Severity, a.Name)
+	}
+
+	return fmt.Sprintf("[%s] %s: %s", a.Severity, a.Name, a.Description)
+}
+
+// Alarm defines interfaces for alarm operations
+type Alarm interface {
+	// GetActiveAlarms used for get the current alarms of the storage
+	GetActiveAlarms(ctx context.Context) ([]AlarmInfo, error)
+}
+
+// GetActiveAlarms used for get the current alarms of the storage, the cleared alarms are not included
+func (cli *OceanstorClient) GetActiveAlarms(ctx context.Context) ([]AlarmInfo, error) {
+	var alarms []AlarmInfo
+	for start := 0; ; start += storage.QueryCountPerBatch {
+		url := fmt.Sprintf("/alarm/currentalarm?range=[%d-%d]", start, start+storage.QueryCountPerBatch)
+		resp, err := cli.Get(ctx, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.AssertErrorCode(); err != nil {
+			return nil, err
+		}
+
+		if resp.Data == nil {
+			return alarms, nil
+		}
+
+		respData, ok := resp.Data.([]interface{})
+		if !ok {
+			return nil, pkgUtils.Errorf(ctx, "convert alarms to arr failed, data: %v", resp.Data)
+		}
+
+		for _, item := range respData {
+			data, ok := item.(map[string]interface{})
+			if !ok {
+				log.AddContext(ctx).Warningf("convert alarm %v to map failed", item)
+				continue
+			}
+
+			alarms = append(alarms, parseAlarmInfo(data))
+		}
+
+		if len(respData) < storage.QueryCountPerBatch {
+			return alarms, nil
+		}
+	}
+}
+
+func parseAlarmInfo(data map[string]interface{}) AlarmInfo {
+	alarm := AlarmInfo{}
+	alarm.ID, _ = data["sequence"].(string)
+	alarm.Name, _ = data["name"].(string)
+	alarm.Description, _ = data["description"].(string)
+	alarm.Location, _ = data["location"].(string)
+	switch level := data["level"].(type) {
+	case string:
+		if value, err := strconv.Atoi(level); err == nil {
+			alarm.Severity = AlarmSeverity(value)
+		}
+	case float64:
+		alarm.Severity = AlarmSeverity(level)
+	}
+
+	return alarm
+}
+
+// MostSevereAlarm returns the most severe alarm whose severity is not lower than the minSeverity,
+// the first one is returned if several alarms are equally severe, and nil is returned if no alarm matches
+func MostSevereAlarm(alarms []AlarmInfo, minSeverity AlarmSeverity) *AlarmInfo {
+	var result *AlarmInfo
+	for i := range alarms {
+		if alarms[i].Severity < minSeverity {
+			continue
+		}
+		if result == nil || alarms[i].Severity > result.Severity {
+			result = &alarms[i]
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+)
+
+func TestOceanstorClient_GetActiveAlarms(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	alarms := []interface{}{
+		map[string]interface{}{"sequence": "1", "name": "Storage Pool Is Degraded", "level": "4",
+			"description": "disk failure", "location": "pool0"},
+		map[string]interface{}{"sequence": "2", "name": "Disk Is Removed", "level": float64(2)},
+	}
+	var url string
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli.RestClient, "Get", func(_ *RestClient, _ context.Context, u string,
+		_ map[string]interface{}) (base.Response, error) {
+		url = u
+		return base.Response{Error: map[string]interface{}{"code": float64(0)}, Data: alarms}, nil
+	})
+	defer patches.Reset()
+
+	// action
+	got, err := cli.GetActiveAlarms(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "/alarm/currentalarm?range=[0-100]", url)
+	require.Equal(t, []AlarmInfo{
+		{ID: "1", Name: "Storage Pool Is Degraded", Severity: AlarmSeverityCritical, Description: "disk failure",
+			Location: "pool0"},
+		{ID: "2", Name: "Disk Is Removed", Severity: AlarmSeverityWarning},
+	}, got)
+}
+
+func TestOceanstorClient_GetActiveAlarms_NoAlarm(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get",
+		base.Response{Error: map[string]interface{}{"code": float64(0)}}, nil)
+	defer patches.Reset()
+
+	// action
+	got, err := cli.GetActiveAlarms(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestMostSevereAlarm(t *testing.T) {
+	// arrange
+	alarms := []AlarmInfo{
+		{ID: "1", Severity: AlarmSeverityWarning},
+		{ID: "2", Severity: AlarmSeverityMajor},
+		{ID: "3", Severity: AlarmSeverityMajor},
+	}
+
+	// action
+	major := MostSevereAlarm(alarms, AlarmSeverityMajor)
+	critical := MostSevereAlarm(alarms, AlarmSeverityCritical)
+
+	// assert
+	require.Equal(t, "2", major.ID)
+	require.Nil(t, critical)
+}
@@ -0,0 +1,90 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+type applicationTypeCacheEntry struct {
+	id       string
+	expireAt time.Time
+}
+
+var (
+	// applicationTypeCacheTTL is the duration that a found application type id is reused by the following creates
+	applicationTypeCacheTTL = 10 * time.Minute
+
+	// applicationTypeCache stores the application type ids of each storage device, keyed by the device sn and
+	// then the application type name
+	applicationTypeCache      = map[string]map[string]applicationTypeCacheEntry{}
+	applicationTypeCacheMutex sync.Mutex
+)
+
+// GetApplicationTypeByName used for get the application type id by name, the id is reused from the cache of the
+// storage device within applicationTypeCacheTTL, the types that do not exist are not cached so that they can be
+// found once created
+func (cli *OceanstorClient) GetApplicationTypeByName(ctx context.Context, appType string) (string, error) {
+	deviceSN := cli.GetDeviceSN()
+	if deviceSN == "" {
+		return cli.ApplicationTypeClient.GetApplicationTypeByName(ctx, appType)
+	}
+
+	if id, ok := getCachedApplicationTypeID(deviceSN, appType); ok {
+		log.AddContext(ctx).Debugf("Get application type %s of device %s from cache", appType, deviceSN)
+		return id, nil
+	}
+
+	id, err := cli.ApplicationTypeClient.GetApplicationTypeByName(ctx, appType)
+	if err != nil || id == "" {
+		return id, err
+	}
+
+	applicationTypeCacheMutex.Lock()
+	defer applicationTypeCacheMutex.Unlock()
+	if _, ok := applicationTypeCache[deviceSN]; !ok {
+		applicationTypeCache[deviceSN] = map[string]applicationTypeCacheEntry{}
+	}
+	applicationTypeCache[deviceSN][appType] = applicationTypeCacheEntry{
+		id:       id,
+		expireAt: time.Now().Add(applicationTypeCacheTTL),
+	}
+	return id, nil
+}
+
+func getCachedApplicationTypeID(deviceSN, appType string) (string, bool) {
+	applicationTypeCacheMutex.Lock()
+	defer applicationTypeCacheMutex.Unlock()
+	entry, ok := applicationTypeCache[deviceSN][appType]
+	if !ok || !time.Now().Before(entry.expireAt) {
+		return "", false
+	}
+
+	return entry.id, true
+}
+
+// invalidateApplicationTypeCache drops the cached application type ids of the storage device,
+// the types may be changed after relogin, e.g. the storage is upgraded
+func invalidateApplicationTypeCache(deviceSN string) {
+	applicationTypeCacheMutex.Lock()
+	defer applicationTypeCacheMutex.Unlock()
+	delete(applicationTypeCache, deviceSN)
+}
@@ -0,0 +1,136 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+)
+
+func mockApplicationTypeCli(t *testing.T, deviceSN string) *OceanstorClient {
+	t.Helper()
+	restClient, _ := NewRestClient(context.Background(), &NewClientConfig{})
+	restClient.DeviceId = deviceSN
+	t.Cleanup(func() { invalidateApplicationTypeCache(deviceSN) })
+	return &OceanstorClient{
+		RestClient:            restClient,
+		ApplicationTypeClient: &base.ApplicationTypeClient{RestClientInterface: restClient},
+	}
+}
+
+func mockGetApplicationType(cli *OceanstorClient, id string, calls *int) *gomonkey.Patches {
+	return gomonkey.ApplyMethod(cli.ApplicationTypeClient, "GetApplicationTypeByName",
+		func(_ *base.ApplicationTypeClient, _ context.Context, _ string) (string, error) {
+			*calls++
+			return id, nil
+		})
+}
+
+func TestOceanstorClient_GetApplicationTypeByName_CacheHit(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockApplicationTypeCli(t, "sn-app-type-hit")
+	other := mockApplicationTypeCli(t, "sn-app-type-other")
+	calls := 0
+
+	// mock
+	patches := mockGetApplicationType(cli, "6", &calls)
+	defer patches.Reset()
+
+	// act
+	first, firstErr := cli.GetApplicationTypeByName(ctx, "Oracle_OLAP")
+	second, secondErr := cli.GetApplicationTypeByName(ctx, "Oracle_OLAP")
+	_, otherErr := other.GetApplicationTypeByName(ctx, "Oracle_OLAP")
+
+	// assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.NoError(t, otherErr)
+	require.Equal(t, "6", first)
+	require.Equal(t, "6", second)
+	require.Equal(t, 2, calls)
+}
+
+func TestOceanstorClient_GetApplicationTypeByName_NotExistNotCached(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockApplicationTypeCli(t, "sn-app-type-miss")
+	calls := 0
+
+	// mock
+	patches := mockGetApplicationType(cli, "", &calls)
+	defer patches.Reset()
+
+	// act
+	_, _ = cli.GetApplicationTypeByName(ctx, "unknown")
+	id, err := cli.GetApplicationTypeByName(ctx, "unknown")
+
+	// assert
+	require.NoError(t, err)
+	require.Empty(t, id)
+	require.Equal(t, 2, calls)
+}
+
+func TestOceanstorClient_GetApplicationTypeByName_Expired(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockApplicationTypeCli(t, "sn-app-type-expired")
+	calls := 0
+
+	// mock
+	patches := mockGetApplicationType(cli, "6", &calls)
+	defer patches.Reset()
+	patches.ApplyGlobalVar(&applicationTypeCacheTTL, time.Duration(0))
+
+	// act
+	_, _ = cli.GetApplicationTypeByName(ctx, "Oracle_OLAP")
+	id, err := cli.GetApplicationTypeByName(ctx, "Oracle_OLAP")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "6", id)
+	require.Equal(t, 2, calls)
+}
+
+func TestOceanstorClient_GetApplicationTypeByName_InvalidatedByReLogin(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockApplicationTypeCli(t, "sn-app-type-relogin")
+	calls := 0
+
+	// mock
+	patches := mockGetApplicationType(cli, "6", &calls)
+	defer patches.Reset()
+	patches.ApplyMethodReturn(cli.RestClient, "Login", nil)
+
+	// act
+	_, _ = cli.GetApplicationTypeByName(ctx, "Oracle_OLAP")
+	reLoginErr := cli.ReLogin(ctx)
+	id, err := cli.GetApplicationTypeByName(ctx, "Oracle_OLAP")
+
+	// assert
+	require.NoError(t, reLoginErr)
+	require.NoError(t, err)
+	require.Equal(t, "6", id)
+	require.Equal(t, 2, calls)
+}
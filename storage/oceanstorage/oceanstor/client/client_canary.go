@@ -0,0 +1,189 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+const (
+	// CanaryTypeLun creates a lun in the canary
+	CanaryTypeLun = "lun"
+	// CanaryTypeFilesystem creates a filesystem in the canary
+	CanaryTypeFilesystem = "filesystem"
+
+	// CanaryStepCreate is the step to create the canary object
+	CanaryStepCreate = "create"
+	// CanaryStepMap is the step to add the canary lun to the lun group
+	CanaryStepMap = "map"
+	// CanaryStepUnmap is the step to remove the canary lun from the lun group
+	CanaryStepUnmap = "unmap"
+	// CanaryStepDelete is the step to delete the canary object
+	CanaryStepDelete = "delete"
+
+	canaryNamePrefix  = "csi_canary_"
+	canaryDescription = "Canary created from huawei-csi, safe to delete"
+	// canaryCapacity is 1GiB in sectors
+	canaryCapacity  int64 = 2097152
+	canaryAllocType       = 1
+)
+
+// CanaryParam holds the parameters of the synthetic provisioning probe
+type CanaryParam struct {
+	// Type is CanaryTypeLun or CanaryTypeFilesystem
+	Type     string
+	PoolID   string
+	VStoreID string
+	// LunGroupID is optional, the canary lun is added to and removed from the lun group if it is set
+	LunGroupID string
+}
+
+// CanaryStepResult holds the result of a step of the canary
+type CanaryStepResult struct {
+	Step string
+	Err  error
+}
+
+// CanaryReport holds the result of the synthetic provisioning probe
+type CanaryReport struct {
+	Type       string
+	ObjectName string
+	ObjectID   string
+	Steps      []*CanaryStepResult
+}
+
+// Passed returns whether all the steps of the canary succeeded
+func (r *CanaryReport) Passed() bool {
+	return r.Err() == nil
+}
+
+// Err returns the joined errors of the failed steps, nil means the canary passed
+func (r *CanaryReport) Err() error {
+	var errs []error
+	for _, step := range r.Steps {
+		if step.Err != nil {
+			errs = append(errs, fmt.Errorf("canary %s %s failed: %w", r.Type, step.Step, step.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *CanaryReport) record(step string, err error) {
+	r.Steps = append(r.Steps, &CanaryStepResult{Step: step, Err: err})
+}
+
+// Canary creates a tiny lun or filesystem in the pool, optionally maps and unmaps the lun, then deletes it,
+// so that the provisioning failures such as the pool or permission issues can be found before a real volume
+// is created. The canary object is always cleaned up, even if the creation fails partially.
+func (cli *OceanstorClient) Canary(ctx context.Context, param *CanaryParam) *CanaryReport {
+	report := &CanaryReport{
+		Type:       param.Type,
+		ObjectName: fmt.Sprintf("%s%d", canaryNamePrefix, time.Now().UnixNano()),
+	}
+
+	switch param.Type {
+	case CanaryTypeLun:
+		cli.lunCanary(ctx, param, report)
+	case CanaryTypeFilesystem:
+		cli.filesystemCanary(ctx, param, report)
+	default:
+		report.record(CanaryStepCreate, fmt.Errorf("unsupported canary type %s", param.Type))
+	}
+
+	if report.Passed() {
+		log.AddContext(ctx).Infof("Canary %s %s of backend %s passed", report.Type, report.ObjectName, cli.BackendID)
+	} else {
+		log.AddContext(ctx).Warningf("Canary %s %s of backend %s failed, error: %v",
+			report.Type, report.ObjectName, cli.BackendID, report.Err())
+	}
+
+	return report
+}
+
+func (cli *OceanstorClient) lunCanary(ctx context.Context, param *CanaryParam, report *CanaryReport) {
+	lun, err := cli.CreateLun(ctx, map[string]any{
+		"name":        report.ObjectName,
+		"parentid":    param.PoolID,
+		"capacity":    canaryCapacity,
+		"description": canaryDescription,
+		"alloctype":   canaryAllocType,
+	})
+	report.record(CanaryStepCreate, err)
+	if err != nil {
+		// the lun may have been created even though the request failed, e.g. the request timed out
+		lun, err = cli.GetLunByName(ctx, report.ObjectName)
+		if err != nil {
+			report.record(CanaryStepDelete, fmt.Errorf("query the canary for cleanup failed: %w", err))
+		}
+		if err != nil || lun == nil {
+			return
+		}
+	}
+
+	report.ObjectID, _ = lun["ID"].(string)
+	defer func() {
+		report.record(CanaryStepDelete, cli.DeleteLun(ctx, report.ObjectID))
+	}()
+
+	if param.LunGroupID == "" || !report.Passed() {
+		return
+	}
+
+	err = cli.AddLunToGroup(ctx, report.ObjectID, param.LunGroupID)
+	report.record(CanaryStepMap, err)
+	// the lun may have been added to the group even though the request failed, so always try to remove it
+	report.record(CanaryStepUnmap, cli.RemoveLunFromGroup(ctx, report.ObjectID, param.LunGroupID))
+}
+
+func (cli *OceanstorClient) filesystemCanary(ctx context.Context, param *CanaryParam, report *CanaryReport) {
+	data := map[string]any{
+		"NAME":        report.ObjectName,
+		"PARENTID":    param.PoolID,
+		"CAPACITY":    canaryCapacity,
+		"DESCRIPTION": canaryDescription,
+		"ALLOCTYPE":   canaryAllocType,
+	}
+	if param.VStoreID != "" {
+		data["vstoreId"] = param.VStoreID
+	}
+
+	fs, err := cli.CreateFileSystem(ctx, data)
+	report.record(CanaryStepCreate, err)
+	if err != nil {
+		// the filesystem may have been created even though the request failed, e.g. the request timed out
+		fs, err = cli.GetFileSystemByName(ctx, report.ObjectName)
+		if err != nil {
+			report.record(CanaryStepDelete, fmt.Errorf("query the canary for cleanup failed: %w", err))
+		}
+		if err != nil || fs == nil {
+			return
+		}
+	}
+
+	report.ObjectID, _ = fs["ID"].(string)
+	params := map[string]any{"ID": report.ObjectID}
+	if param.VStoreID != "" {
+		params["vstoreId"] = param.VStoreID
+	}
+	report.record(CanaryStepDelete, cli.SafeDeleteFileSystem(ctx, params))
+}
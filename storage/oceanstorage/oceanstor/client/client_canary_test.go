@@ -0,0 +1,114 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/client"
+)
+
+func TestOceanstorClient_Canary_LunSuccess(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockCli()
+	var steps []string
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli, "CreateLun", map[string]any{"ID": "1"}, nil).
+		ApplyMethod(cli, "AddLunToGroup", func(_ *client.OceanstorClient, _ context.Context, lunID, groupID string) error {
+			steps = append(steps, "add "+lunID+" to "+groupID)
+			return nil
+		}).
+		ApplyMethod(cli, "RemoveLunFromGroup",
+			func(_ *client.OceanstorClient, _ context.Context, lunID, groupID string) error {
+				steps = append(steps, "remove "+lunID+" from "+groupID)
+				return nil
+			}).
+		ApplyMethod(cli, "DeleteLun", func(_ *client.OceanstorClient, _ context.Context, id string) error {
+			steps = append(steps, "delete "+id)
+			return nil
+		})
+	defer patches.Reset()
+
+	// act
+	report := cli.Canary(ctx, &client.CanaryParam{Type: client.CanaryTypeLun, PoolID: "0", LunGroupID: "2"})
+
+	// assert
+	require.True(t, report.Passed())
+	require.Equal(t, "1", report.ObjectID)
+	require.Equal(t, []string{"add 1 to 2", "remove 1 from 2", "delete 1"}, steps)
+}
+
+func TestOceanstorClient_Canary_LunCreateFailedCleanup(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockCli()
+	var deleted []string
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli, "CreateLun", nil, assert.AnError).
+		ApplyMethodReturn(cli, "GetLunByName", map[string]any{"ID": "1"}, nil).
+		ApplyMethod(cli, "DeleteLun", func(_ *client.OceanstorClient, _ context.Context, id string) error {
+			deleted = append(deleted, id)
+			return nil
+		})
+	defer patches.Reset()
+
+	// act
+	report := cli.Canary(ctx, &client.CanaryParam{Type: client.CanaryTypeLun, PoolID: "0", LunGroupID: "2"})
+
+	// assert
+	require.False(t, report.Passed())
+	require.ErrorIs(t, report.Err(), assert.AnError)
+	require.ErrorContains(t, report.Err(), "canary lun create failed")
+	require.Equal(t, []string{"1"}, deleted)
+	require.Len(t, report.Steps, 2)
+	require.Equal(t, client.CanaryStepDelete, report.Steps[1].Step)
+	require.NoError(t, report.Steps[1].Err)
+}
+
+func TestOceanstorClient_Canary_FilesystemCreateFailedNothingLeft(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockCli()
+	deleteCalled := false
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli, "CreateFileSystem", nil, assert.AnError).
+		ApplyMethodReturn(cli, "GetFileSystemByName", nil, nil).
+		ApplyMethod(cli, "SafeDeleteFileSystem",
+			func(_ *client.OceanstorClient, _ context.Context, _ map[string]any) error {
+				deleteCalled = true
+				return nil
+			})
+	defer patches.Reset()
+
+	// act
+	report := cli.Canary(ctx, &client.CanaryParam{Type: client.CanaryTypeFilesystem, PoolID: "0"})
+
+	// assert
+	require.False(t, report.Passed())
+	require.ErrorContains(t, report.Err(), "canary filesystem create failed")
+	require.False(t, deleteCalled)
+	require.Len(t, report.Steps, 1)
+}
@@ -54,18 +54,8 @@ func (cli *OceanstorClient) DeleteClonePair(ctx context.Context, clonePairID str
 		"isDeleteDstLun": false,
 	}
 
-	resp, err := cli.Delete(ctx, "/clonepair", data)
-	if err != nil {
-		return err
-	}
-
-	code := int64(resp.Error["code"].(float64))
-	if code == clonePairNotExist {
-		log.AddContext(ctx).Infof("ClonePair %s does not exist while deleting", clonePairID)
-		return nil
-	}
-	if code != 0 {
-		return fmt.Errorf("Delete ClonePair %s error: %d", clonePairID, code)
+	if err := cli.DeleteIfExists(ctx, "/clonepair", data); err != nil {
+		return fmt.Errorf("delete clone pair %s failed: %w", clonePairID, err)
 	}
 
 	return nil
@@ -20,9 +20,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
 const (
@@ -36,8 +38,23 @@ const (
 	SecurityStyleUnix int = 3
 
 	dtreeNotExist = 1077955336
+
+	// maxDTreeNameLength is the maximum length of dtree name
+	maxDTreeNameLength = 255
+
+	// dTreeLicenseFeature is the license feature that the quotas of the DTrees are depended on
+	dTreeLicenseFeature = "SmartQuota"
+
+	// unlimitedQuota is the value of the quota items that are not limited
+	unlimitedQuota = "18446744073709551615"
 )
 
+// ErrDTreeNotLicensed is returned when the DTree feature is not licensed on the storage
+var ErrDTreeNotLicensed = errors.New("dtree is not licensed")
+
+// inheritedQuotaKeys are the quota items inherited by the DTree from its parent filesystem
+var inheritedQuotaKeys = []string{"SPACEHARDQUOTA", "SPACESOFTQUOTA", "FILEHARDQUOTA", "FILESOFTQUOTA"}
+
 // DTree defines interfaces for DTree operations
 type DTree interface {
 	// CreateDTree use for create a dTree
@@ -48,6 +65,8 @@ type DTree interface {
 	DeleteDTreeByID(ctx context.Context, vStoreID, dTreeID string) error
 	// DeleteDTreeByName use for delete a dTree by name
 	DeleteDTreeByName(ctx context.Context, parentName, dTreeName, vStoreID string) error
+	// CreateDTreeWithInheritedQuota use for create a dTree with the quota of its parent filesystem
+	CreateDTreeWithInheritedQuota(ctx context.Context, parentFsID, name string) (string, error)
 }
 
 // CreateDTree use for create a dTree
@@ -87,17 +106,12 @@ func (cli *OceanstorClient) GetDTreeByName(ctx context.Context,
 
 // DeleteDTreeByID use for delete a dTree
 func (cli *OceanstorClient) DeleteDTreeByID(ctx context.Context, vStoreID, dTreeID string) error {
-	url := fmt.Sprintf("/QUOTATREE")
-	resp, err := cli.Delete(ctx, url, map[string]interface{}{
+	err := cli.DeleteIfExists(ctx, "/QUOTATREE", map[string]interface{}{
 		"ID":       dTreeID,
 		"vstoreId": vStoreID,
 	})
 	if err != nil {
-		return err
-	}
-
-	if utils.ResCodeExist(resp.Error["code"]) {
-		return errors.New(fmt.Sprintf("%s", resp.Error["description"]))
+		return fmt.Errorf("delete dtree %s failed: %w", dTreeID, err)
 	}
 
 	return nil
@@ -105,19 +119,111 @@ func (cli *OceanstorClient) DeleteDTreeByID(ctx context.Context, vStoreID, dTree
 
 // DeleteDTreeByName use for delete a dTree
 func (cli *OceanstorClient) DeleteDTreeByName(ctx context.Context, parentName, dTreeName, vStoreID string) error {
-	url := fmt.Sprintf("/QUOTATREE")
-	resp, err := cli.Delete(ctx, url, map[string]interface{}{
+	err := cli.DeleteIfExists(ctx, "/QUOTATREE", map[string]interface{}{
 		"PARENTNAME": parentName,
 		"vstoreId":   vStoreID,
 		"NAME":       dTreeName,
 	})
 	if err != nil {
-		return err
+		return fmt.Errorf("delete dtree %s of %s failed: %w", dTreeName, parentName, err)
 	}
 
-	if utils.ResCodeExist(resp.Error["code"]) {
-		return errors.New(fmt.Sprintf("%s", resp.Error["description"]))
+	return nil
+}
+
+// CreateDTreeWithInheritedQuota creates a dTree under the filesystem and applies the directory quota of the
+// filesystem to it, so that the nested dTrees are limited as their parent. The dTree is created without quota
+// if the filesystem has no directory quota, and it is deleted if the quota fails to be applied.
+func (cli *OceanstorClient) CreateDTreeWithInheritedQuota(ctx context.Context,
+	parentFsID, name string) (string, error) {
+	features, err := cli.GetLicenseFeature(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get license feature failed: %w", err)
+	}
+	if !utils.IsSupportFeature(features, dTreeLicenseFeature) {
+		return "", fmt.Errorf("create dtree %s failed: %w", name, ErrDTreeNotLicensed)
 	}
 
-	return nil
+	parentFs, err := cli.GetFileSystemByID(ctx, parentFsID)
+	if err != nil {
+		return "", fmt.Errorf("get parent filesystem %s of dtree %s failed: %w", parentFsID, name, err)
+	}
+	vStoreID, _ := utils.ToStringWithFlag(parentFs["vstoreId"])
+
+	parentQuota, err := cli.getFsDirQuota(ctx, parentFsID, vStoreID)
+	if err != nil {
+		return "", err
+	}
+
+	dTree, err := cli.CreateDTree(ctx, map[string]interface{}{
+		"NAME":          name,
+		"PARENTID":      parentFsID,
+		"PARENTTYPE":    ParentTypeFS,
+		"securityStyle": SecurityStyleUnix,
+		"vstoreId":      vStoreID,
+	})
+	if err != nil {
+		return "", err
+	}
+	dTreeID, _ := utils.ToStringWithFlag(dTree["ID"])
+
+	if len(parentQuota) == 0 {
+		log.AddContext(ctx).Infof("parent filesystem %s of dtree %s has no quota to inherit", parentFsID, name)
+		return dTreeID, nil
+	}
+
+	data := map[string]interface{}{
+		"PARENTTYPE":    ParentTypeDTree,
+		"PARENTID":      dTreeID,
+		"QUOTATYPE":     QuotaTypeDir,
+		"SPACEUNITTYPE": SpaceUnitTypeBytes,
+		"vstoreId":      vStoreID,
+	}
+	for k, v := range parentQuota {
+		data[k] = v
+	}
+	if _, err = cli.CreateQuota(ctx, data); err != nil {
+		if deleteErr := cli.DeleteDTreeByID(ctx, vStoreID, dTreeID); deleteErr != nil {
+			log.AddContext(ctx).Errorf("revert dtree %s failed, error: %v", dTreeID, deleteErr)
+		}
+		return "", fmt.Errorf("inherit quota of filesystem %s for dtree %s failed: %w", parentFsID, name, err)
+	}
+
+	return dTreeID, nil
+}
+
+// getFsDirQuota returns the limited items of the directory quota of the filesystem,
+// it is empty if the filesystem has no directory quota
+func (cli *OceanstorClient) getFsDirQuota(ctx context.Context, fsID, vStoreID string) (map[string]string, error) {
+	quotas, err := cli.BatchGetQuota(ctx, map[string]interface{}{
+		"PARENTTYPE":    ParentTypeFS,
+		"PARENTID":      fsID,
+		"range":         "[0-100]",
+		"vstoreId":      vStoreID,
+		"QUERYTYPE":     "2",
+		"SPACEUNITTYPE": SpaceUnitTypeBytes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get quota of filesystem %s failed: %w", fsID, err)
+	}
+
+	result := map[string]string{}
+	for _, item := range quotas {
+		quota, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if fmt.Sprintf("%v", quota["QUOTATYPE"]) != strconv.Itoa(QuotaTypeDir) {
+			continue
+		}
+
+		for _, key := range inheritedQuotaKeys {
+			if value, _ := utils.ToStringWithFlag(quota[key]); value != "" && value != unlimitedQuota {
+				result[key] = value
+			}
+		}
+		break
+	}
+
+	return result, nil
 }
@@ -19,9 +19,13 @@ package client
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
 )
 
 func TestCreateDTree_Success(t *testing.T) {
@@ -239,3 +243,108 @@ func TestDeleteDTreeByName_Failed(t *testing.T) {
 	// assert
 	require.Error(t, err)
 }
+
+func TestCreateDTreeWithInheritedQuota_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{}
+	quotas := []interface{}{
+		map[string]interface{}{"QUOTATYPE": "2", "SPACEHARDQUOTA": "1024"},
+		map[string]interface{}{"QUOTATYPE": "1", "SPACEHARDQUOTA": "2048", "SPACESOFTQUOTA": unlimitedQuota},
+	}
+	var gotQuota map[string]interface{}
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyMethodReturn((*base.SystemClient)(nil), "GetLicenseFeature", map[string]int{"SmartQuota": 1}, nil).
+		ApplyMethodReturn((*base.FilesystemClient)(nil), "GetFileSystemByID",
+			map[string]interface{}{"ID": "1", "vstoreId": "0"}, nil).
+		ApplyMethodReturn(cli, "BatchGetQuota", quotas, nil).
+		ApplyMethodReturn(cli, "CreateDTree", map[string]interface{}{"ID": "1@4097"}, nil).
+		ApplyMethod(cli, "CreateQuota", func(_ *OceanstorClient, _ context.Context,
+			params map[string]interface{}) (map[string]interface{}, error) {
+			gotQuota = params
+			return map[string]interface{}{"ID": "q1"}, nil
+		})
+
+	// action
+	dTreeID, err := cli.CreateDTreeWithInheritedQuota(ctx, "1", "dtree1")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "1@4097", dTreeID)
+	require.Equal(t, "1@4097", gotQuota["PARENTID"])
+	require.Equal(t, ParentTypeDTree, gotQuota["PARENTTYPE"])
+	require.Equal(t, "2048", gotQuota["SPACEHARDQUOTA"])
+	require.NotContains(t, gotQuota, "SPACESOFTQUOTA")
+}
+
+func TestCreateDTreeWithInheritedQuota_NotLicensed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{}
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyMethodReturn((*base.SystemClient)(nil), "GetLicenseFeature", map[string]int{"SmartQuota": 0}, nil)
+
+	// action
+	_, err := cli.CreateDTreeWithInheritedQuota(ctx, "1", "dtree1")
+
+	// assert
+	require.ErrorIs(t, err, ErrDTreeNotLicensed)
+}
+
+func TestCreateDTreeWithInheritedQuota_ParentNotExist(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{}
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyMethodReturn((*base.SystemClient)(nil), "GetLicenseFeature", map[string]int{"SmartQuota": 1}, nil).
+		ApplyMethodReturn((*base.FilesystemClient)(nil), "GetFileSystemByID", nil, errors.New("not exist")).
+		ApplyMethod(cli, "CreateDTree", func(_ *OceanstorClient, _ context.Context,
+			_ map[string]interface{}) (map[string]interface{}, error) {
+			t.Fatal("dtree should not be created")
+			return nil, nil
+		})
+
+	// action
+	_, err := cli.CreateDTreeWithInheritedQuota(ctx, "1", "dtree1")
+
+	// assert
+	require.ErrorContains(t, err, "get parent filesystem 1")
+}
+
+func TestCreateDTreeWithInheritedQuota_RevertWhenQuotaFailed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{}
+	quotas := []interface{}{map[string]interface{}{"QUOTATYPE": "1", "SPACEHARDQUOTA": "2048"}}
+	var deletedID string
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyMethodReturn((*base.SystemClient)(nil), "GetLicenseFeature", map[string]int{"SmartQuota": 2}, nil).
+		ApplyMethodReturn((*base.FilesystemClient)(nil), "GetFileSystemByID",
+			map[string]interface{}{"ID": "1", "vstoreId": "0"}, nil).
+		ApplyMethodReturn(cli, "BatchGetQuota", quotas, nil).
+		ApplyMethodReturn(cli, "CreateDTree", map[string]interface{}{"ID": "1@4097"}, nil).
+		ApplyMethodReturn(cli, "CreateQuota", nil, errors.New("create quota failed")).
+		ApplyMethod(cli, "DeleteDTreeByID", func(_ *OceanstorClient, _ context.Context, _, dTreeID string) error {
+			deletedID = dTreeID
+			return nil
+		})
+
+	// action
+	_, err := cli.CreateDTreeWithInheritedQuota(ctx, "1", "dtree1")
+
+	// assert
+	require.ErrorContains(t, err, "create quota failed")
+	require.Equal(t, "1@4097", deletedID)
+}
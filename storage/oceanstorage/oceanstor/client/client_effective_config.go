@@ -0,0 +1,191 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+const (
+	redactedValue      = "******"
+	defaultConfigValue = "default"
+	noProxyConfigValue = "none"
+	customConfigValue  = "custom"
+	unknownConfigValue = "unknown"
+	certSourceSecret   = "secret"
+	certSourceNone     = "none"
+)
+
+// EffectiveConfig is the redacted snapshot of the resolved settings of the rest client, used for audit
+type EffectiveConfig struct {
+	Urls                []string `json:"urls"`
+	ActiveURL           string   `json:"activeUrl"`
+	User                string   `json:"user"`
+	SecretNamespace     string   `json:"secretNamespace"`
+	SecretName          string   `json:"secretName"`
+	CredentialsPath     string   `json:"credentialsPath"`
+	VStoreName          string   `json:"vstoreName"`
+	VStoreID            string   `json:"vstoreId"`
+	BackendID           string   `json:"backendId"`
+	Storage             string   `json:"storage"`
+	Product             string   `json:"product"`
+	AuthenticationMode  string   `json:"authenticationMode"`
+	LoginScope          string   `json:"loginScope"`
+	LoginTimeout        string   `json:"loginTimeout"`
+	SessionTimeout      string   `json:"sessionTimeout"`
+	VerboseLog          bool     `json:"verboseLog"`
+	ParallelCount       int      `json:"parallelCount"`
+	TenantParallelCount int      `json:"tenantParallelCount"`
+	SystemCacheTTL      string   `json:"systemCacheTTL"`
+	PoolCacheTTL        string   `json:"poolCacheTTL"`
+	MaxResponseBodySize int64    `json:"maxResponseBodySize"`
+	UseCert             bool     `json:"useCert"`
+	CertSource          string   `json:"certSource"`
+	CertSecretMeta      string   `json:"certSecretMeta"`
+	CASecretMeta        string   `json:"caSecretMeta"`
+	InsecureSkipVerify  bool     `json:"insecureSkipVerify"`
+	TLSMinVersion       string   `json:"tlsMinVersion"`
+	TLSMaxVersion       string   `json:"tlsMaxVersion"`
+	HTTPTimeout         string   `json:"httpTimeout"`
+	Proxy               string   `json:"proxy"`
+	MaxIdleConns        int      `json:"maxIdleConns"`
+	MaxIdleConnsPerHost int      `json:"maxIdleConnsPerHost"`
+	IdleConnTimeout     string   `json:"idleConnTimeout"`
+	KeepAlive           string   `json:"keepAlive"`
+	Headers             []string `json:"headers"`
+	Token               string   `json:"token"`
+
+	// HostOverrides are the IPs that the host names of the urls are dialed with
+	HostOverrides map[string]string `json:"hostOverrides,omitempty"`
+}
+
+// EffectiveConfig returns the redacted snapshot of the resolved settings, no credential is included
+func (cli *RestClient) EffectiveConfig() *EffectiveConfig {
+	config := &EffectiveConfig{
+		Urls:                append([]string{}, cli.Urls...),
+		ActiveURL:           cli.Url,
+		User:                cli.User,
+		SecretNamespace:     cli.SecretNamespace,
+		SecretName:          cli.SecretName,
+		CredentialsPath:     cli.CredentialsPath,
+		VStoreName:          cli.VStoreName,
+		VStoreID:            cli.VStoreID,
+		BackendID:           cli.BackendID,
+		Storage:             cli.Storage,
+		Product:             string(cli.Product),
+		AuthenticationMode:  cli.AuthenticationMode,
+		LoginScope:          cli.LoginScope,
+		LoginTimeout:        cli.LoginTimeout.String(),
+		SessionTimeout:      cli.SessionTimeout.String(),
+		VerboseLog:          cli.VerboseLog,
+		ParallelCount:       cli.ParallelCount,
+		TenantParallelCount: cli.TenantSemaphore.Permits(),
+		SystemCacheTTL:      cli.SystemCacheTTL.String(),
+		PoolCacheTTL:        cli.PoolCacheTTL.String(),
+		MaxResponseBodySize: getMaxResponseBodySize(cli.MaxResponseBodySize),
+		KeepAlive:           cli.Transport.KeepAlive.String(),
+		UseCert:             cli.UseCert,
+		CertSource:          certSourceNone,
+		CertSecretMeta:      cli.CertSecretMeta,
+		CASecretMeta:        cli.CASecretMeta,
+		// only the names of the custom headers are exported since the values may be credentials
+		Headers:       slices.Sorted(maps.Keys(cli.Headers)),
+		HostOverrides: maps.Clone(cli.Transport.HostOverrides),
+	}
+
+	if cli.UseCert {
+		config.CertSource = certSourceSecret
+	}
+
+	if cli.Token != "" {
+		config.Token = redactedValue
+	}
+
+	config.setHTTPConfig(cli.Client)
+	if cli.ProxyURL != "" && config.Proxy == customConfigValue {
+		config.Proxy = redactProxyURL(cli.ProxyURL)
+	}
+	return config
+}
+
+// DescribeConfig returns the effective config in a single line for the diagnostics logs, e.g. at the startup
+// of each backend, it is built from EffectiveConfig so that no credential is included
+func (cli *RestClient) DescribeConfig() string {
+	data, err := json.Marshal(cli.EffectiveConfig())
+	if err != nil {
+		return fmt.Sprintf("describe config of backend %s failed: %v", cli.BackendID, err)
+	}
+
+	return string(data)
+}
+
+func (config *EffectiveConfig) setHTTPConfig(client interface{}) {
+	config.TLSMinVersion = unknownConfigValue
+	config.TLSMaxVersion = unknownConfigValue
+	config.HTTPTimeout = unknownConfigValue
+	config.IdleConnTimeout = unknownConfigValue
+	config.Proxy = unknownConfigValue
+
+	httpClient, ok := client.(*http.Client)
+	if !ok {
+		return
+	}
+
+	config.HTTPTimeout = httpClient.Timeout.String()
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		return
+	}
+
+	config.Proxy = noProxyConfigValue
+	if transport.Proxy != nil {
+		config.Proxy = customConfigValue
+	}
+
+	config.MaxIdleConns = transport.MaxIdleConns
+	config.MaxIdleConnsPerHost = transport.MaxIdleConnsPerHost
+	config.IdleConnTimeout = transport.IdleConnTimeout.String()
+	config.TLSMinVersion = defaultConfigValue
+	config.TLSMaxVersion = defaultConfigValue
+	if transport.TLSClientConfig == nil {
+		return
+	}
+
+	config.InsecureSkipVerify = transport.TLSClientConfig.InsecureSkipVerify
+	if transport.TLSClientConfig.MinVersion != 0 {
+		config.TLSMinVersion = tls.VersionName(transport.TLSClientConfig.MinVersion)
+	}
+
+	if transport.TLSClientConfig.MaxVersion != 0 {
+		config.TLSMaxVersion = tls.VersionName(transport.TLSClientConfig.MaxVersion)
+	}
+}
+
+func redactProxyURL(proxyURL string) string {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return customConfigValue
+	}
+
+	return u.Redacted()
+}
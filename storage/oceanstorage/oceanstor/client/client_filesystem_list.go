@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+// FilesystemInfo holds the brief information of a file system
+type FilesystemInfo struct {
+	ID   string
+	Name string
+	// Capacity is the capacity of the file system in bytes
+	Capacity int64
+}
+
+// ListFilesystemsByPrefix used for list the file systems whose names start with the prefix, the names are
+// filtered by the fuzzy match of the storage and then checked by the prefix, all file systems are listed if
+// the prefix is empty
+func (cli *OceanstorClient) ListFilesystemsByPrefix(ctx context.Context, prefix string) ([]FilesystemInfo, error) {
+	var filesystems []FilesystemInfo
+	for start := 0; ; start += storage.QueryCountPerBatch {
+		url := fmt.Sprintf("/filesystem?range=[%d-%d]", start, start+storage.QueryCountPerBatch)
+		if prefix != "" {
+			url = fmt.Sprintf("/filesystem?filter=NAME:%s&range=[%d-%d]",
+				prefix, start, start+storage.QueryCountPerBatch)
+		}
+
+		resp, err := cli.Get(ctx, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		code, ok := resp.Error["code"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("list filesystems error: invalid response %v", resp.Error)
+		}
+		if int64(code) != 0 {
+			return nil, fmt.Errorf("list filesystems with prefix %s error: %d", prefix, int64(code))
+		}
+
+		respData, ok := resp.Data.([]interface{})
+		if !ok {
+			// no file system left in the page
+			return filesystems, nil
+		}
+
+		for _, item := range respData {
+			data, ok := item.(map[string]interface{})
+			if !ok {
+				log.AddContext(ctx).Warningf("convert filesystem %v to map failed", item)
+				continue
+			}
+
+			fs := parseFilesystemInfo(data)
+			if strings.HasPrefix(fs.Name, prefix) {
+				filesystems = append(filesystems, fs)
+			}
+		}
+
+		if len(respData) < storage.QueryCountPerBatch {
+			return filesystems, nil
+		}
+	}
+}
+
+func parseFilesystemInfo(data map[string]interface{}) FilesystemInfo {
+	fs := FilesystemInfo{}
+	fs.ID, _ = data["ID"].(string)
+	fs.Name, _ = data["NAME"].(string)
+	if capacity, ok := data["CAPACITY"].(string); ok {
+		sectors, err := strconv.ParseInt(capacity, constants.DefaultIntBase, constants.DefaultIntBitSize)
+		if err == nil {
+			fs.Capacity = sectors * constants.AllocationUnitBytes
+		}
+	}
+
+	return fs
+}
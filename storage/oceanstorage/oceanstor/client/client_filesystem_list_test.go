@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+)
+
+func TestOceanstorClient_ListFilesystemsByPrefix(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	firstPage := make([]interface{}, 0, storage.QueryCountPerBatch)
+	for i := 0; i < storage.QueryCountPerBatch; i++ {
+		name := fmt.Sprintf("pvc-%d", i)
+		if i%2 == 1 {
+			// the fuzzy match of the storage also returns the names containing the prefix
+			name = "old-" + name
+		}
+		firstPage = append(firstPage, map[string]interface{}{"ID": fmt.Sprint(i), "NAME": name, "CAPACITY": "2"})
+	}
+	secondPage := []interface{}{map[string]interface{}{"ID": "100", "NAME": "pvc-100", "CAPACITY": "4"}}
+	var urls []string
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli.RestClient, "Get", func(_ *RestClient, _ context.Context, url string,
+		_ map[string]interface{}) (base.Response, error) {
+		urls = append(urls, url)
+		resp := base.Response{Error: map[string]interface{}{"code": float64(0)}, Data: firstPage}
+		if strings.Contains(url, "range=[100-200]") {
+			resp.Data = secondPage
+		}
+		return resp, nil
+	})
+	defer patches.Reset()
+
+	// action
+	filesystems, err := cli.ListFilesystemsByPrefix(ctx, "pvc-")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, []string{"/filesystem?filter=NAME:pvc-&range=[0-100]",
+		"/filesystem?filter=NAME:pvc-&range=[100-200]"}, urls)
+	require.Len(t, filesystems, storage.QueryCountPerBatch/2+1)
+	require.Equal(t, FilesystemInfo{ID: "100", Name: "pvc-100", Capacity: 2048}, filesystems[len(filesystems)-1])
+}
+
+func TestOceanstorClient_ListFilesystemsByPrefix_Error(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	resp := base.Response{Error: map[string]interface{}{"code": float64(1077949006)}}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get", resp, nil)
+	defer patches.Reset()
+
+	// action
+	_, err := cli.ListFilesystemsByPrefix(ctx, "pvc-")
+
+	// assert
+	require.ErrorContains(t, err, "1077949006")
+}
@@ -42,23 +42,17 @@ type FSSnapshot interface {
 	GetFSSnapshotByName(ctx context.Context, parentID, snapshotName string) (map[string]interface{}, error)
 	// GetFSSnapshotCountByParentId used for get file system snapshot count by parent id
 	GetFSSnapshotCountByParentId(ctx context.Context, ParentId string) (int, error)
+	// CreateFSSnapshotConsistencyGroup used for create the snapshots of file systems at the same point
+	CreateFSSnapshotConsistencyGroup(ctx context.Context, fsIDs []string,
+		name string) (*FSSnapshotConsistencyGroup, error)
+	// DeleteFSSnapshotConsistencyGroup used for delete the snapshot consistency group and its snapshots
+	DeleteFSSnapshotConsistencyGroup(ctx context.Context, groupID string) error
 }
 
 // DeleteFSSnapshot used for delete file system snapshot by id
 func (cli *OceanstorClient) DeleteFSSnapshot(ctx context.Context, snapshotID string) error {
-	url := fmt.Sprintf("/FSSNAPSHOT/%s", snapshotID)
-	resp, err := cli.Delete(ctx, url, nil)
-	if err != nil {
-		return err
-	}
-
-	code := int64(resp.Error["code"].(float64))
-	if code == fsSnapshotNotExist {
-		log.AddContext(ctx).Infof("FS Snapshot %s does not exist while deleting", snapshotID)
-		return nil
-	}
-	if code != 0 {
-		return fmt.Errorf("Delete FS snapshot %s error: %d", snapshotID, code)
+	if err := cli.DeleteIfExists(ctx, fmt.Sprintf("/FSSNAPSHOT/%s", snapshotID), nil); err != nil {
+		return fmt.Errorf("delete FS snapshot %s failed: %w", snapshotID, err)
 	}
 
 	return nil
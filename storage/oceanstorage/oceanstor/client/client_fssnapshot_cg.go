@@ -0,0 +1,158 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+const fsSnapshotCGUrl = "/fs_snapshot_consistency_group"
+
+// FSSnapshotConsistencyGroup holds the snapshots of the filesystems taken at the same point
+type FSSnapshotConsistencyGroup struct {
+	ID   string
+	Name string
+	// Snapshots are the ids of the member snapshots keyed by the ids of the filesystems
+	Snapshots map[string]string
+}
+
+// CreateFSSnapshotConsistencyGroup snapshots the filesystems at the same point by the consistency group of the
+// storage. The creation is atomic, the group and the created member snapshots are deleted if any of the
+// filesystems is not snapshotted.
+func (cli *OceanstorClient) CreateFSSnapshotConsistencyGroup(ctx context.Context,
+	fsIDs []string, name string) (*FSSnapshotConsistencyGroup, error) {
+	if len(fsIDs) == 0 {
+		return nil, errors.New("no filesystem is specified for the snapshot consistency group")
+	}
+
+	resp, err := cli.Post(ctx, fsSnapshotCGUrl, map[string]interface{}{
+		"NAME":         name,
+		"DESCRIPTION":  description,
+		"PARENTTYPE":   "40",
+		"PARENTIDLIST": fsIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	code, ok := resp.Error["code"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("create snapshot consistency group %s error: invalid response %v", name, resp.Error)
+	}
+	if int64(code) != 0 {
+		return nil, fmt.Errorf("create snapshot consistency group %s for FS %v error: %d", name, fsIDs, int64(code))
+	}
+
+	respData, ok := resp.Data.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("convert resp.Data to map[string]interface{} failed")
+	}
+
+	group := parseFSSnapshotConsistencyGroup(respData)
+	var missing []string
+	for _, fsID := range fsIDs {
+		if _, exist := group.Snapshots[fsID]; !exist {
+			missing = append(missing, fsID)
+		}
+	}
+	if len(missing) == 0 {
+		log.AddContext(ctx).Infof("Created snapshot consistency group %s of FS %v, snapshots: %v",
+			group.ID, fsIDs, group.Snapshots)
+		return group, nil
+	}
+
+	if err = cli.deleteFSSnapshotConsistencyGroup(ctx, group); err != nil {
+		log.AddContext(ctx).Errorf("Rollback snapshot consistency group %s failed, error: %v", group.ID, err)
+	}
+	return nil, fmt.Errorf("create snapshot consistency group %s error: FS %v are not snapshotted", name, missing)
+}
+
+// DeleteFSSnapshotConsistencyGroup deletes the member snapshots and then the consistency group,
+// the group which has already been deleted is treated as success
+func (cli *OceanstorClient) DeleteFSSnapshotConsistencyGroup(ctx context.Context, groupID string) error {
+	resp, err := cli.Get(ctx, fmt.Sprintf("%s/%s", fsSnapshotCGUrl, groupID), nil)
+	if err != nil {
+		return err
+	}
+
+	if isObjectNotFound(resp) {
+		log.AddContext(ctx).Infof("Snapshot consistency group %s does not exist while deleting", groupID)
+		return nil
+	}
+
+	code, ok := resp.Error["code"].(float64)
+	if !ok {
+		return fmt.Errorf("get snapshot consistency group %s error: invalid response %v", groupID, resp.Error)
+	}
+	if int64(code) != 0 {
+		return fmt.Errorf("get snapshot consistency group %s error: %d", groupID, int64(code))
+	}
+
+	respData, ok := resp.Data.(map[string]interface{})
+	if !ok {
+		return errors.New("convert resp.Data to map[string]interface{} failed")
+	}
+
+	return cli.deleteFSSnapshotConsistencyGroup(ctx, parseFSSnapshotConsistencyGroup(respData))
+}
+
+func (cli *OceanstorClient) deleteFSSnapshotConsistencyGroup(ctx context.Context,
+	group *FSSnapshotConsistencyGroup) error {
+	var errs []error
+	for fsID, snapshotID := range group.Snapshots {
+		if err := cli.DeleteFSSnapshot(ctx, snapshotID); err != nil {
+			errs = append(errs, fmt.Errorf("delete snapshot %s of FS %s failed: %w", snapshotID, fsID, err))
+		}
+	}
+	if len(errs) != 0 {
+		// the group is kept so that the remaining snapshots can be found by the group id and deleted again
+		return errors.Join(errs...)
+	}
+
+	if err := cli.DeleteIfExists(ctx, fmt.Sprintf("%s/%s", fsSnapshotCGUrl, group.ID), nil); err != nil {
+		return fmt.Errorf("delete snapshot consistency group %s failed: %w", group.ID, err)
+	}
+
+	return nil
+}
+
+func parseFSSnapshotConsistencyGroup(data map[string]interface{}) *FSSnapshotConsistencyGroup {
+	group := &FSSnapshotConsistencyGroup{Snapshots: map[string]string{}}
+	group.ID, _ = utils.ToStringWithFlag(data["ID"])
+	group.Name, _ = utils.ToStringWithFlag(data["NAME"])
+
+	snapshots, _ := data["SNAPSHOTLIST"].([]interface{})
+	for _, item := range snapshots {
+		snapshot, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		snapshotID, _ := utils.ToStringWithFlag(snapshot["ID"])
+		parentID, _ := utils.ToStringWithFlag(snapshot["PARENTID"])
+		if snapshotID != "" && parentID != "" {
+			group.Snapshots[parentID] = snapshotID
+		}
+	}
+
+	return group
+}
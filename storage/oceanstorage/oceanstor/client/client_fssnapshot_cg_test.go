@@ -0,0 +1,152 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+)
+
+func TestCreateFSSnapshotConsistencyGroup_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	resp := base.Response{
+		Error: map[string]interface{}{"code": float64(0)},
+		Data: map[string]interface{}{"ID": "cg1", "NAME": "group", "SNAPSHOTLIST": []interface{}{
+			map[string]interface{}{"ID": "s1", "PARENTID": "1"},
+			map[string]interface{}{"ID": "s2", "PARENTID": "2"},
+		}},
+	}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Post", resp, nil)
+	defer patches.Reset()
+
+	// act
+	group, err := cli.CreateFSSnapshotConsistencyGroup(ctx, []string{"1", "2"}, "group")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "cg1", group.ID)
+	require.Equal(t, map[string]string{"1": "s1", "2": "s2"}, group.Snapshots)
+}
+
+func TestCreateFSSnapshotConsistencyGroup_RollbackWhenMemberMissing(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	resp := base.Response{
+		Error: map[string]interface{}{"code": float64(0)},
+		Data: map[string]interface{}{"ID": "cg1", "SNAPSHOTLIST": []interface{}{
+			map[string]interface{}{"ID": "s1", "PARENTID": "1"},
+		}},
+	}
+	var deletedSnapshots, deletedUrls []string
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Post", resp, nil).
+		ApplyMethod(cli, "DeleteFSSnapshot", func(_ *OceanstorClient, _ context.Context, id string) error {
+			deletedSnapshots = append(deletedSnapshots, id)
+			return nil
+		}).
+		ApplyMethod(cli, "DeleteIfExists", func(_ *OceanstorClient, _ context.Context, url string,
+			_ map[string]interface{}) error {
+			deletedUrls = append(deletedUrls, url)
+			return nil
+		})
+	defer patches.Reset()
+
+	// act
+	_, err := cli.CreateFSSnapshotConsistencyGroup(ctx, []string{"1", "2"}, "group")
+
+	// assert
+	require.ErrorContains(t, err, "FS [2] are not snapshotted")
+	require.Equal(t, []string{"s1"}, deletedSnapshots)
+	require.Equal(t, []string{fsSnapshotCGUrl + "/cg1"}, deletedUrls)
+}
+
+func TestCreateFSSnapshotConsistencyGroup_Failed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	resp := base.Response{Error: map[string]interface{}{"code": float64(1077949006)}}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Post", resp, nil)
+	defer patches.Reset()
+
+	// act
+	_, err := cli.CreateFSSnapshotConsistencyGroup(ctx, []string{"1"}, "group")
+
+	// assert
+	require.ErrorContains(t, err, "1077949006")
+}
+
+func TestDeleteFSSnapshotConsistencyGroup_NotExist(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	resp := base.Response{Error: map[string]interface{}{"code": float64(objectNotExist)}}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get", resp, nil)
+	defer patches.Reset()
+
+	// act
+	err := cli.DeleteFSSnapshotConsistencyGroup(ctx, "cg1")
+
+	// assert
+	require.NoError(t, err)
+}
+
+func TestDeleteFSSnapshotConsistencyGroup_KeepGroupWhenSnapshotFailed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	resp := base.Response{
+		Error: map[string]interface{}{"code": float64(0)},
+		Data: map[string]interface{}{"ID": "cg1", "SNAPSHOTLIST": []interface{}{
+			map[string]interface{}{"ID": "s1", "PARENTID": "1"},
+		}},
+	}
+	wantErr := errors.New("storage busy")
+	groupDeleted := false
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get", resp, nil).
+		ApplyMethodReturn(cli, "DeleteFSSnapshot", wantErr).
+		ApplyMethod(cli, "DeleteIfExists", func(_ *OceanstorClient, _ context.Context, _ string,
+			_ map[string]interface{}) error {
+			groupDeleted = true
+			return nil
+		})
+	defer patches.Reset()
+
+	// act
+	err := cli.DeleteFSSnapshotConsistencyGroup(ctx, "cg1")
+
+	// assert
+	require.ErrorIs(t, err, wantErr)
+	require.False(t, groupDeleted)
+}
@@ -19,8 +19,11 @@ package client
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
@@ -28,6 +31,14 @@ const (
 	hyperMetroNotExist int64 = 1077674242
 )
 
+const (
+	hyperMetroPairRunningStatusPause      = "41"
+	hyperMetroPairRunningStatusForceStart = "93"
+	hyperMetroPairRunningStatusError      = "94"
+	hyperMetroPairIsPrimary               = "true"
+	hyperMetroDomainRunningStatusNormal   = "1"
+)
+
 const (
 	// MetroPairSyncSpeedLow is low synchronization rate of the HyperMetro pair.
 	MetroPairSyncSpeedLow = iota + 1
@@ -39,6 +50,30 @@ const (
 	MetroPairSyncSpeedHighest
 )
 
+// metroPairSyncSpeeds maps the names of the synchronization rates configured in the StorageClass to the SPEED
+var metroPairSyncSpeeds = map[string]int{
+	"low":     MetroPairSyncSpeedLow,
+	"medium":  MetroPairSyncSpeedMedium,
+	"high":    MetroPairSyncSpeedHigh,
+	"highest": MetroPairSyncSpeedHighest,
+}
+
+// ParseMetroPairSyncSpeed parses the synchronization rate of the HyperMetro pair,
+// both the name (low/medium/high/highest) and the SPEED value (1 to 4) are accepted
+func ParseMetroPairSyncSpeed(speed string) (int, error) {
+	if value, ok := metroPairSyncSpeeds[strings.ToLower(strings.TrimSpace(speed))]; ok {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(speed)
+	if err != nil || value < MetroPairSyncSpeedLow || value > MetroPairSyncSpeedHighest {
+		return 0, fmt.Errorf("invalid metroPairSyncSpeed [%s], it must be one of low, medium, high, highest "+
+			"or an integer between %d and %d", speed, MetroPairSyncSpeedLow, MetroPairSyncSpeedHighest)
+	}
+
+	return value, nil
+}
+
 // HyperMetro defines interfaces for hyper metro operations
 type HyperMetro interface {
 	// GetHyperMetroDomainByName used for get hyper metro domain by name
@@ -59,6 +94,19 @@ type HyperMetro interface {
 	SyncHyperMetroPair(ctx context.Context, pairID string) error
 	// StopHyperMetroPair used for stop hyper metro pair
 	StopHyperMetroPair(ctx context.Context, pairID string) error
+	// DetectHyperMetroSplitBrain used for detect whether the hyper metro pair is in split-brain state
+	DetectHyperMetroSplitBrain(ctx context.Context, pairID string) (*HyperMetroSplitBrainStatus, error)
+}
+
+// HyperMetroSplitBrainStatus is the split-brain detection result of a hyper metro pair
+type HyperMetroSplitBrainStatus struct {
+	PairID        string
+	RunningStatus string
+	IsPrimary     bool
+	DomainStatus  string
+	// SplitBrain is true when both ends of the pair may consider themselves as primary
+	SplitBrain bool
+	Reason     string
 }
 
 // GetHyperMetroDomainByName used for get hyper metro domain by name
@@ -292,18 +340,62 @@ func (cli *OceanstorClient) DeleteHyperMetroPair(ctx context.Context, pairID str
 		url += "?isOnlineDeleting=0"
 	}
 
-	resp, err := cli.Delete(ctx, url, nil)
+	if err := cli.DeleteIfExists(ctx, url, nil); err != nil {
+		return fmt.Errorf("delete hypermetro %s failed: %w", pairID, err)
+	}
+
+	return nil
+}
+
+// DetectHyperMetroSplitBrain used for detect whether the hyper metro pair is in split-brain state.
+// A pair is considered as split-brain when it is force started, or when its link is down while the
+// arbitration of its domain is abnormal and the local end still works as primary.
+func (cli *OceanstorClient) DetectHyperMetroSplitBrain(ctx context.Context,
+	pairID string) (*HyperMetroSplitBrainStatus, error) {
+	pair, err := cli.GetHyperMetroPair(ctx, pairID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pair == nil {
+		return nil, fmt.Errorf("hypermetro %s does not exist", pairID)
+	}
+
+	status := &HyperMetroSplitBrainStatus{
+		PairID:        pairID,
+		RunningStatus: utils.GetValueOrFallback(pair, "RUNNINGSTATUS", ""),
+		IsPrimary:     utils.GetValueOrFallback(pair, "ISPRIMARY", "") == hyperMetroPairIsPrimary,
+	}
+
+	if status.RunningStatus == hyperMetroPairRunningStatusForceStart {
+		status.SplitBrain = true
+		status.Reason = "pair is force started, both ends may accept writes"
+		log.AddContext(ctx).Warningf("Hypermetro %s is in split-brain state: %s", pairID, status.Reason)
+		return status, nil
+	}
+
+	if status.RunningStatus != hyperMetroPairRunningStatusPause &&
+		status.RunningStatus != hyperMetroPairRunningStatusError {
+		return status, nil
+	}
+
+	domainID := utils.GetValueOrFallback(pair, "DOMAINID", "")
+	if domainID == "" {
+		return status, nil
+	}
+
+	domain, err := cli.GetHyperMetroDomain(ctx, domainID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	code := int64(resp.Error["code"].(float64))
-	if code == hyperMetroNotExist {
-		log.AddContext(ctx).Infof("Hypermetro %s to Delete does not exist", pairID)
-		return nil
-	} else if code != 0 {
-		return fmt.Errorf("Delete hypermetro %s error: %d", pairID, code)
+	status.DomainStatus = utils.GetValueOrFallback(domain, "RUNNINGSTATUS", "")
+	if status.DomainStatus != hyperMetroDomainRunningStatusNormal && status.IsPrimary {
+		status.SplitBrain = true
+		status.Reason = "pair link is down and domain arbitration is abnormal while local end is primary"
+		log.AddContext(ctx).Warningf("Hypermetro %s of domain %s is in split-brain state: %s",
+			pairID, domainID, status.Reason)
 	}
 
-	return nil
+	return status, nil
 }
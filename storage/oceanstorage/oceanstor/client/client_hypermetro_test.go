@@ -0,0 +1,169 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/client"
+)
+
+func TestOceanstorClient_DetectHyperMetroSplitBrain(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("normal pair", func(t *testing.T) {
+		// arrange
+		cli := mockCli()
+		pair := map[string]interface{}{"ID": "1", "RUNNINGSTATUS": "1", "ISPRIMARY": "true", "DOMAINID": "d1"}
+
+		// mock
+		patches := gomonkey.ApplyMethodReturn(cli, "GetHyperMetroPair", pair, nil)
+		defer patches.Reset()
+
+		// act
+		status, err := cli.DetectHyperMetroSplitBrain(ctx, "1")
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, status.SplitBrain)
+		require.True(t, status.IsPrimary)
+		require.Empty(t, status.Reason)
+	})
+
+	t.Run("force started pair", func(t *testing.T) {
+		// arrange
+		cli := mockCli()
+		pair := map[string]interface{}{"ID": "1", "RUNNINGSTATUS": "93", "ISPRIMARY": "true", "DOMAINID": "d1"}
+
+		// mock
+		patches := gomonkey.ApplyMethodReturn(cli, "GetHyperMetroPair", pair, nil)
+		defer patches.Reset()
+
+		// act
+		status, err := cli.DetectHyperMetroSplitBrain(ctx, "1")
+
+		// assert
+		require.NoError(t, err)
+		require.True(t, status.SplitBrain)
+		require.NotEmpty(t, status.Reason)
+	})
+
+	t.Run("link down with abnormal arbitration", func(t *testing.T) {
+		// arrange
+		cli := mockCli()
+		pair := map[string]interface{}{"ID": "1", "RUNNINGSTATUS": "41", "ISPRIMARY": "true", "DOMAINID": "d1"}
+		domain := map[string]interface{}{"ID": "d1", "RUNNINGSTATUS": "2"}
+
+		// mock
+		patches := gomonkey.ApplyMethodReturn(cli, "GetHyperMetroPair", pair, nil).
+			ApplyMethodReturn(cli, "GetHyperMetroDomain", domain, nil)
+		defer patches.Reset()
+
+		// act
+		status, err := cli.DetectHyperMetroSplitBrain(ctx, "1")
+
+		// assert
+		require.NoError(t, err)
+		require.True(t, status.SplitBrain)
+		require.Equal(t, "2", status.DomainStatus)
+	})
+
+	t.Run("link down with normal arbitration", func(t *testing.T) {
+		// arrange
+		cli := mockCli()
+		pair := map[string]interface{}{"ID": "1", "RUNNINGSTATUS": "41", "ISPRIMARY": "true", "DOMAINID": "d1"}
+		domain := map[string]interface{}{"ID": "d1", "RUNNINGSTATUS": "1"}
+
+		// mock
+		patches := gomonkey.ApplyMethodReturn(cli, "GetHyperMetroPair", pair, nil).
+			ApplyMethodReturn(cli, "GetHyperMetroDomain", domain, nil)
+		defer patches.Reset()
+
+		// act
+		status, err := cli.DetectHyperMetroSplitBrain(ctx, "1")
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, status.SplitBrain)
+	})
+
+	t.Run("pair not exist", func(t *testing.T) {
+		// arrange
+		cli := mockCli()
+
+		// mock
+		patches := gomonkey.ApplyMethodReturn(cli, "GetHyperMetroPair", map[string]interface{}(nil), nil)
+		defer patches.Reset()
+
+		// act
+		status, err := cli.DetectHyperMetroSplitBrain(ctx, "1")
+
+		// assert
+		require.Error(t, err)
+		require.Nil(t, status)
+	})
+
+	t.Run("get pair failed", func(t *testing.T) {
+		// arrange
+		cli := mockCli()
+
+		// mock
+		patches := gomonkey.ApplyMethodReturn(cli, "GetHyperMetroPair", nil, assert.AnError)
+		defer patches.Reset()
+
+		// act
+		_, err := cli.DetectHyperMetroSplitBrain(ctx, "1")
+
+		// assert
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestParseMetroPairSyncSpeed(t *testing.T) {
+	// arrange
+	tests := []struct {
+		speed   string
+		want    int
+		wantErr bool
+	}{
+		{speed: "low", want: client.MetroPairSyncSpeedLow},
+		{speed: "Highest", want: client.MetroPairSyncSpeedHighest},
+		{speed: "3", want: client.MetroPairSyncSpeedHigh},
+		{speed: "5", wantErr: true},
+		{speed: "fast", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.speed, func(t *testing.T) {
+			// action
+			got, err := client.ParseMetroPairSyncSpeed(tt.speed)
+
+			// assert
+			if tt.wantErr {
+				require.ErrorContains(t, err, "invalid metroPairSyncSpeed")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
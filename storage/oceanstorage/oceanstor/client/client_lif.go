@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	netUrl "net/url"
+	"slices"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
@@ -32,8 +33,12 @@ type LIF interface {
 	GetCurrentLifWwn() string
 	// GetCurrentLif get current lif
 	GetCurrentLif(ctx context.Context) string
+	// BackendNetworkReport reports the logic ports of the urls and the portals the backend relies on
+	BackendNetworkReport(ctx context.Context, portals ...string) *NetworkReport
 }
 
+const lifRunningStatusLinkUp = "10"
+
 // GetLogicPort gets logic port information by port address
 func (cli *OceanstorClient) GetLogicPort(ctx context.Context, addr string) (*Lif, error) {
 	url := fmt.Sprintf("/lif?filter=IPV4ADDR:%s&range=[0-100]", addr)
@@ -73,3 +78,47 @@ func (cli *OceanstorClient) GetCurrentLif(ctx context.Context) string {
 	}
 	return u.Hostname()
 }
+
+// BackendNetworkReport reports the logic ports of the urls and the portals the backend relies on,
+// the ports which are down or not homed on the current site are flagged
+func (cli *OceanstorClient) BackendNetworkReport(ctx context.Context, portals ...string) *NetworkReport {
+	var addrs []string
+	for _, rawUrl := range cli.Urls {
+		u, err := netUrl.Parse(rawUrl)
+		if err != nil || u.Hostname() == "" {
+			log.AddContext(ctx).Warningf("skip the url %s in network report, error: %v", rawUrl, err)
+			continue
+		}
+		addrs = append(addrs, u.Hostname())
+	}
+	addrs = append(addrs, portals...)
+
+	report := &NetworkReport{}
+	for _, addr := range addrs {
+		if slices.ContainsFunc(report.Ports, func(port *LogicPortReport) bool { return port.Address == addr }) {
+			continue
+		}
+		report.Ports = append(report.Ports, cli.logicPortReport(ctx, addr))
+	}
+
+	return report
+}
+
+func (cli *OceanstorClient) logicPortReport(ctx context.Context, addr string) *LogicPortReport {
+	report := &LogicPortReport{Address: addr}
+	lif, err := cli.GetLogicPort(ctx, addr)
+	if err != nil {
+		log.AddContext(ctx).Warningf("get logic port %s for network report failed, error: %v", addr, err)
+		report.Err = err
+		return report
+	}
+
+	report.Name = lif.Name
+	report.SiteWwn = lif.HomeSiteWwn
+	report.RunningStatus = lif.RunningStatus
+	report.Protocol = lif.SupportProtocol
+	// the management LIF is not in the lif list and its status is unknown
+	report.Down = lif.ID != "" && lif.RunningStatus != lifRunningStatusLinkUp
+	report.CrossSite = lif.HomeSiteWwn != "" && cli.CurrentSiteWwn != "" && lif.HomeSiteWwn != cli.CurrentSiteWwn
+	return report
+}
@@ -191,3 +191,40 @@ func TestBaseClient_GetLogicPort(t *testing.T) {
 		require.Nil(t, err)
 	})
 }
+
+func TestOceanstorClient_BackendNetworkReport(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockCli()
+	cli.Urls = []string{"https://192.168.1.1:8088", "https://192.168.1.2:8088"}
+	cli.CurrentSiteWwn = "site-a"
+	lifs := map[string]*client.Lif{
+		"192.168.1.1": {},
+		"192.168.1.2": {ID: "2", Name: "lif2", RunningStatus: "10", SupportProtocol: "3", HomeSiteWwn: "site-b"},
+		"192.168.2.1": {ID: "3", Name: "lif3", RunningStatus: "10", SupportProtocol: "1", HomeSiteWwn: "site-a"},
+		"192.168.2.2": {ID: "4", Name: "lif4", RunningStatus: "11", SupportProtocol: "1", HomeSiteWwn: "site-a"},
+	}
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli, "GetLogicPort",
+		func(_ *client.OceanstorClient, _ context.Context, addr string) (*client.Lif, error) {
+			if lif, ok := lifs[addr]; ok {
+				return lif, nil
+			}
+			return nil, assert.AnError
+		})
+	defer patches.Reset()
+
+	// act
+	report := cli.BackendNetworkReport(ctx, "192.168.2.1", "192.168.2.2", "192.168.2.3", "192.168.1.2")
+
+	// assert
+	require.Len(t, report.Ports, 5)
+	require.False(t, report.Ports[0].Down || report.Ports[0].CrossSite)
+	require.True(t, report.Ports[1].CrossSite)
+	require.False(t, report.Ports[2].Down || report.Ports[2].CrossSite)
+	require.Equal(t, "lif3", report.Ports[2].Name)
+	require.True(t, report.Ports[3].Down)
+	require.ErrorIs(t, report.Ports[4].Err, assert.AnError)
+	require.Equal(t, []*client.LogicPortReport{report.Ports[1], report.Ports[3], report.Ports[4]}, report.Degraded())
+}
@@ -18,5 +18,43 @@ package client
 
 // Lif holds the logic port information
 type Lif struct {
-	HomeSiteWwn string `json:"HOMESITEWWN"`
+	ID              string `json:"ID"`
+	Name            string `json:"NAME"`
+	IPv4Addr        string `json:"IPV4ADDR"`
+	RunningStatus   string `json:"RUNNINGSTATUS"`
+	SupportProtocol string `json:"SUPPORTPROTOCOL"`
+	HomeSiteWwn     string `json:"HOMESITEWWN"`
+}
+
+// LogicPortReport holds the network state of a logic port the backend relies on
+type LogicPortReport struct {
+	Address       string
+	Name          string
+	SiteWwn       string
+	RunningStatus string
+	Protocol      string
+
+	// Down means the running status of the logic port is not link up
+	Down bool
+	// CrossSite means the logic port is not homed on the site of the current storage
+	CrossSite bool
+	// Err is the error occurred when querying the logic port
+	Err error
+}
+
+// NetworkReport holds the network state of all logic ports the backend relies on
+type NetworkReport struct {
+	Ports []*LogicPortReport
+}
+
+// Degraded returns the logic ports which are down, cross-site or failed to be queried
+func (r *NetworkReport) Degraded() []*LogicPortReport {
+	var degraded []*LogicPortReport
+	for _, port := range r.Ports {
+		if port.Down || port.CrossSite || port.Err != nil {
+			degraded = append(degraded, port)
+		}
+	}
+
+	return degraded
 }
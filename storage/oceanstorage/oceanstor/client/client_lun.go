@@ -21,10 +21,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
@@ -38,6 +42,8 @@ const (
 	objectNameAlreadyExist int64 = 1077948993
 
 	maxLunNameLength = 31
+	// MaxLunNameLengthOfDoradoV6 is the maximum length of lun name of Dorado V6 and later
+	MaxLunNameLengthOfDoradoV6 = 255
 )
 
 // Lun defines interfaces for lun operations
@@ -50,6 +56,8 @@ type Lun interface {
 	MakeLunName(name string) string
 	// GetLunByID used for get lun by id
 	GetLunByID(ctx context.Context, id string) (map[string]interface{}, error)
+	// GetLunByWWN used for get lun by wwn
+	GetLunByWWN(ctx context.Context, wwn string) (*LunInfo, error)
 	// GetLunGroupByName used for get lun group by name
 	GetLunGroupByName(ctx context.Context, name string) (map[string]interface{}, error)
 	// GetLunCountOfHost used for get lun count of host
@@ -74,6 +82,27 @@ type Lun interface {
 	AddLunToGroup(ctx context.Context, lunID string, groupID string) error
 	// CreateLunGroup used for create lun group
 	CreateLunGroup(ctx context.Context, name string) (map[string]interface{}, error)
+	// IsVolumeMapped used for check whether the lun is mapped to any host
+	IsVolumeMapped(ctx context.Context, volumeID string) (bool, error)
+	// GetLunOwnerController used for get the owning controller of lun
+	GetLunOwnerController(ctx context.Context, lunID string) (string, error)
+	// SetLunOwnerController used for set the owning controller of lun
+	SetLunOwnerController(ctx context.Context, lunID, controllerID string) error
+	// RenameLun used for rename lun
+	RenameLun(ctx context.Context, id, newName string) error
+}
+
+// ErrLunOwnershipNotApplicable means the owning controller of lun can not be set manually,
+// the Dorado storage serves the lun by all controllers symmetrically
+var ErrLunOwnershipNotApplicable = errors.New("manual lun ownership is not applicable")
+
+// LunInfo holds the identity of a lun
+type LunInfo struct {
+	ID         string
+	Name       string
+	WWN        string
+	ParentName string
+	VStoreName string
 }
 
 // QueryAssociateLunGroup used for query associate lun group by object type and object id
@@ -102,6 +131,17 @@ func (cli *OceanstorClient) QueryAssociateLunGroup(ctx context.Context,
 	return respData, nil
 }
 
+// IsVolumeMapped used for check whether the lun is mapped to any host, a lun is mapped through its lun group
+func (cli *OceanstorClient) IsVolumeMapped(ctx context.Context, volumeID string) (bool, error) {
+	lunGroups, err := cli.QueryAssociateLunGroup(ctx, base.AssociateObjTypeLUN, volumeID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Query associated lun groups of lun %s error: %v", volumeID, err)
+		return false, err
+	}
+
+	return len(lunGroups) > 0, nil
+}
+
 // GetLunByName used for get lun by name
 func (cli *OceanstorClient) GetLunByName(ctx context.Context, name string) (map[string]interface{}, error) {
 	url := fmt.Sprintf("/lun?filter=NAME::%s&range=[0-100]", name)
@@ -133,12 +173,24 @@ func (cli *OceanstorClient) GetLunByName(ctx context.Context, name string) (map[
 	return cli.getObjByvStoreName(respData), nil
 }
 
-// MakeLunName v3/v5 storage support 1 to 31 characters
+// MakeLunName truncates the name to the maximum lun name length of the storage, which is the same limit
+// that RenameLun validates the new name against, v3/v5 storage support 1 to 31 characters and v6 support 1 to 255
 func (cli *OceanstorClient) MakeLunName(name string) string {
-	if len(name) <= maxLunNameLength {
+	limit := cli.lunNameLimit()
+	if len(name) <= limit {
 		return name
 	}
-	return name[:maxLunNameLength]
+	return name[:limit]
+}
+
+// RenameLun used for rename lun, ErrObjectNotFound is returned if the lun does not exist
+func (cli *OceanstorClient) RenameLun(ctx context.Context, id, newName string) error {
+	maxNameLength, err := cli.GetMaxNameLength(ObjectTypeLun)
+	if err != nil {
+		return err
+	}
+
+	return cli.renameObject(ctx, fmt.Sprintf("/lun/%s", id), newName, maxNameLength)
 }
 
 // GetLunByID used for get lun by id
@@ -163,6 +215,106 @@ func (cli *OceanstorClient) GetLunByID(ctx context.Context, id string) (map[stri
 	return lun, nil
 }
 
+// GetLunByWWN used for get lun by wwn, nil is returned if the lun does not exist.
+// The storage is queried with the wwn filter directly, the storage which does not support the wwn filter
+// is queried by the lun list page by page until the lun is found.
+func (cli *OceanstorClient) GetLunByWWN(ctx context.Context, wwn string) (*LunInfo, error) {
+	url := fmt.Sprintf("/lun?filter=WWN::%s&range=[0-100]", wwn)
+	resp, err := cli.Get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	code, ok := resp.Error["code"].(float64)
+	if ok && int64(code) == parameterIncorrect {
+		log.AddContext(ctx).Infof("Storage does not support querying lun by wwn, query lun %s by lun list", wwn)
+		return cli.getLunByWWNFromList(ctx, wwn)
+	}
+	if err := resp.AssertErrorCode(); err != nil {
+		return nil, fmt.Errorf("get lun by wwn %s error: %w", wwn, err)
+	}
+
+	luns, err := parseLunList(ctx, resp.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the filter may be ignored by some storage versions, so the wwn of the returned luns is checked again
+	lun := findLunByWWN(luns, wwn)
+	if lun == nil {
+		log.AddContext(ctx).Infof("Lun of wwn %s does not exist", wwn)
+	}
+
+	return lun, nil
+}
+
+func (cli *OceanstorClient) getLunByWWNFromList(ctx context.Context, wwn string) (*LunInfo, error) {
+	for start := 0; ; start += storage.QueryCountPerBatch {
+		url := fmt.Sprintf("/lun?range=[%d-%d]", start, start+storage.QueryCountPerBatch)
+		resp, err := cli.Get(ctx, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.AssertErrorCode(); err != nil {
+			return nil, fmt.Errorf("get lun list error: %w", err)
+		}
+
+		luns, err := parseLunList(ctx, resp.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		if lun := findLunByWWN(luns, wwn); lun != nil {
+			return lun, nil
+		}
+
+		if len(luns) < storage.QueryCountPerBatch {
+			log.AddContext(ctx).Infof("Lun of wwn %s does not exist", wwn)
+			return nil, nil
+		}
+	}
+}
+
+func parseLunList(ctx context.Context, data any) ([]*LunInfo, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	respData, ok := data.([]interface{})
+	if !ok {
+		return nil, pkgUtils.Errorf(ctx, "convert luns to arr failed, data: %v", data)
+	}
+
+	luns := make([]*LunInfo, 0, len(respData))
+	for _, item := range respData {
+		lun, ok := item.(map[string]interface{})
+		if !ok {
+			log.AddContext(ctx).Warningf("convert lun %v to map failed", item)
+			continue
+		}
+
+		luns = append(luns, &LunInfo{
+			ID:         utils.GetValueOrFallback(lun, "ID", ""),
+			Name:       utils.GetValueOrFallback(lun, "NAME", ""),
+			WWN:        utils.GetValueOrFallback(lun, "WWN", ""),
+			ParentName: utils.GetValueOrFallback(lun, "PARENTNAME", ""),
+			VStoreName: utils.GetValueOrFallback(lun, "vstoreName", storage.DefaultVStore),
+		})
+	}
+
+	return luns, nil
+}
+
+func findLunByWWN(luns []*LunInfo, wwn string) *LunInfo {
+	for _, lun := range luns {
+		if strings.EqualFold(lun.WWN, wwn) {
+			return lun
+		}
+	}
+
+	return nil
+}
+
 // AddLunToGroup used for add lun to group
 func (cli *OceanstorClient) AddLunToGroup(ctx context.Context, lunID string, groupID string) error {
 	data := map[string]interface{}{
@@ -281,20 +433,8 @@ func (cli *OceanstorClient) CreateLunGroup(ctx context.Context, name string) (ma
 
 // DeleteLunGroup used for delete lun group by lun group id
 func (cli *OceanstorClient) DeleteLunGroup(ctx context.Context, id string) error {
-	url := fmt.Sprintf("/lungroup/%s", id)
-	resp, err := cli.Delete(ctx, url, nil)
-	if err != nil {
-		return err
-	}
-
-	code := int64(resp.Error["code"].(float64))
-	if code == objectNotExist {
-		log.AddContext(ctx).Infof("Lungroup %s does not exist while deleting", id)
-		return nil
-	}
-	if code != 0 {
-		msg := fmt.Sprintf("Delete lungroup %s error: %d", id, code)
-		return errors.New(msg)
+	if err := cli.DeleteIfExists(ctx, fmt.Sprintf("/lungroup/%s", id), nil); err != nil {
+		return fmt.Errorf("delete lungroup %s failed: %w", id, err)
 	}
 
 	return nil
@@ -318,8 +458,12 @@ func (cli *OceanstorClient) CreateLun(ctx context.Context, params map[string]any
 			"Suggestion: Delete current PVC and check the parameter of the storageClass and PVC and try again", code)
 	}
 
+	if err := base.StorageBusyError(code, resp.Error["description"]); err != nil {
+		return nil, fmt.Errorf("create volume %v error: %w", data, err)
+	}
+
 	if code != 0 {
-		return nil, fmt.Errorf("create volume %v error: %d", data, code)
+		return nil, fmt.Errorf("create volume %v error: %w", data, respStorageError(resp))
 	}
 
 	respData, ok := resp.Data.(map[string]interface{})
@@ -331,20 +475,8 @@ func (cli *OceanstorClient) CreateLun(ctx context.Context, params map[string]any
 
 // DeleteLun used for delete lun by lun id
 func (cli *OceanstorClient) DeleteLun(ctx context.Context, id string) error {
-	url := fmt.Sprintf("/lun/%s", id)
-	resp, err := cli.Delete(ctx, url, nil)
-	if err != nil {
-		return err
-	}
-
-	code := int64(resp.Error["code"].(float64))
-	if code == lunNotExist {
-		log.AddContext(ctx).Infof("Lun %s does not exist while deleting", id)
-		return nil
-	}
-	if code != 0 {
-		msg := fmt.Sprintf("Delete lun %s error: %d", id, code)
-		return errors.New(msg)
+	if err := cli.DeleteIfExists(ctx, fmt.Sprintf("/lun/%s", id), nil); err != nil {
+		return fmt.Errorf("delete lun %s failed: %w", id, err)
 	}
 
 	return nil
@@ -483,6 +615,73 @@ func (cli *OceanstorClient) UpdateLun(ctx context.Context, lunID string, params
 	return nil
 }
 
+// GetLunOwnerController used for get the owning controller of lun
+func (cli *OceanstorClient) GetLunOwnerController(ctx context.Context, lunID string) (string, error) {
+	lun, err := cli.GetLunByID(ctx, lunID)
+	if err != nil {
+		return "", err
+	}
+
+	controllerID, ok := lun["OWNINGCONTROLLER"].(string)
+	if !ok {
+		return "", pkgUtils.Errorf(ctx, "convert owning controller of lun %s to string failed, data: %v",
+			lunID, lun["OWNINGCONTROLLER"])
+	}
+
+	return controllerID, nil
+}
+
+// SetLunOwnerController used for set the owning controller of lun, the controller must exist on the storage
+func (cli *OceanstorClient) SetLunOwnerController(ctx context.Context, lunID, controllerID string) error {
+	if cli.Product.IsDorado() || cli.Product == constants.OceanStorDoradoV3 || cli.Product.IsDoradoV6OrV7() {
+		return fmt.Errorf("%w on product %s of backend %s", ErrLunOwnershipNotApplicable,
+			cli.Product, cli.BackendID)
+	}
+
+	controllerIDs, err := cli.getControllerIDs(ctx)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get controllers of backend %s error: %v", cli.BackendID, err)
+		return err
+	}
+
+	if !slices.Contains(controllerIDs, controllerID) {
+		return fmt.Errorf("controller %s does not exist on backend %s, available controllers: %v",
+			controllerID, cli.BackendID, controllerIDs)
+	}
+
+	return cli.UpdateLun(ctx, lunID, map[string]interface{}{"OWNINGCONTROLLER": controllerID})
+}
+
+func (cli *OceanstorClient) getControllerIDs(ctx context.Context) ([]string, error) {
+	resp, err := cli.Get(ctx, "/controller", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return nil, fmt.Errorf("get controllers error: %d", code)
+	}
+
+	respData, ok := resp.Data.([]interface{})
+	if !ok {
+		return nil, pkgUtils.Errorf(ctx, "convert controllers to arr failed, data: %v", resp.Data)
+	}
+
+	var controllerIDs []string
+	for _, data := range respData {
+		controller, ok := data.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := controller["ID"].(string); ok {
+			controllerIDs = append(controllerIDs, id)
+		}
+	}
+
+	return controllerIDs, nil
+}
+
 func generateCreateLunDataFromParams(params map[string]any) (map[string]any, error) {
 	data := make(map[string]any)
 
@@ -18,11 +18,16 @@ package client
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/require"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
 )
 
 func TestOceanstorClient_CreateLun_Success(t *testing.T) {
@@ -49,6 +54,27 @@ func TestOceanstorClient_CreateLun_Success(t *testing.T) {
 	require.Contains(t, lun, "WWN")
 }
 
+func TestOceanstorClient_CreateLun_StorageBusy(t *testing.T) {
+	// arrange
+	params := map[string]any{
+		"name":        "test-lun-name",
+		"parentid":    "1",
+		"capacity":    int64(1024 * 1024 * 1024),
+		"description": "test-desc",
+		"alloctype":   1,
+	}
+	busyRespBody := `{"error": {"code": 1077949006, "description": "The system is busy."}}`
+
+	// mock
+	mockClient := getMockClient(200, busyRespBody)
+
+	// action
+	_, err := mockClient.CreateLun(context.Background(), params)
+
+	// assert
+	require.ErrorIs(t, err, base.ErrStorageBusy)
+}
+
 func TestOceanstorClient_CreateLun_UnmarshalAdvancedOptionsFailed(t *testing.T) {
 	// arrange
 	params := map[string]any{
@@ -65,6 +91,143 @@ func TestOceanstorClient_CreateLun_UnmarshalAdvancedOptionsFailed(t *testing.T)
 	require.ErrorContains(t, err, "failed to unmarshal advancedOptions")
 }
 
+func TestOceanstorClient_IsVolumeMapped_Mapped(t *testing.T) {
+	// arrange
+	respBody := `{"data": [{"ID": "1", "NAME": "lun-group"}], "error": {"code": 0, "description": "0"}}`
+
+	// mock
+	mockClient := getMockClient(200, respBody)
+
+	// action
+	mapped, err := mockClient.IsVolumeMapped(context.Background(), "1")
+
+	// assert
+	require.NoError(t, err)
+	require.True(t, mapped)
+}
+
+func TestOceanstorClient_IsVolumeMapped_Unmapped(t *testing.T) {
+	// arrange
+	respBody := `{"error": {"code": 0, "description": "0"}}`
+
+	// mock
+	mockClient := getMockClient(200, respBody)
+
+	// action
+	mapped, err := mockClient.IsVolumeMapped(context.Background(), "1")
+
+	// assert
+	require.NoError(t, err)
+	require.False(t, mapped)
+}
+
+func TestOceanstorClient_GetLunOwnerController(t *testing.T) {
+	// arrange
+	respBody := `{"data": {"ID": "1", "OWNINGCONTROLLER": "0A"}, "error": {"code": 0, "description": "0"}}`
+
+	// mock
+	mockClient := getMockClient(200, respBody)
+
+	// action
+	controllerID, err := mockClient.GetLunOwnerController(context.Background(), "1")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "0A", controllerID)
+}
+
+func TestOceanstorClient_SetLunOwnerController_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{Product: constants.OceanStorV5}}
+	controllers := base.Response{Error: map[string]interface{}{"code": float64(0)},
+		Data: []interface{}{map[string]interface{}{"ID": "0A"}, map[string]interface{}{"ID": "0B"}}}
+	var putData map[string]interface{}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get", controllers, nil).
+		ApplyMethod(cli.RestClient, "Put", func(_ *RestClient, _ context.Context, _ string,
+			data map[string]interface{}) (base.Response, error) {
+			putData = data
+			return base.Response{Error: map[string]interface{}{"code": float64(0)}}, nil
+		})
+	defer patches.Reset()
+
+	// action
+	err := cli.SetLunOwnerController(ctx, "1", "0B")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"OWNINGCONTROLLER": "0B"}, putData)
+}
+
+func TestOceanstorClient_SetLunOwnerController_ControllerNotExist(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{Product: constants.OceanStorV5}}
+	controllers := base.Response{Error: map[string]interface{}{"code": float64(0)},
+		Data: []interface{}{map[string]interface{}{"ID": "0A"}, map[string]interface{}{"ID": "0B"}}}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get", controllers, nil)
+	defer patches.Reset()
+
+	// action
+	err := cli.SetLunOwnerController(ctx, "1", "0C")
+
+	// assert
+	require.ErrorContains(t, err, "controller 0C does not exist")
+}
+
+func TestOceanstorClient_SetLunOwnerController_Dorado(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{Product: constants.OceanStorDoradoV6}}
+
+	// action
+	err := cli.SetLunOwnerController(ctx, "1", "0A")
+
+	// assert
+	require.ErrorIs(t, err, ErrLunOwnershipNotApplicable)
+}
+
+func TestOceanstorClient_RenameLun(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name     string
+		newName  string
+		respBody string
+		wantErr  error
+		errMsg   string
+	}{
+		{name: "success", newName: "new-lun_1.0", respBody: `{"error": {"code": 0, "description": "0"}}`},
+		{name: "not found", newName: "new-lun", respBody: `{"error": {"code": 1077936859}}`,
+			wantErr: ErrObjectNotFound},
+		{name: "too long", newName: strings.Repeat("a", maxLunNameLength+1), errMsg: "the length of name"},
+		{name: "invalid charset", newName: "new lun", errMsg: "can only contain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// mock
+			mockClient := getMockClient(200, tt.respBody)
+
+			// action
+			err := mockClient.RenameLun(context.Background(), "1", tt.newName)
+
+			// assert
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.errMsg != "":
+				require.ErrorContains(t, err, tt.errMsg)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_generateCreateLunDataFromParams(t *testing.T) {
 	// arrange
 	tests := []struct {
@@ -104,3 +267,145 @@ func Test_generateCreateLunDataFromParams(t *testing.T) {
 		})
 	}
 }
+
+func TestOceanstorClient_GetMaxNameLength(t *testing.T) {
+	// arrange
+	v5 := &OceanstorClient{RestClient: &RestClient{Product: constants.OceanStorV5}}
+	v6 := &OceanstorClient{RestClient: &RestClient{Product: constants.OceanStorDoradoV6}}
+
+	// action
+	v5Lun, v5LunErr := v5.GetMaxNameLength(ObjectTypeLun)
+	v6Lun, v6LunErr := v6.GetMaxNameLength(ObjectTypeLun)
+	fs, fsErr := v5.GetMaxNameLength(ObjectTypeFilesystem)
+	dtree, dtreeErr := v6.GetMaxNameLength(ObjectTypeDTree)
+	_, unknownErr := v5.GetMaxNameLength("snapshot")
+
+	// assert
+	require.NoError(t, errors.Join(v5LunErr, v6LunErr, fsErr, dtreeErr))
+	require.Equal(t, maxLunNameLength, v5Lun)
+	require.Equal(t, MaxLunNameLengthOfDoradoV6, v6Lun)
+	require.Equal(t, maxFilesystemNameLength, fs)
+	require.Equal(t, maxDTreeNameLength, dtree)
+	require.ErrorContains(t, unknownErr, "unknown")
+}
+
+func TestOceanstorClient_MakeLunName(t *testing.T) {
+	// arrange
+	v5 := &OceanstorClient{RestClient: &RestClient{Product: constants.OceanStorV5}}
+	v6 := &OceanstorClient{RestClient: &RestClient{Product: constants.OceanStorDoradoV6}}
+	name := strings.Repeat("a", MaxLunNameLengthOfDoradoV6+1)
+
+	// action
+	v5Name := v5.MakeLunName(name)
+	v6Name := v6.MakeLunName(name)
+	v6ShortName := v6.MakeLunName(name[:maxLunNameLength+1])
+
+	// assert
+	require.Len(t, v5Name, maxLunNameLength)
+	require.Len(t, v6Name, MaxLunNameLengthOfDoradoV6)
+	require.Len(t, v6ShortName, maxLunNameLength+1)
+}
+
+func TestOceanstorClient_GetLunByWWN_FilterSupported(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	luns := []interface{}{
+		map[string]interface{}{"ID": "1", "NAME": "lun-1", "WWN": "6a8ffba1005d5e5a0001",
+			"PARENTNAME": "pool0"},
+	}
+	var urls []string
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli.RestClient, "Get", func(_ *RestClient, _ context.Context, u string,
+		_ map[string]interface{}) (base.Response, error) {
+		urls = append(urls, u)
+		return base.Response{Error: map[string]interface{}{"code": float64(0)}, Data: luns}, nil
+	})
+	defer patches.Reset()
+
+	// action
+	got, err := cli.GetLunByWWN(ctx, "6A8FFBA1005D5E5A0001")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, []string{"/lun?filter=WWN::6A8FFBA1005D5E5A0001&range=[0-100]"}, urls)
+	require.Equal(t, &LunInfo{ID: "1", Name: "lun-1", WWN: "6a8ffba1005d5e5a0001", ParentName: "pool0",
+		VStoreName: "System_vStore"}, got)
+}
+
+func TestOceanstorClient_GetLunByWWN_FilterIgnored(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	luns := []interface{}{
+		map[string]interface{}{"ID": "1", "NAME": "lun-1", "WWN": "6a8ffba1005d5e5a0001"},
+	}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get",
+		base.Response{Error: map[string]interface{}{"code": float64(0)}, Data: luns}, nil)
+	defer patches.Reset()
+
+	// action
+	got, err := cli.GetLunByWWN(ctx, "6a8ffba1005d5e5a0002")
+
+	// assert
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
+
+func TestOceanstorClient_GetLunByWWN_FallbackToList(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	firstPage := make([]interface{}, 0, 100)
+	for i := 0; i < 100; i++ {
+		firstPage = append(firstPage, map[string]interface{}{"ID": strconv.Itoa(i), "WWN": "wwn-" + strconv.Itoa(i)})
+	}
+	secondPage := []interface{}{map[string]interface{}{"ID": "100", "NAME": "lun-100", "WWN": "wwn-100"}}
+	var urls []string
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli.RestClient, "Get", func(_ *RestClient, _ context.Context, u string,
+		_ map[string]interface{}) (base.Response, error) {
+		urls = append(urls, u)
+		switch u {
+		case "/lun?range=[0-100]":
+			return base.Response{Error: map[string]interface{}{"code": float64(0)}, Data: firstPage}, nil
+		case "/lun?range=[100-200]":
+			return base.Response{Error: map[string]interface{}{"code": float64(0)}, Data: secondPage}, nil
+		default:
+			return base.Response{Error: map[string]interface{}{"code": float64(parameterIncorrect)}}, nil
+		}
+	})
+	defer patches.Reset()
+
+	// action
+	got, err := cli.GetLunByWWN(ctx, "wwn-100")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, []string{"/lun?filter=WWN::wwn-100&range=[0-100]", "/lun?range=[0-100]",
+		"/lun?range=[100-200]"}, urls)
+	require.Equal(t, "100", got.ID)
+	require.Equal(t, "lun-100", got.Name)
+}
+
+func TestOceanstorClient_GetLunByWWN_QueryFailed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get",
+		base.Response{Error: map[string]interface{}{"code": float64(1077949001), "description": "failed"}}, nil)
+	defer patches.Reset()
+
+	// action
+	got, err := cli.GetLunByWWN(ctx, "wwn-1")
+
+	// assert
+	require.ErrorContains(t, err, "get lun by wwn wwn-1 error")
+	require.Nil(t, got)
+}
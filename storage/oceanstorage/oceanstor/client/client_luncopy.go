@@ -168,20 +168,8 @@ func (cli *OceanstorClient) StopLunCopy(ctx context.Context, lunCopyID string) e
 
 // DeleteLunCopy used for delete lun copy by id
 func (cli *OceanstorClient) DeleteLunCopy(ctx context.Context, lunCopyID string) error {
-	url := fmt.Sprintf("/LUNCOPY/%s", lunCopyID)
-
-	resp, err := cli.Delete(ctx, url, nil)
-	if err != nil {
-		return err
-	}
-
-	code := int64(resp.Error["code"].(float64))
-	if code == lunCopyNotExist {
-		log.AddContext(ctx).Infof("Luncopy %s does not exist while deleting", lunCopyID)
-		return nil
-	}
-	if code != 0 {
-		return fmt.Errorf("Delete luncopy %s error: %d", lunCopyID, code)
+	if err := cli.DeleteIfExists(ctx, fmt.Sprintf("/LUNCOPY/%s", lunCopyID), nil); err != nil {
+		return fmt.Errorf("delete luncopy %s failed: %w", lunCopyID, err)
 	}
 
 	return nil
@@ -20,13 +20,18 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
 const (
 	lunSnapshotNotExist  int64 = 1077937880
 	snapshotNotActivated int64 = 1077937891
+
+	maxSnapshotsPerLunOfDoradoV6  int64 = 1024
+	maxSnapshotsPerLunOfOceanStor int64 = 256
 )
 
 // LunSnapshot defines interfaces for lun snapshot operations
@@ -41,6 +46,10 @@ type LunSnapshot interface {
 	ActivateLunSnapshot(ctx context.Context, snapshotID string) error
 	// DeactivateLunSnapshot used for stop lun snapshot
 	DeactivateLunSnapshot(ctx context.Context, snapshotID string) error
+	// GetLunSnapshotCount used for get snapshot count of lun
+	GetLunSnapshotCount(ctx context.Context, lunID string) (int64, error)
+	// GetMaxSnapshotsPerVolume used for get the maximum snapshot count of a lun supported by the storage
+	GetMaxSnapshotsPerVolume(ctx context.Context) int64
 }
 
 // CreateLunSnapshot used for create lun snapshot
@@ -104,19 +113,8 @@ func (cli *OceanstorClient) GetLunSnapshotByName(ctx context.Context, name strin
 
 // DeleteLunSnapshot used for delete lun snapshot
 func (cli *OceanstorClient) DeleteLunSnapshot(ctx context.Context, snapshotID string) error {
-	url := fmt.Sprintf("/snapshot/%s", snapshotID)
-	resp, err := cli.Delete(ctx, url, nil)
-	if err != nil {
-		return err
-	}
-
-	code := int64(resp.Error["code"].(float64))
-	if code == lunSnapshotNotExist {
-		log.AddContext(ctx).Infof("Lun snapshot %s does not exist while deleting", snapshotID)
-		return nil
-	}
-	if code != 0 {
-		return fmt.Errorf("Delete snapshot %s error: %d", snapshotID, code)
+	if err := cli.DeleteIfExists(ctx, fmt.Sprintf("/snapshot/%s", snapshotID), nil); err != nil {
+		return fmt.Errorf("delete lun snapshot %s failed: %w", snapshotID, err)
 	}
 
 	return nil
@@ -162,3 +160,38 @@ func (cli *OceanstorClient) DeactivateLunSnapshot(ctx context.Context, snapshotI
 
 	return nil
 }
+
+// GetLunSnapshotCount used for get snapshot count of lun
+func (cli *OceanstorClient) GetLunSnapshotCount(ctx context.Context, lunID string) (int64, error) {
+	url := fmt.Sprintf("/snapshot/count?PARENTID=%s", lunID)
+	resp, err := cli.Get(ctx, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	code := int64(resp.Error["code"].(float64))
+	if code != 0 {
+		return 0, fmt.Errorf("Get snapshot count of lun %s error: %d", lunID, code)
+	}
+
+	respData, ok := resp.Data.(map[string]interface{})
+	if !ok {
+		return 0, pkgUtils.Errorf(ctx, "convert respData to map failed, data: %v", resp.Data)
+	}
+
+	countStr, ok := respData["COUNT"].(string)
+	if !ok {
+		return 0, pkgUtils.Errorf(ctx, "convert countStr to string failed, data: %v", respData["COUNT"])
+	}
+
+	return utils.ParseIntWithDefault(countStr, constants.DefaultIntBase, constants.DefaultIntBitSize, 0), nil
+}
+
+// GetMaxSnapshotsPerVolume used for get the maximum snapshot count of a lun supported by the storage
+func (cli *OceanstorClient) GetMaxSnapshotsPerVolume(ctx context.Context) int64 {
+	if cli.Product.IsDoradoV6OrV7() {
+		return maxSnapshotsPerLunOfDoradoV6
+	}
+
+	return maxSnapshotsPerLunOfOceanStor
+}
@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+)
+
+func TestOceanstorClient_GetLunSnapshotCount(t *testing.T) {
+	// arrange
+	cli := mockCli()
+	resp := base.Response{Error: map[string]interface{}{"code": float64(0)},
+		Data: map[string]interface{}{"COUNT": "12"}}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get", resp, nil)
+	defer patches.Reset()
+
+	// act
+	count, err := cli.GetLunSnapshotCount(context.Background(), "10")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, int64(12), count)
+}
+
+func TestOceanstorClient_GetLunSnapshotCount_ErrorCode(t *testing.T) {
+	// arrange
+	cli := mockCli()
+	resp := base.Response{Error: map[string]interface{}{"code": float64(1077936859)}}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get", resp, nil)
+	defer patches.Reset()
+
+	// act
+	_, err := cli.GetLunSnapshotCount(context.Background(), "10")
+
+	// assert
+	require.ErrorContains(t, err, "1077936859")
+}
+
+func TestOceanstorClient_GetMaxSnapshotsPerVolume(t *testing.T) {
+	// arrange
+	doradoCli := mockCli()
+	doradoCli.Product = constants.OceanStorDoradoV6
+	oceanstorCli := mockCli()
+	oceanstorCli.Product = constants.OceanStorV5
+
+	// act
+	doradoMax := doradoCli.GetMaxSnapshotsPerVolume(context.Background())
+	oceanstorMax := oceanstorCli.GetMaxSnapshotsPerVolume(context.Background())
+
+	// assert
+	require.Equal(t, int64(1024), doradoMax)
+	require.Equal(t, int64(256), oceanstorMax)
+}
@@ -0,0 +1,50 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import "fmt"
+
+const (
+	// ObjectTypeLun is the object type of lun whose name length is limited
+	ObjectTypeLun = "lun"
+	// ObjectTypeFilesystem is the object type of file system whose name length is limited
+	ObjectTypeFilesystem = "fs"
+	// ObjectTypeDTree is the object type of dtree whose name length is limited
+	ObjectTypeDTree = "dtree"
+)
+
+// GetMaxNameLength returns the maximum name length of the object type on the storage. The limit is determined
+// by the product in the system info, which is cached by the client and does not change for a backend.
+func (cli *OceanstorClient) GetMaxNameLength(objType string) (int, error) {
+	switch objType {
+	case ObjectTypeLun:
+		return cli.lunNameLimit(), nil
+	case ObjectTypeFilesystem:
+		return maxFilesystemNameLength, nil
+	case ObjectTypeDTree:
+		return maxDTreeNameLength, nil
+	default:
+		return 0, fmt.Errorf("the name length of object type %s is unknown", objType)
+	}
+}
+
+func (cli *OceanstorClient) lunNameLimit() int {
+	if cli.Product.IsDoradoV6OrV7() {
+		return MaxLunNameLengthOfDoradoV6
+	}
+	return maxLunNameLength
+}
@@ -0,0 +1,79 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+type poolCacheEntry struct {
+	pools    map[string]interface{}
+	expireAt time.Time
+}
+
+var (
+	// poolCache stores the pools of each storage device, keyed by the device sn
+	poolCache      = map[string]poolCacheEntry{}
+	poolCacheMutex sync.Mutex
+)
+
+// GetAllPools used for get all pools, the result is reused from the cache of the storage device
+// within PoolCacheTTL if the cache is enabled
+func (cli *OceanstorClient) GetAllPools(ctx context.Context) (map[string]interface{}, error) {
+	deviceSN := cli.GetDeviceSN()
+	if cli.PoolCacheTTL <= 0 || deviceSN == "" {
+		return cli.SystemClient.GetAllPools(ctx)
+	}
+
+	if pools, ok := getCachedPools(deviceSN); ok {
+		log.AddContext(ctx).Debugf("Get pools of device %s from cache", deviceSN)
+		return pools, nil
+	}
+
+	pools, err := cli.SystemClient.GetAllPools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	poolCacheMutex.Lock()
+	defer poolCacheMutex.Unlock()
+	poolCache[deviceSN] = poolCacheEntry{pools: pools, expireAt: time.Now().Add(cli.PoolCacheTTL)}
+	return pools, nil
+}
+
+// InvalidatePoolCache drops the cached pools of the storage device,
+// it is called after the volumes that change the pool usage are created, expanded or deleted
+func (cli *OceanstorClient) InvalidatePoolCache() {
+	poolCacheMutex.Lock()
+	defer poolCacheMutex.Unlock()
+	delete(poolCache, cli.GetDeviceSN())
+}
+
+func getCachedPools(deviceSN string) (map[string]interface{}, bool) {
+	poolCacheMutex.Lock()
+	defer poolCacheMutex.Unlock()
+	entry, ok := poolCache[deviceSN]
+	if !ok || !time.Now().Before(entry.expireAt) {
+		return nil, false
+	}
+
+	return entry.pools, true
+}
@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/client"
+)
+
+func mockPoolCacheCli(deviceSN string, ttl time.Duration) *client.OceanstorClient {
+	cli := mockCli()
+	cli.DeviceId = deviceSN
+	cli.PoolCacheTTL = ttl
+	cli.SystemClient = &base.SystemClient{RestClientInterface: cli.RestClient}
+	return cli
+}
+
+func TestOceanstorClient_GetAllPools_CacheHit(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockPoolCacheCli("sn-cache-hit", time.Minute)
+	defer cli.InvalidatePoolCache()
+	calls := 0
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli.SystemClient, "GetAllPools",
+		func(_ *base.SystemClient, _ context.Context) (map[string]interface{}, error) {
+			calls++
+			return map[string]interface{}{"pool": map[string]interface{}{"ID": "0"}}, nil
+		})
+	defer patches.Reset()
+
+	// act
+	first, firstErr := cli.GetAllPools(ctx)
+	second, secondErr := cli.GetAllPools(ctx)
+
+	// assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.Equal(t, first, second)
+	require.Equal(t, 1, calls)
+}
+
+func TestOceanstorClient_GetAllPools_Invalidate(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockPoolCacheCli("sn-invalidate", time.Minute)
+	other := mockPoolCacheCli("sn-invalidate-other", time.Minute)
+	defer other.InvalidatePoolCache()
+	calls := 0
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli.SystemClient, "GetAllPools",
+		func(_ *base.SystemClient, _ context.Context) (map[string]interface{}, error) {
+			calls++
+			return map[string]interface{}{}, nil
+		})
+	defer patches.Reset()
+
+	// act
+	_, _ = cli.GetAllPools(ctx)
+	_, _ = other.GetAllPools(ctx)
+	cli.InvalidatePoolCache()
+	_, _ = cli.GetAllPools(ctx)
+	_, _ = other.GetAllPools(ctx)
+	cli.InvalidatePoolCache()
+
+	// assert
+	require.Equal(t, 3, calls)
+}
+
+func TestOceanstorClient_GetAllPools_CacheDisabled(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockPoolCacheCli("sn-disabled", 0)
+	calls := 0
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli.SystemClient, "GetAllPools",
+		func(_ *base.SystemClient, _ context.Context) (map[string]interface{}, error) {
+			calls++
+			return map[string]interface{}{}, nil
+		})
+	defer patches.Reset()
+
+	// act
+	_, _ = cli.GetAllPools(ctx)
+	_, _ = cli.GetAllPools(ctx)
+
+	// assert
+	require.Equal(t, 2, calls)
+}
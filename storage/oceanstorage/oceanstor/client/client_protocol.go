@@ -0,0 +1,147 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+const (
+	fcPortRunningStatusLinkUp    = "10"
+	iscsiPortRunningStatusLinkUp = "10"
+
+	// the SUPPORTPROTOCOL of lif is a bitmask of the protocols served by the lif
+	lifProtocolNfs          = 1
+	lifProtocolNVMeOverRoCE = 1024
+
+	protocolFc   = "fc"
+	protocolRoCE = "roce"
+)
+
+// Protocol defines interfaces for front-end protocol operations
+type Protocol interface {
+	// GetSupportedProtocols used for get the protocols which have online service ports on the storage
+	GetSupportedProtocols(ctx context.Context) ([]string, error)
+}
+
+// GetSupportedProtocols used for get the protocols which have online service ports on the storage,
+// the nfs and roce are served by the logic ports, the iscsi by the iscsi target ports and the fc by the fc ports,
+// only the ports which are linked up are counted
+func (cli *OceanstorClient) GetSupportedProtocols(ctx context.Context) ([]string, error) {
+	lifs, err := base.GetBatchObjs(ctx, cli.RestClient, "/lif")
+	if err != nil {
+		return nil, err
+	}
+
+	var protocols []string
+	addProtocol := func(protocol string) {
+		if !slices.Contains(protocols, protocol) {
+			protocols = append(protocols, protocol)
+		}
+	}
+
+	for _, lif := range lifs {
+		if lif["RUNNINGSTATUS"] != lifRunningStatusLinkUp {
+			continue
+		}
+
+		supportProtocol, _ := lif["SUPPORTPROTOCOL"].(string)
+		mask, err := strconv.Atoi(supportProtocol)
+		if err != nil {
+			log.AddContext(ctx).Warningf("parse support protocol %s of lif %v failed, error: %v",
+				supportProtocol, lif["NAME"], err)
+			continue
+		}
+		if mask&lifProtocolNfs != 0 {
+			addProtocol(constants.ProtocolNfs)
+		}
+		if mask&lifProtocolNVMeOverRoCE != 0 {
+			addProtocol(protocolRoCE)
+		}
+	}
+
+	iscsiPortUp, err := cli.hasIscsiPortLinkUp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if iscsiPortUp {
+		addProtocol(constants.ProtocolIscsi)
+	}
+
+	fcPortUp, err := cli.hasFcPortLinkUp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if fcPortUp {
+		addProtocol(protocolFc)
+	}
+
+	slices.Sort(protocols)
+	return protocols, nil
+}
+
+func (cli *OceanstorClient) hasIscsiPortLinkUp(ctx context.Context) (bool, error) {
+	ports, err := cli.GetIscsiTgtPort(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, data := range ports {
+		port, ok := data.(map[string]interface{})
+		if ok && port["RUNNINGSTATUS"] == iscsiPortRunningStatusLinkUp {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (cli *OceanstorClient) hasFcPortLinkUp(ctx context.Context) (bool, error) {
+	resp, err := cli.Get(ctx, "/fc_port", nil)
+	if err != nil {
+		return false, err
+	}
+	if err := resp.AssertErrorCode(); err != nil {
+		return false, err
+	}
+
+	if resp.Data == nil {
+		log.AddContext(ctx).Infof("fc port does not exist")
+		return false, nil
+	}
+
+	ports, ok := resp.Data.([]interface{})
+	if !ok {
+		return false, pkgUtils.Errorf(ctx, "convert fc ports to arr failed, data: %v", resp.Data)
+	}
+
+	for _, data := range ports {
+		port, ok := data.(map[string]interface{})
+		if ok && port["RUNNINGSTATUS"] == fcPortRunningStatusLinkUp {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
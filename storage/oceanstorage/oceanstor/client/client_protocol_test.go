@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+)
+
+func mockProtocolCli() *OceanstorClient {
+	restClient := &RestClient{}
+	return &OceanstorClient{RestClient: restClient, IscsiClient: &base.IscsiClient{RestClientInterface: restClient}}
+}
+
+func mockProtocolPorts(cli *OceanstorClient, lifs, iscsiPorts, fcPorts []interface{}) (*gomonkey.Patches, *[]string) {
+	var urls []string
+	patches := gomonkey.ApplyMethod(cli.RestClient, "Get", func(_ *RestClient, _ context.Context, url string,
+		_ map[string]interface{}) (base.Response, error) {
+		urls = append(urls, url)
+		resp := base.Response{Error: map[string]interface{}{"code": float64(0)}}
+		switch url {
+		case "/lif?range=[0-100]":
+			resp.Data = lifs
+		case "/iscsi_tgt_port":
+			resp.Data = iscsiPorts
+		case "/fc_port":
+			resp.Data = fcPorts
+		}
+		return resp, nil
+	})
+	return patches, &urls
+}
+
+func TestOceanstorClient_GetSupportedProtocols(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockProtocolCli()
+	lifs := []interface{}{
+		map[string]interface{}{"NAME": "lif1", "RUNNINGSTATUS": "10", "SUPPORTPROTOCOL": "3"},
+		map[string]interface{}{"NAME": "lif2", "RUNNINGSTATUS": "11", "SUPPORTPROTOCOL": "1024"},
+	}
+	iscsiPorts := []interface{}{map[string]interface{}{
+		"ID": "0+iqn.2006-08.com.huawei:oceanstor::20000:127.0.0.1", "RUNNINGSTATUS": "10"}}
+	fcPorts := []interface{}{map[string]interface{}{"ID": "1", "RUNNINGSTATUS": "10"}}
+
+	// mock
+	patches, urls := mockProtocolPorts(cli, lifs, iscsiPorts, fcPorts)
+	defer patches.Reset()
+
+	// action
+	protocols, err := cli.GetSupportedProtocols(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, []string{"fc", "iscsi", "nfs"}, protocols)
+	require.Equal(t, []string{"/lif?range=[0-100]", "/iscsi_tgt_port", "/fc_port"}, *urls)
+}
+
+func TestOceanstorClient_GetSupportedProtocols_FcPortsDown(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockProtocolCli()
+	lifs := []interface{}{
+		map[string]interface{}{"NAME": "lif1", "RUNNINGSTATUS": "10", "SUPPORTPROTOCOL": "1024"},
+	}
+	fcPorts := []interface{}{map[string]interface{}{"ID": "1", "RUNNINGSTATUS": "11"}}
+
+	// mock
+	patches, _ := mockProtocolPorts(cli, lifs, nil, fcPorts)
+	defer patches.Reset()
+
+	// action
+	protocols, err := cli.GetSupportedProtocols(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, []string{"roce"}, protocols)
+}
+
+func TestOceanstorClient_GetSupportedProtocols_IscsiPortsDown(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockProtocolCli()
+	iscsiPorts := []interface{}{map[string]interface{}{
+		"ID": "0+iqn.2006-08.com.huawei:oceanstor::20000:127.0.0.1", "RUNNINGSTATUS": "11"}}
+	fcPorts := []interface{}{map[string]interface{}{"ID": "1", "RUNNINGSTATUS": "10"}}
+
+	// mock
+	patches, _ := mockProtocolPorts(cli, nil, iscsiPorts, fcPorts)
+	defer patches.Reset()
+
+	// action
+	protocols, err := cli.GetSupportedProtocols(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, []string{"fc"}, protocols)
+}
@@ -123,16 +123,12 @@ func (cli *OceanstorClient) BatchGetQuota(ctx context.Context, params map[string
 
 // DeleteQuota deletes quota by id
 func (cli *OceanstorClient) DeleteQuota(ctx context.Context, quotaID, vStoreID string, forceFlag bool) error {
-	resp, err := cli.Delete(ctx, fmt.Sprintf("/FS_QUOTA/%v", quotaID), map[string]interface{}{
+	err := cli.DeleteIfExists(ctx, fmt.Sprintf("/FS_QUOTA/%v", quotaID), map[string]interface{}{
 		"forceFlag": forceFlag,
 		"vstoreId":  vStoreID,
 	})
 	if err != nil {
-		return err
-	}
-
-	if utils.ResCodeExist(resp.Error["code"]) {
-		return fmt.Errorf("delete quota failed, quotaID: %v error: %s", quotaID, resp.Error["description"])
+		return fmt.Errorf("delete quota %s failed: %w", quotaID, err)
 	}
 
 	return nil
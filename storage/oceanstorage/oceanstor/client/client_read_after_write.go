@@ -0,0 +1,66 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+var (
+	// readAfterWriteRetries is the number of extra GETs sent when the object created just now is not found
+	readAfterWriteRetries = 2
+	// readAfterWriteInterval is the delay before each extra GET
+	readAfterWriteInterval = 500 * time.Millisecond
+)
+
+type readAfterWriteKey struct{}
+
+// WithReadAfterWrite marks the GET requests sent with the context as reads of objects created just now,
+// the storage may not expose such objects at once, so a not found response is retried for a few times.
+// It should only be used right after a creation, otherwise a genuine not found response is delayed.
+func WithReadAfterWrite(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readAfterWriteKey{}, true)
+}
+
+func isReadAfterWrite(ctx context.Context) bool {
+	enabled, ok := ctx.Value(readAfterWriteKey{}).(bool)
+	return ok && enabled
+}
+
+// getAfterWrite sends the GET request and retries it while the object is not found
+func (cli *RestClient) getAfterWrite(ctx context.Context,
+	url string, data map[string]interface{}) (base.Response, error) {
+	resp, err := cli.Call(ctx, "GET", url, data)
+	for i := 0; i < readAfterWriteRetries && isObjectNotFound(resp); i++ {
+		log.AddContext(ctx).Infof("Object %s is not found right after the creation, retry %d after %s",
+			url, i+1, readAfterWriteInterval)
+
+		select {
+		case <-ctx.Done():
+			return resp, err
+		case <-time.After(readAfterWriteInterval):
+		}
+
+		resp, err = cli.Call(ctx, "GET", url, data)
+	}
+
+	return resp, err
+}
@@ -18,16 +18,32 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
 const (
 	replicationNotExist int64 = 1077937923
+
+	replicationPairRunningStatusNormal = "1"
+)
+
+var (
+	// replicationPairPollInitialInterval is the first interval to query the replication pair while waiting for
+	// its status, the interval doubles up to replicationPairPollMaxInterval
+	replicationPairPollInitialInterval = time.Second
+	// replicationPairPollMaxInterval is the maximum interval to query the replication pair while waiting
+	replicationPairPollMaxInterval = 10 * time.Second
 )
 
+// ErrReplicationPairWaitTimeout indicates an error that the replication pair does not reach the status in time
+var ErrReplicationPairWaitTimeout = errors.New("wait replication pair timeout")
+
 // Replication defines interfaces for replication operations
 type Replication interface {
 	// GetReplicationPairByResID used for get replication
@@ -44,6 +60,10 @@ type Replication interface {
 	SyncReplicationPair(ctx context.Context, pairID string) error
 	// SplitReplicationPair used for split replication pair by pair id
 	SplitReplicationPair(ctx context.Context, pairID string) error
+	// WaitReplicationPairState used for wait replication pair until its running status is the target status
+	WaitReplicationPairState(ctx context.Context, pairID, targetStatus string, timeout time.Duration) error
+	// GetReplicationProgress used for get the sync progress percentage of replication pair
+	GetReplicationProgress(ctx context.Context, pairID string) (int, error)
 }
 
 // CreateReplicationPair used for create replication pair
@@ -107,19 +127,8 @@ func (cli *OceanstorClient) SyncReplicationPair(ctx context.Context, pairID stri
 
 // DeleteReplicationPair used for delete replication pair by pair id
 func (cli *OceanstorClient) DeleteReplicationPair(ctx context.Context, pairID string) error {
-	url := fmt.Sprintf("/REPLICATIONPAIR/%s", pairID)
-	resp, err := cli.Delete(ctx, url, nil)
-	if err != nil {
-		return err
-	}
-
-	code := int64(resp.Error["code"].(float64))
-	if code == replicationNotExist {
-		log.AddContext(ctx).Infof("Replication pair %s does not exist while deleting", pairID)
-		return nil
-	}
-	if code != 0 {
-		return fmt.Errorf("Delete replication pair %s error: %d", pairID, code)
+	if err := cli.DeleteIfExists(ctx, fmt.Sprintf("/REPLICATIONPAIR/%s", pairID), nil); err != nil {
+		return fmt.Errorf("delete replication pair %s failed: %w", pairID, err)
 	}
 
 	return nil
@@ -211,3 +220,55 @@ func (cli *OceanstorClient) GetReplicationvStorePairByvStore(ctx context.Context
 	}
 	return pair, nil
 }
+
+// WaitReplicationPairState used for wait replication pair until its running status is the target status,
+// ErrReplicationPairWaitTimeout is returned if the status is not reached within the timeout
+func (cli *OceanstorClient) WaitReplicationPairState(ctx context.Context, pairID, targetStatus string,
+	timeout time.Duration) error {
+	var status interface{}
+	err := utils.PollUntil(ctx, replicationPairPollInitialInterval, replicationPairPollMaxInterval, timeout,
+		func() (bool, error) {
+			pair, err := cli.GetReplicationPairByID(ctx, pairID)
+			if err != nil {
+				return false, err
+			}
+
+			status = pair["RUNNINGSTATUS"]
+			if status == targetStatus {
+				return true, nil
+			}
+
+			log.AddContext(ctx).Debugf("Replication pair %s is in running status %v with progress %d%%, "+
+				"wait for %s", pairID, status, getReplicationPairProgress(pair), targetStatus)
+			return false, nil
+		})
+	if errors.Is(err, utils.ErrPollTimeout) {
+		return fmt.Errorf("%w, pair %s is in running status %v after %s, expect %s",
+			ErrReplicationPairWaitTimeout, pairID, status, timeout, targetStatus)
+	}
+	if err != nil {
+		return fmt.Errorf("wait replication pair %s for running status %s error: %w", pairID, targetStatus, err)
+	}
+
+	log.AddContext(ctx).Infof("Replication pair %s reaches running status %s", pairID, targetStatus)
+	return nil
+}
+
+// GetReplicationProgress used for get the sync progress percentage of replication pair, the value is between 0 and
+// 100, and it is 100 when the pair is in normal status because all the data is synchronized
+func (cli *OceanstorClient) GetReplicationProgress(ctx context.Context, pairID string) (int, error) {
+	pair, err := cli.GetReplicationPairByID(ctx, pairID)
+	if err != nil {
+		return 0, err
+	}
+
+	return getReplicationPairProgress(pair), nil
+}
+
+func getReplicationPairProgress(pair map[string]interface{}) int {
+	if pair["RUNNINGSTATUS"] == replicationPairRunningStatusNormal {
+		return utils.ProgressMax
+	}
+
+	return utils.ParseProgress(pair["REPLICATIONPROGRESS"])
+}
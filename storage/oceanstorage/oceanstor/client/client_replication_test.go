@@ -0,0 +1,140 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func mockReplicationPairStatus(t *testing.T, statuses ...string) (*int, *gomonkey.Patches) {
+	t.Helper()
+	calls := 0
+	patches := gomonkey.ApplyMethod(testClient, "GetReplicationPairByID",
+		func(_ *OceanstorClient, _ context.Context, pairID string) (map[string]interface{}, error) {
+			status := statuses[min(calls, len(statuses)-1)]
+			calls++
+			return map[string]interface{}{"ID": pairID, "RUNNINGSTATUS": status}, nil
+		})
+	patches.ApplyGlobalVar(&replicationPairPollInitialInterval, time.Millisecond)
+	patches.ApplyGlobalVar(&replicationPairPollMaxInterval, time.Millisecond)
+	return &calls, patches
+}
+
+func TestOceanstorClient_WaitReplicationPairState_Reached(t *testing.T) {
+	// arrange
+	calls, patches := mockReplicationPairStatus(t, "23", "23", "1")
+	defer patches.Reset()
+
+	// act
+	err := testClient.WaitReplicationPairState(context.Background(), "pair-1", "1", time.Minute)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, 3, *calls)
+}
+
+func TestOceanstorClient_WaitReplicationPairState_Timeout(t *testing.T) {
+	// arrange
+	_, patches := mockReplicationPairStatus(t, "23")
+	defer patches.Reset()
+
+	// act
+	err := testClient.WaitReplicationPairState(context.Background(), "pair-1", "1", 10*time.Millisecond)
+
+	// assert
+	require.ErrorIs(t, err, ErrReplicationPairWaitTimeout)
+}
+
+func TestOceanstorClient_WaitReplicationPairState_Canceled(t *testing.T) {
+	// arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, patches := mockReplicationPairStatus(t, "23")
+	defer patches.Reset()
+	patches.ApplyGlobalVar(&replicationPairPollInitialInterval, time.Hour)
+	patches.ApplyGlobalVar(&replicationPairPollMaxInterval, time.Hour)
+
+	// act
+	err := testClient.WaitReplicationPairState(ctx, "pair-1", "1", time.Minute)
+
+	// assert
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestOceanstorClient_WaitReplicationPairState_QueryFailed(t *testing.T) {
+	// arrange
+	queryErr := errors.New("mock query error")
+	patches := gomonkey.ApplyMethodReturn(testClient, "GetReplicationPairByID", nil, queryErr)
+	defer patches.Reset()
+
+	// act
+	err := testClient.WaitReplicationPairState(context.Background(), "pair-1", "1", time.Minute)
+
+	// assert
+	require.ErrorIs(t, err, queryErr)
+}
+
+func TestOceanstorClient_GetReplicationProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		pair     map[string]interface{}
+		expected int
+	}{
+		{name: "synchronizing", expected: 73,
+			pair: map[string]interface{}{"RUNNINGSTATUS": "23", "REPLICATIONPROGRESS": "73"}},
+		{name: "normal", expected: 100,
+			pair: map[string]interface{}{"RUNNINGSTATUS": "1", "REPLICATIONPROGRESS": "0"}},
+		{name: "split without progress", expected: 0,
+			pair: map[string]interface{}{"RUNNINGSTATUS": "26"}},
+		{name: "progress out of range", expected: 100,
+			pair: map[string]interface{}{"RUNNINGSTATUS": "23", "REPLICATIONPROGRESS": "120"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			patches := gomonkey.ApplyMethodReturn(testClient, "GetReplicationPairByID", tt.pair, nil)
+			defer patches.Reset()
+
+			// act
+			progress, err := testClient.GetReplicationProgress(context.Background(), "pair-1")
+
+			// assert
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, progress)
+		})
+	}
+}
+
+func TestOceanstorClient_GetReplicationProgress_QueryFailed(t *testing.T) {
+	// arrange
+	wantErr := errors.New("query failed")
+	patches := gomonkey.ApplyMethodReturn(testClient, "GetReplicationPairByID", nil, wantErr)
+	defer patches.Reset()
+
+	// act
+	_, err := testClient.GetReplicationProgress(context.Background(), "pair-1")
+
+	// assert
+	require.ErrorIs(t, err, wantErr)
+}
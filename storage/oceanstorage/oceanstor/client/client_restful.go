@@ -24,12 +24,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
+	"maps"
 	"net/http"
 	netUrl "net/url"
 	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
@@ -60,10 +63,68 @@ type RestClient struct {
 	DeviceId           string
 	Token              string
 	AuthenticationMode string
+	// LoginScope overrides the scope of the auth info in the secret when login if it is not empty
+	LoginScope string
+	// CredentialsPath is the directory of the mounted secret, the k8s Secret API is used if it is empty
+	CredentialsPath string
+
+	// expectedDeviceSN is the sn of the device recorded at the first login, the client refuses to work with
+	// another device after relogin, e.g. the url is pointed to another array by a misconfigured failover
+	expectedDeviceSN string
 
 	SystemInfoRefreshing uint32
 	ReLoginMutex         sync.Mutex
 	RequestSemaphore     *utils.Semaphore
+	ParallelCount        int
+	UseCert              bool
+	CertSecretMeta       string
+	// CASecretMeta is the secret of the CA bundle that the server certificate is verified against
+	CASecretMeta string
+	// ProxyURL is the proxy the requests are routed through, no proxy is used if it is empty
+	ProxyURL string
+	// Transport is the idle connection and TCP keepalive settings of the http transport, it is kept when the client
+	// is recreated
+	Transport storage.TransportConfig
+	// LoginTimeout bounds the total time of the login attempts across the urls, it is not bounded if not positive
+	LoginTimeout time.Duration
+	// SessionTimeout is the idle timeout of the login session requested from the storage,
+	// the default of the storage is used if it is not positive
+	SessionTimeout time.Duration
+	// negotiatedSessionTimeout is the idle timeout of the session returned by the storage at the last login,
+	// it is zero if the storage does not return it
+	negotiatedSessionTimeout time.Duration
+	// lastActive is the time of the last response of the session
+	lastActive time.Time
+	// sessionMutex guards negotiatedSessionTimeout and lastActive
+	sessionMutex sync.RWMutex
+	// reLoginBackoff delays the relogin after the storage is unreachable
+	reLoginBackoff storage.ReLoginBackoff
+	// VerboseLog logs the bodies of all the requests and responses at info level, except the login ones
+	VerboseLog bool
+	// Headers are the static headers added to every request
+	Headers map[string]string
+	// TenantSemaphore limits the concurrency of each tenant within the RequestSemaphore
+	TenantSemaphore *utils.TenantSemaphore
+	// semaphoreRef references the request semaphore of the storage device shared by the clients
+	semaphoreRef storage.RequestSemaphoreRef
+
+	// MaxResponseBodySize is the maximum bytes of the response body
+	MaxResponseBodySize int64
+
+	// RetryBudget limits the relogin and resend of the requests, so that a failing backend sheds load
+	RetryBudget *utils.RetryBudget
+
+	// RateLimiter bounds the request rate independent of the RequestSemaphore, it is a no-op if it is nil
+	RateLimiter *rate.Limiter
+
+	// SystemCacheTTL is the duration that the result of GetSystem is reused, the cache is disabled if it is negative
+	SystemCacheTTL   time.Duration
+	systemCache      map[string]interface{}
+	systemCacheTime  time.Time
+	systemCacheMutex sync.Mutex
+
+	// PoolCacheTTL is the duration that the result of GetAllPools is reused, the cache is disabled if it is not positive
+	PoolCacheTTL time.Duration
 }
 
 // NewRestClient inits a new rest client
@@ -71,6 +132,16 @@ func NewRestClient(ctx context.Context, param *NewClientConfig) (*RestClient, er
 	var err error
 	var parallelCount int
 
+	if err = storage.ValidateRestURLs(param.Urls); err != nil {
+		log.AddContext(ctx).Errorf("validate urls of backend %s failed, error: %v", param.BackendID, err)
+		return nil, err
+	}
+
+	if err = pkgUtils.CheckLoginScope(param.LoginScope); err != nil {
+		log.AddContext(ctx).Errorf("validate login scope of backend %s failed, error: %v", param.BackendID, err)
+		return nil, err
+	}
+
 	parallelCount, err = strconv.Atoi(param.ParallelNum)
 	if err != nil || parallelCount > MaxParallelCount || parallelCount < MinParallelCount {
 		log.Infof("the config parallelNum %d is invalid, set it to the default value %d",
@@ -79,7 +150,9 @@ func NewRestClient(ctx context.Context, param *NewClientConfig) (*RestClient, er
 	}
 
 	log.AddContext(ctx).Infof("Init parallel count is %d", parallelCount)
-	httpClient, err := storage.NewHTTPClientByCertMeta(ctx, param.UseCert, param.CertSecretMeta)
+	tenantParallelCount := getTenantParallelCount(ctx, param.TenantParallelNum, parallelCount)
+	httpClient, err := storage.NewHTTPClientByCertMeta(ctx, param.UseCert, param.CertSecretMeta, param.CASecretMeta,
+		param.ProxyURL, param.Transport)
 	if err != nil {
 		log.AddContext(ctx).Errorf("new http client by cert meta failed, err is %v", err)
 		return nil, err
@@ -91,13 +164,111 @@ func NewRestClient(ctx context.Context, param *NewClientConfig) (*RestClient, er
 		Storage:          param.Storage,
 		SecretName:       param.SecretName,
 		SecretNamespace:  param.SecretNamespace,
+		CredentialsPath:  param.CredentialsPath,
 		VStoreName:       param.VstoreName,
 		Client:           httpClient,
 		BackendID:        param.BackendID,
 		RequestSemaphore: utils.NewSemaphore(parallelCount),
+		ParallelCount:    parallelCount,
+		UseCert:          param.UseCert,
+		CertSecretMeta:   param.CertSecretMeta,
+		CASecretMeta:     param.CASecretMeta,
+		LoginScope:       param.LoginScope,
+		ProxyURL:         param.ProxyURL,
+		Transport:        param.Transport,
+		LoginTimeout:     param.LoginTimeout,
+		SessionTimeout:   param.SessionTimeout,
+		VerboseLog:       param.VerboseLog,
+		Headers:          maps.Clone(param.Headers),
+		TenantSemaphore:  utils.NewTenantSemaphore(tenantParallelCount),
+		SystemCacheTTL:   getSystemCacheTTL(param.SystemCacheTTL),
+		PoolCacheTTL:     param.PoolCacheTTL,
+
+		MaxResponseBodySize: getMaxResponseBodySize(param.MaxResponseBodySize),
+		RetryBudget:         newRetryBudget(param.RetryBudget, param.RetryBudgetRefillInterval),
+		RateLimiter:         newRateLimiter(param.RateLimit, param.RateBurst),
 	}, nil
 }
 
+func newRateLimiter(limit float64, burst int) *rate.Limiter {
+	if limit <= 0 {
+		return nil
+	}
+
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return rate.NewLimiter(rate.Limit(limit), burst)
+}
+
+// waitRateLimit blocks until the request is allowed by the rate limiter or the ctx is done,
+// it returns immediately if the rate limiter is disabled
+func (cli *RestClient) waitRateLimit(ctx context.Context) error {
+	if cli.RateLimiter == nil {
+		return nil
+	}
+
+	return cli.RateLimiter.Wait(ctx)
+}
+
+// newRetryBudget returns the retry budget of the backend, the budget is opt-in and disabled if it is not positive
+func newRetryBudget(budget int, refillInterval time.Duration) *utils.RetryBudget {
+	if refillInterval <= 0 {
+		refillInterval = DefaultRetryBudgetRefillInterval
+	}
+
+	return utils.NewRetryBudget(budget, refillInterval)
+}
+
+func getMaxResponseBodySize(size int64) int64 {
+	if size <= 0 {
+		return DefaultMaxResponseBodySize
+	}
+
+	return size
+}
+
+// readResponseBody reads the response body, ErrResponseTooLarge is returned if it exceeds MaxResponseBodySize
+func (cli *RestClient) readResponseBody(body io.Reader) ([]byte, error) {
+	limit := getMaxResponseBodySize(cli.MaxResponseBodySize)
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w, the limit is %d bytes", ErrResponseTooLarge, limit)
+	}
+
+	return data, nil
+}
+
+// getTenantParallelCount returns the concurrency limit of each tenant, 0 means no limit
+func getTenantParallelCount(ctx context.Context, tenantParallelNum string, parallelCount int) int {
+	if tenantParallelNum == "" {
+		return 0
+	}
+
+	tenantParallelCount, err := strconv.Atoi(tenantParallelNum)
+	if err != nil || tenantParallelCount < MinParallelCount || tenantParallelCount > parallelCount {
+		log.AddContext(ctx).Warningf("the config tenantParallelNum %s is invalid, it should be %d~%d, "+
+			"disable the tenant concurrency limit", tenantParallelNum, MinParallelCount, parallelCount)
+		return 0
+	}
+
+	log.AddContext(ctx).Infof("Init tenant parallel count is %d", tenantParallelCount)
+	return tenantParallelCount
+}
+
+func getSystemCacheTTL(ttl time.Duration) time.Duration {
+	if ttl == 0 {
+		return DefaultSystemCacheTTL
+	}
+
+	return ttl
+}
+
 // Call provides call for restful request
 func (cli *RestClient) Call(ctx context.Context,
 	method string, url string,
@@ -105,32 +276,122 @@ func (cli *RestClient) Call(ctx context.Context,
 	var r base.Response
 	var err error
 
+	cli.refreshIdleSession(ctx, method, url)
 	r, err = cli.BaseCall(ctx, method, url, data)
 	if !base.NeedReLogin(r, err) {
 		return r, err
 	}
 
+	if err = cli.reLoginForRetry(ctx, method, url); err != nil {
+		return r, err
+	}
+
+	return cli.BaseCall(ctx, method, url, data)
+}
+
+// refreshIdleSession relogins before sending the request if the session has been idle for nearly the session
+// timeout returned by the storage, so that the request is not rejected and resent after the session expires
+func (cli *RestClient) refreshIdleSession(ctx context.Context, method string, url string) {
+	if !cli.isSessionExpiring() {
+		return
+	}
+
+	log.AddContext(ctx).Infof("Session of backend %s is about to expire, relogin before request method: %s, Url: %s",
+		cli.BackendID, method, url)
+	if err := cli.reLoginForRetry(ctx, method, url); err != nil {
+		// the request is still sent, and it relogins again if the session is expired
+		log.AddContext(ctx).Warningf("Refresh session of backend %s failed, error: %v", cli.BackendID, err)
+	}
+}
+
+// isSessionExpiring returns whether the session has been idle for the last part of the session timeout
+func (cli *RestClient) isSessionExpiring() bool {
+	cli.sessionMutex.RLock()
+	defer cli.sessionMutex.RUnlock()
+	if cli.negotiatedSessionTimeout <= 0 || cli.lastActive.IsZero() {
+		return false
+	}
+
+	margin := cli.negotiatedSessionTimeout / sessionRefreshDivisor
+	return time.Since(cli.lastActive) >= cli.negotiatedSessionTimeout-margin
+}
+
+// touchSession records the activity of the session
+func (cli *RestClient) touchSession() {
+	cli.sessionMutex.Lock()
+	defer cli.sessionMutex.Unlock()
+	cli.lastActive = time.Now()
+}
+
+// reLoginForRetry relogins and refreshes the system info so that the failed request can be resent
+func (cli *RestClient) reLoginForRetry(ctx context.Context, method string, url string) error {
 	// Current connection fails, try to relogin to other Urls if exist,
 	// if relogin success, resend the request again.
+	if !cli.RetryBudget.TryAcquire() {
+		log.AddContext(ctx).Warningf("Retry budget of backend %s is exhausted, skip relogin and resend request "+
+			"method: %s, Url: %s", cli.BackendID, method, url)
+		return fmt.Errorf("%w, request method: %s, url: %s", ErrRetryBudgetExhausted, method, url)
+	}
+
 	log.AddContext(ctx).Infof("Try to relogin and resend request method: %s, Url: %s", method, url)
-	err = cli.ReLogin(ctx)
-	if err != nil {
-		return r, err
+	if err := cli.ReLogin(ctx); err != nil {
+		return err
 	}
 
 	// If the logical port changes from storage A to storage B, the system information must be updated.
-	if err = cli.SetSystemInfo(ctx); err != nil {
+	if err := cli.SetSystemInfo(ctx); err != nil {
 		log.AddContext(ctx).Errorf("after relogin, can't get system info, error: %v", err)
-		return r, err
+		return err
 	}
 
-	return cli.BaseCall(ctx, method, url, data)
+	return nil
 }
 
-// BaseCall provides base call for request
+// BaseCall provides base call for request, the request is traced in a span named by the method and the url
 func (cli *RestClient) BaseCall(ctx context.Context, method string, url string,
 	data map[string]interface{}) (base.Response, error) {
-	var r base.Response
+	ctx, span := storage.StartRequestSpan(ctx, method, url, cli.BackendID, cli.GetDeviceSN())
+	r, err := cli.baseCall(ctx, method, url, data)
+	base.EndRequestSpan(span, r, err)
+	return r, err
+}
+
+func (cli *RestClient) baseCall(ctx context.Context, method string, url string,
+	data map[string]interface{}) (base.Response, error) {
+	return cli.doRequest(ctx, method, url, data,
+		func(req *http.Request, resp *http.Response) (base.Response, error) {
+			var r base.Response
+			body, err := cli.readResponseBody(resp.Body)
+			if err != nil {
+				log.AddContext(ctx).Errorf("Read response data error: %v", err)
+				return base.Response{}, err
+			}
+
+			log.FilteredLog(ctx, isFilterLog(method, url), cli.isDebugLog(method, url),
+				fmt.Sprintf("Response method: %s, Url: %s, body: %s", method, req.URL, body))
+
+			if err = storage.CheckJSONResponse(resp, body); err != nil {
+				log.AddContext(ctx).Errorf("Response method: %s, Url: %s error: %v", method, req.URL, err)
+				return base.Response{StatusCode: resp.StatusCode}, err
+			}
+
+			err = json.Unmarshal(body, &r)
+			if err != nil {
+				log.AddContext(ctx).Errorf("json.Unmarshal data %s error: %v", body, err)
+				return base.Response{StatusCode: resp.StatusCode}, err
+			}
+
+			r.StatusCode = resp.StatusCode
+			r.RecordOperationID(ctx, method, url, resp.Header)
+			cli.touchSession()
+			return r, nil
+		})
+}
+
+// doRequest sends the request under the semaphores and the rate limit, the response is parsed by parse
+// before the semaphores are released
+func (cli *RestClient) doRequest(ctx context.Context, method string, url string, data map[string]interface{},
+	parse func(req *http.Request, resp *http.Response) (base.Response, error)) (base.Response, error) {
 	var req *http.Request
 	var err error
 
@@ -152,51 +413,58 @@ func (cli *RestClient) BaseCall(ctx context.Context, method string, url string,
 		return base.Response{}, err
 	}
 
-	log.FilteredLog(ctx, isFilterLog(method, url), utils.IsDebugLog(method, url, debugLog, debugLogRegex),
+	log.FilteredLog(ctx, isFilterLog(method, url), cli.isDebugLog(method, url),
 		fmt.Sprintf("Request method: %s, Url: %s, body: %v", method, req.URL, data))
 
 	if cli.RequestSemaphore == nil {
 		return base.Response{}, errors.New("request semaphore is nil")
 	}
 
-	cli.RequestSemaphore.Acquire()
-	defer cli.RequestSemaphore.Release()
-
-	if storage.RequestSemaphoreMap[cli.GetDeviceSN()] != nil {
-		storage.RequestSemaphoreMap[cli.GetDeviceSN()].Acquire()
-		defer storage.RequestSemaphoreMap[cli.GetDeviceSN()].Release()
-	} else {
-		storage.RequestSemaphoreMap[storage.UninitializedStorage].Acquire()
-		defer storage.RequestSemaphoreMap[storage.UninitializedStorage].Release()
+	tenant := utils.GetTenant(ctx)
+	if err = cli.TenantSemaphore.AcquireCtx(ctx, tenant); err != nil {
+		log.AddContext(ctx).Errorf("Acquire tenant semaphore of method: %s, Url: %s, error: %v",
+			method, req.URL, err)
+		return base.Response{}, err
 	}
+	defer cli.TenantSemaphore.Release(tenant)
 
-	resp, err := cli.Client.Do(req)
-	if err != nil {
-		log.AddContext(ctx).Errorf("Send request method: %s, Url: %s, error: %v", method, req.URL, err)
-		return base.Response{}, errors.New(storage.Unconnected)
+	if err = cli.RequestSemaphore.AcquireCtx(ctx); err != nil {
+		log.AddContext(ctx).Errorf("Acquire request semaphore of method: %s, Url: %s, error: %v",
+			method, req.URL, err)
+		return base.Response{}, err
 	}
-	defer resp.Body.Close()
+	defer cli.RequestSemaphore.Release()
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		log.AddContext(ctx).Errorf("Read response data error: %v", err)
+	storageSemaphore := storage.GetRequestSemaphoreOrDefault(cli.GetDeviceSN())
+	if err = storageSemaphore.AcquireCtx(ctx); err != nil {
+		log.AddContext(ctx).Errorf("Acquire storage semaphore of method: %s, Url: %s, error: %v",
+			method, req.URL, err)
 		return base.Response{}, err
 	}
+	defer storageSemaphore.Release()
 
-	log.FilteredLog(ctx, isFilterLog(method, url), utils.IsDebugLog(method, url, debugLog, debugLogRegex),
-		fmt.Sprintf("Response method: %s, Url: %s, body: %s", method, req.URL, body))
+	if err = cli.waitRateLimit(ctx); err != nil {
+		log.AddContext(ctx).Errorf("Wait for the rate limit of request method: %s, Url: %s, error: %v",
+			method, req.URL, err)
+		return base.Response{}, err
+	}
 
-	err = json.Unmarshal(body, &r)
+	resp, err := cli.Client.Do(req)
 	if err != nil {
-		log.AddContext(ctx).Errorf("json.Unmarshal data %s error: %v", body, err)
-		return base.Response{}, err
+		log.AddContext(ctx).Errorf("Send request method: %s, Url: %s, error: %v", method, req.URL, err)
+		return base.Response{}, errors.New(storage.Unconnected)
 	}
+	defer resp.Body.Close()
 
-	return r, nil
+	return parse(req, resp)
 }
 
 // Get provides http request of GET method
 func (cli *RestClient) Get(ctx context.Context, url string, data map[string]interface{}) (base.Response, error) {
+	if isReadAfterWrite(ctx) {
+		return cli.getAfterWrite(ctx, url, data)
+	}
+
 	return cli.Call(ctx, "GET", url, data)
 }
 
@@ -222,6 +490,7 @@ func (cli *RestClient) GetRequest(ctx context.Context,
 	var req *http.Request
 	var err error
 
+	url, data = withVStoreParam(ctx, method, url, data)
 	reqUrl := cli.Url
 	if cli.DeviceId != "" {
 		reqUrl += "/" + cli.DeviceId
@@ -239,7 +508,7 @@ func (cli *RestClient) GetRequest(ctx context.Context,
 		reqBody = bytes.NewReader(reqBytes)
 	}
 
-	req, err = http.NewRequest(method, reqUrl, reqBody)
+	req, err = http.NewRequestWithContext(storage.RequestContext(ctx), method, reqUrl, reqBody)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Construct http request error: %s", err.Error())
 		return req, err
@@ -247,9 +516,12 @@ func (cli *RestClient) GetRequest(ctx context.Context,
 
 	req.Header.Set("Connection", "keep-alive")
 	req.Header.Set("Content-Type", "application/json")
+	req = storage.TraceConnection(req, cli.BackendID)
+	storage.InjectTraceContext(ctx, req.Header)
+	storage.SetCustomHeaders(ctx, req.Header, cli.Headers)
 
 	if cli.Token != "" {
-		req.Header.Set("iBaseToken", cli.Token)
+		req.Header.Set(storage.AuthTokenHeader, cli.Token)
 	}
 
 	return req, nil
@@ -260,7 +532,7 @@ func (cli *RestClient) Login(ctx context.Context) error {
 	var resp base.Response
 	var err error
 
-	cli.Client, err = storage.NewHTTPClientByBackendID(ctx, cli.BackendID)
+	cli.Client, err = cli.newHTTPClient(ctx)
 	if err != nil {
 		log.AddContext(ctx).Errorf("new http client by backend %s failed, err is %v", cli.BackendID, err)
 		return err
@@ -270,39 +542,30 @@ func (cli *RestClient) Login(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	defer storage.ClearLoginPassword(data)
 
 	cli.DeviceId = ""
 	cli.Token = ""
-	for i, url := range cli.Urls {
-		cli.Url = url + "/deviceManager/rest"
-
-		log.AddContext(ctx).Infof("Try to login %s", cli.Url)
-		resp, err = cli.BaseCall(ctx, "POST", "/xx/sessions", data)
-		if err == nil {
-			/* Sort the login Url to the last slot of san addresses, so that
-			   if this connection error, next time will try other Url first. */
-			cli.Urls[i], cli.Urls[len(cli.Urls)-1] = cli.Urls[len(cli.Urls)-1], cli.Urls[i]
-			break
-		} else if err.Error() != storage.Unconnected {
-			log.AddContext(ctx).Errorf("Login %s error", cli.Url)
-			break
-		}
-
-		log.AddContext(ctx).Warningf("Login %s error due to connection failure, gonna try another Url", cli.Url)
+	resp, err = cli.loginCall(ctx, data)
+	if err == nil && isSessionTimeoutRejected(resp, data) {
+		log.AddContext(ctx).Warningf("Login %s with %s %v is rejected, retry without it, the default "+
+			"session timeout of the storage is used", cli.Url, sessionTimeoutField, data[sessionTimeoutField])
+		delete(data, sessionTimeoutField)
+		resp, err = cli.loginCall(ctx, data)
 	}
-
 	if err != nil {
 		return err
 	}
 
 	errCode, _ := resp.Error["code"].(float64)
 	if code := int64(errCode); code != 0 {
-		msg := fmt.Sprintf("Login %s error: %+v", cli.Url, resp)
+		msg := fmt.Sprintf("Login %s error code %s: %+v", cli.Url, utils.FormatErrorCode(code), resp)
 		if utils.Contains(base.WrongPasswordErrorCodes, code) || utils.Contains(base.AccountBeenLocked, code) ||
 			code == storage.IPLockErrorCode {
 			if err := pkgUtils.SetStorageBackendContentOnlineStatus(ctx, cli.BackendID, false); err != nil {
 				msg = msg + fmt.Sprintf("\nSetStorageBackendContentOffline [%s] failed. error: %v", cli.BackendID, err)
 			}
+			return fmt.Errorf("%w: %s", ErrLoginRejected, msg)
 		}
 		return errors.New(msg)
 	}
@@ -318,28 +581,81 @@ func (cli *RestClient) Login(ctx context.Context) error {
 	return nil
 }
 
+func (cli *RestClient) loginCall(ctx context.Context, data map[string]interface{}) (base.Response, error) {
+	var resp base.Response
+	var err error
+	budget := storage.NewLoginBudget(cli.LoginTimeout)
+	for i, url := range cli.Urls {
+		cli.Url, err = storage.JoinRestURL(url, storage.DeviceManagerRestPath)
+		if err != nil {
+			log.AddContext(ctx).Errorf("Login %s error: %v", url, err)
+			break
+		}
+
+		log.AddContext(ctx).Infof("Try to login %s", cli.Url)
+		resp, err = cli.loginAttempt(ctx, budget, len(cli.Urls)-i, data)
+		if err == nil {
+			/* Sort the login Url to the last slot of san addresses, so that
+			   if this connection error, next time will try other Url first. */
+			cli.Urls[i], cli.Urls[len(cli.Urls)-1] = cli.Urls[len(cli.Urls)-1], cli.Urls[i]
+			break
+		} else if err.Error() != storage.Unconnected {
+			log.AddContext(ctx).Errorf("Login %s error", cli.Url)
+			break
+		}
+
+		log.AddContext(ctx).Warningf("Login %s error due to connection failure, gonna try another Url", cli.Url)
+	}
+
+	if err != nil && err.Error() == storage.Unconnected {
+		if budgetErr := budget.Err(); budgetErr != nil {
+			return base.Response{}, budgetErr
+		}
+		return base.Response{}, fmt.Errorf("%w after trying %d urls: %w", ErrLoginUnreachable, len(cli.Urls), err)
+	}
+
+	return resp, err
+}
+
+// loginAttempt sends the login request to the current url within its share of the login budget
+func (cli *RestClient) loginAttempt(ctx context.Context, budget *storage.LoginBudget, remaining int,
+	data map[string]interface{}) (base.Response, error) {
+	attemptCtx, cancel, err := budget.AttemptContext(ctx, remaining)
+	if err != nil {
+		return base.Response{}, err
+	}
+	defer cancel()
+
+	return cli.BaseCall(attemptCtx, "POST", "/xx/sessions", data)
+}
+
 func (cli *RestClient) setDataFromRespData(ctx context.Context, resp base.Response) error {
 	respData, ok := resp.Data.(map[string]interface{})
 	if !ok {
 		return pkgUtils.Errorln(ctx, fmt.Sprintf("convert resp.Data to map[string]interface{} failed,"+
 			" data type: [%T]", resp.Data))
 	}
-	cli.DeviceId, ok = respData["deviceid"].(string)
+	deviceID, ok := respData["deviceid"].(string)
 	if !ok {
 		return pkgUtils.Errorln(ctx, fmt.Sprintf("convert respData[\"deviceid\"]: [%v] to string failed",
 			respData["deviceid"]))
 	}
 
-	if storage.RequestSemaphoreMap[cli.DeviceId] == nil {
-		storage.RequestSemaphoreMap[cli.DeviceId] = utils.NewSemaphore(storage.MaxStorageThreads)
-	}
-
-	cli.Token, ok = respData["iBaseToken"].(string)
+	token, ok := respData["iBaseToken"].(string)
 	if !ok {
 		return pkgUtils.Errorln(ctx, fmt.Sprintf("convert respData[\"iBaseToken\"]: [%T] to string failed",
 			respData["iBaseToken"]))
 	}
 
+	if err := cli.checkDeviceSN(ctx, deviceID); err != nil {
+		cli.logoutSession(ctx, deviceID, token)
+		return err
+	}
+
+	cli.DeviceId = deviceID
+	cli.semaphoreRef.Bind(cli.DeviceId)
+	cli.Token = token
+
 	vStoreName, exist := respData["vstoreName"].(string)
 	vStoreID, idExist := respData["vstoreId"].(string)
 	if !exist && !idExist {
@@ -358,12 +674,76 @@ func (cli *RestClient) setDataFromRespData(ctx context.Context, resp base.Respon
 		cli.VStoreID = vStoreID
 	}
 
+	cli.setNegotiatedSessionTimeout(ctx, respData)
 	log.AddContext(ctx).Infof("Login %s success", cli.Url)
 	return nil
 }
 
-// Logout logout
+// isSessionTimeoutRejected returns whether the login is rejected because the storage does not support the
+// requested session timeout
+func isSessionTimeoutRejected(resp base.Response, data map[string]interface{}) bool {
+	if _, exist := data[sessionTimeoutField]; !exist {
+		return false
+	}
+
+	errCode, _ := resp.Error["code"].(float64)
+	return int64(errCode) == parameterIncorrect
+}
+
+// setNegotiatedSessionTimeout records the idle timeout of the session returned by the storage,
+// the storage that ignores the requested timeout does not return it, and the default of the storage is in effect
+func (cli *RestClient) setNegotiatedSessionTimeout(ctx context.Context, respData map[string]interface{}) {
+	cli.sessionMutex.Lock()
+	defer cli.sessionMutex.Unlock()
+	cli.negotiatedSessionTimeout = 0
+	cli.lastActive = time.Now()
+	seconds, err := parseSessionTimeout(respData[sessionTimeoutField])
+	if err != nil || seconds <= 0 {
+		if cli.SessionTimeout > 0 {
+			log.AddContext(ctx).Debugf("storage %s does not return the session timeout, the requested %s "+
+				"may be ignored", cli.Url, cli.SessionTimeout)
+		}
+		return
+	}
+
+	cli.negotiatedSessionTimeout = time.Duration(seconds) * time.Second
+	log.AddContext(ctx).Infof("the session timeout of backend %s is %s", cli.BackendID, cli.negotiatedSessionTimeout)
+}
+
+func parseSessionTimeout(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case float64:
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, constants.DefaultIntBase, constants.DefaultIntBitSize)
+	default:
+		return 0, fmt.Errorf("invalid session timeout %v", value)
+	}
+}
+
+// GetSessionTimeout returns the idle timeout of the session returned by the storage at the last login,
+// zero is returned if it is unknown
+func (cli *RestClient) GetSessionTimeout() time.Duration {
+	cli.sessionMutex.RLock()
+	defer cli.sessionMutex.RUnlock()
+	return cli.negotiatedSessionTimeout
+}
+
+// Logout logout and release the reference to the request semaphore of the storage device
 func (cli *RestClient) Logout(ctx context.Context) {
+	defer cli.semaphoreRef.Release()
+	cli.logout(ctx)
+}
+
+// Close logs out the session and releases the resources held by the client, such as the idle connections
+// and the reference to the request semaphore of the device
+func (cli *RestClient) Close(ctx context.Context) {
+	cli.Logout(ctx)
+	storage.CloseIdleConnections(cli.Client)
+	log.AddContext(ctx).Infof("Client of backend %s is closed", cli.BackendID)
+}
+
+func (cli *RestClient) logout(ctx context.Context) {
 	resp, err := cli.BaseCall(ctx, "DELETE", "/sessions", nil)
 	if err != nil {
 		log.AddContext(ctx).Warningf("Logout %s error: %v", cli.Url, err)
@@ -379,6 +759,14 @@ func (cli *RestClient) Logout(ctx context.Context) {
 	log.AddContext(ctx).Infof("Logout %s success", cli.Url)
 }
 
+// logoutSession logs out the session just created on the unintended device instead of leaving it until
+// timeout, the client is left without session afterwards
+func (cli *RestClient) logoutSession(ctx context.Context, deviceID, token string) {
+	cli.DeviceId, cli.Token = deviceID, token
+	cli.logout(ctx)
+	cli.DeviceId, cli.Token = "", ""
+}
+
 // ReLogin logout and login again
 func (cli *RestClient) ReLogin(ctx context.Context) error {
 	oldToken := cli.Token
@@ -389,13 +777,25 @@ func (cli *RestClient) ReLogin(ctx context.Context) error {
 	if cli.Token != "" && oldToken != cli.Token {
 		// Coming here indicates other thread had already done relogin, so no need to relogin again
 		return nil
-	} else if cli.Token != "" {
-		cli.Logout(ctx)
 	}
 
+	if err := cli.reLoginBackoff.Allow(); err != nil {
+		log.AddContext(ctx).Warningf("Skip relogin of backend %s: %v", cli.BackendID, err)
+		return err
+	}
+
+	if cli.Token != "" {
+		// keep the reference to the request semaphore, the device is referenced again after login
+		cli.logout(ctx)
+	}
+
+	// the controller may be switched after relogin, so the cached system info is stale
+	cli.invalidateSystemCache()
+	invalidateRoCEPortalCache(cli.BackendID)
+	invalidateApplicationTypeCache(cli.DeviceId)
 	err := cli.Login(ctx)
-	if err != nil {
-		log.AddContext(ctx).Errorf("Try to relogin error: %v", err)
+	if failure := cli.reLoginBackoff.Record(err); err != nil {
+		log.AddContext(ctx).Errorf("Try to relogin error, failure: %s, err: %v", failure, err)
 		return err
 	}
 
@@ -403,23 +803,33 @@ func (cli *RestClient) ReLogin(ctx context.Context) error {
 }
 
 func (cli *RestClient) getRequestParams(ctx context.Context, backendID string) (map[string]interface{}, error) {
-	params, err := pkgUtils.GetAuthInfoFromBackendID(ctx, backendID)
+	params, err := pkgUtils.SelectAuthSource(cli.CredentialsPath, pkgUtils.NewBackendAuthSource(backendID)).
+		GetAuthInfo(ctx)
 	if err != nil {
 		return nil, err
 	}
 	cli.User = params.User
 
+	password, err := storage.DecryptPassword(ctx, backendID, params.Password)
+	params.Password = ""
+	if err != nil {
+		return nil, err
+	}
+
 	data := map[string]interface{}{
 		"username": params.User,
-		"password": params.Password,
-		"scope":    params.Scope,
+		"password": password,
+		"scope":    cli.getLoginScope(params.Scope),
 	}
-	params.Password = ""
 
 	if len(cli.VStoreName) > 0 && cli.VStoreName != storage.DefaultVStore {
 		data["vstorename"] = cli.VStoreName
 	}
 
+	if seconds := int64(cli.SessionTimeout / time.Second); seconds > 0 {
+		data[sessionTimeoutField] = seconds
+	}
+
 	return data, err
 }
 
@@ -447,33 +857,100 @@ func (cli *RestClient) SetSystemInfo(ctx context.Context) error {
 }
 
 func (cli *RestClient) setBaseInfo(ctx context.Context) error {
-	system, err := cli.GetSystem(ctx)
+	// the system info must be fresh here, because the controller may be switched
+	system, err := cli.getSystemInfo(ctx, true)
 	if err != nil {
 		log.AddContext(ctx).Errorf("get system info failed, error: %v", err)
 		return err
 	}
 
-	cli.Product, err = utils.GetProductVersion(system)
-	if err != nil {
-		log.AddContext(ctx).Errorf("get product version failed, error: %v", err)
-		return err
+	cli.Product = system.Product
+	if system.PointRelease != "" {
+		cli.StorageVersion = system.PointRelease
 	}
 
-	storagePointVersion, ok := system["pointRelease"].(string)
-	if ok {
-		cli.StorageVersion = storagePointVersion
+	if system.WWN != "" {
+		cli.CurrentSiteWwn = system.WWN
 	}
 
-	currentSiteWwn, ok := system["wwn"].(string)
-	if ok {
-		cli.CurrentSiteWwn = currentSiteWwn
+	if system.SN != "" {
+		if err := cli.checkDeviceSN(ctx, system.SN); err != nil {
+			cli.logoutSession(ctx, cli.DeviceId, cli.Token)
+			return err
+		}
 	}
 
 	return nil
 }
 
-// GetSystem used for get system info
+// checkDeviceSN records the device sn at the first login, and returns ErrDeviceSNMismatch if the device
+// is not the recorded one afterwards, so that the data operations never land on an unintended array
+func (cli *RestClient) checkDeviceSN(ctx context.Context, deviceSN string) error {
+	if cli.expectedDeviceSN == "" {
+		cli.expectedDeviceSN = deviceSN
+		return nil
+	}
+
+	if deviceSN != cli.expectedDeviceSN {
+		log.AddContext(ctx).Errorf("backend %s is connected to device %s through %s, but device %s is expected",
+			cli.BackendID, deviceSN, cli.Url, cli.expectedDeviceSN)
+		return fmt.Errorf("%w, backend %s expects device %s, but %s is connected through %s",
+			ErrDeviceSNMismatch, cli.BackendID, cli.expectedDeviceSN, deviceSN, cli.Url)
+	}
+
+	return nil
+}
+
+// GetSystem used for get system info, the result may be reused from the cache within SystemCacheTTL
 func (cli *RestClient) GetSystem(ctx context.Context) (map[string]interface{}, error) {
+	return cli.getSystem(ctx, false)
+}
+
+func (cli *RestClient) getSystem(ctx context.Context, bypassCache bool) (map[string]interface{}, error) {
+	if !bypassCache {
+		if system := cli.getCachedSystem(); system != nil {
+			return system, nil
+		}
+	}
+
+	system, err := cli.querySystem(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cli.setCachedSystem(system)
+	return system, nil
+}
+
+func (cli *RestClient) getCachedSystem() map[string]interface{} {
+	cli.systemCacheMutex.Lock()
+	defer cli.systemCacheMutex.Unlock()
+
+	if cli.systemCache == nil || time.Since(cli.systemCacheTime) >= cli.SystemCacheTTL {
+		return nil
+	}
+
+	return cli.systemCache
+}
+
+func (cli *RestClient) setCachedSystem(system map[string]interface{}) {
+	if cli.SystemCacheTTL <= 0 {
+		return
+	}
+
+	cli.systemCacheMutex.Lock()
+	defer cli.systemCacheMutex.Unlock()
+	cli.systemCache = system
+	cli.systemCacheTime = time.Now()
+}
+
+func (cli *RestClient) invalidateSystemCache() {
+	cli.systemCacheMutex.Lock()
+	defer cli.systemCacheMutex.Unlock()
+	cli.systemCache = nil
+}
+
+func (cli *RestClient) querySystem(ctx context.Context) (map[string]interface{}, error) {
 	resp, err := cli.Get(ctx, "/system/", nil)
 	if err != nil {
 		return nil, err
@@ -537,6 +1014,11 @@ func (cli *RestClient) GetCurrentSiteWwn() string {
 	return cli.CurrentSiteWwn
 }
 
+// GetActiveURL used for get the url which is currently serving requests
+func (cli *RestClient) GetActiveURL() string {
+	return cli.Url
+}
+
 // GetCurrentLif used for get current lif wwn
 func (cli *RestClient) GetCurrentLif(ctx context.Context) string {
 	u, err := netUrl.Parse(cli.Url)
@@ -572,22 +1054,49 @@ func (cli *RestClient) GetLogicPort(ctx context.Context, addr string) (*Lif, err
 	return lifs[0], nil
 }
 
+// newHTTPClient creates the http client by the latest cert secret of the backend,
+// the CA bundle and the proxy of the client are kept
+func (cli *RestClient) newHTTPClient(ctx context.Context) (storage.HTTP, error) {
+	useCert, certMeta, err := pkgUtils.GetCertSecretFromBackendID(ctx, cli.BackendID)
+	if err != nil {
+		return nil, fmt.Errorf("get cert secret from backend %s failed, error: %w", cli.BackendID, err)
+	}
+
+	return storage.NewHTTPClientByCertMeta(ctx, useCert, certMeta, cli.CASecretMeta, cli.ProxyURL, cli.Transport)
+}
+
+// getLoginScope returns the login scope configured for the backend if it is set, otherwise the scope of the secret
+func (cli *RestClient) getLoginScope(secretScope string) string {
+	if cli.LoginScope != "" {
+		return cli.LoginScope
+	}
+
+	return secretScope
+}
+
 // ValidateLogin validates the login info
 func (cli *RestClient) ValidateLogin(ctx context.Context) error {
 	var resp base.Response
 	var err error
 
-	params, err := pkgUtils.GetAuthInfoFromSecret(ctx, cli.SecretName, cli.SecretNamespace)
+	params, err := pkgUtils.SelectAuthSource(cli.CredentialsPath,
+		pkgUtils.NewSecretAuthSource(cli.SecretName, cli.SecretNamespace)).GetAuthInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	password, err := storage.DecryptPassword(ctx, cli.BackendID, params.Password)
+	params.Password = ""
 	if err != nil {
 		return err
 	}
 
 	data := map[string]interface{}{
 		"username": cli.User,
-		"password": params.Password,
-		"scope":    params.Scope,
+		"password": password,
+		"scope":    cli.getLoginScope(params.Scope),
 	}
-	params.Password = ""
+	defer storage.ClearLoginPassword(data)
 
 	if len(cli.VStoreName) > 0 && cli.VStoreName != storage.DefaultVStore {
 		data["vstorename"] = cli.VStoreName
@@ -595,25 +1104,7 @@ func (cli *RestClient) ValidateLogin(ctx context.Context) error {
 
 	cli.DeviceId = ""
 	cli.Token = ""
-	for i, url := range cli.Urls {
-		cli.Url = url + "/deviceManager/rest"
-
-		log.AddContext(ctx).Infof("Try to login %s", cli.Url)
-		resp, err = cli.BaseCall(ctx, "POST", "/xx/sessions", data)
-		if err == nil {
-			/* Sort the login Url to the last slot of san addresses, so that
-			   if this connection error, next time will try other Url first. */
-			cli.Urls[i], cli.Urls[len(cli.Urls)-1] = cli.Urls[len(cli.Urls)-1], cli.Urls[i]
-			break
-		} else if err.Error() != storage.Unconnected {
-			log.AddContext(ctx).Errorf("Login %s error", cli.Url)
-			break
-		}
-
-		log.AddContext(ctx).Warningf("Login %s error due to connection failure, gonna try another Url",
-			cli.Url)
-	}
-
+	resp, err = cli.loginCall(ctx, data)
 	if err != nil {
 		return err
 	}
@@ -20,14 +20,20 @@ package client
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 )
 
 func TestRestClient_ValidateLogin_GetPasswordError(t *testing.T) {
@@ -109,6 +115,146 @@ func TestRestClient_ValidateLogin_StatusCodeError(t *testing.T) {
 	require.ErrorContains(t, gotErr, wantMsg)
 }
 
+func TestNewRestClient_InvalidLoginScope(t *testing.T) {
+	// act
+	cli, err := NewRestClient(context.Background(), &NewClientConfig{LoginScope: "2"})
+
+	// assert
+	require.Nil(t, cli)
+	require.ErrorContains(t, err, "loginScope must be one of")
+}
+
+func TestRestClient_ValidateLogin_LoginScopeOverride(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{LoginScope: "1"})
+	cli.Urls = []string{"https://127.0.0.1:8088"}
+	var loginData map[string]interface{}
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyFuncReturn(pkgUtils.GetAuthInfoFromSecret, &pkgUtils.BackendAuthInfo{Scope: "0"}, nil).
+		ApplyMethod(cli, "BaseCall", func(_ *RestClient, _ context.Context, _, _ string,
+			data map[string]interface{}) (base.Response, error) {
+			loginData = map[string]interface{}{"scope": data["scope"]}
+			return base.Response{}, errors.New("login error")
+		})
+
+	// act
+	_ = cli.ValidateLogin(context.Background())
+
+	// assert
+	require.Equal(t, "1", loginData["scope"])
+}
+
+func TestRestClient_getRequestParams_SecretScope(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{})
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, &pkgUtils.BackendAuthInfo{Scope: "1"}, nil)
+
+	// act
+	data, err := cli.getRequestParams(context.Background(), "backend")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "1", data["scope"])
+}
+
+func TestRestClient_getRequestParams_CredentialsPath(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "user"), []byte("file-user"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "password"), []byte("file-password"), 0600))
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{CredentialsPath: dir})
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyFunc(pkgUtils.GetAuthInfoFromBackendID,
+		func(_ context.Context, _ string) (*pkgUtils.BackendAuthInfo, error) {
+			t.Error("the k8s Secret API should not be used")
+			return nil, errors.New("no permission")
+		})
+
+	// act
+	data, err := cli.getRequestParams(context.Background(), "backend")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "file-user", data["username"])
+	require.Equal(t, "file-password", data["password"])
+	require.Equal(t, "file-user", cli.User)
+}
+
+func TestRestClient_getRequestParams_SessionTimeout(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{SessionTimeout: 30 * time.Minute})
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, &pkgUtils.BackendAuthInfo{Scope: "0"}, nil)
+
+	// act
+	data, err := cli.getRequestParams(context.Background(), "backend")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, int64(1800), data[sessionTimeoutField])
+}
+
+func TestRestClient_getRequestParams_DefaultSessionTimeout(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{})
+
+	// mock
+	patches := gomonkey.NewPatches()
+	defer patches.Reset()
+	patches.ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, &pkgUtils.BackendAuthInfo{Scope: "0"}, nil)
+
+	// act
+	data, err := cli.getRequestParams(context.Background(), "backend")
+
+	// assert
+	require.NoError(t, err)
+	require.NotContains(t, data, sessionTimeoutField)
+}
+
+func TestRestClient_setDataFromRespData_NegotiatedSessionTimeout(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{SessionTimeout: 30 * time.Minute})
+	resp := base.Response{Data: map[string]interface{}{
+		"deviceid":          "device",
+		"iBaseToken":        "token",
+		sessionTimeoutField: float64(1200),
+	}}
+
+	// act
+	err := cli.setDataFromRespData(context.Background(), resp)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, 20*time.Minute, cli.GetSessionTimeout())
+}
+
+func TestRestClient_setDataFromRespData_SessionTimeoutIgnored(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{SessionTimeout: 30 * time.Minute})
+	cli.negotiatedSessionTimeout = time.Minute
+	resp := base.Response{Data: map[string]interface{}{"deviceid": "device", "iBaseToken": "token"}}
+
+	// act
+	err := cli.setDataFromRespData(context.Background(), resp)
+
+	// assert
+	require.NoError(t, err)
+	require.Zero(t, cli.GetSessionTimeout())
+}
+
 func TestRestClient_setDeviceIdFromRespData_TypeConversionError(t *testing.T) {
 	// arrange
 	cli, _ := NewRestClient(context.Background(), &NewClientConfig{})
@@ -126,3 +272,579 @@ func TestRestClient_setDeviceIdFromRespData_TypeConversionError(t *testing.T) {
 	require.Empty(t, cli.DeviceId)
 	require.Empty(t, cli.Token)
 }
+
+func TestRestClient_GetSystem_ReuseCacheWithinTTL(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{})
+	var calls int
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli, "Get",
+		func(_ *RestClient, _ context.Context, _ string, _ map[string]interface{}) (base.Response, error) {
+			calls++
+			return base.Response{Error: map[string]interface{}{"code": float64(0)},
+				Data: map[string]interface{}{"pointRelease": "6.1.8"}}, nil
+		})
+	defer patches.Reset()
+
+	// act
+	first, firstErr := cli.GetSystem(context.Background())
+	second, secondErr := cli.GetSystem(context.Background())
+
+	// assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.Equal(t, first, second)
+	require.Equal(t, 1, calls)
+}
+
+func TestRestClient_GetSystemInfo(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{})
+	system := map[string]interface{}{"ID": "sn", "NAME": "array", "wwn": "wwn", "pointRelease": "6.1.8",
+		"PRODUCTMODE": "820", "PRODUCTVERSION": "V600R005C00", "HEALTHSTATUS": "1", "RUNNINGSTATUS": "1"}
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli, "Get",
+		base.Response{Error: map[string]interface{}{"code": float64(0)}, Data: system}, nil)
+	defer patches.Reset()
+
+	// act
+	info, err := cli.GetSystemInfo(context.Background())
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, &StorageSystemInfo{SN: "sn", Name: "array", WWN: "wwn", PointRelease: "6.1.8",
+		ProductMode: "820", ProductVersion: "V600R005C00", HealthStatus: "1", RunningStatus: "1",
+		Product: constants.OceanStorDoradoV6}, info)
+}
+
+func TestRestClient_GetSystemInfo_NoProductVersion(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{})
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli, "Get", base.Response{Error: map[string]interface{}{"code": float64(0)},
+		Data: map[string]interface{}{"ID": "sn"}}, nil)
+	defer patches.Reset()
+
+	// act
+	_, err := cli.GetSystemInfo(context.Background())
+
+	// assert
+	require.ErrorContains(t, err, "PRODUCTVERSION")
+}
+
+func TestRestClient_GetSystem_BypassCache(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{})
+	var calls int
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli, "Get",
+		func(_ *RestClient, _ context.Context, _ string, _ map[string]interface{}) (base.Response, error) {
+			calls++
+			return base.Response{Error: map[string]interface{}{"code": float64(0)},
+				Data: map[string]interface{}{"PRODUCTVERSION": "V600R005C00"}}, nil
+		}).ApplyMethodReturn(cli, "Logout").ApplyMethodReturn(cli, "Login", nil)
+	defer patches.Reset()
+
+	// act
+	_, err := cli.GetSystem(context.Background())
+	require.NoError(t, err)
+	setErr := cli.setBaseInfo(context.Background())
+	cli.Token = "token"
+	reLoginErr := cli.ReLogin(context.Background())
+	_, getErr := cli.GetSystem(context.Background())
+
+	// assert
+	require.NoError(t, setErr)
+	require.NoError(t, reLoginErr)
+	require.NoError(t, getErr)
+	require.Equal(t, 3, calls)
+}
+
+func TestRestClient_GetSystem_CacheDisabled(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{SystemCacheTTL: -1})
+	var calls int
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli, "Get",
+		func(_ *RestClient, _ context.Context, _ string, _ map[string]interface{}) (base.Response, error) {
+			calls++
+			return base.Response{Error: map[string]interface{}{"code": float64(0)},
+				Data: map[string]interface{}{}}, nil
+		})
+	defer patches.Reset()
+
+	// act
+	_, firstErr := cli.GetSystem(context.Background())
+	_, secondErr := cli.GetSystem(context.Background())
+
+	// assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.Equal(t, 2, calls)
+}
+
+func TestRestClient_readResponseBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   int64
+		body    string
+		wantErr bool
+	}{
+		{name: "below the limit", limit: 10, body: "123456789", wantErr: false},
+		{name: "equal to the limit", limit: 10, body: "1234567890", wantErr: false},
+		{name: "exceed the limit", limit: 10, body: "12345678901", wantErr: true},
+		{name: "default limit", limit: 0, body: "{}", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			cli := &RestClient{MaxResponseBodySize: tt.limit}
+
+			// act
+			got, err := cli.readResponseBody(strings.NewReader(tt.body))
+
+			// assert
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrResponseTooLarge)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.body, string(got))
+		})
+	}
+}
+
+func TestNewRestClient_MaxResponseBodySize(t *testing.T) {
+	// arrange
+	defaultCli, _ := NewRestClient(context.Background(), &NewClientConfig{})
+	customCli, _ := NewRestClient(context.Background(), &NewClientConfig{MaxResponseBodySize: 1024})
+
+	// assert
+	require.Equal(t, DefaultMaxResponseBodySize, defaultCli.MaxResponseBodySize)
+	require.Equal(t, int64(1024), customCli.MaxResponseBodySize)
+}
+
+func TestRestClient_Call_RetryBudgetExhausted(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli, _ := NewRestClient(ctx, &NewClientConfig{RetryBudget: 2, RetryBudgetRefillInterval: time.Hour})
+	unconnected := errors.New(storage.Unconnected)
+	var reLoginCount int
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli, "BaseCall", base.Response{}, unconnected).
+		ApplyMethod(cli, "ReLogin", func(_ *RestClient, _ context.Context) error {
+			reLoginCount++
+			return unconnected
+		})
+	defer patches.Reset()
+
+	// act
+	var errs []error
+	for i := 0; i < 4; i++ {
+		_, err := cli.Call(ctx, "GET", "/system/", nil)
+		errs = append(errs, err)
+	}
+
+	// assert
+	require.Equal(t, 2, reLoginCount)
+	require.ErrorIs(t, errs[0], unconnected)
+	require.ErrorIs(t, errs[1], unconnected)
+	require.ErrorIs(t, errs[2], ErrRetryBudgetExhausted)
+	require.ErrorIs(t, errs[3], ErrRetryBudgetExhausted)
+	require.Equal(t, 0, cli.RetryBudget.Available())
+}
+
+func TestRestClient_Call_RetryBudgetDisabled(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli, _ := NewRestClient(ctx, &NewClientConfig{})
+	wantErr := errors.New(storage.Unconnected)
+	var reLoginCount int
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli, "BaseCall", base.Response{}, wantErr).
+		ApplyMethod(cli, "ReLogin", func(_ *RestClient, _ context.Context) error {
+			reLoginCount++
+			return wantErr
+		})
+	defer patches.Reset()
+
+	// act
+	for i := 0; i < 11; i++ {
+		_, _ = cli.Call(ctx, "GET", "/system/", nil)
+	}
+
+	// assert
+	require.Equal(t, 11, reLoginCount)
+	require.Equal(t, -1, cli.RetryBudget.Available())
+}
+
+func TestRestClient_Logout_ReleaseRequestSemaphore(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	deviceID := "logout-device-id"
+	loginResp := base.Response{Data: map[string]interface{}{"deviceid": deviceID, "iBaseToken": "token"}}
+	first, _ := NewRestClient(ctx, &NewClientConfig{})
+	second, _ := NewRestClient(ctx, &NewClientConfig{})
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(first, "BaseCall", base.Response{
+		Error: map[string]interface{}{"code": float64(0)}}, nil).
+		ApplyMethod(first, "Login", func(cli *RestClient, ctx context.Context) error {
+			return cli.setDataFromRespData(ctx, loginResp)
+		})
+	defer patches.Reset()
+
+	// act
+	require.NoError(t, first.Login(ctx))
+	require.NoError(t, second.Login(ctx))
+	require.NoError(t, first.ReLogin(ctx))
+	first.Logout(ctx)
+	sharedAfterFirstLogout := storage.GetRequestSemaphore(deviceID)
+	second.Logout(ctx)
+
+	// assert
+	require.NotNil(t, sharedAfterFirstLogout)
+	require.Nil(t, storage.GetRequestSemaphore(deviceID))
+}
+
+func TestRestClient_ReLogin_DeviceSNMismatch(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli, _ := NewRestClient(ctx, &NewClientConfig{})
+	loginResps := []base.Response{
+		{Data: map[string]interface{}{"deviceid": "sn-expected", "iBaseToken": "token-1"}},
+		{Data: map[string]interface{}{"deviceid": "sn-other", "iBaseToken": "token-2"}},
+	}
+	var logins int
+	var loggedOutTokens []string
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli, "BaseCall", func(cli *RestClient, _ context.Context, method, url string,
+		_ map[string]interface{}) (base.Response, error) {
+		if method == "DELETE" && url == "/sessions" {
+			loggedOutTokens = append(loggedOutTokens, cli.DeviceId+"/"+cli.Token)
+		}
+		return base.Response{Error: map[string]interface{}{"code": float64(0)}}, nil
+	}).ApplyMethod(cli, "Login", func(cli *RestClient, ctx context.Context) error {
+		resp := loginResps[logins]
+		logins++
+		return cli.setDataFromRespData(ctx, resp)
+	})
+	defer patches.Reset()
+
+	// act
+	loginErr := cli.Login(ctx)
+	reLoginErr := cli.ReLogin(ctx)
+
+	// assert
+	require.NoError(t, loginErr)
+	require.ErrorIs(t, reLoginErr, ErrDeviceSNMismatch)
+	require.Equal(t, []string{"sn-expected/token-1", "sn-other/token-2"}, loggedOutTokens)
+	require.Empty(t, cli.Token)
+	require.Equal(t, "sn-expected", cli.expectedDeviceSN)
+	cli.Logout(ctx)
+}
+
+func TestRestClient_setBaseInfo_DeviceSNMismatch(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{})
+	cli.expectedDeviceSN = "sn-expected"
+	cli.DeviceId, cli.Token = "sn-other", "token-2"
+	var loggedOut bool
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli, "Get", base.Response{
+		Error: map[string]interface{}{"code": float64(0)},
+		Data:  map[string]interface{}{"PRODUCTVERSION": "V600R005C00", "ID": "sn-other"}}, nil).
+		ApplyMethod(cli, "BaseCall", func(cli *RestClient, _ context.Context, method, url string,
+			_ map[string]interface{}) (base.Response, error) {
+			loggedOut = method == "DELETE" && url == "/sessions" && cli.Token == "token-2"
+			return base.Response{Error: map[string]interface{}{"code": float64(0)}}, nil
+		})
+	defer patches.Reset()
+
+	// act
+	err := cli.setBaseInfo(context.Background())
+
+	// assert
+	require.ErrorIs(t, err, ErrDeviceSNMismatch)
+	require.True(t, loggedOut)
+	require.Empty(t, cli.Token)
+}
+
+type idleConnectionsClient struct {
+	storage.HTTP
+	closedIdleConnections int
+}
+
+func (c *idleConnectionsClient) CloseIdleConnections() {
+	c.closedIdleConnections++
+}
+
+func TestRestClient_Close(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	deviceID := "close-device-id"
+	loginResp := base.Response{Data: map[string]interface{}{"deviceid": deviceID, "iBaseToken": "token"}}
+	httpClient := &idleConnectionsClient{}
+	cli, _ := NewRestClient(ctx, &NewClientConfig{})
+	cli.Client = httpClient
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli, "BaseCall", base.Response{
+		Error: map[string]interface{}{"code": float64(0)}}, nil).
+		ApplyMethod(cli, "Login", func(cli *RestClient, ctx context.Context) error {
+			return cli.setDataFromRespData(ctx, loginResp)
+		})
+	defer patches.Reset()
+
+	// act
+	require.NoError(t, cli.Login(ctx))
+	cli.Close(ctx)
+
+	// assert
+	require.Equal(t, 1, httpClient.closedIdleConnections)
+	require.Nil(t, storage.GetRequestSemaphore(deviceID))
+}
+
+func TestRestClient_GetRequest_CustomHeaders(t *testing.T) {
+	// arrange
+	cli, err := NewRestClient(context.Background(), &NewClientConfig{
+		Headers: map[string]string{"X-Route": "array-1", "X-Request-ID": "static", "ibasetoken": "forged"},
+	})
+	require.NoError(t, err)
+	cli.Url = "https://127.0.0.1:8088"
+	cli.Token = "token"
+	ctx := storage.WithHeaders(context.Background(), map[string]string{"X-Request-ID": "correlation-id"})
+
+	// act
+	req, err := cli.GetRequest(ctx, "GET", "/system/", nil)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "array-1", req.Header.Get("X-Route"))
+	require.Equal(t, "correlation-id", req.Header.Get("X-Request-ID"))
+	require.Equal(t, "token", req.Header.Get(storage.AuthTokenHeader))
+}
+
+func TestRestClient_BaseCall_RateLimitCanceled(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{RateLimit: 0.001})
+	cli.Url = "https://127.0.0.1:8088"
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.NoError(t, cli.RateLimiter.Wait(ctx))
+
+	// act
+	_, err := cli.BaseCall(ctx, "GET", "/system/", nil)
+
+	// assert
+	require.ErrorContains(t, err, "would exceed context deadline")
+}
+
+func TestRestClient_BaseCall_SemaphoreCanceled(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{})
+	cli.Url = "https://127.0.0.1:8088"
+	cli.RequestSemaphore = utils.NewSemaphore(1)
+	cli.RequestSemaphore.Acquire()
+	defer cli.RequestSemaphore.Release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// act
+	_, err := cli.BaseCall(ctx, "GET", "/system/", nil)
+
+	// assert
+	require.ErrorIs(t, err, utils.ErrSemaphoreAcquireCanceled)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNewRestClient_RateLimitDisabled(t *testing.T) {
+	// act
+	cli, err := NewRestClient(context.Background(), &NewClientConfig{})
+
+	// assert
+	require.NoError(t, err)
+	require.Nil(t, cli.RateLimiter)
+}
+
+func TestRestClient_isDebugLog(t *testing.T) {
+	tests := []struct {
+		name       string
+		verboseLog bool
+		method     string
+		url        string
+		want       bool
+	}{
+		{name: "allowlisted url", method: "GET", url: "/system/", want: true},
+		{name: "allowlisted url with verbose log", verboseLog: true, method: "GET", url: "/system/", want: false},
+		{name: "other url", method: "POST", url: "/lun", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			cli, _ := NewRestClient(context.Background(), &NewClientConfig{VerboseLog: tt.verboseLog})
+
+			// act
+			got := cli.isDebugLog(tt.method, tt.url)
+
+			// assert
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRestClient_Get_ReadAfterWrite(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{})
+	notFound := base.Response{Error: map[string]interface{}{"code": float64(404), "description": UrlNotFound}}
+	found := base.Response{Error: map[string]interface{}{"code": float64(0)}, Data: map[string]interface{}{}}
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		responses []base.Response
+		wantCalls int
+		wantFound bool
+	}{
+		{name: "not opted in", ctx: context.Background(),
+			responses: []base.Response{notFound, found}, wantCalls: 1},
+		{name: "visible after retry", ctx: WithReadAfterWrite(context.Background()),
+			responses: []base.Response{notFound, found}, wantCalls: 2, wantFound: true},
+		{name: "truly not found", ctx: WithReadAfterWrite(context.Background()),
+			responses: []base.Response{notFound}, wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// mock
+			calls := 0
+			patches := gomonkey.ApplyMethod(cli, "Call", func(_ *RestClient, _ context.Context, _, _ string,
+				_ map[string]interface{}) (base.Response, error) {
+				resp := tt.responses[min(calls, len(tt.responses)-1)]
+				calls++
+				return resp, nil
+			}).ApplyGlobalVar(&readAfterWriteInterval, time.Millisecond)
+			defer patches.Reset()
+
+			// act
+			resp, err := cli.Get(tt.ctx, "/lun/1", nil)
+
+			// assert
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCalls, calls)
+			require.Equal(t, tt.wantFound, !isObjectNotFound(resp))
+		})
+	}
+}
+
+func TestRestClient_Login_AllUrlUnconnected(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli, _ := NewRestClient(ctx, &NewClientConfig{})
+	cli.Urls = []string{"https://127.0.0.1:8088", "https://127.0.0.2:8088"}
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(pkgUtils.GetCertSecretFromBackendID, false, "", nil).
+		ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, &pkgUtils.BackendAuthInfo{}, nil).
+		ApplyFuncReturn(storage.DecryptPassword, "password", nil).
+		ApplyMethodReturn(cli, "BaseCall", base.Response{}, errors.New(storage.Unconnected))
+	defer patches.Reset()
+
+	// act
+	err := cli.Login(ctx)
+
+	// assert
+	require.ErrorIs(t, err, ErrLoginUnreachable)
+	require.ErrorContains(t, err, storage.Unconnected)
+	require.Equal(t, storage.LoginFailureConnectivity, storage.ClassifyLoginFailure(err))
+}
+
+func TestRestClient_Login_WrongPassword(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli, _ := NewRestClient(ctx, &NewClientConfig{})
+	cli.Urls = []string{"https://127.0.0.1:8088"}
+	var online *bool
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(pkgUtils.GetCertSecretFromBackendID, false, "", nil).
+		ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, &pkgUtils.BackendAuthInfo{}, nil).
+		ApplyFuncReturn(storage.DecryptPassword, "password", nil).
+		ApplyMethodReturn(cli, "BaseCall", base.Response{
+			Error: map[string]interface{}{"code": float64(base.WrongPasswordErrorCodes[0])}}, nil).
+		ApplyFunc(pkgUtils.SetStorageBackendContentOnlineStatus, func(_ context.Context, _ string, status bool) error {
+			online = &status
+			return nil
+		})
+	defer patches.Reset()
+
+	// act
+	err := cli.Login(ctx)
+
+	// assert
+	require.ErrorIs(t, err, ErrLoginRejected)
+	require.Equal(t, storage.LoginFailureAuth, storage.ClassifyLoginFailure(err))
+	require.NotNil(t, online)
+	require.False(t, *online)
+}
+
+func TestRestClient_Login_SessionTimeoutRejected(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli, _ := NewRestClient(ctx, &NewClientConfig{SessionTimeout: 30 * time.Minute})
+	cli.Urls = []string{"https://127.0.0.1:8088"}
+	var requests []map[string]interface{}
+
+	// mock
+	patches := gomonkey.ApplyFuncReturn(pkgUtils.GetCertSecretFromBackendID, false, "", nil).
+		ApplyFuncReturn(pkgUtils.GetAuthInfoFromBackendID, &pkgUtils.BackendAuthInfo{}, nil).
+		ApplyFuncReturn(storage.DecryptPassword, "password", nil).
+		ApplyFuncReturn(pkgUtils.SetStorageBackendContentOnlineStatus, nil).
+		ApplyMethod(cli, "BaseCall", func(_ *RestClient, _ context.Context, _ string, _ string,
+			data map[string]interface{}) (base.Response, error) {
+			_, withTimeout := data[sessionTimeoutField]
+			requests = append(requests, map[string]interface{}{sessionTimeoutField: withTimeout})
+			if withTimeout {
+				return base.Response{Error: map[string]interface{}{"code": float64(parameterIncorrect)}}, nil
+			}
+			return base.Response{Error: map[string]interface{}{"code": float64(0)},
+				Data: map[string]interface{}{"deviceid": "device", "iBaseToken": "token"}}, nil
+		})
+	defer patches.Reset()
+
+	// act
+	err := cli.Login(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, []map[string]interface{}{{sessionTimeoutField: true}, {sessionTimeoutField: false}}, requests)
+	require.Zero(t, cli.GetSessionTimeout())
+}
+
+func TestRestClient_isSessionExpiring(t *testing.T) {
+	// arrange
+	cli, _ := NewRestClient(context.Background(), &NewClientConfig{})
+
+	// act & assert
+	require.False(t, cli.isSessionExpiring())
+
+	cli.negotiatedSessionTimeout = 10 * time.Minute
+	cli.lastActive = time.Now().Add(-5 * time.Minute)
+	require.False(t, cli.isSessionExpiring())
+
+	cli.lastActive = time.Now().Add(-9*time.Minute - 30*time.Second)
+	require.True(t, cli.isSessionExpiring())
+
+	cli.touchSession()
+	require.False(t, cli.isSessionExpiring())
+}
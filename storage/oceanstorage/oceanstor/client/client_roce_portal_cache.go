@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+type rocePortalCacheEntry struct {
+	portal   map[string]interface{}
+	expireAt time.Time
+}
+
+var (
+	// rocePortalCacheTTL is the duration that a discovered RoCE portal is reused by the following connects
+	rocePortalCacheTTL = 30 * time.Second
+
+	// rocePortalCache stores the RoCE portals of each backend, keyed by the backend id and then the portal ip
+	rocePortalCache      = map[string]map[string]rocePortalCacheEntry{}
+	rocePortalCacheMutex sync.Mutex
+)
+
+// GetRoCEPortalByIP used for get RoCE portal by ip, the portal is reused from the cache of the backend
+// within rocePortalCacheTTL, the portals that do not exist are not cached so that they can be found once created
+func (cli *OceanstorClient) GetRoCEPortalByIP(ctx context.Context, tgtPortal string) (map[string]interface{}, error) {
+	if cli.BackendID == "" {
+		return cli.RoCEClient.GetRoCEPortalByIP(ctx, tgtPortal)
+	}
+
+	if portal, ok := getCachedRoCEPortal(cli.BackendID, tgtPortal); ok {
+		log.AddContext(ctx).Debugf("Get RoCE portal %s of backend %s from cache", tgtPortal, cli.BackendID)
+		return portal, nil
+	}
+
+	portal, err := cli.RoCEClient.GetRoCEPortalByIP(ctx, tgtPortal)
+	if err != nil || portal == nil {
+		return portal, err
+	}
+
+	rocePortalCacheMutex.Lock()
+	defer rocePortalCacheMutex.Unlock()
+	if _, ok := rocePortalCache[cli.BackendID]; !ok {
+		rocePortalCache[cli.BackendID] = map[string]rocePortalCacheEntry{}
+	}
+	rocePortalCache[cli.BackendID][tgtPortal] = rocePortalCacheEntry{
+		portal:   portal,
+		expireAt: time.Now().Add(rocePortalCacheTTL),
+	}
+	return portal, nil
+}
+
+func getCachedRoCEPortal(backendID, tgtPortal string) (map[string]interface{}, bool) {
+	rocePortalCacheMutex.Lock()
+	defer rocePortalCacheMutex.Unlock()
+	entry, ok := rocePortalCache[backendID][tgtPortal]
+	if !ok || !time.Now().Before(entry.expireAt) {
+		return nil, false
+	}
+
+	return entry.portal, true
+}
+
+// invalidateRoCEPortalCache drops the cached RoCE portals of the backend,
+// the portals may be different after relogin, e.g. the controller is switched
+func invalidateRoCEPortalCache(backendID string) {
+	rocePortalCacheMutex.Lock()
+	defer rocePortalCacheMutex.Unlock()
+	delete(rocePortalCache, backendID)
+}
@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+)
+
+func mockRoCEPortalCacheCli(t *testing.T, backendID string) (*OceanstorClient, *int, *gomonkey.Patches) {
+	t.Helper()
+	restClient, _ := NewRestClient(context.Background(), &NewClientConfig{BackendID: backendID})
+	cli := &OceanstorClient{RestClient: restClient, RoCEClient: &base.RoCEClient{RestClientInterface: restClient}}
+	t.Cleanup(func() { invalidateRoCEPortalCache(backendID) })
+
+	var mutex sync.Mutex
+	calls := 0
+	patches := gomonkey.ApplyMethod(cli.RoCEClient, "GetRoCEPortalByIP",
+		func(_ *base.RoCEClient, _ context.Context, tgtPortal string) (map[string]interface{}, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			calls++
+			return map[string]interface{}{"IPV4ADDR": tgtPortal, "SUPPORTPROTOCOL": "64"}, nil
+		})
+	return cli, &calls, patches
+}
+
+func TestOceanstorClient_GetRoCEPortalByIP_CacheHit(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli, calls, patches := mockRoCEPortalCacheCli(t, "roce-cache-hit")
+	defer patches.Reset()
+
+	// act
+	portal, err := cli.GetRoCEPortalByIP(ctx, "192.168.1.1")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = cli.GetRoCEPortalByIP(ctx, "192.168.1.1")
+		}()
+	}
+	wg.Wait()
+	_, otherErr := cli.GetRoCEPortalByIP(ctx, "192.168.1.2")
+
+	// assert
+	require.NoError(t, err)
+	require.NoError(t, otherErr)
+	require.Equal(t, "192.168.1.1", portal["IPV4ADDR"])
+	require.Equal(t, 2, *calls)
+}
+
+func TestOceanstorClient_GetRoCEPortalByIP_Expired(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli, calls, patches := mockRoCEPortalCacheCli(t, "roce-expired")
+	defer patches.Reset()
+
+	// mock
+	patches.ApplyGlobalVar(&rocePortalCacheTTL, time.Duration(0))
+
+	// act
+	_, firstErr := cli.GetRoCEPortalByIP(ctx, "192.168.1.1")
+	_, secondErr := cli.GetRoCEPortalByIP(ctx, "192.168.1.1")
+
+	// assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.Equal(t, 2, *calls)
+}
+
+func TestOceanstorClient_GetRoCEPortalByIP_InvalidatedOnReLogin(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli, calls, patches := mockRoCEPortalCacheCli(t, "roce-relogin")
+	defer patches.Reset()
+
+	// mock
+	patches.ApplyMethodReturn(cli.RestClient, "Login", nil)
+
+	// act
+	_, firstErr := cli.GetRoCEPortalByIP(ctx, "192.168.1.1")
+	reLoginErr := cli.ReLogin(ctx)
+	_, secondErr := cli.GetRoCEPortalByIP(ctx, "192.168.1.1")
+
+	// assert
+	require.NoError(t, firstErr)
+	require.NoError(t, reLoginErr)
+	require.NoError(t, secondErr)
+	require.Equal(t, 2, *calls)
+}
@@ -0,0 +1,174 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+const (
+	// sessionPageSize is the page size when listing the online sessions
+	sessionPageSize = 100
+)
+
+// Session defines interfaces for the login session operations
+type Session interface {
+	// ListSessions used for list the online sessions of the storage
+	ListSessions(ctx context.Context) ([]*StorageSession, error)
+	// CleanStaleSessions used for find or log out the stale sessions left by the plugin
+	CleanStaleSessions(ctx context.Context, param *StaleSessionParam) ([]*StorageSession, error)
+}
+
+// StorageSession holds the information of an online session of the storage
+type StorageSession struct {
+	ID        string
+	UserName  string
+	IP        string
+	LoginTime time.Time
+}
+
+// StaleSessionParam holds the parameters to identify the stale sessions
+type StaleSessionParam struct {
+	// UserPattern is a path.Match pattern of the user names of the sessions, it is required
+	UserPattern string
+	// MinLoginAge is the minimum time since the login of the stale sessions, it is required
+	MinLoginAge time.Duration
+	// Logout logs out the stale sessions, otherwise they are only found and returned
+	Logout bool
+}
+
+// ListSessions used for list the online sessions of the storage
+func (cli *OceanstorClient) ListSessions(ctx context.Context) ([]*StorageSession, error) {
+	var sessions []*StorageSession
+	for start := 0; ; start += sessionPageSize {
+		url := fmt.Sprintf("/online_user?range=[%d-%d]", start, start+sessionPageSize)
+		resp, err := cli.Get(ctx, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		code, ok := resp.Error["code"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("list sessions error: invalid response %v", resp.Error)
+		}
+		if int64(code) != 0 {
+			return nil, fmt.Errorf("list sessions error: %d", int64(code))
+		}
+
+		respData, ok := resp.Data.([]interface{})
+		if !ok {
+			// no session left in the page
+			return sessions, nil
+		}
+
+		for _, item := range respData {
+			data, ok := item.(map[string]interface{})
+			if !ok {
+				log.AddContext(ctx).Warningf("convert session %v to map failed", item)
+				continue
+			}
+			sessions = append(sessions, parseStorageSession(data))
+		}
+
+		if len(respData) < sessionPageSize {
+			return sessions, nil
+		}
+	}
+}
+
+// CleanStaleSessions finds the sessions of the matched users which were logged in before the minimum login age,
+// and logs them out if Logout is set, so that a backend refusing logins because of the session limit can be
+// recovered by the operator from the sessions left by crashed controllers. It is never run by the plugin itself.
+// The storage only reports the login time of a session rather than its last activity, so a session still in use
+// is logged out as well if it is old enough, and its client has to relogin. Hence the pattern should only match
+// the users dedicated to the plugin, and the sessions found without Logout should be checked first.
+// The returned sessions are the ones found, or the ones logged out successfully if Logout is set.
+func (cli *OceanstorClient) CleanStaleSessions(ctx context.Context,
+	param *StaleSessionParam) ([]*StorageSession, error) {
+	if param == nil || param.UserPattern == "" {
+		return nil, errors.New("the user pattern of the stale sessions is required")
+	}
+	if param.MinLoginAge <= 0 {
+		return nil, fmt.Errorf("the minimum login age %s of the stale sessions must be positive", param.MinLoginAge)
+	}
+	userName, minAge := param.UserPattern, param.MinLoginAge
+
+	sessions, err := cli.ListSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []*StorageSession
+	var errs []error
+	now := time.Now()
+	for _, session := range sessions {
+		matched, err := path.Match(userName, session.UserName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user name pattern %s: %w", userName, err)
+		}
+		if !matched || session.LoginTime.IsZero() || now.Sub(session.LoginTime) < minAge {
+			continue
+		}
+
+		if !param.Logout {
+			stale = append(stale, session)
+			log.AddContext(ctx).Infof("Found stale session %s of user %s from %s, login at %s",
+				session.ID, session.UserName, session.IP, session.LoginTime)
+			continue
+		}
+
+		if err := cli.deleteSession(ctx, session.ID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		stale = append(stale, session)
+		log.AddContext(ctx).Infof("Logged out stale session %s of user %s from %s, login at %s",
+			session.ID, session.UserName, session.IP, session.LoginTime)
+	}
+
+	return stale, errors.Join(errs...)
+}
+
+func (cli *OceanstorClient) deleteSession(ctx context.Context, sessionID string) error {
+	url := fmt.Sprintf("/online_user/%s", sessionID)
+	if err := cli.DeleteIfExists(ctx, url, nil); err != nil {
+		return fmt.Errorf("log out session %s failed: %w", sessionID, err)
+	}
+
+	return nil
+}
+
+func parseStorageSession(data map[string]interface{}) *StorageSession {
+	session := &StorageSession{}
+	session.ID, _ = data["ID"].(string)
+	session.UserName, _ = data["NAME"].(string)
+	session.IP, _ = data["IPADDRESS"].(string)
+
+	loginTime, _ := data["LOGINTIME"].(string)
+	if seconds, err := strconv.ParseInt(loginTime, 10, 64); err == nil {
+		session.LoginTime = time.Unix(seconds, 0)
+	}
+
+	return session
+}
@@ -0,0 +1,150 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/client"
+)
+
+func mockSessions(cli *client.OceanstorClient, sessions ...map[string]interface{}) *gomonkey.Patches {
+	data := make([]interface{}, 0, len(sessions))
+	for _, session := range sessions {
+		data = append(data, session)
+	}
+
+	return gomonkey.ApplyMethodReturn(cli.RestClient, "Get",
+		base.Response{Error: map[string]interface{}{"code": float64(0)}, Data: data}, nil)
+}
+
+func loginAgo(age time.Duration) string {
+	return strconv.FormatInt(time.Now().Add(-age).Unix(), 10)
+}
+
+func TestOceanstorClient_ListSessions(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockCli()
+	loginTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	// mock
+	patches := mockSessions(cli, map[string]interface{}{"ID": "1", "NAME": "user", "IPADDRESS": "10.0.0.1",
+		"LOGINTIME": strconv.FormatInt(loginTime.Unix(), 10)})
+	defer patches.Reset()
+
+	// act
+	sessions, err := cli.ListSessions(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, []*client.StorageSession{{ID: "1", UserName: "user", IP: "10.0.0.1", LoginTime: loginTime}},
+		sessions)
+}
+
+func TestOceanstorClient_CleanStaleSessions(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockCli()
+	var deleted []string
+
+	// mock
+	patches := mockSessions(cli,
+		map[string]interface{}{"ID": "1", "NAME": "user", "LOGINTIME": loginAgo(48 * time.Hour)},
+		map[string]interface{}{"ID": "2", "NAME": "user", "LOGINTIME": loginAgo(time.Hour)},
+		map[string]interface{}{"ID": "3", "NAME": "admin", "LOGINTIME": loginAgo(48 * time.Hour)}).
+		ApplyMethod(cli, "DeleteIfExists",
+			func(_ *client.OceanstorClient, _ context.Context, url string, _ map[string]interface{}) error {
+				deleted = append(deleted, url)
+				return nil
+			})
+	defer patches.Reset()
+
+	// act
+	stale, err := cli.CleanStaleSessions(ctx,
+		&client.StaleSessionParam{UserPattern: "user", MinLoginAge: 24 * time.Hour, Logout: true})
+
+	// assert
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	require.Equal(t, "1", stale[0].ID)
+	require.Equal(t, []string{"/online_user/1"}, deleted)
+}
+
+func TestOceanstorClient_CleanStaleSessions_DefaultDryRunPattern(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockCli()
+
+	// mock
+	patches := mockSessions(cli,
+		map[string]interface{}{"ID": "1", "NAME": "csi_a", "LOGINTIME": loginAgo(2 * time.Hour)},
+		map[string]interface{}{"ID": "2", "NAME": "csi_b", "LOGINTIME": loginAgo(2 * time.Hour)},
+		map[string]interface{}{"ID": "3", "NAME": "admin", "LOGINTIME": loginAgo(2 * time.Hour)}).
+		ApplyMethodReturn(cli, "DeleteIfExists", assert.AnError)
+	defer patches.Reset()
+
+	// act
+	stale, err := cli.CleanStaleSessions(ctx,
+		&client.StaleSessionParam{UserPattern: "csi_*", MinLoginAge: time.Hour})
+
+	// assert
+	require.NoError(t, err)
+	require.Len(t, stale, 2)
+}
+
+func TestOceanstorClient_CleanStaleSessions_LogoutFailed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockCli()
+
+	// mock
+	patches := mockSessions(cli,
+		map[string]interface{}{"ID": "1", "NAME": "user", "LOGINTIME": loginAgo(48 * time.Hour)}).
+		ApplyMethodReturn(cli, "DeleteIfExists", assert.AnError)
+	defer patches.Reset()
+
+	// act
+	stale, err := cli.CleanStaleSessions(ctx,
+		&client.StaleSessionParam{UserPattern: "user", MinLoginAge: 24 * time.Hour, Logout: true})
+
+	// assert
+	require.ErrorIs(t, err, assert.AnError)
+	require.Empty(t, stale)
+}
+
+func TestOceanstorClient_CleanStaleSessions_ParamRequired(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockCli()
+
+	// act
+	_, patternErr := cli.CleanStaleSessions(ctx, &client.StaleSessionParam{MinLoginAge: time.Hour})
+	_, ageErr := cli.CleanStaleSessions(ctx, &client.StaleSessionParam{UserPattern: "user"})
+
+	// assert
+	require.ErrorContains(t, patternErr, "user pattern")
+	require.ErrorContains(t, ageErr, "minimum login age")
+}
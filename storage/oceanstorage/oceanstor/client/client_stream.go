@@ -0,0 +1,158 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+// StreamHandler handles an item of the data list of the response
+type StreamHandler func(item json.RawMessage) error
+
+// ErrResponseDataNotList indicates an error that the data of the streamed response is not a list
+var ErrResponseDataNotList = errors.New("response data is not a list")
+
+// CallStream provides call for restful request whose response data is a list, the items of the list are
+// decoded and passed to the handler one by one instead of the whole body being buffered.
+// The Data of the returned response is always nil, and the request is resent after relogin
+// only if no item has been handled.
+func (cli *RestClient) CallStream(ctx context.Context, method string, url string,
+	data map[string]interface{}, handler StreamHandler) (base.Response, error) {
+	var handled int
+	countedHandler := func(item json.RawMessage) error {
+		handled++
+		return handler(item)
+	}
+
+	r, err := cli.BaseCallStream(ctx, method, url, data, countedHandler)
+	if handled > 0 || !base.NeedReLogin(r, err) {
+		return r, err
+	}
+
+	if err = cli.reLoginForRetry(ctx, method, url); err != nil {
+		return r, err
+	}
+
+	return cli.BaseCallStream(ctx, method, url, data, handler)
+}
+
+// BaseCallStream provides base streaming call for request, the request is traced in a span
+// named by the method and the url
+func (cli *RestClient) BaseCallStream(ctx context.Context, method string, url string,
+	data map[string]interface{}, handler StreamHandler) (base.Response, error) {
+	ctx, span := storage.StartRequestSpan(ctx, method, url, cli.BackendID, cli.GetDeviceSN())
+	r, err := cli.doRequest(ctx, method, url, data,
+		func(req *http.Request, resp *http.Response) (base.Response, error) {
+			r, count, err := decodeStreamResponse(json.NewDecoder(resp.Body), handler)
+			r.StatusCode = resp.StatusCode
+			if err != nil {
+				log.AddContext(ctx).Errorf("Decode streamed response of method: %s, Url: %s error: %v",
+					method, req.URL, err)
+				return r, err
+			}
+
+			log.AddContext(ctx).Debugf("Response method: %s, Url: %s, %d items are streamed, error: %v",
+				method, req.URL, count, r.Error)
+			return r, nil
+		})
+	base.EndRequestSpan(span, r, err)
+	return r, err
+}
+
+// decodeStreamResponse decodes the response like {"data": [...], "error": {...}} token by token,
+// so that only one item of the data list is held in memory at a time
+func decodeStreamResponse(decoder *json.Decoder, handler StreamHandler) (base.Response, int, error) {
+	var r base.Response
+	var count int
+	if err := expectDelim(decoder, '{'); err != nil {
+		return r, count, err
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return r, count, err
+		}
+
+		switch token {
+		case "data":
+			count, err = decodeStreamData(decoder, handler)
+		case "error":
+			err = decoder.Decode(&r.Error)
+		default:
+			var ignored json.RawMessage
+			err = decoder.Decode(&ignored)
+		}
+
+		if err != nil {
+			return r, count, err
+		}
+	}
+
+	return r, count, expectDelim(decoder, '}')
+}
+
+func decodeStreamData(decoder *json.Decoder, handler StreamHandler) (int, error) {
+	var count int
+	token, err := decoder.Token()
+	if err != nil {
+		return count, err
+	}
+
+	if token == nil {
+		return count, nil
+	}
+
+	if token != json.Delim('[') {
+		return count, fmt.Errorf("%w, got token %v", ErrResponseDataNotList, token)
+	}
+
+	for decoder.More() {
+		var item json.RawMessage
+		if err = decoder.Decode(&item); err != nil {
+			return count, err
+		}
+
+		count++
+		if err = handler(item); err != nil {
+			return count, err
+		}
+	}
+
+	return count, expectDelim(decoder, ']')
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("unexpected token %v, expect %v", token, delim)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestClient_CallStream_Success(t *testing.T) {
+	// arrange
+	body := `{"data": [{"ID": "1"}, {"ID": "2"}], "error": {"code": 0, "description": "0"}}`
+	var ids []string
+
+	// mock
+	mockClient := getMockClient(http.StatusOK, body)
+
+	// act
+	resp, err := mockClient.CallStream(context.Background(), "GET", "/lun", nil,
+		func(item json.RawMessage) error {
+			var lun map[string]interface{}
+			if err := json.Unmarshal(item, &lun); err != nil {
+				return err
+			}
+			ids = append(ids, lun["ID"].(string))
+			return nil
+		})
+
+	// assert
+	require.NoError(t, err)
+	require.NoError(t, resp.AssertErrorCode())
+	require.Nil(t, resp.Data)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, []string{"1", "2"}, ids)
+}
+
+func TestRestClient_CallStream_HandlerFailed(t *testing.T) {
+	// arrange
+	body := `{"data": [{"ID": "1"}, {"ID": "2"}], "error": {"code": 0, "description": "0"}}`
+	handlerErr := errors.New("mock handler error")
+	var calls int
+
+	// mock
+	mockClient := getMockClient(http.StatusOK, body)
+
+	// act
+	_, err := mockClient.CallStream(context.Background(), "GET", "/lun", nil,
+		func(item json.RawMessage) error {
+			calls++
+			return handlerErr
+		})
+
+	// assert
+	require.ErrorIs(t, err, handlerErr)
+	require.Equal(t, 1, calls)
+}
+
+func TestDecodeStreamResponse(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name      string
+		body      string
+		wantCount int
+		wantCode  float64
+		wantErr   error
+	}{
+		{name: "error before data", body: `{"error": {"code": 0}, "data": [1, 2, 3]}`, wantCount: 3},
+		{name: "data is null", body: `{"data": null, "error": {"code": 1077948996}}`, wantCode: 1077948996},
+		{name: "data is absent", body: `{"error": {"code": -401}}`, wantCode: -401},
+		{name: "unknown field is skipped", body: `{"extra": {"a": [1]}, "data": [{}], "error": {"code": 0}}`,
+			wantCount: 1},
+		{name: "data is not list", body: `{"data": {"ID": "1"}, "error": {"code": 0}}`,
+			wantErr: ErrResponseDataNotList},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			resp, count, err := decodeStreamResponse(json.NewDecoder(strings.NewReader(tt.body)),
+				func(json.RawMessage) error { return nil })
+
+			// assert
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCount, count)
+			require.Equal(t, tt.wantCode, resp.Error["code"])
+		})
+	}
+}
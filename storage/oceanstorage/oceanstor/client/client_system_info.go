@@ -0,0 +1,70 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
+)
+
+// StorageSystemInfo holds the typed fields of the system info of the storage
+type StorageSystemInfo struct {
+	SN             string
+	Name           string
+	WWN            string
+	PointRelease   string
+	ProductMode    string
+	ProductVersion string
+	HealthStatus   string
+	RunningStatus  string
+	Product        constants.OceanstorVersion
+}
+
+// GetSystemInfo used for get the typed system info, the result may be reused from the cache within SystemCacheTTL
+func (cli *RestClient) GetSystemInfo(ctx context.Context) (*StorageSystemInfo, error) {
+	return cli.getSystemInfo(ctx, false)
+}
+
+func (cli *RestClient) getSystemInfo(ctx context.Context, bypassCache bool) (*StorageSystemInfo, error) {
+	system, err := cli.getSystem(ctx, bypassCache)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStorageSystemInfo(system)
+}
+
+func parseStorageSystemInfo(system map[string]interface{}) (*StorageSystemInfo, error) {
+	product, err := utils.GetProductVersion(system)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StorageSystemInfo{
+		SN:             utils.GetValueOrFallback(system, "ID", ""),
+		Name:           utils.GetValueOrFallback(system, "NAME", ""),
+		WWN:            utils.GetValueOrFallback(system, "wwn", ""),
+		PointRelease:   utils.GetValueOrFallback(system, "pointRelease", ""),
+		ProductMode:    utils.GetValueOrFallback(system, "PRODUCTMODE", ""),
+		ProductVersion: utils.GetValueOrFallback(system, "PRODUCTVERSION", ""),
+		HealthStatus:   utils.GetValueOrFallback(system, "HEALTHSTATUS", ""),
+		RunningStatus:  utils.GetValueOrFallback(system, "RUNNINGSTATUS", ""),
+		Product:        product,
+	}, nil
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -1742,3 +1743,146 @@ func TestMain(m *testing.M) {
 
 	m.Run()
 }
+
+func TestOceanstorClient_DeleteIfExists_Success(t *testing.T) {
+	// arrange
+	body := `{"data": {}, "error": {"code": 0, "description": "0"}}`
+
+	// mock
+	mockClient := getMockClient(http.StatusOK, body)
+
+	// act
+	err := mockClient.DeleteIfExists(context.Background(), "/lun/1", nil)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestOceanstorClient_DeleteIfExists_ObjectNotExist(t *testing.T) {
+	// arrange
+	body := `{"data": {}, "error": {"code": 1077948996, "description": "object not exist"}}`
+
+	// mock
+	mockClient := getMockClient(http.StatusOK, body)
+
+	// act
+	err := mockClient.DeleteIfExists(context.Background(), "/lun/1", nil)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestOceanstorClient_DeleteIfExists_UrlNotFound(t *testing.T) {
+	// arrange
+	body := `<html><body>404_NotFound</body></html>`
+
+	// mock
+	mockClient := getMockClient(http.StatusNotFound, body)
+
+	// act
+	err := mockClient.DeleteIfExists(context.Background(), "/lun/1", nil)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestOceanstorClient_DeleteIfExists_Failed(t *testing.T) {
+	// arrange
+	body := `{"data": {}, "error": {"code": 1077949061, "description": "busy"}}`
+
+	// mock
+	mockClient := getMockClient(http.StatusOK, body)
+
+	// act
+	err := mockClient.DeleteIfExists(context.Background(), "/lun/1", nil)
+
+	// assert
+	assert.ErrorContains(t, err, "delete /lun/1 error: 1077949061")
+}
+
+func TestOceanstorClient_DeleteObjects_NotExist(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   int64
+		delete func(cli *OceanstorClient) error
+	}{
+		{name: "clone pair", code: clonePairNotExist,
+			delete: func(cli *OceanstorClient) error { return cli.DeleteClonePair(context.Background(), "1") }},
+		{name: "hypermetro", code: hyperMetroNotExist,
+			delete: func(cli *OceanstorClient) error {
+				return cli.DeleteHyperMetroPair(context.Background(), "1", true)
+			}},
+		{name: "luncopy", code: lunCopyNotExist,
+			delete: func(cli *OceanstorClient) error { return cli.DeleteLunCopy(context.Background(), "1") }},
+		{name: "replication pair", code: replicationNotExist,
+			delete: func(cli *OceanstorClient) error { return cli.DeleteReplicationPair(context.Background(), "1") }},
+		{name: "dtree", code: dtreeNotExist,
+			delete: func(cli *OceanstorClient) error { return cli.DeleteDTreeByID(context.Background(), "0", "1") }},
+		{name: "lun snapshot", code: lunSnapshotNotExist,
+			delete: func(cli *OceanstorClient) error { return cli.DeleteLunSnapshot(context.Background(), "1") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// mock
+			mockClient := getMockClient(http.StatusOK,
+				fmt.Sprintf(`{"data": {}, "error": {"code": %d, "description": "not exist"}}`, tt.code))
+
+			// act
+			err := tt.delete(mockClient)
+
+			// assert
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOceanstorClient_DeleteLunCopy_FailedWithID(t *testing.T) {
+	// arrange
+	body := `{"data": {}, "error": {"code": 1077949061, "description": "busy"}}`
+
+	// mock
+	mockClient := getMockClient(http.StatusOK, body)
+
+	// act
+	err := mockClient.DeleteLunCopy(context.Background(), "7")
+
+	// assert
+	assert.ErrorContains(t, err, "delete luncopy 7 failed: delete /LUNCOPY/7 error: 1077949061")
+}
+
+func TestOceanstorClient_IsTokenValid(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name       string
+		token      string
+		statusCode int
+		body       string
+		want       bool
+	}{
+		{name: "token is accepted", token: "mock-token", statusCode: http.StatusOK,
+			body: `{"data": {}, "error": {"code": 0, "description": "0"}}`, want: true},
+		{name: "token is unauthorized", token: "mock-token", statusCode: http.StatusOK,
+			body: `{"data": {}, "error": {"code": -401, "description": "unauthorized"}}`, want: false},
+		{name: "user is offline", token: "mock-token", statusCode: http.StatusOK,
+			body: `{"data": {}, "error": {"code": 1077949069, "description": "offline"}}`, want: false},
+		{name: "token is empty", token: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// mock
+			mockClient := getMockClient(tt.statusCode, tt.body)
+			token := mockClient.Token
+			mockClient.Token = tt.token
+			defer func() { mockClient.Token = token }()
+
+			// act
+			got, err := mockClient.IsTokenValid(context.Background())
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
@@ -35,8 +36,38 @@ const (
 	msgTimeOut            int64 = 1077949001
 	exceedFSCapacityUpper int64 = 1073844377
 	lessFSCapacityLower   int64 = 1073844376
+
+	maxFilesystemNameLength = 255
+)
+
+const (
+	// TieringPolicyNone indicates the data of file system is not relocated between tiers
+	TieringPolicyNone = "none"
+	// TieringPolicyAuto indicates the data of file system is relocated automatically by its hotness
+	TieringPolicyAuto = "auto"
+	// TieringPolicyHighest indicates the data of file system is relocated to the high-performance tier
+	TieringPolicyHighest = "highest"
+	// TieringPolicyLowest indicates the data of file system is relocated to the capacity tier
+	TieringPolicyLowest = "lowest"
+)
+
+// tieringPolicies maps the SmartTier policy to the DATATRANSFERPOLICY of file system
+var tieringPolicies = map[string]int{
+	TieringPolicyNone:    0,
+	TieringPolicyAuto:    1,
+	TieringPolicyHighest: 2,
+	TieringPolicyLowest:  3,
+}
+
+// the intervals to query the file system while waiting for its deletion
+var (
+	fsDeletedPollInitialInterval = time.Second
+	fsDeletedPollMaxInterval     = 10 * time.Second
 )
 
+// ErrTieringNotSupported indicates the storage does not support SmartTier
+var ErrTieringNotSupported = errors.New("SmartTier is not supported")
+
 // OceanstorFilesystem defines interfaces for file system operations
 type OceanstorFilesystem interface {
 	base.Filesystem
@@ -48,23 +79,22 @@ type OceanstorFilesystem interface {
 	GetFileSystemByName(ctx context.Context, name string) (map[string]interface{}, error)
 	// CreateFileSystem used for create file system
 	CreateFileSystem(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error)
+	// GetTieringPolicy used for get the SmartTier policy of file system
+	GetTieringPolicy(ctx context.Context, fsID string) (string, error)
+	// SetTieringPolicy used for reconcile the SmartTier policy of file system
+	SetTieringPolicy(ctx context.Context, fsID, policy string) error
+	// RenameFilesystem used for rename file system
+	RenameFilesystem(ctx context.Context, id, newName string) error
+	// ListFilesystemsByPrefix used for list the file systems whose names start with the prefix
+	ListFilesystemsByPrefix(ctx context.Context, prefix string) ([]FilesystemInfo, error)
+	// WaitForFilesystemDeleted used for wait until the file system no longer exists
+	WaitForFilesystemDeleted(ctx context.Context, fsID string, timeout time.Duration) error
 }
 
 // SafeDeleteFileSystem used for delete file system
 func (cli *OceanstorClient) SafeDeleteFileSystem(ctx context.Context, params map[string]interface{}) error {
-	resp, err := cli.SafeDelete(ctx, "/filesystem", params)
-	if err != nil {
-		return err
-	}
-
-	code := int64(resp.Error["code"].(float64))
-	if code == filesystemNotExist {
-		log.AddContext(ctx).Infof("Filesystem %s does not exist while deleting", params)
-		return nil
-	}
-
-	if code != 0 {
-		return utils.Errorf(ctx, "Delete filesystem %s error: %d", params, code)
+	if err := cli.DeleteIfExists(ctx, "/filesystem", params); err != nil {
+		return fmt.Errorf("delete filesystem %v failed: %w", params["ID"], err)
 	}
 
 	return nil
@@ -78,21 +108,52 @@ func (cli *OceanstorClient) SafeDeleteNfsShare(ctx context.Context, id, vStoreID
 		data["vstoreId"] = vStoreID
 	}
 
-	resp, err := cli.SafeDelete(ctx, url, data)
+	if err := cli.DeleteIfExists(ctx, url, data); err != nil {
+		return fmt.Errorf("delete nfs share %s failed: %w", id, err)
+	}
+
+	return nil
+}
+
+// RenameFilesystem used for rename file system, ErrObjectNotFound is returned if the file system does not exist
+func (cli *OceanstorClient) RenameFilesystem(ctx context.Context, id, newName string) error {
+	maxNameLength, err := cli.GetMaxNameLength(ObjectTypeFilesystem)
 	if err != nil {
 		return err
 	}
 
-	code := int64(resp.Error["code"].(float64))
-	if code == shareNotExist {
-		log.AddContext(ctx).Infof("Nfs share %s does not exist while deleting", id)
-		return nil
-	}
+	return cli.renameObject(ctx, fmt.Sprintf("/filesystem/%s", id), newName, maxNameLength)
+}
 
-	if code != 0 {
-		return fmt.Errorf("delete nfs share %s error: %d", id, code)
+// WaitForFilesystemDeleted used for wait until the file system no longer exists, the storage frees the space
+// of a deleted file system asynchronously, so the file system may still be queried for a while after deletion
+func (cli *OceanstorClient) WaitForFilesystemDeleted(ctx context.Context, fsID string, timeout time.Duration) error {
+	url := fmt.Sprintf("/filesystem/%s", fsID)
+	err := utils.PollUntil(ctx, fsDeletedPollInitialInterval, fsDeletedPollMaxInterval, timeout, func() (bool, error) {
+		resp, err := cli.Get(ctx, url, nil)
+		if isObjectNotFound(resp) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		code, ok := resp.Error["code"].(float64)
+		if !ok {
+			return false, fmt.Errorf("get filesystem %s error: invalid response %v", fsID, resp.Error)
+		}
+		if int64(code) != 0 {
+			return false, fmt.Errorf("get filesystem %s error: %d", fsID, int64(code))
+		}
+
+		log.AddContext(ctx).Infof("Filesystem %s is still being deleted", fsID)
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wait filesystem %s deleted error: %w", fsID, err)
 	}
 
+	log.AddContext(ctx).Infof("Filesystem %s is deleted", fsID)
 	return nil
 }
 
@@ -144,14 +205,14 @@ func (cli *OceanstorClient) CreateFileSystem(ctx context.Context, params map[str
 		}
 	}
 
-	err = dealCreateFSError(ctx, code)
+	err = dealCreateFSError(ctx, code, resp)
 	if err != nil {
 		return nil, err
 	}
 	return cli.getResponseDataMap(ctx, resp.Data)
 }
 
-func dealCreateFSError(ctx context.Context, code int64) error {
+func dealCreateFSError(ctx context.Context, code int64, resp base.Response) error {
 	suggestMsg := "Suggestion: Delete current PVC and specify the proper capacity of the file system and try again."
 	if code == exceedFSCapacityUpper {
 		return utils.Errorf(ctx, "create filesystem error. ErrorCode: %d. Reason: the entered capacity is "+
@@ -163,9 +224,85 @@ func dealCreateFSError(ctx context.Context, code int64) error {
 			"less than the minimum capacity of the file system. %s", code, suggestMsg)
 	}
 
+	if err := base.StorageBusyError(code, resp.Error["description"]); err != nil {
+		log.AddContext(ctx).Errorf("Create filesystem error: %v", err)
+		return fmt.Errorf("create filesystem error: %w", err)
+	}
+
 	if code != 0 {
-		return utils.Errorf(ctx, "Create filesystem error. ErrorCode: %d.", code)
+		err := respStorageError(resp)
+		log.AddContext(ctx).Errorf("Create filesystem error: %v", err)
+		return fmt.Errorf("Create filesystem error: %w", err)
+	}
+
+	return nil
+}
+
+// TieringPolicyValue returns the DATATRANSFERPOLICY of the SmartTier policy,
+// an error is returned if the policy is not supported
+func TieringPolicyValue(policy string) (int, error) {
+	value, ok := tieringPolicies[policy]
+	if !ok {
+		return 0, fmt.Errorf("tiering policy %s must be one of %s, %s, %s, %s", policy,
+			TieringPolicyNone, TieringPolicyAuto, TieringPolicyHighest, TieringPolicyLowest)
+	}
+
+	return value, nil
+}
+
+// GetTieringPolicy used for get the SmartTier policy of file system
+func (cli *OceanstorClient) GetTieringPolicy(ctx context.Context, fsID string) (string, error) {
+	if !cli.Product.IsSupportSmartTier() {
+		return "", fmt.Errorf("%w by product %s", ErrTieringNotSupported, cli.Product)
+	}
+
+	fs, err := cli.GetFileSystemByID(ctx, fsID)
+	if err != nil {
+		return "", err
+	}
+
+	rawValue, ok := fs["DATATRANSFERPOLICY"].(string)
+	if !ok {
+		return "", fmt.Errorf("convert DATATRANSFERPOLICY %v of filesystem %s to string failed",
+			fs["DATATRANSFERPOLICY"], fsID)
+	}
+
+	value, err := strconv.Atoi(rawValue)
+	if err != nil {
+		return "", fmt.Errorf("parse DATATRANSFERPOLICY %s of filesystem %s failed: %w", rawValue, fsID, err)
+	}
+
+	for policy, policyValue := range tieringPolicies {
+		if policyValue == value {
+			return policy, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown DATATRANSFERPOLICY %d of filesystem %s", value, fsID)
+}
+
+// SetTieringPolicy used for reconcile the SmartTier policy of file system,
+// nothing will be updated if the current policy is already as expected
+func (cli *OceanstorClient) SetTieringPolicy(ctx context.Context, fsID, policy string) error {
+	value, err := TieringPolicyValue(policy)
+	if err != nil {
+		return err
+	}
+
+	current, err := cli.GetTieringPolicy(ctx, fsID)
+	if err != nil {
+		return err
+	}
+
+	if current == policy {
+		log.AddContext(ctx).Infof("tiering policy of filesystem %s is already %s, skip update", fsID, policy)
+		return nil
+	}
+
+	if err = cli.UpdateFileSystem(ctx, fsID, map[string]interface{}{"DATATRANSFERPOLICY": value}); err != nil {
+		return err
 	}
 
+	log.AddContext(ctx).Infof("tiering policy of filesystem %s is updated from %s to %s", fsID, current, policy)
 	return nil
 }
@@ -25,7 +25,10 @@ import (
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 )
 
 func TestOceanstorClient_SafeDeleteFileSystem_Success(t *testing.T) {
@@ -82,10 +85,25 @@ func TestOceanstorClient_SafeDeleteFileSystem_OtherError(t *testing.T) {
 	err := mockClient.SafeDeleteFileSystem(ctx, params)
 
 	// Assert
-	require.ErrorContains(t, err, "Delete filesystem")
+	require.ErrorContains(t, err, "delete /filesystem error")
 	require.Contains(t, err.Error(), "1077939726")
 }
 
+func TestOceanstorClient_RenameFilesystem_NotExist(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	notExistResp := `{"data": {}, "error": {"code": 1073752065}}`
+
+	// Mock
+	mockClient := getMockClient(200, notExistResp)
+
+	// Action
+	err := mockClient.RenameFilesystem(ctx, "1", "new-fs")
+
+	// Assert
+	require.ErrorIs(t, err, ErrObjectNotFound)
+}
+
 func TestOceanstorClient_SafeDeleteNfsShare_SuccessWithVStore(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
@@ -163,7 +181,7 @@ func TestOceanstorClient_SafeDeleteNfsShare_OtherError(t *testing.T) {
 	err := mockClient.SafeDeleteNfsShare(ctx, id, vStoreID)
 
 	// Assert
-	require.ErrorContains(t, err, "delete nfs share nfs-004 error: 1077939726")
+	require.ErrorContains(t, err, "delete /NFSHARE/nfs-004 error: 1077939726")
 }
 
 func TestOceanstorClient_GetFileSystemByName_Success(t *testing.T) {
@@ -417,3 +435,110 @@ func TestCreateFileSystem_MinimumCapacityNotReached(t *testing.T) {
 	require.ErrorContains(t, err, "less than the minimum capacity")
 	require.ErrorContains(t, err, "Suggestion: Delete current PVC")
 }
+
+func TestOceanstorClient_SetTieringPolicy_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockClient := getMockClient(200, "")
+	originProduct := mockClient.Product
+	mockClient.Product = constants.OceanStorV5
+	defer func() { mockClient.Product = originProduct }()
+	var updateParams map[string]interface{}
+
+	// Mock
+	patches := gomonkey.ApplyMethodReturn(mockClient.FilesystemClient, "GetFileSystemByID",
+		map[string]interface{}{"ID": "fs-001", "DATATRANSFERPOLICY": "1"}, nil).
+		ApplyMethod(mockClient.FilesystemClient, "UpdateFileSystem", func(_ *base.FilesystemClient,
+			_ context.Context, _ string, params map[string]interface{}) error {
+			updateParams = params
+			return nil
+		})
+	defer patches.Reset()
+
+	// Action
+	err := mockClient.SetTieringPolicy(ctx, "fs-001", TieringPolicyLowest)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"DATATRANSFERPOLICY": 3}, updateParams)
+}
+
+func TestOceanstorClient_SetTieringPolicy_InvalidPolicy(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockClient := getMockClient(200, "")
+	originProduct := mockClient.Product
+	mockClient.Product = constants.OceanStorV5
+	defer func() { mockClient.Product = originProduct }()
+
+	// Action
+	err := mockClient.SetTieringPolicy(ctx, "fs-001", "coldest")
+
+	// Assert
+	require.ErrorContains(t, err, "tiering policy coldest must be one of")
+}
+
+func TestOceanstorClient_SetTieringPolicy_ProductNotSupported(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockClient := getMockClient(200, "")
+	originProduct := mockClient.Product
+	mockClient.Product = constants.OceanStorDoradoV6
+	defer func() { mockClient.Product = originProduct }()
+
+	// Action
+	err := mockClient.SetTieringPolicy(ctx, "fs-001", TieringPolicyAuto)
+
+	// Assert
+	require.ErrorIs(t, err, ErrTieringNotSupported)
+}
+
+func TestOceanstorClient_WaitForFilesystemDeleted(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	existResp := base.Response{Error: map[string]interface{}{"code": float64(0)},
+		Data: map[string]interface{}{"ID": "1"}}
+	notExistResp := base.Response{Error: map[string]interface{}{"code": float64(filesystemNotExist)}}
+	var calls int
+
+	// mock
+	patches := gomonkey.ApplyGlobalVar(&fsDeletedPollInitialInterval, time.Millisecond).
+		ApplyGlobalVar(&fsDeletedPollMaxInterval, time.Millisecond).
+		ApplyMethod(cli.RestClient, "Get", func(_ *RestClient, _ context.Context, _ string,
+			_ map[string]interface{}) (base.Response, error) {
+			calls++
+			if calls < 3 {
+				return existResp, nil
+			}
+			return notExistResp, nil
+		})
+	defer patches.Reset()
+
+	// action
+	err := cli.WaitForFilesystemDeleted(ctx, "1", time.Minute)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+}
+
+func TestOceanstorClient_WaitForFilesystemDeleted_Timeout(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := &OceanstorClient{RestClient: &RestClient{}}
+	existResp := base.Response{Error: map[string]interface{}{"code": float64(0)},
+		Data: map[string]interface{}{"ID": "1"}}
+
+	// mock
+	patches := gomonkey.ApplyGlobalVar(&fsDeletedPollInitialInterval, time.Millisecond).
+		ApplyGlobalVar(&fsDeletedPollMaxInterval, time.Millisecond).
+		ApplyMethodReturn(cli.RestClient, "Get", existResp, nil)
+	defer patches.Reset()
+
+	// action
+	err := cli.WaitForFilesystemDeleted(ctx, "1", 10*time.Millisecond)
+
+	// assert
+	require.ErrorIs(t, err, utils.ErrPollTimeout)
+}
@@ -17,14 +17,47 @@
 package client
 
 import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
 // OceanstorVStore defines interfaces for vstore operations
 type OceanstorVStore interface {
 	base.VStore
+	// SwitchVStore used for get a context whose calls operate on the vstore without re-login
+	SwitchVStore(ctx context.Context, vStoreName string) (context.Context, error)
+	// GetVStoreQuota used for get the capacity quota and the usage of the vstore
+	GetVStoreQuota(ctx context.Context, vStoreID string) (*VStoreQuota, error)
+}
+
+// CapacityQuota holds a capacity quota of the vstore in bytes
+type CapacityQuota struct {
+	Total int64
+	Free  int64
+}
+
+// Used returns the used bytes of the quota
+func (q *CapacityQuota) Used() int64 {
+	return q.Total - q.Free
+}
+
+// VStoreQuota holds the capacity quotas of the vstore, the quota is nil if it is not set
+type VStoreQuota struct {
+	SAN *CapacityQuota
+	NAS *CapacityQuota
 }
 
+// vStoreContextKey is the key of the ID of the vstore switched to in the context
+type vStoreContextKey struct{}
+
 // GetvStoreName used for get vstore name in oceanstor client
 func (cli *OceanstorClient) GetvStoreName() string {
 	return cli.VStoreName
@@ -34,3 +67,117 @@ func (cli *OceanstorClient) GetvStoreName() string {
 func (cli *OceanstorClient) GetvStoreID() string {
 	return cli.VStoreID
 }
+
+// SwitchVStore used for get a context whose calls operate on the vstore without re-login,
+// the vstore must exist on the storage. Only the calls with the returned context carry the vstoreId parameter,
+// the client and the calls with other contexts keep the vstore of the login session.
+func (cli *OceanstorClient) SwitchVStore(ctx context.Context, vStoreName string) (context.Context, error) {
+	if vStoreName == "" {
+		return nil, fmt.Errorf("the vstore name to switch to is empty")
+	}
+
+	vStore, err := cli.GetvStoreByName(ctx, vStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("get vstore %s failed, error: %w", vStoreName, err)
+	}
+
+	if vStore == nil {
+		return nil, fmt.Errorf("vstore %s does not exist on backend %s", vStoreName, cli.BackendID)
+	}
+
+	vStoreID, ok := vStore["ID"].(string)
+	if !ok || vStoreID == "" {
+		return nil, fmt.Errorf("convert vstore ID: [%v] of vstore %s to string failed", vStore["ID"], vStoreName)
+	}
+
+	log.AddContext(ctx).Infof("Switch vstore of backend %s from %s to %s", cli.BackendID, cli.VStoreName, vStoreName)
+	return context.WithValue(ctx, vStoreContextKey{}, vStoreID), nil
+}
+
+// withVStoreParam adds the vstoreId parameter of the vstore switched to in ctx to the url or the data,
+// the parameter set by the caller is kept
+func withVStoreParam(ctx context.Context, method, url string,
+	data map[string]interface{}) (string, map[string]interface{}) {
+	vStoreID, ok := ctx.Value(vStoreContextKey{}).(string)
+	if !ok || vStoreID == "" {
+		return url, data
+	}
+
+	if method == http.MethodGet || method == http.MethodDelete {
+		if strings.Contains(url, "vstoreId=") {
+			return url, data
+		}
+		if strings.Contains(url, "?") {
+			return url + "&vstoreId=" + vStoreID, data
+		}
+		return url + "?vstoreId=" + vStoreID, data
+	}
+
+	if _, exist := data["vstoreId"]; exist {
+		return url, data
+	}
+
+	withVStore := maps.Clone(data)
+	if withVStore == nil {
+		withVStore = make(map[string]interface{})
+	}
+	withVStore["vstoreId"] = vStoreID
+	return url, withVStore
+}
+
+// GetVStoreQuota used for get the capacity quota and the usage of the vstore,
+// the quotas are only returned by Dorado V6 6.1.5 and later versions
+func (cli *OceanstorClient) GetVStoreQuota(ctx context.Context, vStoreID string) (*VStoreQuota, error) {
+	url := fmt.Sprintf("/vstore/%s", vStoreID)
+	resp, err := cli.Get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = resp.AssertErrorCode(); err != nil {
+		return nil, fmt.Errorf("get vstore %s error: %w", vStoreID, err)
+	}
+
+	vStore, ok := resp.Data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("convert vstore %s to map failed, data: %v", vStoreID, resp.Data)
+	}
+
+	quota := &VStoreQuota{}
+	if quota.SAN, err = parseCapacityQuota(vStore, "sanCapacityQuota", "sanFreeCapacityQuota"); err != nil {
+		return nil, err
+	}
+	if quota.NAS, err = parseCapacityQuota(vStore, "nasCapacityQuota", "nasFreeCapacityQuota"); err != nil {
+		return nil, err
+	}
+
+	return quota, nil
+}
+
+func parseCapacityQuota(vStore map[string]interface{}, totalKey, freeKey string) (*CapacityQuota, error) {
+	var total, free int64
+	var err error
+	if totalStr, ok := vStore[totalKey].(string); ok {
+		total, err = strconv.ParseInt(totalStr, constants.DefaultIntBase, constants.DefaultIntBitSize)
+		if err != nil {
+			return nil, fmt.Errorf("parse vstore %s failed, error: %w", totalKey, err)
+		}
+	}
+
+	if freeStr, ok := vStore[freeKey].(string); ok {
+		free, err = strconv.ParseInt(freeStr, constants.DefaultIntBase, constants.DefaultIntBitSize)
+		if err != nil {
+			return nil, fmt.Errorf("parse vstore %s failed, error: %w", freeKey, err)
+		}
+	}
+
+	// if not set quota, the total quota is 0, the free quota is -1
+	if total == 0 || free == -1 {
+		return nil, nil
+	}
+
+	return &CapacityQuota{
+		Total: total * constants.AllocationUnitBytes,
+		Free:  free * constants.AllocationUnitBytes,
+	}, nil
+}
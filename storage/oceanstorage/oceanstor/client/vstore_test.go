@@ -0,0 +1,155 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/base"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/client"
+)
+
+func mockVStoreCli() *client.OceanstorClient {
+	cli := mockCli()
+	cli.VStoreName = "login-vstore"
+	cli.VStoreID = "1"
+	cli.VStoreClient = &base.VStoreClient{RestClientInterface: cli.RestClient}
+	return cli
+}
+
+func TestOceanstorClient_SwitchVStore_Success(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockVStoreCli()
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli.VStoreClient, "GetvStoreByName",
+		func(_ *base.VStoreClient, _ context.Context, name string) (map[string]interface{}, error) {
+			return map[string]interface{}{"ID": "2", "NAME": name}, nil
+		})
+	defer patches.Reset()
+
+	// act
+	switchedCtx, err := cli.SwitchVStore(ctx, "target-vstore")
+	getReq, getErr := cli.GetRequest(switchedCtx, http.MethodGet, "/lun?range=[0-100]", nil)
+	postReq, postErr := cli.GetRequest(switchedCtx, http.MethodPost, "/lun", map[string]interface{}{"NAME": "lun"})
+	loginReq, loginErr := cli.GetRequest(ctx, http.MethodGet, "/lun", nil)
+
+	// assert
+	require.NoError(t, err)
+	require.NoError(t, getErr)
+	require.NoError(t, postErr)
+	require.NoError(t, loginErr)
+	require.Equal(t, "2", getReq.URL.Query().Get("vstoreId"))
+	body, err := io.ReadAll(postReq.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"NAME":"lun","vstoreId":"2"}`, string(body))
+	require.Empty(t, loginReq.URL.Query().Get("vstoreId"))
+	require.Equal(t, "login-vstore", cli.GetvStoreName())
+	require.Equal(t, "1", cli.GetvStoreID())
+}
+
+func TestOceanstorClient_SwitchVStore_NotExist(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockVStoreCli()
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli.VStoreClient, "GetvStoreByName",
+		func(_ *base.VStoreClient, _ context.Context, _ string) (map[string]interface{}, error) {
+			return nil, nil
+		})
+	defer patches.Reset()
+
+	// act
+	_, err := cli.SwitchVStore(ctx, "target-vstore")
+
+	// assert
+	require.ErrorContains(t, err, "does not exist")
+	require.Equal(t, "login-vstore", cli.GetvStoreName())
+	require.Equal(t, "1", cli.GetvStoreID())
+}
+
+func TestOceanstorClient_SwitchVStore_QueryFailed(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockVStoreCli()
+
+	// mock
+	patches := gomonkey.ApplyMethod(cli.VStoreClient, "GetvStoreByName",
+		func(_ *base.VStoreClient, _ context.Context, _ string) (map[string]interface{}, error) {
+			return nil, errors.New("mock error")
+		})
+	defer patches.Reset()
+
+	// act
+	_, err := cli.SwitchVStore(ctx, "target-vstore")
+
+	// assert
+	require.ErrorContains(t, err, "mock error")
+	require.Equal(t, "1", cli.GetvStoreID())
+}
+
+func TestOceanstorClient_GetVStoreQuota(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockVStoreCli()
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get", base.Response{
+		Error: map[string]interface{}{"code": float64(0)},
+		Data: map[string]interface{}{"ID": "1", "sanCapacityQuota": "0", "sanFreeCapacityQuota": "-1",
+			"nasCapacityQuota": "2097152", "nasFreeCapacityQuota": "524288"},
+	}, nil)
+	defer patches.Reset()
+
+	// act
+	quota, err := cli.GetVStoreQuota(ctx, "1")
+
+	// assert
+	require.NoError(t, err)
+	require.Nil(t, quota.SAN)
+	require.Equal(t, &client.CapacityQuota{Total: 1 << 30, Free: 1 << 28}, quota.NAS)
+	require.Equal(t, int64(3<<28), quota.NAS.Used())
+}
+
+func TestOceanstorClient_GetVStoreQuota_InvalidQuota(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	cli := mockVStoreCli()
+
+	// mock
+	patches := gomonkey.ApplyMethodReturn(cli.RestClient, "Get", base.Response{
+		Error: map[string]interface{}{"code": float64(0)},
+		Data:  map[string]interface{}{"ID": "1", "sanCapacityQuota": "invalid"},
+	}, nil)
+	defer patches.Reset()
+
+	// act
+	quota, err := cli.GetVStoreQuota(ctx, "1")
+
+	// assert
+	require.ErrorContains(t, err, "sanCapacityQuota")
+	require.Nil(t, quota)
+}
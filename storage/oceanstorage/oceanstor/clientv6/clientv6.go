@@ -61,8 +61,3 @@ func (cli *V6Client) SplitCloneFS(ctx context.Context,
 
 	return nil
 }
-
-// MakeLunName  v6 storage lun name support 1 to 255 characters
-func (cli *V6Client) MakeLunName(name string) string {
-	return name
-}
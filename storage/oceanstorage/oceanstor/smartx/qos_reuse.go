@@ -0,0 +1,211 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package smartx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+const (
+	// sharedQosPrefix is the name prefix of the QoS policies shared by the volumes with the same QoS parameters,
+	// the policies with other names are never reused or cleaned up
+	sharedQosPrefix = "k8s_shared_"
+
+	// sharedQosKeyLength is the length of the key in the name, so that the name fits the limit of 31 characters
+	sharedQosKeyLength = 16
+)
+
+// qosListMutex serializes the read-modify-write of the object lists of the QoS policies, the list is replaced
+// as a whole by UpdateQos, so the concurrent updates of the same policy lose the objects of each other
+var qosListMutex sync.Mutex
+
+// QosKey returns a stable key of the QoS parameters of the object type, the parameters in any order have the
+// same key, and the key differs if any of the parameters differs
+func QosKey(objType string, params map[string]int) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	var builder strings.Builder
+	builder.WriteString(objType)
+	for _, k := range keys {
+		builder.WriteString(fmt.Sprintf(";%s=%d", k, params[k]))
+	}
+
+	sum := sha256.Sum256([]byte(builder.String()))
+	return hex.EncodeToString(sum[:])[:sharedQosKeyLength]
+}
+
+// SharedQosName returns the name of the QoS policy shared by the objects with the same QoS parameters
+func SharedQosName(objType string, params map[string]int) string {
+	return fmt.Sprintf("%s%s_%s", sharedQosPrefix, objType, QosKey(objType, params))
+}
+
+// CreateOrReuseQos associates the object with the QoS policy of the same parameters and returns its id,
+// the policy is created only if no matching one exists. The object is removed from the policy by DeleteQos,
+// which deletes the policy after the last object is removed.
+// Note that the storage applies the limits of a policy to all its objects in aggregate, e.g. MAXIOPS=1000 shared
+// by 10 volumes limits the 10 volumes to 1000 IOPS in total rather than 1000 IOPS each, so it is only used when
+// the StorageClass opts in by the sharedQoS parameter.
+func (p *Client) CreateOrReuseQos(ctx context.Context,
+	objID, objType, vStoreID string, params map[string]int) (string, error) {
+	if err := p.upgradeIOPriority(ctx, objID, objType, params); err != nil {
+		return "", err
+	}
+
+	qosListMutex.Lock()
+	defer qosListMutex.Unlock()
+
+	name := SharedQosName(objType, params)
+	qos, err := p.cli.GetQosByName(ctx, name, vStoreID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get qos by name %s error: %v", name, err)
+		return "", err
+	}
+
+	if qos == nil {
+		// the policy created by another request concurrently is returned as well, the object is associated below
+		qos, err = p.cli.CreateQos(ctx, p.getCreateQosArgs(name, objID, objType, vStoreID, params))
+		if err != nil {
+			log.AddContext(ctx).Errorf("Create qos %v for obj %s of type %s error: %v",
+				params, objID, objType, err)
+			return "", err
+		}
+	} else {
+		log.AddContext(ctx).Infof("Reuse qos %s for obj %s of type %s", name, objID, objType)
+	}
+
+	qosID, ok := qos["ID"].(string)
+	if !ok {
+		return "", errors.New("qos ID is expected as string")
+	}
+
+	if err = p.associateQos(ctx, qos, objID, objType, vStoreID); err != nil {
+		return "", err
+	}
+
+	if err = p.activateQos(ctx, qos, vStoreID); err != nil {
+		return "", err
+	}
+
+	return qosID, nil
+}
+
+// CleanupUnreferencedQos deletes the shared QoS policies which are not associated with any object,
+// e.g. the policies left when the volumes are deleted outside of the plugin, and returns their names
+func (p *Client) CleanupUnreferencedQos(ctx context.Context) ([]string, error) {
+	qosListMutex.Lock()
+	defer qosListMutex.Unlock()
+
+	allQos, err := p.cli.GetAllQos(ctx)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get all qos error: %v", err)
+		return nil, err
+	}
+
+	var deleted []string
+	var errs []error
+	for _, qos := range allQos {
+		name, _ := qos["NAME"].(string)
+		if !strings.HasPrefix(name, sharedQosPrefix) {
+			continue
+		}
+
+		lunList, lunErr := getQosObjList(qos, "LUNLIST")
+		fsList, fsErr := getQosObjList(qos, "FSLIST")
+		if lunErr != nil || fsErr != nil || len(lunList) != 0 || len(fsList) != 0 {
+			continue
+		}
+
+		qosID, _ := qos["ID"].(string)
+		vStoreID, _ := qos["vstoreId"].(string)
+		if err := p.deleteUnreferencedQos(ctx, qosID, vStoreID); err != nil {
+			errs = append(errs, fmt.Errorf("delete qos %s failed: %w", name, err))
+			continue
+		}
+
+		log.AddContext(ctx).Infof("Deleted unreferenced qos %s", name)
+		deleted = append(deleted, name)
+	}
+
+	return deleted, errors.Join(errs...)
+}
+
+func (p *Client) deleteUnreferencedQos(ctx context.Context, qosID, vStoreID string) error {
+	if err := p.cli.DeactivateQos(ctx, qosID, vStoreID); err != nil {
+		return err
+	}
+
+	return p.cli.DeleteQos(ctx, qosID, vStoreID)
+}
+
+func (p *Client) associateQos(ctx context.Context,
+	qos map[string]interface{}, objID, objType, vStoreID string) error {
+	listObj := getQosListObj(objType)
+	objList, err := getQosObjList(qos, listObj)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(objList, objID) {
+		return nil
+	}
+
+	qosID, _ := qos["ID"].(string)
+	err = p.cli.UpdateQos(ctx, qosID, vStoreID, map[string]interface{}{listObj: append(objList, objID)})
+	if err != nil {
+		log.AddContext(ctx).Errorf("Add obj %s of type %s to qos %s error: %v", objID, objType, qosID, err)
+		return err
+	}
+
+	return nil
+}
+
+func getQosListObj(objType string) string {
+	if objType == "fs" {
+		return "FSLIST"
+	}
+
+	return "LUNLIST"
+}
+
+// getQosObjList returns the objects of the list of the qos, the list is a marshaled string on the storage
+func getQosObjList(qos map[string]interface{}, listObj string) ([]string, error) {
+	listStr, _ := qos[listObj].(string)
+	if listStr == "" {
+		return nil, nil
+	}
+
+	var objList []string
+	if err := json.Unmarshal([]byte(listStr), &objList); err != nil {
+		return nil, fmt.Errorf("unmarshal %s %s of qos error: %w", listObj, listStr, err)
+	}
+
+	return objList, nil
+}
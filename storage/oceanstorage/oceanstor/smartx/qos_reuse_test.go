@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package smartx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/test/mocks/mock_client"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
+)
+
+const (
+	logName = "smartxTest.log"
+)
+
+func TestMain(m *testing.M) {
+	log.MockInitLogging(logName)
+	defer log.MockStopLogging(logName)
+
+	m.Run()
+}
+
+func TestQosKey(t *testing.T) {
+	// arrange
+	params := map[string]int{"MAXIOPS": 1000, "MAXBANDWIDTH": 100}
+	sameParams := map[string]int{"MAXBANDWIDTH": 100, "MAXIOPS": 1000}
+	otherParams := map[string]int{"MAXBANDWIDTH": 100, "MAXIOPS": 2000}
+
+	// act
+	key := QosKey("lun", params)
+
+	// assert
+	require.Len(t, key, sharedQosKeyLength)
+	require.Equal(t, key, QosKey("lun", sameParams))
+	require.NotEqual(t, key, QosKey("lun", otherParams))
+	require.NotEqual(t, key, QosKey("fs", params))
+	require.LessOrEqual(t, len(SharedQosName("lun", params)), 31)
+}
+
+func TestClient_CreateOrReuseQos_Reuse(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	params := map[string]int{"MAXIOPS": 1000}
+	name := SharedQosName("lun", params)
+	qos := map[string]interface{}{"ID": "1", "NAME": name, "ENABLESTATUS": "true", "LUNLIST": `["10"]`}
+
+	// mock
+	cli.EXPECT().GetQosByName(ctx, name, "0").Return(qos, nil)
+	cli.EXPECT().UpdateQos(ctx, "1", "0", map[string]interface{}{"LUNLIST": []string{"10", "11"}}).Return(nil)
+
+	// act
+	qosID, err := NewSmartX(cli).CreateOrReuseQos(ctx, "11", "lun", "0", params)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "1", qosID)
+}
+
+func TestClient_CreateOrReuseQos_Create(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	params := map[string]int{"MAXIOPS": 1000}
+	name := SharedQosName("fs", params)
+	qos := map[string]interface{}{"ID": "2", "NAME": name, "ENABLESTATUS": "false", "FSLIST": `["11"]`}
+
+	// mock
+	cli.EXPECT().GetQosByName(ctx, name, "").Return(nil, nil)
+	cli.EXPECT().CreateQos(ctx, gomock.Any()).Return(qos, nil)
+	cli.EXPECT().ActivateQos(ctx, "2", "").Return(nil)
+
+	// act
+	qosID, err := NewSmartX(cli).CreateOrReuseQos(ctx, "11", "fs", "", params)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "2", qosID)
+}
+
+func TestClient_CleanupUnreferencedQos(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	allQos := []map[string]interface{}{
+		{"ID": "1", "NAME": "k8s_shared_lun_1", "LUNLIST": "[]", "FSLIST": "", "vstoreId": "0"},
+		{"ID": "2", "NAME": "k8s_shared_lun_2", "LUNLIST": `["10"]`, "vstoreId": "0"},
+		{"ID": "3", "NAME": "k8s_lun10_20250101000000", "LUNLIST": "[]", "vstoreId": "0"},
+	}
+
+	// mock
+	cli.EXPECT().GetAllQos(ctx).Return(allQos, nil)
+	cli.EXPECT().DeactivateQos(ctx, "1", "0").Return(nil)
+	cli.EXPECT().DeleteQos(ctx, "1", "0").Return(nil)
+
+	// act
+	deleted, err := NewSmartX(cli).CleanupUnreferencedQos(ctx)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, []string{"k8s_shared_lun_1"}, deleted)
+}
@@ -274,30 +274,8 @@ func (p *Client) getQosName(objID, objType string) string {
 func (p *Client) CreateQos(ctx context.Context,
 	objID, objType, vStoreID string,
 	params map[string]int) (string, error) {
-	var err error
-	var lowerLimit bool
-
-	for k := range params {
-		if strings.HasPrefix(k, "MIN") || strings.HasPrefix(k, "LATENCY") {
-			lowerLimit = true
-		}
-	}
-
-	if lowerLimit {
-		data := map[string]interface{}{
-			"IOPRIORITY": 3,
-		}
-
-		if objType == "fs" {
-			err = p.cli.UpdateFileSystem(ctx, objID, data)
-		} else {
-			err = p.cli.UpdateLun(ctx, objID, data)
-		}
-
-		if err != nil {
-			log.AddContext(ctx).Errorf("Upgrade obj %s of type %s IOPRIORITY error: %v", objID, objID, err)
-			return "", err
-		}
+	if err := p.upgradeIOPriority(ctx, objID, objType, params); err != nil {
+		return "", err
 	}
 
 	name := p.getQosName(objID, objType)
@@ -313,24 +291,69 @@ func (p *Client) CreateQos(ctx context.Context,
 		return "", errors.New("qos ID is expected as string")
 	}
 
+	if err = p.activateQos(ctx, qos, vStoreID); err != nil {
+		return "", err
+	}
+
+	return qosID, nil
+}
+
+// upgradeIOPriority upgrades the IOPRIORITY of the object if the params contain the lower limits
+func (p *Client) upgradeIOPriority(ctx context.Context, objID, objType string, params map[string]int) error {
+	var lowerLimit bool
+	for k := range params {
+		if strings.HasPrefix(k, "MIN") || strings.HasPrefix(k, "LATENCY") {
+			lowerLimit = true
+		}
+	}
+
+	if !lowerLimit {
+		return nil
+	}
+
+	var err error
+	data := map[string]interface{}{
+		"IOPRIORITY": 3,
+	}
+
+	if objType == "fs" {
+		err = p.cli.UpdateFileSystem(ctx, objID, data)
+	} else {
+		err = p.cli.UpdateLun(ctx, objID, data)
+	}
+
+	if err != nil {
+		log.AddContext(ctx).Errorf("Upgrade obj %s of type %s IOPRIORITY error: %v", objID, objID, err)
+		return err
+	}
+
+	return nil
+}
+
+// activateQos activates the qos if it is not enabled
+func (p *Client) activateQos(ctx context.Context, qos map[string]interface{}, vStoreID string) error {
+	qosID, _ := qos["ID"].(string)
 	qosStatus, ok := qos["ENABLESTATUS"].(string)
 	if !ok {
-		return "", errors.New("ENABLESTATUS parameter is expected as string")
+		return errors.New("ENABLESTATUS parameter is expected as string")
 	}
 
 	if qosStatus == "false" {
 		err := p.cli.ActivateQos(ctx, qosID, vStoreID)
 		if err != nil {
 			log.AddContext(ctx).Errorf("Activate qos %s error: %v", qosID, err)
-			return "", err
+			return err
 		}
 	}
 
-	return qosID, nil
+	return nil
 }
 
 // DeleteQos deletes qos by id
 func (p *Client) DeleteQos(ctx context.Context, qosID, objID, objType, vStoreID string) error {
+	qosListMutex.Lock()
+	defer qosListMutex.Unlock()
+
 	qos, err := p.cli.GetQosByID(ctx, qosID, vStoreID)
 	if err != nil {
 		log.AddContext(ctx).Errorf("Get qos by ID %s error: %v", qosID, err)
@@ -30,6 +30,10 @@ import (
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
+// expandAllocationUnit is the unit in sectors, 1 MiB, that both the current and the requested size are rounded up to
+// when expanding, so that a requested size rounds to the current size is treated as a no-op rather than a shrink
+const expandAllocationUnit int64 = 2048
+
 // Base defines the base storage client
 type Base struct {
 	cli              client.OceanstorClientInterface
@@ -289,9 +293,9 @@ func (p *Base) getMetroPairSyncSpeed(_ context.Context, params map[string]interf
 	}
 
 	if v, exist := params["metropairsyncspeed"].(string); exist && v != "" {
-		speed, err := strconv.Atoi(v)
-		if err != nil || speed < client.MetroPairSyncSpeedLow || speed > client.MetroPairSyncSpeedHighest {
-			return fmt.Errorf("error config %s for metroPairSyncSpeed", v)
+		speed, err := client.ParseMetroPairSyncSpeed(v)
+		if err != nil {
+			return err
 		}
 		params["metropairsyncspeed"] = speed
 	}
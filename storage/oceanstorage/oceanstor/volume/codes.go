@@ -50,11 +50,12 @@ const (
 
 	hyperMetroDomainRunningStatusNormal = "1"
 
-	lunCopyHealthStatusFault    = "2"
-	lunCopyRunningStatusQueuing = "37"
-	lunCopyRunningStatusCopying = "39"
-	lunCopyRunningStatusStop    = "38"
-	lunCopyRunningStatusPaused  = "41"
+	lunCopyHealthStatusFault     = "2"
+	lunCopyRunningStatusQueuing  = "37"
+	lunCopyRunningStatusCopying  = "39"
+	lunCopyRunningStatusStop     = "38"
+	lunCopyRunningStatusPaused   = "41"
+	lunCopyRunningStatusComplete = "40"
 
 	clonePairHealthStatusFault         = "1"
 	clonePairRunningStatusUnsyncing    = "0"
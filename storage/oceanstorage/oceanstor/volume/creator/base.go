@@ -53,8 +53,10 @@ type BaseCreator struct {
 	allocType          int
 	isShowSnapDir      *bool
 	snapshotReservePer *int
+	tieringPolicy      string
 
-	qos map[string]int
+	qos         map[string]int
+	isSharedQoS bool
 
 	// fields about shares
 	isCreateNfsShare bool
@@ -83,6 +85,7 @@ func (c *BaseCreator) Init(params *Parameter) {
 	c.capacity = params.Capacity()
 	c.allocType = params.AllocType()
 	c.qos = params.QoS()
+	c.isSharedQoS = params.IsSharedQoS()
 	c.authClient = params.AuthClient()
 	c.allSquash = params.AllSquash()
 	c.rootSquash = params.RootSquash()
@@ -92,6 +95,7 @@ func (c *BaseCreator) Init(params *Parameter) {
 	c.domainId = params.MetroDomainID()
 	c.vStorePairId = params.VStorePairId()
 	c.metroPairSyncSpeed = params.SyncMetroPairSpeed()
+	c.tieringPolicy = params.TieringPolicy()
 
 	if !params.IsSkipNfsShareAndQos() {
 		c.isCreateNfsShare = true
@@ -253,8 +257,14 @@ func (c *BaseCreator) CreateQoS(ctx context.Context, fsID, vStoreId string) (str
 		return "", nil
 	}
 
+	var qosID string
+	var err error
 	smartX := smartx.NewSmartX(c.cli)
-	qosID, err := smartX.CreateQos(ctx, fsID, FilesystemObjectType, vStoreId, c.qos)
+	if c.isSharedQoS {
+		qosID, err = smartX.CreateOrReuseQos(ctx, fsID, FilesystemObjectType, vStoreId, c.qos)
+	} else {
+		qosID, err = smartX.CreateQos(ctx, fsID, FilesystemObjectType, vStoreId, c.qos)
+	}
 	if err != nil {
 		return "", fmt.Errorf("create qos %v for fs %s error: %w", c.qos, fsID, err)
 	}
@@ -318,5 +318,13 @@ func (creator *CloneFsCreator) updateFilesystem(ctx context.Context, fsId string
 		req["SNAPSHOTRESERVEPER"] = *creator.snapshotReservePer
 	}
 
+	if creator.tieringPolicy != "" {
+		value, err := client.TieringPolicyValue(creator.tieringPolicy)
+		if err != nil {
+			return err
+		}
+		req["DATATRANSFERPOLICY"] = value
+	}
+
 	return creator.cli.UpdateFileSystem(ctx, fsId, req)
 }
@@ -205,6 +205,14 @@ func (creator *FilesystemCreator) genCreateRequest(ctx context.Context, poolId s
 		req["SNAPSHOTRESERVEPER"] = *creator.snapshotReservePer
 	}
 
+	if creator.tieringPolicy != "" {
+		value, err := client.TieringPolicyValue(creator.tieringPolicy)
+		if err != nil {
+			return nil, err
+		}
+		req["DATATRANSFERPOLICY"] = value
+	}
+
 	if creator.workloadTypeID != "" {
 		id, err := strconv.ParseUint(creator.workloadTypeID, 0, 32)
 		if err != nil {
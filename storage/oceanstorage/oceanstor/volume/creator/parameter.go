@@ -118,12 +118,16 @@ const (
 	IsSkipNfsShareAndQoS = "skipNfsShareAndQos"
 	// QoSKey is the string of qos's key
 	QoSKey = "qos"
+	// SharedQoSKey is the string of SharedQoS's key
+	SharedQoSKey = "sharedqos"
 	// WorkloadTypeIDKey is the string of WorkloadTypeID's key
 	WorkloadTypeIDKey = "workloadTypeID"
 	// IsShowSnapDirKey is the string of IsShowSnapDir's key
 	IsShowSnapDirKey = "isshowsnapdir"
 	// SnapshotReservePerKey is the string of SnapshotReservePer's key
 	SnapshotReservePerKey = "reservedsnapshotspaceratio"
+	// TieringPolicyKey is the string of TieringPolicy's key
+	TieringPolicyKey = "tieringpolicy"
 	// AccessKrb5Key is the string of AccessKrb5's key
 	AccessKrb5Key = "accesskrb5"
 	// AccessKrb5iKey is the string of AccessKrb5i's key
@@ -280,6 +284,11 @@ func (p *Parameter) QoS() map[string]int {
 	return utils.GetValueOrFallback[map[string]int](p.params, QoSKey, nil)
 }
 
+// IsSharedQoS gets the SharedQoS value of the params map.
+func (p *Parameter) IsSharedQoS() bool {
+	return utils.GetValueOrFallback(p.params, SharedQoSKey, false)
+}
+
 // WorkloadTypeID gets the WorkloadTypeID value of the params map.
 func (p *Parameter) WorkloadTypeID() string {
 	return utils.GetValueOrFallback(p.params, WorkloadTypeIDKey, "")
@@ -295,6 +304,11 @@ func (p *Parameter) SnapshotReservePer() (int, bool) {
 	return utils.GetValue[int](p.params, SnapshotReservePerKey)
 }
 
+// TieringPolicy gets the TieringPolicy value of the params map.
+func (p *Parameter) TieringPolicy() string {
+	return utils.GetValueOrFallback(p.params, TieringPolicyKey, "")
+}
+
 // AccessKrb5 gets the AccessKrb5 value of the params map.
 func (p *Parameter) AccessKrb5() int {
 	val := AccessKrb(utils.GetValueOrFallback(p.params, AccessKrb5Key, ""))
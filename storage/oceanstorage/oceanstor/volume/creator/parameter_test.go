@@ -472,3 +472,16 @@ func TestParameter_AdvancedOptions(t *testing.T) {
 	// assert
 	require.Equal(t, value, advancedOptions)
 }
+
+func TestParameters_TieringPolicy(t *testing.T) {
+	// arrange
+	want := "auto"
+	in := map[string]any{"tieringpolicy": want}
+	params := creator.NewParameter(in)
+
+	// act
+	got := params.TieringPolicy()
+
+	// assert
+	require.Equal(t, want, got)
+}
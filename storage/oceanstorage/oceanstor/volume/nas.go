@@ -272,6 +272,10 @@ func (p *NAS) preCreate(ctx context.Context, params map[string]interface{}) erro
 		params["reservedsnapshotspaceratio"] = intVal
 	}
 
+	if err := p.checkTieringPolicy(ctx, params); err != nil {
+		return err
+	}
+
 	params["localVStoreID"] = p.LocVStoreID
 	params["remoteVStoreID"] = p.RmtVStoreID
 	params["product"] = p.product
@@ -279,6 +283,25 @@ func (p *NAS) preCreate(ctx context.Context, params map[string]interface{}) erro
 	return nil
 }
 
+// checkTieringPolicy checks the tieringPolicy in sc is supported by the policies and the product
+func (p *NAS) checkTieringPolicy(ctx context.Context, params map[string]interface{}) error {
+	policy, exist := params["tieringpolicy"].(string)
+	if !exist || policy == "" {
+		return nil
+	}
+
+	if _, err := client.TieringPolicyValue(policy); err != nil {
+		return utils.Errorf(ctx, "parameter tieringPolicy in sc is invalid: %v", err)
+	}
+
+	if !p.product.IsSupportSmartTier() {
+		return utils.Errorf(ctx, "parameter tieringPolicy [%s] in sc is invalid: %v by product %s",
+			policy, client.ErrTieringNotSupported, p.product)
+	}
+
+	return nil
+}
+
 // Create creates fs volume
 func (p *NAS) Create(ctx context.Context, params map[string]interface{}) (utils.Volume, error) {
 	err := p.preCreate(ctx, params)
@@ -483,8 +506,11 @@ func (p *NAS) Expand(ctx context.Context, fsName string, newSize int64) error {
 	}
 
 	curSize := utils.ParseIntWithDefault(fs["CAPACITY"].(string), 10, 64, 0)
-	if err := p.assertExpandSize(ctx, fsName, curSize, newSize); err != nil {
+	newSize, needExpand, err := p.assertExpandSize(ctx, fsName, curSize, newSize)
+	if err != nil {
 		return err
+	} else if !needExpand {
+		return nil
 	}
 
 	hyperMetroIDs, err := p.parseHyperMetroPairs(fs)
@@ -1253,19 +1279,17 @@ func (p *NAS) parseHyperMetroPairs(fsMap map[string]any) ([]string, error) {
 	return hyperMetroIds, nil
 }
 
-func (p *NAS) assertExpandSize(ctx context.Context, fsName string, curSize, newSize int64) error {
-	if newSize == curSize {
+func (p *NAS) assertExpandSize(ctx context.Context, fsName string, curSize, newSize int64) (int64, bool, error) {
+	expandSize, needExpand, err := utils.GetExpandCapacity(curSize, newSize, expandAllocationUnit)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Filesystem %s can not be expanded, error: %v", fsName, err)
+		return 0, false, fmt.Errorf("filesystem %s can not be expanded: %w", fsName, err)
+	} else if !needExpand {
 		log.AddContext(ctx).Infof("the size of filesystem %s has not changed and the current size is %d",
-			fsName, newSize)
-		return nil
-	} else if newSize < curSize {
-		msg := fmt.Sprintf("Filesystem %s newSize %d must be greater than or equal to curSize %d",
-			fsName, newSize, curSize)
-		log.AddContext(ctx).Errorln(msg)
-		return errors.New(msg)
+			fsName, curSize)
 	}
 
-	return nil
+	return expandSize, needExpand, nil
 }
 
 func isHyperMetroFromParams(params map[string]any) (bool, error) {
@@ -38,9 +38,25 @@ const (
 	waitUntilInterval = 5 * time.Second
 )
 
+var (
+	// lunCopyPollInitialInterval is the first interval to query the lun copy while waiting for it to finish,
+	// the interval doubles up to lunCopyPollMaxInterval since a large lun copy may last for hours
+	lunCopyPollInitialInterval = time.Second
+	// lunCopyPollMaxInterval is the maximum interval to query the lun copy while waiting for it to finish
+	lunCopyPollMaxInterval = 30 * time.Second
+)
+
+var (
+	// ErrSnapshotLimitReached indicates an error that the lun has reached the maximum snapshot count.
+	ErrSnapshotLimitReached = errors.New("snapshot limit reached")
+	// ErrVolumeMapped indicates an error that the lun to delete is still mapped to host.
+	ErrVolumeMapped = errors.New("volume is still mapped")
+)
+
 // SAN provides base san client
 type SAN struct {
 	Base
+	unmapWaitTimeout time.Duration
 }
 
 // NewSAN inits a new san client
@@ -56,6 +72,13 @@ func NewSAN(cli, metroRemoteCli, replicaRemoteCli client.OceanstorClientInterfac
 	}
 }
 
+// WithUnmapWaitTimeout sets the timeout to wait for the lun to be unmapped before deleting it,
+// a mapped lun is refused to delete immediately if the timeout is not positive
+func (p *SAN) WithUnmapWaitTimeout(timeout time.Duration) *SAN {
+	p.unmapWaitTimeout = timeout
+	return p
+}
+
 func (p *SAN) preCreate(ctx context.Context, params map[string]interface{}) error {
 	err := p.commonPreCreate(ctx, params)
 	if err != nil {
@@ -99,6 +122,9 @@ func (p *SAN) Create(ctx context.Context, params map[string]interface{}) (utils.
 	}
 
 	taskflow.AddTask("Create-Local-LUN", p.createLocalLun, p.revertLocalLun)
+	if _, ok := params["preferredcontroller"].(string); ok {
+		taskflow.AddTask("Set-Preferred-Controller", p.setPreferredController, nil)
+	}
 	taskflow.AddTask("Create-Local-QoS", p.createLocalQoS, p.revertLocalQoS)
 
 	if hyperMetroOK && hyperMetro {
@@ -163,6 +189,15 @@ func (p *SAN) Delete(ctx context.Context, name string) error {
 	if err != nil {
 		return pkgUtils.Errorf(ctx, "Unmarshal san HASRSSOBJECT failed, data: %v, err: %v", rssStr, err)
 	}
+	lunID, ok := lun["ID"].(string)
+	if !ok {
+		return pkgUtils.Errorf(ctx, "convert lunID to string failed, data: %v", lun["ID"])
+	}
+
+	if err = p.checkVolumeUnmapped(ctx, lunName, lunID); err != nil {
+		return err
+	}
+
 	taskflow := flow.NewTaskFlow(ctx, "Delete-LUN-Volume")
 	if hyperMetro, ok := rss["HyperMetro"]; ok && hyperMetro == "TRUE" {
 		taskflow.AddTask("Delete-HyperMetro", p.deleteHyperMetro, nil)
@@ -181,7 +216,7 @@ func (p *SAN) Delete(ctx context.Context, name string) error {
 
 	params := map[string]interface{}{
 		"lun":     lun,
-		"lunID":   lun["ID"].(string),
+		"lunID":   lunID,
 		"lunName": lunName,
 	}
 
@@ -189,6 +224,32 @@ func (p *SAN) Delete(ctx context.Context, name string) error {
 	return err
 }
 
+func (p *SAN) checkVolumeUnmapped(ctx context.Context, lunName, lunID string) error {
+	mapped, err := p.cli.IsVolumeMapped(ctx, lunID)
+	if err != nil {
+		return err
+	}
+
+	if mapped && p.unmapWaitTimeout > 0 {
+		log.AddContext(ctx).Infof("Lun %s is still mapped, wait %s for it to be unmapped", lunName, p.unmapWaitTimeout)
+		err = utils.WaitUntil(func() (bool, error) {
+			mapped, err = p.cli.IsVolumeMapped(ctx, lunID)
+			return !mapped, err
+		}, p.unmapWaitTimeout, waitUntilInterval)
+		if err != nil && !mapped {
+			return err
+		}
+	}
+
+	if mapped {
+		err = fmt.Errorf("refuse to delete lun %s: %w", lunName, ErrVolumeMapped)
+		log.AddContext(ctx).Errorln(err)
+		return err
+	}
+
+	return nil
+}
+
 // Expand expands volume size
 func (p *SAN) Expand(ctx context.Context, name string, newSize int64) (bool, error) {
 	lunName := p.cli.MakeLunName(name)
@@ -204,15 +265,14 @@ func (p *SAN) Expand(ctx context.Context, name string, newSize int64) (bool, err
 
 	isAttached := lun["EXPOSEDTOINITIATOR"] == "true"
 	curSize := utils.ParseIntWithDefault(lun["CAPACITY"].(string), 10, 64, 0)
-	if newSize == curSize {
+	newSize, needExpand, err := utils.GetExpandCapacity(curSize, newSize, expandAllocationUnit)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Lun %s can not be expanded, error: %v", lunName, err)
+		return false, fmt.Errorf("lun %s can not be expanded: %w", lunName, err)
+	} else if !needExpand {
 		log.AddContext(ctx).Infof("the size of lun %s has not changed and the current size is %d",
-			lunName, newSize)
+			lunName, curSize)
 		return isAttached, nil
-	} else if newSize < curSize {
-		msg := fmt.Sprintf("Lun %s newSize %d must be greater than or equal to curSize %d",
-			lunName, newSize, curSize)
-		log.AddContext(ctx).Errorln(msg)
-		return false, errors.New(msg)
 	}
 
 	var rss map[string]string
@@ -275,7 +335,8 @@ func (p *SAN) createLocalLun(ctx context.Context,
 			return nil, err
 		}
 	} else {
-		err := p.waitCloneFinish(ctx, lun, taskResult)
+		// the LUN may be left by an interrupted clone, resume waiting for the existing copy
+		err := p.waitCloneFinish(ctx, lun, params)
 		if err != nil {
 			log.AddContext(ctx).Errorf("Wait clone finish for LUN %s error: %v", lunName, err)
 			return nil, err
@@ -656,6 +717,9 @@ func (p *SAN) createLunCopy(ctx context.Context,
 			log.AddContext(ctx).Errorf("Create luncopy from %s to %s error: %v", snapshotID, dstLunID, err)
 			return "", err
 		}
+	} else if isLunCopyInProgress(lunCopy) {
+		log.AddContext(ctx).Infof("Luncopy %s is already in progress, resume waiting for it", lunCopyName)
+		return lunCopyName, nil
 	}
 
 	lunCopyID, ok := lunCopy["ID"].(string)
@@ -700,6 +764,28 @@ func (p *SAN) revertLocalLun(ctx context.Context, taskResult map[string]interfac
 	return err
 }
 
+func (p *SAN) setPreferredController(ctx context.Context,
+	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
+	controllerID, ok := params["preferredcontroller"].(string)
+	if !ok {
+		return nil, pkgUtils.Errorf(ctx, "controllerID convert to string failed, data: %v",
+			params["preferredcontroller"])
+	}
+
+	lunID, ok := taskResult["localLunID"].(string)
+	if !ok {
+		return nil, pkgUtils.Errorf(ctx, "lunID convert to string failed, data: %v", taskResult["localLunID"])
+	}
+
+	err := p.cli.SetLunOwnerController(ctx, lunID, controllerID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Set preferred controller %s of lun %s error: %v", controllerID, lunID, err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
 func (p *SAN) createLocalQoS(ctx context.Context,
 	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
 	qos, exist := params["qos"].(map[string]int)
@@ -711,15 +797,15 @@ func (p *SAN) createLocalQoS(ctx context.Context,
 	if !ok {
 		return nil, pkgUtils.Errorf(ctx, "lunID convert to string failed, data: %v", taskResult["localLunID"])
 	}
-	lun, err := p.cli.GetLunByID(ctx, lunID)
+	// the lun is created by the previous task, it may not be visible yet on a busy storage
+	lun, err := p.cli.GetLunByID(client.WithReadAfterWrite(ctx), lunID)
 	if err != nil {
 		return nil, err
 	}
 
 	qosID, exist := lun["IOCLASSID"].(string)
 	if !exist || qosID == "" {
-		smartX := smartx.NewSmartX(p.cli)
-		qosID, err = smartX.CreateQos(ctx, lunID, "lun", "", qos)
+		qosID, err = createLunQoS(ctx, p.cli, lunID, params, qos)
 		if err != nil {
 			log.AddContext(ctx).Errorf("Create qos %v for lun %s error: %v", qos, lunID, err)
 			return nil, err
@@ -731,6 +817,17 @@ func (p *SAN) createLocalQoS(ctx context.Context,
 	}, nil
 }
 
+// createLunQoS creates the qos of the lun, the policy of the same parameters is reused if sharedqos is set
+func createLunQoS(ctx context.Context, cli client.OceanstorClientInterface,
+	lunID string, params map[string]interface{}, qos map[string]int) (string, error) {
+	smartX := smartx.NewSmartX(cli)
+	if shared, _ := params["sharedqos"].(bool); shared {
+		return smartX.CreateOrReuseQos(ctx, lunID, "lun", "", qos)
+	}
+
+	return smartX.CreateQos(ctx, lunID, "lun", "", qos)
+}
+
 func (p *SAN) revertLocalQoS(ctx context.Context, taskResult map[string]interface{}) error {
 	lunID, lunIDExist := taskResult["localLunID"].(string)
 	qosID, qosIDExist := taskResult["localQosID"].(string)
@@ -820,45 +917,104 @@ func (p *SAN) deleteLunCopy(ctx context.Context, lunCopyName string, isDeleteSna
 }
 
 func (p *SAN) waitLunCopyFinish(ctx context.Context, lunCopyName string) error {
-	err := utils.WaitUntil(func() (bool, error) {
-		lunCopy, err := p.cli.GetLunCopyByName(ctx, lunCopyName)
-		if err != nil {
-			return false, err
-		}
-		if lunCopy == nil {
-			return true, nil
-		}
+	return p.waitLunCopy(ctx, lunCopyName, p.cli.GetLunCopyByName, checkLunCopyFinished)
+}
 
-		healthStatus, ok := lunCopy["HEALTHSTATUS"].(string)
-		if !ok {
-			return false, pkgUtils.Errorf(ctx, "healthStatus convert to string failed, data: %v", lunCopy["HEALTHSTATUS"])
-		}
-		if healthStatus == lunCopyHealthStatusFault {
-			return false, fmt.Errorf("luncopy %s is at fault status", lunCopyName)
-		}
+// GetLunCopyProgress used for get the progress percentage of lun copy, the value is between 0 and 100
+func (p *SAN) GetLunCopyProgress(ctx context.Context, lunCopyID string) (int, error) {
+	lunCopy, err := p.cli.GetLunCopyByID(ctx, lunCopyID)
+	if err != nil {
+		return 0, err
+	}
+	if lunCopy == nil {
+		return 0, pkgUtils.Errorf(ctx, "luncopy %s does not exist", lunCopyID)
+	}
 
-		runningStatus, ok := lunCopy["RUNNINGSTATUS"].(string)
-		if !ok {
-			return false, pkgUtils.Errorf(ctx, "runningStatus convert to string failed, data: %v", lunCopy["RUNNINGSTATUS"])
-		}
-		if runningStatus == lunCopyRunningStatusQueuing ||
-			runningStatus == lunCopyRunningStatusCopying {
-			return false, nil
-		} else if runningStatus == lunCopyRunningStatusStop ||
-			runningStatus == lunCopyRunningStatusPaused {
-			return false, fmt.Errorf("Luncopy %s is stopped", lunCopyName)
-		} else {
-			return true, nil
-		}
-	}, waitUntilTimeout, waitUntilInterval)
+	finished, err := checkLunCopyStatus(lunCopyID, lunCopy)
+	if err != nil {
+		return 0, err
+	}
+	if finished {
+		return utils.ProgressMax, nil
+	}
+
+	return utils.ParseProgress(lunCopy["COPYPROGRESS"]), nil
+}
+
+// WaitLunCopy used for wait lun copy until it finishes, an error is returned if the lun copy
+// is faulty, stopped, paused or in an unknown status, or the ctx is done
+func (p *SAN) WaitLunCopy(ctx context.Context, lunCopyID string) error {
+	return p.waitLunCopy(ctx, lunCopyID, p.cli.GetLunCopyByID, checkLunCopyStatus)
+}
+
+// waitLunCopy polls the lun copy queried by getLunCopy with the key, which is its name or id, until checkStatus
+// regards it as finished, the lun copy that no longer exists is regarded as finished
+func (p *SAN) waitLunCopy(ctx context.Context, key string,
+	getLunCopy func(context.Context, string) (map[string]interface{}, error),
+	checkStatus func(string, map[string]interface{}) (bool, error)) error {
+	err := utils.PollUntil(ctx, lunCopyPollInitialInterval, lunCopyPollMaxInterval, waitUntilTimeout,
+		func() (bool, error) {
+			lunCopy, err := getLunCopy(ctx, key)
+			if err != nil {
+				return false, err
+			}
+			if lunCopy == nil {
+				return true, nil
+			}
 
+			finished, err := checkStatus(key, lunCopy)
+			if err != nil || finished {
+				return finished, err
+			}
+
+			log.AddContext(ctx).Infof("Luncopy %s is in progress %d%%",
+				key, utils.ParseProgress(lunCopy["COPYPROGRESS"]))
+			return false, nil
+		})
 	if err != nil {
-		return err
+		return fmt.Errorf("wait luncopy %s error: %w", key, err)
 	}
 
+	log.AddContext(ctx).Infof("Luncopy %s is finished", key)
 	return nil
 }
 
+// checkLunCopyStatus returns whether the lun copy is finished, an error is returned if it is faulty,
+// stopped, paused or in an unknown running status
+func checkLunCopyStatus(key string, lunCopy map[string]interface{}) (bool, error) {
+	if utils.GetValueOrFallback(lunCopy, "HEALTHSTATUS", "") == lunCopyHealthStatusFault {
+		return false, fmt.Errorf("luncopy %s is at fault status", key)
+	}
+
+	runningStatus := utils.GetValueOrFallback(lunCopy, "RUNNINGSTATUS", "")
+	switch runningStatus {
+	case lunCopyRunningStatusComplete:
+		return true, nil
+	case lunCopyRunningStatusQueuing, lunCopyRunningStatusCopying:
+		return false, nil
+	case lunCopyRunningStatusStop, lunCopyRunningStatusPaused:
+		return false, fmt.Errorf("luncopy %s is stopped or paused, running status: %s", key, runningStatus)
+	default:
+		return false, fmt.Errorf("luncopy %s is at unknown running status: %v", key, lunCopy["RUNNINGSTATUS"])
+	}
+}
+
+// checkLunCopyFinished returns whether the lun copy created by the plugin itself is finished, any running status
+// other than queuing, copying, stopped and paused is regarded as finished
+func checkLunCopyFinished(key string, lunCopy map[string]interface{}) (bool, error) {
+	if utils.GetValueOrFallback(lunCopy, "HEALTHSTATUS", "") == lunCopyHealthStatusFault {
+		return checkLunCopyStatus(key, lunCopy)
+	}
+
+	switch utils.GetValueOrFallback(lunCopy, "RUNNINGSTATUS", "") {
+	case lunCopyRunningStatusQueuing, lunCopyRunningStatusCopying, lunCopyRunningStatusStop,
+		lunCopyRunningStatusPaused:
+		return checkLunCopyStatus(key, lunCopy)
+	default:
+		return true, nil
+	}
+}
+
 func (p *SAN) waitClonePairFinish(ctx context.Context, clonePairID string) error {
 	err := utils.WaitUntil(func() (bool, error) {
 		clonePair, err := p.cli.GetClonePairInfo(ctx, clonePairID)
@@ -901,14 +1057,19 @@ func (p *SAN) waitClonePairFinish(ctx context.Context, clonePairID string) error
 }
 
 func (p *SAN) waitCloneFinish(ctx context.Context,
-	lun map[string]interface{}, taskResult map[string]interface{}) error {
+	lun map[string]interface{}, params map[string]interface{}) error {
 	lunID, ok := lun["ID"].(string)
 	if !ok {
 		return pkgUtils.Errorf(ctx, "lunID convert to string failed, data: %v", lun["ID"])
 	}
 	if p.product.IsDoradoV6OrV7() {
 		// ID of clone pair is the same as destination LUN ID
-		err := p.waitClonePairFinish(ctx, lunID)
+		err := p.resumeClonePair(ctx, lunID)
+		if err != nil {
+			return err
+		}
+
+		err = p.waitClonePairFinish(ctx, lunID)
 		if err != nil {
 			return err
 		}
@@ -919,16 +1080,49 @@ func (p *SAN) waitCloneFinish(ctx context.Context,
 		}
 
 		if len(lunCopyName) > 0 {
+			log.AddContext(ctx).Infof("Resume waiting for luncopy %s of LUN %s", lunCopyName, lunID)
 			err := p.waitLunCopyFinish(ctx, lunCopyName)
 			if err != nil {
 				return err
 			}
+
+			// the snapshot is created temporarily only when cloning from a LUN
+			_, isDeleteSnapshot := params["clonefrom"]
+			err = p.deleteLunCopy(ctx, lunCopyName, isDeleteSnapshot)
+			if err != nil {
+				log.AddContext(ctx).Errorf("Delete luncopy %s error: %v", lunCopyName, err)
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// resumeClonePair starts synchronizing the clone pair which was created but not started before interrupted
+func (p *SAN) resumeClonePair(ctx context.Context, clonePairID string) error {
+	clonePair, err := p.cli.GetClonePairInfo(ctx, clonePairID)
+	if err != nil {
+		return err
+	}
+
+	if clonePair == nil {
+		return nil
+	}
+
+	if utils.GetValueOrFallback(clonePair, "syncStatus", "") != clonePairRunningStatusUnsyncing {
+		return nil
+	}
+
+	log.AddContext(ctx).Infof("ClonePair %s is not synchronized, resume synchronizing it", clonePairID)
+	return p.cli.SyncClonePair(ctx, clonePairID)
+}
+
+func isLunCopyInProgress(lunCopy map[string]interface{}) bool {
+	runningStatus := utils.GetValueOrFallback(lunCopy, "RUNNINGSTATUS", "")
+	return runningStatus == lunCopyRunningStatusQueuing || runningStatus == lunCopyRunningStatusCopying
+}
+
 func (p *SAN) createRemoteLun(ctx context.Context,
 	params, taskResult map[string]interface{}) (map[string]interface{}, error) {
 	lunName, ok := params["name"].(string)
@@ -1003,8 +1197,7 @@ func (p *SAN) createRemoteQoS(ctx context.Context,
 
 	qosID, exist := lun["IOCLASSID"].(string)
 	if !exist || qosID == "" {
-		smartX := smartx.NewSmartX(remoteCli)
-		qosID, err = smartX.CreateQos(ctx, lunID, "lun", "", qos)
+		qosID, err = createLunQoS(ctx, remoteCli, lunID, params, qos)
 		if err != nil {
 			log.AddContext(ctx).Errorf("Create qos %v for lun %s error: %v", qos, lunID, err)
 			return nil, err
@@ -1064,7 +1257,7 @@ func (p *SAN) createHyperMetro(ctx context.Context,
 			"ISFIRSTSYNC":    needFirstSync,
 			"LOCALOBJID":     localLunID,
 			"REMOTEOBJID":    remoteLunID,
-			"SPEED":          4,
+			"SPEED":          utils.GetValueOrFallback(params, "metropairsyncspeed", client.MetroPairSyncSpeedHighest),
 		}
 
 		pair, err := p.cli.CreateHyperMetroPair(ctx, data)
@@ -1512,6 +1705,11 @@ func (p *SAN) CreateSnapshot(ctx context.Context,
 		}
 	}
 
+	err = p.checkSnapshotLimit(ctx, lunName, lunId)
+	if err != nil {
+		return nil, err
+	}
+
 	taskflow := flow.NewTaskFlow(ctx, "Create-LUN-Snapshot")
 	taskflow.AddTask("Create-Snapshot", p.createSnapshot, p.revertSnapshot)
 	taskflow.AddTask("Active-Snapshot", p.activateSnapshot, nil)
@@ -1537,6 +1735,24 @@ func (p *SAN) CreateSnapshot(ctx context.Context,
 	return p.getSnapshotReturnInfo(snapshot, snapshotSize), nil
 }
 
+func (p *SAN) checkSnapshotLimit(ctx context.Context, lunName, lunID string) error {
+	count, err := p.cli.GetLunSnapshotCount(ctx, lunID)
+	if err != nil {
+		log.AddContext(ctx).Errorf("Get snapshot count of lun %s error: %v", lunName, err)
+		return err
+	}
+
+	maxCount := p.cli.GetMaxSnapshotsPerVolume(ctx)
+	if count >= maxCount {
+		err = fmt.Errorf("create snapshot for lun %s failed, %w: the lun already has %d snapshots, "+
+			"the maximum is %d", lunName, ErrSnapshotLimitReached, count, maxCount)
+		log.AddContext(ctx).Errorln(err)
+		return err
+	}
+
+	return nil
+}
+
 // DeleteSnapshot deletes lun snapshot
 func (p *SAN) DeleteSnapshot(ctx context.Context, snapshotName string) error {
 	snapshot, err := p.cli.GetLunSnapshotByName(ctx, snapshotName)
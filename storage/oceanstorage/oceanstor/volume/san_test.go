@@ -0,0 +1,460 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package volume
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/constants"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/smartx"
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/test/mocks/mock_client"
+)
+
+func TestSAN_createLocalLun_ResumeInProgressLunCopy(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV3)
+	params := map[string]interface{}{"name": "dst-lun", "clonefrom": "src-lun"}
+	lun := map[string]interface{}{"ID": "10", "WWN": "wwn-10", "LUNCOPYIDS": `["5"]`}
+	lunCopyName := "k8s_luncopy_7_to_10"
+	lunCopy := map[string]interface{}{"ID": "5", "NAME": lunCopyName, "HEALTHSTATUS": "1",
+		"RUNNINGSTATUS": "40", "SOURCELUNNAME": "k8s_lun_2_to_10_snap"}
+
+	// mock
+	cli.EXPECT().GetLunByName(ctx, "dst-lun").Return(lun, nil)
+	cli.EXPECT().GetLunByID(ctx, "10").Return(lun, nil)
+	cli.EXPECT().GetLunCopyByID(ctx, "5").Return(lunCopy, nil)
+	cli.EXPECT().GetLunCopyByName(ctx, lunCopyName).Return(lunCopy, nil).Times(2)
+	cli.EXPECT().DeleteLunCopy(ctx, "5").Return(nil)
+	cli.EXPECT().GetLunSnapshotByName(ctx, "k8s_lun_2_to_10_snap").
+		Return(map[string]interface{}{"ID": "7"}, nil)
+	cli.EXPECT().DeactivateLunSnapshot(ctx, "7").Return(nil)
+	cli.EXPECT().DeleteLunSnapshot(ctx, "7").Return(nil)
+
+	// action
+	res, err := san.createLocalLun(ctx, params, map[string]interface{}{})
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "10", res["localLunID"])
+	require.Equal(t, "wwn-10", res["lunWWN"])
+}
+
+func TestSAN_createLunCopy_AttachInProgressLunCopy(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV3)
+	lunCopyName := "k8s_luncopy_7_to_10"
+
+	// mock
+	cli.EXPECT().GetLunCopyByName(ctx, lunCopyName).
+		Return(map[string]interface{}{"ID": "5", "RUNNINGSTATUS": lunCopyRunningStatusCopying}, nil)
+
+	// action
+	name, err := san.createLunCopy(ctx, "7", "10", 1, true)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, lunCopyName, name)
+}
+
+func TestSAN_createLocalLun_ResumeInProgressClonePair(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV6)
+	params := map[string]interface{}{"name": "dst-lun", "clonefrom": "src-lun"}
+	lun := map[string]interface{}{"ID": "10", "WWN": "wwn-10"}
+
+	// mock
+	cli.EXPECT().GetLunByName(ctx, "dst-lun").Return(lun, nil)
+	gomock.InOrder(
+		cli.EXPECT().GetClonePairInfo(ctx, "10").Return(map[string]interface{}{
+			"copyStatus": "0", "syncStatus": clonePairRunningStatusSyncing}, nil),
+		cli.EXPECT().GetClonePairInfo(ctx, "10").Return(map[string]interface{}{
+			"copyStatus": "0", "syncStatus": clonePairRunningStatusNormal}, nil),
+	)
+	cli.EXPECT().DeleteClonePair(ctx, "10").Return(nil)
+
+	// action
+	res, err := san.createLocalLun(ctx, params, map[string]interface{}{})
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "10", res["localLunID"])
+}
+
+func TestSAN_createLocalLun_ResumeUnsyncedClonePair(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV6)
+	params := map[string]interface{}{"name": "dst-lun", "clonefrom": "src-lun"}
+	lun := map[string]interface{}{"ID": "10", "WWN": "wwn-10"}
+
+	// mock
+	cli.EXPECT().GetLunByName(ctx, "dst-lun").Return(lun, nil)
+	gomock.InOrder(
+		cli.EXPECT().GetClonePairInfo(ctx, "10").Return(map[string]interface{}{
+			"copyStatus": "0", "syncStatus": clonePairRunningStatusUnsyncing}, nil),
+		cli.EXPECT().SyncClonePair(ctx, "10").Return(nil),
+		cli.EXPECT().GetClonePairInfo(ctx, "10").Return(map[string]interface{}{
+			"copyStatus": "0", "syncStatus": clonePairRunningStatusNormal}, nil),
+		cli.EXPECT().DeleteClonePair(ctx, "10").Return(nil),
+	)
+
+	// action
+	_, err := san.createLocalLun(ctx, params, map[string]interface{}{})
+
+	// assert
+	require.NoError(t, err)
+}
+
+func TestSAN_CreateSnapshot_SnapshotLimitReached(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV6)
+
+	// mock
+	cli.EXPECT().GetLunByName(ctx, "lun").Return(map[string]interface{}{"ID": "10"}, nil)
+	cli.EXPECT().GetLunSnapshotByName(ctx, "snapshot").Return(nil, nil)
+	cli.EXPECT().GetLunSnapshotCount(ctx, "10").Return(int64(1024), nil)
+	cli.EXPECT().GetMaxSnapshotsPerVolume(ctx).Return(int64(1024))
+
+	// action
+	_, err := san.CreateSnapshot(ctx, "lun", "snapshot")
+
+	// assert
+	require.ErrorIs(t, err, ErrSnapshotLimitReached)
+}
+
+func TestSAN_CreateSnapshot_BelowSnapshotLimit(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV6)
+	snapshot := map[string]interface{}{"ID": "7", "NAME": "snapshot", "PARENTID": "10",
+		"USERCAPACITY": "2097152", "RUNNINGSTATUS": "43", "TIMESTAMP": "1700000000", "WWN": "wwn-7"}
+
+	// mock
+	cli.EXPECT().GetLunByName(ctx, "lun").Return(map[string]interface{}{"ID": "10"}, nil)
+	gomock.InOrder(
+		cli.EXPECT().GetLunSnapshotByName(ctx, "snapshot").Return(nil, nil),
+		cli.EXPECT().GetLunSnapshotByName(ctx, "snapshot").Return(snapshot, nil).AnyTimes(),
+	)
+	cli.EXPECT().GetLunSnapshotCount(ctx, "10").Return(int64(1023), nil)
+	cli.EXPECT().GetMaxSnapshotsPerVolume(ctx).Return(int64(1024))
+	cli.EXPECT().CreateLunSnapshot(ctx, "snapshot", "10").Return(snapshot, nil)
+	cli.EXPECT().ActivateLunSnapshot(ctx, "7").Return(nil)
+
+	// action
+	res, err := san.CreateSnapshot(ctx, "lun", "snapshot")
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "10", res["ParentID"])
+}
+
+func TestSAN_Delete_VolumeMapped(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV6)
+
+	// mock
+	cli.EXPECT().MakeLunName("lun").Return("lun")
+	cli.EXPECT().GetLunByName(ctx, "lun").Return(map[string]interface{}{"ID": "10", "HASRSSOBJECT": "{}"}, nil)
+	cli.EXPECT().IsVolumeMapped(ctx, "10").Return(true, nil)
+
+	// action
+	err := san.Delete(ctx, "lun")
+
+	// assert
+	require.ErrorIs(t, err, ErrVolumeMapped)
+}
+
+func TestSAN_Delete_VolumeUnmapped(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV6)
+
+	// mock
+	cli.EXPECT().MakeLunName("lun").Return("lun")
+	cli.EXPECT().GetLunByName(ctx, "lun").Return(map[string]interface{}{"ID": "10", "HASRSSOBJECT": "{}"}, nil)
+	cli.EXPECT().IsVolumeMapped(ctx, "10").Return(false, nil)
+	cli.EXPECT().GetLunByName(ctx, "lun").Return(map[string]interface{}{"ID": "10"}, nil)
+	cli.EXPECT().DeleteLun(ctx, "10").Return(nil)
+
+	// action
+	err := san.Delete(ctx, "lun")
+
+	// assert
+	require.NoError(t, err)
+}
+
+func TestSAN_Expand_RoundsToCurrentSize(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV6)
+	lun := map[string]interface{}{"ID": "10", "CAPACITY": "4096", "EXPOSEDTOINITIATOR": "true"}
+
+	// mock
+	cli.EXPECT().MakeLunName("lun").Return("lun")
+	cli.EXPECT().GetLunByName(ctx, "lun").Return(lun, nil)
+
+	// action
+	isAttached, err := san.Expand(ctx, "lun", 4095)
+
+	// assert
+	require.NoError(t, err)
+	require.True(t, isAttached)
+}
+
+func TestSAN_Expand_Shrink(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV6)
+	lun := map[string]interface{}{"ID": "10", "CAPACITY": "4096", "EXPOSEDTOINITIATOR": "true"}
+
+	// mock
+	cli.EXPECT().MakeLunName("lun").Return("lun")
+	cli.EXPECT().GetLunByName(ctx, "lun").Return(lun, nil)
+
+	// action
+	_, err := san.Expand(ctx, "lun", 2048)
+
+	// assert
+	require.ErrorContains(t, err, "must be greater than or equal to curSize")
+}
+
+func TestSAN_setPreferredController(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorV5)
+	params := map[string]interface{}{"preferredcontroller": "0B"}
+
+	// mock
+	cli.EXPECT().SetLunOwnerController(ctx, "10", "0B").Return(nil)
+
+	// action
+	_, err := san.setPreferredController(ctx, params, map[string]interface{}{"localLunID": "10"})
+
+	// assert
+	require.NoError(t, err)
+}
+
+func TestSAN_createLocalQoS_SharedQoS(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV6)
+	qos := map[string]int{"MAXIOPS": 1000}
+	params := map[string]interface{}{"qos": qos, "sharedqos": true}
+	name := smartx.SharedQosName("lun", qos)
+	sharedQos := map[string]interface{}{"ID": "3", "NAME": name, "ENABLESTATUS": "true", "LUNLIST": `["9"]`}
+
+	// mock
+	cli.EXPECT().GetLunByID(gomock.Any(), "10").Return(map[string]interface{}{"ID": "10"}, nil)
+	cli.EXPECT().GetQosByName(ctx, name, "").Return(sharedQos, nil)
+	cli.EXPECT().UpdateQos(ctx, "3", "", map[string]interface{}{"LUNLIST": []string{"9", "10"}}).Return(nil)
+
+	// action
+	res, err := san.createLocalQoS(ctx, params, map[string]interface{}{"localLunID": "10"})
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, "3", res["localQosID"])
+}
+
+func TestSAN_GetLunCopyProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		lunCopy  map[string]interface{}
+		expected int
+		wantErr  bool
+	}{
+		{name: "copying", expected: 42,
+			lunCopy: map[string]interface{}{"RUNNINGSTATUS": lunCopyRunningStatusCopying, "COPYPROGRESS": "42"}},
+		{name: "queuing without progress", expected: 0,
+			lunCopy: map[string]interface{}{"RUNNINGSTATUS": lunCopyRunningStatusQueuing}},
+		{name: "progress out of range", expected: 100,
+			lunCopy: map[string]interface{}{"RUNNINGSTATUS": lunCopyRunningStatusCopying, "COPYPROGRESS": "120"}},
+		{name: "finished", expected: 100,
+			lunCopy: map[string]interface{}{"RUNNINGSTATUS": lunCopyRunningStatusComplete, "COPYPROGRESS": "0"}},
+		{name: "fault", wantErr: true, lunCopy: map[string]interface{}{
+			"HEALTHSTATUS": lunCopyHealthStatusFault, "RUNNINGSTATUS": lunCopyRunningStatusCopying}},
+		{name: "stopped", wantErr: true,
+			lunCopy: map[string]interface{}{"RUNNINGSTATUS": lunCopyRunningStatusStop}},
+		{name: "unknown status", wantErr: true,
+			lunCopy: map[string]interface{}{"RUNNINGSTATUS": "99"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctx := context.Background()
+			cli := mock_client.NewMockOceanstorClientInterface(gomock.NewController(t))
+			san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV3)
+
+			// mock
+			cli.EXPECT().GetLunCopyByID(ctx, "5").Return(tt.lunCopy, nil)
+
+			// action
+			progress, err := san.GetLunCopyProgress(ctx, "5")
+
+			// assert
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, progress)
+		})
+	}
+}
+
+func TestSAN_WaitLunCopy(t *testing.T) {
+	tests := []struct {
+		name      string
+		lunCopies []map[string]interface{}
+		errMsg    string
+	}{
+		{name: "finished", lunCopies: []map[string]interface{}{
+			{"RUNNINGSTATUS": lunCopyRunningStatusQueuing},
+			{"RUNNINGSTATUS": lunCopyRunningStatusCopying, "COPYPROGRESS": "50"},
+			{"RUNNINGSTATUS": lunCopyRunningStatusComplete}}},
+		{name: "paused", errMsg: "stopped or paused", lunCopies: []map[string]interface{}{
+			{"RUNNINGSTATUS": lunCopyRunningStatusCopying, "COPYPROGRESS": "50"},
+			{"RUNNINGSTATUS": lunCopyRunningStatusPaused}}},
+		{name: "unknown status", errMsg: "unknown running status", lunCopies: []map[string]interface{}{
+			{"RUNNINGSTATUS": lunCopyRunningStatusCopying, "COPYPROGRESS": "50"},
+			{"RUNNINGSTATUS": "99"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctx := context.Background()
+			cli := mock_client.NewMockOceanstorClientInterface(gomock.NewController(t))
+			san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV3)
+			patches := gomonkey.ApplyGlobalVar(&lunCopyPollInitialInterval, time.Millisecond)
+			defer patches.Reset()
+			patches.ApplyGlobalVar(&lunCopyPollMaxInterval, time.Millisecond)
+
+			// mock
+			calls := make([]any, 0, len(tt.lunCopies))
+			for _, lunCopy := range tt.lunCopies {
+				calls = append(calls, cli.EXPECT().GetLunCopyByID(ctx, "5").Return(lunCopy, nil))
+			}
+			gomock.InOrder(calls...)
+
+			// action
+			err := san.WaitLunCopy(ctx, "5")
+
+			// assert
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSAN_waitLunCopyFinish(t *testing.T) {
+	tests := []struct {
+		name      string
+		lunCopies []map[string]interface{}
+		errMsg    string
+	}{
+		{name: "unknown status regarded as finished", lunCopies: []map[string]interface{}{
+			{"RUNNINGSTATUS": lunCopyRunningStatusCopying, "COPYPROGRESS": "50"},
+			{"RUNNINGSTATUS": "99"}}},
+		{name: "stopped", errMsg: "stopped or paused", lunCopies: []map[string]interface{}{
+			{"RUNNINGSTATUS": lunCopyRunningStatusStop}}},
+		{name: "fault", errMsg: "fault status", lunCopies: []map[string]interface{}{
+			{"HEALTHSTATUS": lunCopyHealthStatusFault, "RUNNINGSTATUS": "99"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctx := context.Background()
+			cli := mock_client.NewMockOceanstorClientInterface(gomock.NewController(t))
+			san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV3)
+			patches := gomonkey.ApplyGlobalVar(&lunCopyPollInitialInterval, time.Millisecond)
+			defer patches.Reset()
+			patches.ApplyGlobalVar(&lunCopyPollMaxInterval, time.Millisecond)
+
+			// mock
+			calls := make([]any, 0, len(tt.lunCopies))
+			for _, lunCopy := range tt.lunCopies {
+				calls = append(calls, cli.EXPECT().GetLunCopyByName(ctx, "copy").Return(lunCopy, nil))
+			}
+			gomock.InOrder(calls...)
+
+			// action
+			err := san.waitLunCopyFinish(ctx, "copy")
+
+			// assert
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSAN_WaitLunCopy_Canceled(t *testing.T) {
+	// arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cli := mock_client.NewMockOceanstorClientInterface(gomock.NewController(t))
+	san := NewSAN(cli, nil, nil, constants.OceanStorDoradoV3)
+
+	// action
+	err := san.WaitLunCopy(ctx, "5")
+
+	// assert
+	require.ErrorIs(t, err, context.Canceled)
+}
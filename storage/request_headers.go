@@ -0,0 +1,58 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"maps"
+	"net/http"
+)
+
+// AuthTokenHeader is the header of the storage session token, it can not be overridden by the custom headers
+const AuthTokenHeader = "iBaseToken"
+
+type requestHeadersKey struct{}
+
+// WithHeaders returns a copy of ctx carrying the headers to be added to the storage rest requests sent with it,
+// the headers override the static headers of the backend and the headers already carried by ctx
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	merged := maps.Clone(HeadersFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(headers))
+	}
+	maps.Copy(merged, headers)
+	return context.WithValue(ctx, requestHeadersKey{}, merged)
+}
+
+// HeadersFromContext returns the headers carried by ctx, nil is returned if there is none
+func HeadersFromContext(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(requestHeadersKey{}).(map[string]string)
+	return headers
+}
+
+// SetCustomHeaders sets the static headers of the backend and then the headers carried by ctx to the header,
+// the AuthTokenHeader is skipped so that the session token always wins
+func SetCustomHeaders(ctx context.Context, header http.Header, static map[string]string) {
+	for _, headers := range []map[string]string{static, HeadersFromContext(ctx)} {
+		for key, value := range headers {
+			if http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(AuthTokenHeader) {
+				continue
+			}
+			header.Set(key, value)
+		}
+	}
+}
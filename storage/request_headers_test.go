@@ -0,0 +1,51 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithHeaders_Merge(t *testing.T) {
+	// arrange
+	parent := WithHeaders(context.Background(), map[string]string{"X-Route": "a", "X-Request-ID": "1"})
+
+	// action
+	ctx := WithHeaders(parent, map[string]string{"X-Request-ID": "2"})
+
+	// assert
+	require.Equal(t, map[string]string{"X-Route": "a", "X-Request-ID": "2"}, HeadersFromContext(ctx))
+	require.Equal(t, map[string]string{"X-Route": "a", "X-Request-ID": "1"}, HeadersFromContext(parent))
+}
+
+func TestSetCustomHeaders_TokenNotOverridden(t *testing.T) {
+	// arrange
+	header := http.Header{}
+	header.Set(AuthTokenHeader, "token")
+	ctx := WithHeaders(context.Background(), map[string]string{"IBASETOKEN": "forged", "X-Request-ID": "2"})
+
+	// action
+	SetCustomHeaders(ctx, header, map[string]string{"iBaseToken": "forged", "X-Request-ID": "1"})
+
+	// assert
+	require.Equal(t, "token", header.Get(AuthTokenHeader))
+	require.Equal(t, "2", header.Get("X-Request-ID"))
+}
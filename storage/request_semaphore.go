@@ -0,0 +1,103 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"sync"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
+)
+
+var (
+	// requestSemaphoreMap stores the total connection num of each storage,
+	// it is accessed by multiple clients concurrently and must be guarded by requestSemaphoreMutex
+	requestSemaphoreMap   = map[string]*utils.Semaphore{UninitializedStorage: utils.NewSemaphore(MaxStorageThreads)}
+	requestSemaphoreRefs  = map[string]int{}
+	requestSemaphoreMutex sync.RWMutex
+)
+
+// GetRequestSemaphore returns the request semaphore of the storage device,
+// nil is returned if the device is not referenced by any client
+func GetRequestSemaphore(deviceSN string) *utils.Semaphore {
+	requestSemaphoreMutex.RLock()
+	defer requestSemaphoreMutex.RUnlock()
+	return requestSemaphoreMap[deviceSN]
+}
+
+// GetRequestSemaphoreOrDefault returns the request semaphore of the storage device,
+// the semaphore of UninitializedStorage is returned if the device is not referenced by any client
+func GetRequestSemaphoreOrDefault(deviceSN string) *utils.Semaphore {
+	if sem := GetRequestSemaphore(deviceSN); sem != nil {
+		return sem
+	}
+
+	return GetRequestSemaphore(UninitializedStorage)
+}
+
+// RequestSemaphoreRef is the reference of a client to the request semaphore of a storage device,
+// the semaphore is created by the first reference and removed when no client references it
+type RequestSemaphoreRef struct {
+	mutex    sync.Mutex
+	deviceSN string
+}
+
+// Bind references the request semaphore of the device, the previous referenced device is released
+func (r *RequestSemaphoreRef) Bind(deviceSN string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.deviceSN == deviceSN {
+		return
+	}
+
+	releaseRequestSemaphore(r.deviceSN)
+	r.deviceSN = ""
+	if deviceSN == "" || deviceSN == UninitializedStorage {
+		return
+	}
+
+	requestSemaphoreMutex.Lock()
+	defer requestSemaphoreMutex.Unlock()
+	if requestSemaphoreMap[deviceSN] == nil {
+		requestSemaphoreMap[deviceSN] = utils.NewSemaphore(MaxStorageThreads)
+	}
+	requestSemaphoreRefs[deviceSN]++
+	r.deviceSN = deviceSN
+}
+
+// Release releases the reference to the request semaphore of the device
+func (r *RequestSemaphoreRef) Release() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	releaseRequestSemaphore(r.deviceSN)
+	r.deviceSN = ""
+}
+
+func releaseRequestSemaphore(deviceSN string) {
+	if deviceSN == "" {
+		return
+	}
+
+	requestSemaphoreMutex.Lock()
+	defer requestSemaphoreMutex.Unlock()
+	requestSemaphoreRefs[deviceSN]--
+	if requestSemaphoreRefs[deviceSN] > 0 {
+		return
+	}
+
+	delete(requestSemaphoreRefs, deviceSN)
+	delete(requestSemaphoreMap, deviceSN)
+}
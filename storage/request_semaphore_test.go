@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestSemaphoreRef_SharedDevice(t *testing.T) {
+	// arrange
+	deviceSN := "shared-device-sn"
+	var first, second RequestSemaphoreRef
+
+	// action
+	first.Bind(deviceSN)
+	second.Bind(deviceSN)
+	second.Bind(deviceSN)
+	shared := GetRequestSemaphore(deviceSN)
+	first.Release()
+
+	// assert
+	require.NotNil(t, shared)
+	require.Same(t, shared, GetRequestSemaphore(deviceSN))
+	require.Same(t, shared, GetRequestSemaphoreOrDefault(deviceSN))
+
+	// action
+	second.Release()
+	second.Release()
+
+	// assert
+	require.Nil(t, GetRequestSemaphore(deviceSN))
+	require.Same(t, GetRequestSemaphore(UninitializedStorage), GetRequestSemaphoreOrDefault(deviceSN))
+	require.NotContains(t, requestSemaphoreRefs, deviceSN)
+}
+
+func TestRequestSemaphoreRef_BindOtherDevice(t *testing.T) {
+	// arrange
+	var ref RequestSemaphoreRef
+
+	// action
+	ref.Bind("old-device-sn")
+	ref.Bind("new-device-sn")
+
+	// assert
+	require.Nil(t, GetRequestSemaphore("old-device-sn"))
+	require.NotNil(t, GetRequestSemaphore("new-device-sn"))
+
+	// action
+	ref.Release()
+
+	// assert
+	require.Nil(t, GetRequestSemaphore("new-device-sn"))
+	require.NotNil(t, GetRequestSemaphore(UninitializedStorage))
+}
+
+func TestRequestSemaphoreRef_ConcurrentBind(t *testing.T) {
+	// arrange
+	const clients = 50
+	refs := make([]RequestSemaphoreRef, clients)
+	var wg sync.WaitGroup
+
+	// action
+	for i := range refs {
+		wg.Add(1)
+		go func(ref *RequestSemaphoreRef, deviceSN string) {
+			defer wg.Done()
+			ref.Bind(deviceSN)
+			GetRequestSemaphoreOrDefault(deviceSN)
+			ref.Release()
+		}(&refs[i], fmt.Sprintf("concurrent-device-sn-%d", i%5))
+	}
+	wg.Wait()
+
+	// assert
+	for i := 0; i < 5; i++ {
+		require.Nil(t, GetRequestSemaphore(fmt.Sprintf("concurrent-device-sn-%d", i)))
+	}
+	require.NotNil(t, GetRequestSemaphore(UninitializedStorage))
+}
@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DeviceManagerRestPath is the path of the rest api appended to the configured storage url
+const DeviceManagerRestPath = "/deviceManager/rest"
+
+// ValidateRestURLs rejects the configured storage urls which are obviously malformed
+func ValidateRestURLs(rawURLs []string) error {
+	for _, rawURL := range rawURLs {
+		if err := ValidateRestURL(rawURL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateRestURL rejects the url without http or https scheme, the url without host,
+// and the url with an IPv6 literal host that is not enclosed in brackets
+func ValidateRestURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("url %s is malformed: %w", rawURL, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url %s is malformed: scheme must be http or https", rawURL)
+	}
+
+	if u.Hostname() == "" {
+		return fmt.Errorf("url %s is malformed: host is empty", rawURL)
+	}
+
+	if strings.Contains(u.Hostname(), ":") && !strings.HasPrefix(u.Host, "[") {
+		return fmt.Errorf("url %s is malformed: IPv6 address must be enclosed in brackets", rawURL)
+	}
+
+	return nil
+}
+
+// JoinRestURL appends the path to the configured storage url,
+// the bracketed IPv6 host and the port of the url are preserved
+func JoinRestURL(rawURL, path string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("url %s is malformed: %w", rawURL, err)
+	}
+
+	return u.JoinPath(path).String(), nil
+}
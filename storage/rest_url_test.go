@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRestURL(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name            string
+		url             string
+		wantErrContains string
+	}{
+		{name: "IPv4 with port", url: "https://192.168.1.1:8088"},
+		{name: "bracketed IPv6 with port", url: "https://[fe80::1]:8088"},
+		{name: "bracketed IPv6 without port", url: "https://[2001:db8::1]"},
+		{name: "without scheme", url: "192.168.1.1:8088", wantErrContains: "malformed"},
+		{name: "unsupported scheme", url: "ftp://192.168.1.1", wantErrContains: "scheme"},
+		{name: "empty host", url: "https://:8088", wantErrContains: "host is empty"},
+		{name: "unbracketed IPv6", url: "https://fe80::1:8088", wantErrContains: "brackets"},
+		{name: "invalid port", url: "https://[fe80::1]:port", wantErrContains: "malformed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// action
+			err := ValidateRestURL(tt.url)
+
+			// assert
+			if tt.wantErrContains == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErrContains)
+			}
+		})
+	}
+}
+
+func TestJoinRestURL(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "IPv4 with port", url: "https://192.168.1.1:8088",
+			want: "https://192.168.1.1:8088/deviceManager/rest"},
+		{name: "bracketed IPv6 with port", url: "https://[fe80::1]:8088",
+			want: "https://[fe80::1]:8088/deviceManager/rest"},
+		{name: "trailing slash", url: "https://[2001:db8::1]:8088/",
+			want: "https://[2001:db8::1]:8088/deviceManager/rest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// action
+			got, err := JoinRestURL(tt.url, DeviceManagerRestPath)
+
+			// assert
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -18,21 +18,22 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
 
 	pkgUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/pkg/utils"
-	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils/log"
 )
 
-var (
-	// RequestSemaphoreMap stores the total connection num of each storage
-	RequestSemaphoreMap = map[string]*utils.Semaphore{UninitializedStorage: utils.NewSemaphore(MaxStorageThreads)}
-)
-
 // HTTP defines for http request process
 type HTTP interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -69,8 +70,12 @@ func NewHTTPClientByBackendID(ctx context.Context, backendID string) (HTTP, erro
 	return client, nil
 }
 
-// NewHTTPClientByCertMeta provides a new http client by cert meta
-func NewHTTPClientByCertMeta(ctx context.Context, useCert bool, certMeta string) (HTTP, error) {
+// NewHTTPClientByCertMeta provides a new http client by cert meta, the server certificate is verified against
+// the CA bundle of caSecretMeta as well if it is set. The requests are routed through the proxy if proxyURL is set,
+// otherwise they are sent to the storage directly. The idle connections are kept as transportConfig,
+// and they are probed by the TCP keepalive of transportConfig so that the half-open ones are torn down.
+func NewHTTPClientByCertMeta(ctx context.Context, useCert bool, certMeta, caSecretMeta, proxyURL string,
+	transportConfig TransportConfig) (HTTP, error) {
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		log.AddContext(ctx).Errorf("create jar failed, error: %v", err)
@@ -82,15 +87,140 @@ func NewHTTPClientByCertMeta(ctx context.Context, useCert bool, certMeta string)
 		return nil, err
 	}
 
+	if caSecretMeta != "" {
+		certPool, err = pkgUtils.GetCAPool(ctx, caSecretMeta, certPool)
+		if err != nil {
+			return nil, err
+		}
+		useCert = true
+	}
+
+	proxy, err := NewProxyFunc(proxyURL)
+	if err != nil {
+		log.AddContext(ctx).Errorf("create proxy failed, error: %v", err)
+		return nil, err
+	}
+
 	return &http.Client{
 		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: !useCert, RootCAs: certPool},
+			Proxy:               proxy,
+			DialContext:         newDialContext(transportConfig),
+			TLSClientConfig:     &tls.Config{InsecureSkipVerify: !useCert, RootCAs: certPool},
+			MaxIdleConns:        transportConfig.MaxIdleConns,
+			MaxIdleConnsPerHost: transportConfig.MaxIdleConnsPerHost,
+			IdleConnTimeout:     transportConfig.IdleConnTimeout,
 		},
 		Jar:     jar,
 		Timeout: defaultHttpTimeout,
 	}, nil
 }
 
+// TransportConfig stores the idle connection and TCP keepalive settings of the http transport,
+// zero means the default of net/http
+type TransportConfig struct {
+	// MaxIdleConns is the maximum idle connections across all hosts, zero means no limit
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum idle connections of each host, zero means http.DefaultMaxIdleConnsPerHost
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is the time an idle connection is kept before it is closed, zero means no limit
+	IdleConnTimeout time.Duration
+	// KeepAlive is the idle time before the TCP keepalive probes and the interval between them, a connection
+	// silently dropped by the network is closed after the probes fail instead of hanging the next request.
+	// It only matters for the connections kept longer than it, so it should be shorter than the IdleConnTimeout
+	// and the idle timeout of the firewalls on the path. Zero means the default of net, and negative disables it.
+	KeepAlive time.Duration
+	// HostOverrides maps the host names of the urls to the IPs they are dialed with instead of the ones resolved
+	// by the DNS, the host names are matched case-insensitively and the certificate is still verified against them
+	HostOverrides map[string]string
+}
+
+func newDialer(transportConfig TransportConfig) *net.Dialer {
+	return &net.Dialer{KeepAlive: transportConfig.KeepAlive}
+}
+
+// newDialContext returns the dial function of the http transport, the address whose host is overridden by
+// the HostOverrides of transportConfig is dialed with the IP of the override
+func newDialContext(transportConfig TransportConfig) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := newDialer(transportConfig)
+	if len(transportConfig.HostOverrides) == 0 {
+		return dialer.DialContext
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, overrideHost(transportConfig.HostOverrides, addr))
+	}
+}
+
+func overrideHost(hostOverrides map[string]string, addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	for name, ip := range hostOverrides {
+		if strings.EqualFold(name, host) {
+			return net.JoinHostPort(ip, port)
+		}
+	}
+
+	return addr
+}
+
+// NewProxyFunc returns the proxy function of the http transport, nil is returned if proxyURL is empty so that
+// the requests are not routed through any proxy. The user info of the proxy url is used to authenticate with the proxy.
+func NewProxyFunc(proxyURL string) (func(*http.Request) (*url.URL, error), error) {
+	if proxyURL == "" {
+		return nil, nil
+	}
+
+	// the error of url.Parse contains the raw url, unwrap it to avoid leaking the proxy credentials
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("proxy url is malformed: %w", errors.Unwrap(err))
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("proxy url %s is malformed: scheme must be http or https", u.Redacted())
+	}
+
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("proxy url %s is malformed: host is empty", u.Redacted())
+	}
+
+	return http.ProxyURL(u), nil
+}
+
+// ErrNonJSONResponse indicates an error that the response body is not json, e.g. an error page of a gateway
+var ErrNonJSONResponse = errors.New("non-json response")
+
+// maxResponseSnippetSize is the maximum bytes of the response body included in the error of a non-json response
+const maxResponseSnippetSize = 256
+
+// CheckJSONResponse returns ErrNonJSONResponse with the http status and a truncated snippet of the body
+// if the body does not start with a json object or array, so that the unreadable json.Unmarshal error is avoided
+func CheckJSONResponse(resp *http.Response, body []byte) error {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return nil
+	}
+
+	snippet := trimmed
+	if len(snippet) > maxResponseSnippetSize {
+		snippet = snippet[:maxResponseSnippetSize]
+	}
+
+	return fmt.Errorf("%w, status: %s, content type: %q, body: %q", ErrNonJSONResponse, resp.Status,
+		resp.Header.Get("Content-Type"), strings.ToValidUTF8(string(snippet), ""))
+}
+
+// CloseIdleConnections closes the idle connections of the http client if the client supports it
+func CloseIdleConnections(client HTTP) {
+	closer, ok := client.(interface{ CloseIdleConnections() })
+	if ok {
+		closer.CloseIdleConnections()
+	}
+}
+
 // NewClientConfig stores the information needed to create a new rest client
 type NewClientConfig struct {
 	Urls            []string
@@ -103,4 +233,20 @@ type NewClientConfig struct {
 	CertSecretMeta  string
 	Storage         string
 	Name            string
+	// CredentialsPath is the directory of the mounted secret, the k8s Secret API is used if it is empty
+	CredentialsPath string
+	// LoginScope overrides the scope of the auth info in the secret when login if it is not empty
+	LoginScope string
+	// CASecretMeta is the secret of the CA bundle that the server certificate is verified against
+	CASecretMeta string
+	// ProxyURL is the proxy the requests are routed through, no proxy is used if it is empty
+	ProxyURL string
+	// Headers are the static headers added to every request
+	Headers map[string]string
+	// Transport is the idle connection and TCP keepalive settings of the http transport
+	Transport TransportConfig
+	// LoginTimeout bounds the total time of the login attempts across the urls, it is not bounded if not positive
+	LoginTimeout time.Duration
+	// VerboseLog logs the bodies of all the requests and responses at info level, except the login ones
+	VerboseLog bool
 }
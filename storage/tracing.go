@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
+)
+
+const (
+	// TracerName is the name of the tracer that creates the spans of the storage rest requests
+	TracerName = "github.com/Huawei/eSDK_K8S_Plugin/v4/storage"
+
+	// SpanAttrBackendID is the span attribute of the backend id
+	SpanAttrBackendID = "storage.backend_id"
+	// SpanAttrDeviceSN is the span attribute of the storage device sn
+	SpanAttrDeviceSN = "storage.device_sn"
+	// SpanAttrErrorCode is the span attribute of the error code returned by the storage
+	SpanAttrErrorCode = "storage.error_code"
+)
+
+// StartRequestSpan starts a client span of the storage rest request as a child of the span in ctx,
+// the span is named by the method and the normalized url so that requests of different objects are grouped.
+// A non-recording span is returned if no tracer provider is configured.
+func StartRequestSpan(ctx context.Context, method, url, backendID, deviceSN string) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(TracerName).Start(ctx, method+" "+NormalizeRequestURL(url),
+		trace.WithSpanKind(trace.SpanKindClient))
+	if span.IsRecording() {
+		span.SetAttributes(
+			attribute.String(SpanAttrBackendID, backendID),
+			attribute.String(SpanAttrDeviceSN, deviceSN),
+		)
+	}
+
+	return ctx, span
+}
+
+// EndRequestSpan records the error code returned by the storage and the request error in the span, then ends it
+func EndRequestSpan(span trace.Span, code int64, hasCode bool, err error) {
+	if span.IsRecording() {
+		if hasCode {
+			span.SetAttributes(attribute.Int64(SpanAttrErrorCode, code))
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else if hasCode && code != SuccessCode {
+			span.SetStatus(codes.Error, fmt.Sprintf("error code %d", code))
+		}
+	}
+
+	span.End()
+}
+
+// InjectTraceContext propagates the span context in ctx to the header of the storage rest request
+func InjectTraceContext(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// NormalizeRequestURL templatizes the url by the utils.DefaultURLTemplateRule, the object ids in the path are
+// replaced with a placeholder and only the significant query parameters are kept,
+// e.g. /lun/12?range=[0-100] is normalized to /lun/{id} and /vstore_pair?REPTYPE=1 is kept as it is
+func NormalizeRequestURL(url string) string {
+	return utils.DefaultURLTemplateRule.Template(url)
+}
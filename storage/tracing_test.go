@@ -0,0 +1,63 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeRequestURL(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "without id", url: "/system/", want: "/system/"},
+		{name: "numeric id", url: "/lun/12", want: "/lun/{id}"},
+		{name: "with query", url: "/filesystem?filter=NAME::pvc-1", want: "/filesystem"},
+		{name: "uuid id", url: "/vstore_pair/2f6e4b0c-1d3a-4c8e", want: "/vstore_pair/{id}"},
+		{name: "id and query", url: "/lun/associate/7?TYPE=11", want: "/lun/associate/{id}"},
+		{name: "significant query", url: "/vstore_pair?filter=ID::3&REPTYPE=1", want: "/vstore_pair?REPTYPE=1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// action
+			got := NormalizeRequestURL(tt.url)
+
+			// assert
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStartRequestSpan_WithoutTracerProvider(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+
+	// action
+	spanCtx, span := StartRequestSpan(ctx, "GET", "/lun/12", "backend-1", "sn-1")
+	EndRequestSpan(span, SuccessCode, true, nil)
+
+	// assert
+	require.False(t, span.IsRecording())
+	require.False(t, span.SpanContext().IsValid())
+	require.NotNil(t, spanCtx)
+}
@@ -44,7 +44,7 @@ func TestControllerPublishVolume_FusionstorageDTree_Success(t *testing.T) {
 	defer cache.BackendCacheProvider.Delete(ctx, data.BackendName)
 
 	// mock
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.ControllerPublishVolume(ctx, data.request())
@@ -64,7 +64,7 @@ func TestControllerPublishVolume_FusionstorageDTree_EmptyDTreeParentNameSuccess(
 	defer cache.BackendCacheProvider.Delete(ctx, data.BackendName)
 
 	// mock
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.ControllerPublishVolume(ctx, data.request())
@@ -65,7 +65,7 @@ func TestCreateVolume_FusionDTree_FullFeaturesSuccess(t *testing.T) {
 	cli.EXPECT().AddNfsShareAuthClient(ctx,
 		&client.AddNfsShareAuthClientRequest{AccessName: data.AuthClient, ShareId: data.FakeShareID, AccessValue: 1,
 			Sync: 0, AllSquash: data.ExpectedAllSquashParam, RootSquash: data.ExpectedRootSquashParam})
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, data.request())
@@ -107,7 +107,7 @@ func TestCreateVolume_FusionDTree_NfsShareExistsSuccess(t *testing.T) {
 	cli.EXPECT().AddNfsShareAuthClient(ctx,
 		&client.AddNfsShareAuthClientRequest{AccessName: data.AuthClient, ShareId: data.FakeShareID, AccessValue: 1,
 			Sync: 0, AllSquash: data.ExpectedAllSquashParam, RootSquash: data.ExpectedRootSquashParam})
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, data.request())
@@ -143,7 +143,7 @@ func TestCreateVolume_FusionDTree_FullFeaturesOtherParamsSuccess(t *testing.T) {
 	cli.EXPECT().AddNfsShareAuthClient(ctx,
 		&client.AddNfsShareAuthClientRequest{AccessName: data.AuthClient, ShareId: data.FakeShareID, AccessValue: 1,
 			Sync: 0, AllSquash: data.ExpectedAllSquashParam, RootSquash: data.ExpectedRootSquashParam})
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, data.request())
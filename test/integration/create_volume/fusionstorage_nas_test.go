@@ -73,7 +73,7 @@ func TestCreateVolume_FusionStorageNas_FullFeaturesSuccess(t *testing.T) {
 	cli.EXPECT().CreateNfsShare(ctx, data.expectedCreateNfsShareParams()).
 		Return(map[string]any{"id": data.FakeShareID}, nil)
 	cli.EXPECT().AllowNfsShareAccess(ctx, data.expectedAllowNfsShareRequest()).Return(nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, data.request())
@@ -104,7 +104,7 @@ func TestCreateVolume_FusionStorageNas_ManageVolumeSuccess(t *testing.T) {
 			SpaceSoftQuota: uint64(data.Capacity),
 			SpaceUnitType:  0,
 		}, nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, data.request())
@@ -130,7 +130,7 @@ func TestCreateVolume_FusionStorageNas_ManageVolumeFailedWithEmptyQuota(t *testi
 	)
 	defer p.Reset()
 	cli.EXPECT().GetQuotaByFileSystemName(ctx, data.FsName).Return(nil, nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	_, err := csiServer.CreateVolume(ctx, data.request())
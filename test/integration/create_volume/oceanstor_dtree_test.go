@@ -40,6 +40,7 @@ import (
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/client"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/test/mocks/mock_client"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/test/utils"
+	baseUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 )
 
 func TestCreateVolume_OceanstorDTree_FullFeaturesSuccess(t *testing.T) {
@@ -54,6 +55,7 @@ func TestCreateVolume_OceanstorDTree_FullFeaturesSuccess(t *testing.T) {
 	// mock
 	p := gomonkey.ApplyMethodReturn(app.GetGlobalConfig().K8sUtils, "GetVolumeConfiguration", map[string]string{}, nil)
 	defer p.Reset()
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeDTree).Return(255, nil)
 	cli.EXPECT().GetFileSystemByName(ctx, data.ExpectedParentName).Return(map[string]any{"ID": data.FakeFsID}, nil)
 	cli.EXPECT().CreateDTree(ctx, data.expectedCreateDTreeParams(t)).Return(map[string]any{"ID": data.FakeDTreeID}, nil)
 	cli.EXPECT().GetNfsShareByPath(ctx, data.expectedSharePath(), data.FakeVStoreID).Return(nil, nil)
@@ -62,7 +64,7 @@ func TestCreateVolume_OceanstorDTree_FullFeaturesSuccess(t *testing.T) {
 	cli.EXPECT().GetNfsShareAccessCount(ctx, data.FakeShareID, data.FakeVStoreID).Return(int64(0), nil)
 	cli.EXPECT().AllowNfsShareAccess(ctx, data.expectedAllowNfsShareRequest()).Return(nil)
 	cli.EXPECT().CreateQuota(ctx, data.expectedCreateQuotaParam()).Return(nil, nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, data.request())
@@ -78,6 +80,7 @@ func TestCreateVolume_OceanstorDTree_SuccessWithScVolumeName(t *testing.T) {
 	data.ScVolumeName = "prefix-{{ .PVCNamespace }}-{{ .PVCName }}"
 	data.ExpectedDTreeName = "prefix-pvcTestNamespace-pvcTestName-pvTestName"
 	ctx := context.Background()
+	tenantCtx := baseUtils.WithTenant(ctx, "pvcTestNamespace")
 	mockCtrl := gomock.NewController(t)
 	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
 	cache.BackendCacheProvider.Store(ctx, data.BackendName, data.backend(cli, constants.OceanStorDoradoV6))
@@ -93,15 +96,16 @@ func TestCreateVolume_OceanstorDTree_SuccessWithScVolumeName(t *testing.T) {
 	// mock
 	p := gomonkey.ApplyMethodReturn(app.GetGlobalConfig().K8sUtils, "GetVolumeConfiguration", map[string]string{}, nil)
 	defer p.Reset()
-	cli.EXPECT().GetFileSystemByName(ctx, data.ExpectedParentName).Return(map[string]any{"ID": data.FakeFsID}, nil)
-	cli.EXPECT().CreateDTree(ctx, data.expectedCreateDTreeParams(t)).Return(map[string]any{"ID": data.FakeDTreeID}, nil)
-	cli.EXPECT().GetNfsShareByPath(ctx, data.expectedSharePath(), data.FakeVStoreID).Return(nil, nil)
-	cli.EXPECT().CreateNfsShare(ctx, data.expectedCreateNfsShareParams()).
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeDTree).Return(255, nil)
+	cli.EXPECT().GetFileSystemByName(tenantCtx, data.ExpectedParentName).Return(map[string]any{"ID": data.FakeFsID}, nil)
+	cli.EXPECT().CreateDTree(tenantCtx, data.expectedCreateDTreeParams(t)).Return(map[string]any{"ID": data.FakeDTreeID}, nil)
+	cli.EXPECT().GetNfsShareByPath(tenantCtx, data.expectedSharePath(), data.FakeVStoreID).Return(nil, nil)
+	cli.EXPECT().CreateNfsShare(tenantCtx, data.expectedCreateNfsShareParams()).
 		Return(map[string]any{"ID": data.FakeShareID}, nil)
-	cli.EXPECT().GetNfsShareAccessCount(ctx, data.FakeShareID, data.FakeVStoreID).Return(int64(0), nil)
-	cli.EXPECT().AllowNfsShareAccess(ctx, data.expectedAllowNfsShareRequest()).Return(nil)
-	cli.EXPECT().CreateQuota(ctx, data.expectedCreateQuotaParam()).Return(nil, nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().GetNfsShareAccessCount(tenantCtx, data.FakeShareID, data.FakeVStoreID).Return(int64(0), nil)
+	cli.EXPECT().AllowNfsShareAccess(tenantCtx, data.expectedAllowNfsShareRequest()).Return(nil)
+	cli.EXPECT().CreateQuota(tenantCtx, data.expectedCreateQuotaParam()).Return(nil, nil)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, createVolumeReq)
@@ -125,14 +129,14 @@ func TestCreateVolume_OceanstorDTree_FailedWithInvalidScVolumeName(t *testing.T)
 	// mock
 	p := gomonkey.ApplyMethodReturn(app.GetGlobalConfig().K8sUtils, "GetVolumeConfiguration", map[string]string{}, nil)
 	defer p.Reset()
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	_, err := csiServer.CreateVolume(ctx, createVolumeReq)
 
 	// assert
 	require.ErrorContains(t, err,
-		"{{.PVCNamespace}} or {{.PVCName}} must be configured in the volumeName parameter at the same time")
+		"both {{.PVCNamespace}} and {{.PVCName}} must be configured in the volumeName parameter")
 }
 
 func TestCreateVolume_OceanstorDTreeV5_SuccessWithScVolumeName(t *testing.T) {
@@ -140,6 +144,7 @@ func TestCreateVolume_OceanstorDTreeV5_SuccessWithScVolumeName(t *testing.T) {
 	data := fakeOceanstorDtreeDataWithSuccess()
 	data.ScVolumeName = "prefix-{{ .PVCNamespace }}-{{ .PVCName }}"
 	ctx := context.Background()
+	tenantCtx := baseUtils.WithTenant(ctx, "pvcTestNamespace")
 	mockCtrl := gomock.NewController(t)
 	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
 	cache.BackendCacheProvider.Store(ctx, data.BackendName, data.backend(cli, constants.OceanStorV5))
@@ -155,15 +160,15 @@ func TestCreateVolume_OceanstorDTreeV5_SuccessWithScVolumeName(t *testing.T) {
 	// mock
 	p := gomonkey.ApplyMethodReturn(app.GetGlobalConfig().K8sUtils, "GetVolumeConfiguration", map[string]string{}, nil)
 	defer p.Reset()
-	cli.EXPECT().GetFileSystemByName(ctx, data.ExpectedParentName).Return(map[string]any{"ID": data.FakeFsID}, nil)
-	cli.EXPECT().CreateDTree(ctx, data.expectedCreateDTreeParams(t)).Return(map[string]any{"ID": data.FakeDTreeID}, nil)
-	cli.EXPECT().GetNfsShareByPath(ctx, data.expectedSharePath(), data.FakeVStoreID).Return(nil, nil)
-	cli.EXPECT().CreateNfsShare(ctx, data.expectedCreateNfsShareParams()).
+	cli.EXPECT().GetFileSystemByName(tenantCtx, data.ExpectedParentName).Return(map[string]any{"ID": data.FakeFsID}, nil)
+	cli.EXPECT().CreateDTree(tenantCtx, data.expectedCreateDTreeParams(t)).Return(map[string]any{"ID": data.FakeDTreeID}, nil)
+	cli.EXPECT().GetNfsShareByPath(tenantCtx, data.expectedSharePath(), data.FakeVStoreID).Return(nil, nil)
+	cli.EXPECT().CreateNfsShare(tenantCtx, data.expectedCreateNfsShareParams()).
 		Return(map[string]any{"ID": data.FakeShareID}, nil)
-	cli.EXPECT().GetNfsShareAccessCount(ctx, data.FakeShareID, data.FakeVStoreID).Return(int64(0), nil)
-	cli.EXPECT().AllowNfsShareAccess(ctx, data.expectedAllowNfsShareRequest()).Return(nil)
-	cli.EXPECT().CreateQuota(ctx, data.expectedCreateQuotaParam()).Return(nil, nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().GetNfsShareAccessCount(tenantCtx, data.FakeShareID, data.FakeVStoreID).Return(int64(0), nil)
+	cli.EXPECT().AllowNfsShareAccess(tenantCtx, data.expectedAllowNfsShareRequest()).Return(nil)
+	cli.EXPECT().CreateQuota(tenantCtx, data.expectedCreateQuotaParam()).Return(nil, nil)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, createVolumeReq)
@@ -185,7 +190,7 @@ func TestCreateVolume_OceanstorDTree_FailedWithInvalidCapacity(t *testing.T) {
 	// mock
 	p := gomonkey.ApplyMethodReturn(app.GetGlobalConfig().K8sUtils, "GetVolumeConfiguration", map[string]string{}, nil)
 	defer p.Reset()
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, data.request())
@@ -207,7 +212,7 @@ func TestCreateVolume_OceanstorDTree_FailedWithEmptyParentName(t *testing.T) {
 	// mock
 	p := gomonkey.ApplyMethodReturn(app.GetGlobalConfig().K8sUtils, "GetVolumeConfiguration", map[string]string{}, nil)
 	defer p.Reset()
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, data.request())
@@ -229,7 +234,8 @@ func TestCreateVolume_OceanstorDTree_FailedWithDifferentParentName(t *testing.T)
 	// mock
 	p := gomonkey.ApplyMethodReturn(app.GetGlobalConfig().K8sUtils, "GetVolumeConfiguration", map[string]string{}, nil)
 	defer p.Reset()
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeDTree).Return(255, nil)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, data.request())
@@ -254,8 +260,9 @@ func TestCreateVolume_OceanstorDTree_AdvancedOptionsUnmarshalFailed(t *testing.T
 	// mock
 	p := gomonkey.ApplyMethodReturn(app.GetGlobalConfig().K8sUtils, "GetVolumeConfiguration", map[string]string{}, nil)
 	defer p.Reset()
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeDTree).Return(255, nil)
 	cli.EXPECT().GetFileSystemByName(ctx, data.ExpectedParentName).Return(map[string]any{"ID": data.FakeFsID}, nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	_, err := csiServer.CreateVolume(ctx, data.request())
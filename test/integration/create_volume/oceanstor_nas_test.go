@@ -40,6 +40,7 @@ import (
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/client"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/test/mocks/mock_client"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/test/utils"
+	baseUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 )
 
 func TestCreateVolume_OceanstorNas_FullFeaturesSuccess(t *testing.T) {
@@ -57,6 +58,7 @@ func TestCreateVolume_OceanstorNas_FullFeaturesSuccess(t *testing.T) {
 	cli.EXPECT().GetvStoreID().Return(data.FakeVStoreID).AnyTimes()
 	cli.EXPECT().GetCurrentLifWwn().Return(data.ExpectedCurrentLifWwn).AnyTimes()
 	cli.EXPECT().GetCurrentSiteWwn().Return(data.ExpectedCurrentSiteWwn).AnyTimes()
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeFilesystem).Return(255, nil)
 	cli.EXPECT().GetPoolByName(ctx, data.ExpectedPoolName).Return(map[string]any{"ID": data.FakePoolID}, nil)
 	cli.EXPECT().GetFileSystemByName(ctx, data.ExpectedFsName).Return(nil, nil)
 	cli.EXPECT().CreateFileSystem(ctx, data.expectedCreateFsParams(t)).Return(map[string]any{"ID": data.FakeFsID}, nil)
@@ -65,7 +67,8 @@ func TestCreateVolume_OceanstorNas_FullFeaturesSuccess(t *testing.T) {
 		nil)
 	cli.EXPECT().GetNfsShareAccessCount(ctx, data.FakeShareID, data.FakeVStoreID).Return(int64(0), nil)
 	cli.EXPECT().AllowNfsShareAccess(ctx, data.expectedAllowNfsShareRequest()).Return(nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().InvalidatePoolCache()
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, data.request())
@@ -81,6 +84,7 @@ func TestCreateVolume_OceanstorNas_SuccessWithScVolumeName(t *testing.T) {
 	data.ScVolumeName = "prefix-{{ .PVCNamespace }}-{{ .PVCName }}"
 	data.ExpectedFsName = "prefix-pvcTestNamespace-pvcTestName-pvTestName"
 	ctx := context.Background()
+	tenantCtx := baseUtils.WithTenant(ctx, "pvcTestNamespace")
 	mockCtrl := gomock.NewController(t)
 	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
 	cache.BackendCacheProvider.Store(ctx, data.BackendName, data.backend(cli, constants.OceanStorDoradoV6))
@@ -99,15 +103,17 @@ func TestCreateVolume_OceanstorNas_SuccessWithScVolumeName(t *testing.T) {
 	cli.EXPECT().GetvStoreID().Return(data.FakeVStoreID).AnyTimes()
 	cli.EXPECT().GetCurrentLifWwn().Return(data.ExpectedCurrentLifWwn).AnyTimes()
 	cli.EXPECT().GetCurrentSiteWwn().Return(data.ExpectedCurrentSiteWwn).AnyTimes()
-	cli.EXPECT().GetPoolByName(ctx, data.ExpectedPoolName).Return(map[string]any{"ID": data.FakePoolID}, nil)
-	cli.EXPECT().GetFileSystemByName(ctx, data.ExpectedFsName).Return(nil, nil)
-	cli.EXPECT().CreateFileSystem(ctx, data.expectedCreateFsParams(t)).Return(map[string]any{"ID": data.FakeFsID}, nil)
-	cli.EXPECT().GetNfsShareByPath(ctx, data.expectedSharePath(), data.FakeVStoreID).Return(nil, nil)
-	cli.EXPECT().CreateNfsShare(ctx, data.expectedCreateNfsShareParams()).Return(map[string]any{"ID": data.FakeShareID},
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeFilesystem).Return(255, nil)
+	cli.EXPECT().GetPoolByName(tenantCtx, data.ExpectedPoolName).Return(map[string]any{"ID": data.FakePoolID}, nil)
+	cli.EXPECT().GetFileSystemByName(tenantCtx, data.ExpectedFsName).Return(nil, nil)
+	cli.EXPECT().CreateFileSystem(tenantCtx, data.expectedCreateFsParams(t)).Return(map[string]any{"ID": data.FakeFsID}, nil)
+	cli.EXPECT().GetNfsShareByPath(tenantCtx, data.expectedSharePath(), data.FakeVStoreID).Return(nil, nil)
+	cli.EXPECT().CreateNfsShare(tenantCtx, data.expectedCreateNfsShareParams()).Return(map[string]any{"ID": data.FakeShareID},
 		nil)
-	cli.EXPECT().GetNfsShareAccessCount(ctx, data.FakeShareID, data.FakeVStoreID).Return(int64(0), nil)
-	cli.EXPECT().AllowNfsShareAccess(ctx, data.expectedAllowNfsShareRequest()).Return(nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().GetNfsShareAccessCount(tenantCtx, data.FakeShareID, data.FakeVStoreID).Return(int64(0), nil)
+	cli.EXPECT().AllowNfsShareAccess(tenantCtx, data.expectedAllowNfsShareRequest()).Return(nil)
+	cli.EXPECT().InvalidatePoolCache()
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, createVolumeReq)
@@ -122,6 +128,7 @@ func TestCreateVolume_OceanstorNasV5_SuccessWithScVolumeName(t *testing.T) {
 	data := fakeOceanstorNasDataWithSuccess()
 	data.ScVolumeName = "prefix-{{ .PVCNamespace }}-{{ .PVCName }}"
 	ctx := context.Background()
+	tenantCtx := baseUtils.WithTenant(ctx, "pvcTestNamespace")
 	mockCtrl := gomock.NewController(t)
 	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
 	cache.BackendCacheProvider.Store(ctx, data.BackendName, data.backend(cli, constants.OceanStorV5))
@@ -140,15 +147,16 @@ func TestCreateVolume_OceanstorNasV5_SuccessWithScVolumeName(t *testing.T) {
 	cli.EXPECT().GetvStoreID().Return(data.FakeVStoreID).AnyTimes()
 	cli.EXPECT().GetCurrentLifWwn().Return(data.ExpectedCurrentLifWwn).AnyTimes()
 	cli.EXPECT().GetCurrentSiteWwn().Return(data.ExpectedCurrentSiteWwn).AnyTimes()
-	cli.EXPECT().GetPoolByName(ctx, data.ExpectedPoolName).Return(map[string]any{"ID": data.FakePoolID}, nil)
-	cli.EXPECT().GetFileSystemByName(ctx, data.ExpectedFsName).Return(nil, nil)
-	cli.EXPECT().CreateFileSystem(ctx, data.expectedCreateFsParams(t)).Return(map[string]any{"ID": data.FakeFsID}, nil)
-	cli.EXPECT().GetNfsShareByPath(ctx, data.expectedSharePath(), data.FakeVStoreID).Return(nil, nil)
-	cli.EXPECT().CreateNfsShare(ctx, data.expectedCreateNfsShareParams()).Return(map[string]any{"ID": data.FakeShareID},
+	cli.EXPECT().GetPoolByName(tenantCtx, data.ExpectedPoolName).Return(map[string]any{"ID": data.FakePoolID}, nil)
+	cli.EXPECT().GetFileSystemByName(tenantCtx, data.ExpectedFsName).Return(nil, nil)
+	cli.EXPECT().CreateFileSystem(tenantCtx, data.expectedCreateFsParams(t)).Return(map[string]any{"ID": data.FakeFsID}, nil)
+	cli.EXPECT().GetNfsShareByPath(tenantCtx, data.expectedSharePath(), data.FakeVStoreID).Return(nil, nil)
+	cli.EXPECT().CreateNfsShare(tenantCtx, data.expectedCreateNfsShareParams()).Return(map[string]any{"ID": data.FakeShareID},
 		nil)
-	cli.EXPECT().GetNfsShareAccessCount(ctx, data.FakeShareID, data.FakeVStoreID).Return(int64(0), nil)
-	cli.EXPECT().AllowNfsShareAccess(ctx, data.expectedAllowNfsShareRequest()).Return(nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().GetNfsShareAccessCount(tenantCtx, data.FakeShareID, data.FakeVStoreID).Return(int64(0), nil)
+	cli.EXPECT().AllowNfsShareAccess(tenantCtx, data.expectedAllowNfsShareRequest()).Return(nil)
+	cli.EXPECT().InvalidatePoolCache()
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, createVolumeReq)
@@ -175,14 +183,14 @@ func TestCreateVolume_OceanstorNas_FailedWithInvalidScVolumeName(t *testing.T) {
 	cli.EXPECT().GetvStoreID().Return(data.FakeVStoreID).AnyTimes()
 	cli.EXPECT().GetCurrentLifWwn().Return(data.ExpectedCurrentLifWwn).AnyTimes()
 	cli.EXPECT().GetCurrentSiteWwn().Return(data.ExpectedCurrentSiteWwn).AnyTimes()
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	_, err := csiServer.CreateVolume(ctx, createVolumeReq)
 
 	// assert
 	require.ErrorContains(t, err,
-		"{{.PVCNamespace}} or {{.PVCName}} must be configured in the volumeName parameter at the same time")
+		"both {{.PVCNamespace}} and {{.PVCName}} must be configured in the volumeName parameter")
 }
 
 func TestCreateVolume_OceanstorNas_UnmarshalAdvancedOptionsFailed(t *testing.T) {
@@ -201,9 +209,10 @@ func TestCreateVolume_OceanstorNas_UnmarshalAdvancedOptionsFailed(t *testing.T)
 	cli.EXPECT().GetvStoreID().Return(data.FakeVStoreID).AnyTimes()
 	cli.EXPECT().GetCurrentLifWwn().Return(data.ExpectedCurrentLifWwn).AnyTimes()
 	cli.EXPECT().GetCurrentSiteWwn().Return(data.ExpectedCurrentSiteWwn).AnyTimes()
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeFilesystem).Return(255, nil)
 	cli.EXPECT().GetPoolByName(ctx, data.ExpectedPoolName).Return(map[string]any{"ID": data.FakePoolID}, nil)
 	cli.EXPECT().GetFileSystemByName(ctx, data.ExpectedFsName).Return(nil, nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	_, err := csiServer.CreateVolume(ctx, data.request())
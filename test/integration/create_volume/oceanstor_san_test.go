@@ -36,6 +36,7 @@ import (
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/storage/oceanstorage/oceanstor/client"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/test/mocks/mock_client"
 	"github.com/Huawei/eSDK_K8S_Plugin/v4/test/utils"
+	baseUtils "github.com/Huawei/eSDK_K8S_Plugin/v4/utils"
 )
 
 func TestCreateVolume_OceanstorSan_FullFeaturesSuccess(t *testing.T) {
@@ -50,12 +51,14 @@ func TestCreateVolume_OceanstorSan_FullFeaturesSuccess(t *testing.T) {
 	// mock
 	p := gomonkey.ApplyMethodReturn(app.GetGlobalConfig().K8sUtils, "GetVolumeConfiguration", map[string]string{}, nil)
 	defer p.Reset()
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeLun).Return(255, nil)
 	cli.EXPECT().GetPoolByName(ctx, data.ExpectedPoolName).Return(map[string]any{"ID": data.FakePoolID}, nil)
 	cli.EXPECT().MakeLunName(data.ExpectedLunName).Return(data.ExpectedLunName)
 	cli.EXPECT().GetLunByName(ctx, data.ExpectedLunName).Return(nil, nil)
 	cli.EXPECT().CreateLun(ctx, data.expectedCreateLunParams()).Return(map[string]any{"ID": data.FakeLunID,
 		"WWN": data.FakeWwn}, nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().InvalidatePoolCache()
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, data.request())
@@ -71,6 +74,7 @@ func TestCreateVolume_OceanstorSan_SuccessWithScVolumeName(t *testing.T) {
 	data.ScVolumeName = "prefix-{{ .PVCNamespace }}-{{ .PVCName }}"
 	data.ExpectedLunName = "prefix-pvcTestNamespace-pvcTestName-pvTestName"
 	ctx := context.Background()
+	tenantCtx := baseUtils.WithTenant(ctx, "pvcTestNamespace")
 	mockCtrl := gomock.NewController(t)
 	cli := mock_client.NewMockOceanstorClientInterface(mockCtrl)
 	cache.BackendCacheProvider.Store(ctx, data.BackendName, data.backend(cli, constants.OceanStorDoradoV6))
@@ -86,12 +90,14 @@ func TestCreateVolume_OceanstorSan_SuccessWithScVolumeName(t *testing.T) {
 	// mock
 	p := gomonkey.ApplyMethodReturn(app.GetGlobalConfig().K8sUtils, "GetVolumeConfiguration", map[string]string{}, nil)
 	defer p.Reset()
-	cli.EXPECT().GetPoolByName(ctx, data.ExpectedPoolName).Return(map[string]any{"ID": data.FakePoolID}, nil)
+	cli.EXPECT().GetMaxNameLength(client.ObjectTypeLun).Return(255, nil)
+	cli.EXPECT().GetPoolByName(tenantCtx, data.ExpectedPoolName).Return(map[string]any{"ID": data.FakePoolID}, nil)
 	cli.EXPECT().MakeLunName(data.ExpectedLunName).Return(data.ExpectedLunName)
-	cli.EXPECT().GetLunByName(ctx, data.ExpectedLunName).Return(nil, nil)
-	cli.EXPECT().CreateLun(ctx, data.expectedCreateLunParams()).Return(map[string]any{"ID": data.FakeLunID,
+	cli.EXPECT().GetLunByName(tenantCtx, data.ExpectedLunName).Return(nil, nil)
+	cli.EXPECT().CreateLun(tenantCtx, data.expectedCreateLunParams()).Return(map[string]any{"ID": data.FakeLunID,
 		"WWN": data.FakeWwn}, nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().InvalidatePoolCache()
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.CreateVolume(ctx, createVolumeReq)
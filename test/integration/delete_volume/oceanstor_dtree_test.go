@@ -56,7 +56,7 @@ func TestDeleteVolume_OceanstorDTree_Success(t *testing.T) {
 		Return(map[string]any{"ID": data.FakeShareID}, nil)
 	cli.EXPECT().DeleteNfsShare(ctx, data.FakeShareID, data.FakeVstoreID).Return(nil)
 	cli.EXPECT().DeleteDTreeByName(ctx, data.ParentName, data.DTreeName, data.FakeVstoreID).Return(nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	resp, err := csiServer.DeleteVolume(ctx, data.request())
@@ -65,7 +65,7 @@ func TestControllerExpandVolume_FusionStorageNas_Success(t *testing.T) {
 			defer p.Reset()
 			cli.EXPECT().GetQuotaByFileSystemName(ctx, data.FsName).Return(data.fakeFsQuota(), nil)
 			cli.EXPECT().UpdateQuota(ctx, data.expectedUpdateQuotaParams()).Return(nil)
-			cli.EXPECT().Logout(ctx)
+			cli.EXPECT().Close(ctx)
 
 			// action
 			resp, err := csiServer.ControllerExpandVolume(ctx, data.request())
@@ -92,7 +92,7 @@ func TestControllerExpandVolume_FusionStorageNas_FailedWithEmptyQuota(t *testing
 		data.fakeVolumeAttributes(), nil)
 	defer p.Reset()
 	cli.EXPECT().GetQuotaByFileSystemName(ctx, data.FsName).Return(nil, nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	_, err := csiServer.ControllerExpandVolume(ctx, data.request())
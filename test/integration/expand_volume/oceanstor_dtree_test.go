@@ -66,7 +66,7 @@ func TestControllerExpandVolume_OceanstorDTree_Success(t *testing.T) {
 			cli.EXPECT().BatchGetQuota(ctx, data.expectedBatchGetQuotaReq()).Return(data.fakeBatchGetQuotaResponse(),
 				nil)
 			cli.EXPECT().UpdateQuota(ctx, data.FakeQuotaID, data.expectedUpdateQuotaReq()).Return(nil)
-			cli.EXPECT().Logout(ctx)
+			cli.EXPECT().Close(ctx)
 
 			// action
 			resp, err := csiServer.ControllerExpandVolume(ctx, data.request())
@@ -96,7 +96,7 @@ func TestControllerExpandVolume_OceanstorNas_FailedWithWrongCapacity(t *testing.
 		data.DTreeName).Return(data.fakeDtreeInfo(), nil)
 	cli.EXPECT().BatchGetQuota(ctx, data.expectedBatchGetQuotaReq()).Return(data.fakeBatchGetQuotaResponse(),
 		nil)
-	cli.EXPECT().Logout(ctx)
+	cli.EXPECT().Close(ctx)
 
 	// action
 	_, err := csiServer.ControllerExpandVolume(ctx, data.request())
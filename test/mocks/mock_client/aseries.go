@@ -29,6 +29,7 @@ import (
 	context "context"
 	http "net/http"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 
@@ -141,6 +142,19 @@ func (mr *MockOceanASeriesClientInterfaceMockRecorder) Call(ctx, method, url, da
 		reflect.TypeOf((*MockOceanASeriesClientInterface)(nil).Call), ctx, method, url, data)
 }
 
+// Close mocks base method.
+func (m *MockOceanASeriesClientInterface) Close(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close", ctx)
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockOceanASeriesClientInterfaceMockRecorder) Close(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close",
+		reflect.TypeOf((*MockOceanASeriesClientInterface)(nil).Close), ctx)
+}
+
 // CreateDataTurboShare mocks base method.
 func (m *MockOceanASeriesClientInterface) CreateDataTurboShare(ctx context.Context,
 	params *client.CreateDataTurboShareParams) (map[string]any, error) {
@@ -508,6 +522,21 @@ func (mr *MockOceanASeriesClientInterfaceMockRecorder) GetFileSystemByName(ctx,
 		reflect.TypeOf((*MockOceanASeriesClientInterface)(nil).GetFileSystemByName), ctx, name, vstoreId)
 }
 
+// GetLicenseExpiry mocks base method.
+func (m *MockOceanASeriesClientInterface) GetLicenseExpiry(ctx context.Context) (map[string]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLicenseExpiry", ctx)
+	ret0, _ := ret[0].(map[string]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLicenseExpiry indicates an expected call of GetLicenseExpiry.
+func (mr *MockOceanASeriesClientInterfaceMockRecorder) GetLicenseExpiry(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLicenseExpiry", reflect.TypeOf((*MockOceanASeriesClientInterface)(nil).GetLicenseExpiry), ctx)
+}
+
 // GetLicenseFeature mocks base method.
 func (m *MockOceanASeriesClientInterface) GetLicenseFeature(ctx context.Context) (map[string]int, error) {
 	m.ctrl.T.Helper()
@@ -692,6 +721,36 @@ func (mr *MockOceanASeriesClientInterfaceMockRecorder) GetRequest(ctx, method, u
 		reflect.TypeOf((*MockOceanASeriesClientInterface)(nil).GetRequest), ctx, method, url, data)
 }
 
+// GetShareSquashConfig mocks base method.
+func (m *MockOceanASeriesClientInterface) GetShareSquashConfig(ctx context.Context, accessID, vStoreID string) (*base.NfsShareSquashConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShareSquashConfig", ctx, accessID, vStoreID)
+	ret0, _ := ret[0].(*base.NfsShareSquashConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShareSquashConfig indicates an expected call of GetShareSquashConfig.
+func (mr *MockOceanASeriesClientInterfaceMockRecorder) GetShareSquashConfig(ctx, accessID, vStoreID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareSquashConfig", reflect.TypeOf((*MockOceanASeriesClientInterface)(nil).GetShareSquashConfig), ctx, accessID, vStoreID)
+}
+
+// GetStorageTime mocks base method.
+func (m *MockOceanASeriesClientInterface) GetStorageTime(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageTime", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageTime indicates an expected call of GetStorageTime.
+func (mr *MockOceanASeriesClientInterfaceMockRecorder) GetStorageTime(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageTime", reflect.TypeOf((*MockOceanASeriesClientInterface)(nil).GetStorageTime), ctx)
+}
+
 // GetSystem mocks base method.
 func (m *MockOceanASeriesClientInterface) GetSystem(ctx context.Context) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -708,6 +767,21 @@ func (mr *MockOceanASeriesClientInterfaceMockRecorder) GetSystem(ctx any) *gomoc
 		reflect.TypeOf((*MockOceanASeriesClientInterface)(nil).GetSystem), ctx)
 }
 
+// GetSystemInfo mocks base method.
+func (m *MockOceanASeriesClientInterface) GetSystemInfo() client.ASeriesSystem {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSystemInfo")
+	ret0, _ := ret[0].(client.ASeriesSystem)
+	return ret0
+}
+
+// GetSystemInfo indicates an expected call of GetSystemInfo.
+func (mr *MockOceanASeriesClientInterfaceMockRecorder) GetSystemInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemInfo",
+		reflect.TypeOf((*MockOceanASeriesClientInterface)(nil).GetSystemInfo))
+}
+
 // GetSystemUTCTime mocks base method.
 func (m *MockOceanASeriesClientInterface) GetSystemUTCTime(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -895,6 +969,20 @@ func (mr *MockOceanASeriesClientInterfaceMockRecorder) RemoveDataTurboShareUser(
 		reflect.TypeOf((*MockOceanASeriesClientInterface)(nil).RemoveDataTurboShareUser), ctx, objID, vstoreId)
 }
 
+// SetShareSquashConfig mocks base method.
+func (m *MockOceanASeriesClientInterface) SetShareSquashConfig(ctx context.Context, accessID, vStoreID string, config *base.NfsShareSquashConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetShareSquashConfig", ctx, accessID, vStoreID, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetShareSquashConfig indicates an expected call of SetShareSquashConfig.
+func (mr *MockOceanASeriesClientInterfaceMockRecorder) SetShareSquashConfig(ctx, accessID, vStoreID, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShareSquashConfig", reflect.TypeOf((*MockOceanASeriesClientInterface)(nil).SetShareSquashConfig), ctx, accessID, vStoreID, config)
+}
+
 // SetSystemInfo mocks base method.
 func (m *MockOceanASeriesClientInterface) SetSystemInfo(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -304,6 +304,18 @@ func (mr *MockDMEASeriesClientInterfaceMockRecorder) Login(ctx any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockDMEASeriesClientInterface)(nil).Login), ctx)
 }
 
+// Close mocks base method.
+func (m *MockDMEASeriesClientInterface) Close(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close", ctx)
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockDMEASeriesClientInterfaceMockRecorder) Close(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDMEASeriesClientInterface)(nil).Close), ctx)
+}
+
 // Logout mocks base method.
 func (m *MockDMEASeriesClientInterface) Logout(ctx context.Context) {
 	m.ctrl.T.Helper()
@@ -1024,6 +1024,18 @@ func (mr *MockIRestClientMockRecorder) Login(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIRestClient)(nil).Login), ctx)
 }
 
+// Close mocks base method.
+func (m *MockIRestClient) Close(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close", ctx)
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIRestClientMockRecorder) Close(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIRestClient)(nil).Close), ctx)
+}
+
 // Logout mocks base method.
 func (m *MockIRestClient) Logout(ctx context.Context) {
 	m.ctrl.T.Helper()
@@ -29,6 +29,7 @@ import (
 	context "context"
 	http "net/http"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 
@@ -233,6 +234,19 @@ func (mr *MockOceandiskClientInterfaceMockRecorder) Call(ctx, method, url, data
 		reflect.TypeOf((*MockOceandiskClientInterface)(nil).Call), ctx, method, url, data)
 }
 
+// Close mocks base method.
+func (m *MockOceandiskClientInterface) Close(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close", ctx)
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockOceandiskClientInterfaceMockRecorder) Close(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close",
+		reflect.TypeOf((*MockOceandiskClientInterface)(nil).Close), ctx)
+}
+
 // CreateHost mocks base method.
 func (m *MockOceandiskClientInterface) CreateHost(ctx context.Context, name string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -758,6 +772,21 @@ func (mr *MockOceandiskClientInterfaceMockRecorder) GetIscsiTgtPort(ctx any) *go
 		reflect.TypeOf((*MockOceandiskClientInterface)(nil).GetIscsiTgtPort), ctx)
 }
 
+// GetLicenseExpiry mocks base method.
+func (m *MockOceandiskClientInterface) GetLicenseExpiry(ctx context.Context) (map[string]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLicenseExpiry", ctx)
+	ret0, _ := ret[0].(map[string]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLicenseExpiry indicates an expected call of GetLicenseExpiry.
+func (mr *MockOceandiskClientInterfaceMockRecorder) GetLicenseExpiry(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLicenseExpiry", reflect.TypeOf((*MockOceandiskClientInterface)(nil).GetLicenseExpiry), ctx)
+}
+
 // GetLicenseFeature mocks base method.
 func (m *MockOceandiskClientInterface) GetLicenseFeature(ctx context.Context) (map[string]int, error) {
 	m.ctrl.T.Helper()
@@ -1004,6 +1033,21 @@ func (mr *MockOceandiskClientInterfaceMockRecorder) GetRoCEPortalByIP(ctx, tgtPo
 		reflect.TypeOf((*MockOceandiskClientInterface)(nil).GetRoCEPortalByIP), ctx, tgtPortal)
 }
 
+// GetStorageTime mocks base method.
+func (m *MockOceandiskClientInterface) GetStorageTime(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageTime", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageTime indicates an expected call of GetStorageTime.
+func (mr *MockOceandiskClientInterfaceMockRecorder) GetStorageTime(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageTime", reflect.TypeOf((*MockOceandiskClientInterface)(nil).GetStorageTime), ctx)
+}
+
 // GetStorageVersion mocks base method.
 func (m *MockOceandiskClientInterface) GetStorageVersion() string {
 	m.ctrl.T.Helper()
@@ -1225,6 +1269,36 @@ func (mr *MockOceandiskClientInterfaceMockRecorder) RemoveNamespaceFromGroup(ctx
 		reflect.TypeOf((*MockOceandiskClientInterface)(nil).RemoveNamespaceFromGroup), ctx, namespaceID, groupID)
 }
 
+// ResizeNamespace mocks base method.
+func (m *MockOceandiskClientInterface) ResizeNamespace(ctx context.Context, namespaceID string,
+	newCapacity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResizeNamespace", ctx, namespaceID, newCapacity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResizeNamespace indicates an expected call of ResizeNamespace.
+func (mr *MockOceandiskClientInterfaceMockRecorder) ResizeNamespace(ctx, namespaceID, newCapacity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeNamespace",
+		reflect.TypeOf((*MockOceandiskClientInterface)(nil).ResizeNamespace), ctx, namespaceID, newCapacity)
+}
+
+// SetIscsiInitiatorChap mocks base method.
+func (m *MockOceandiskClientInterface) SetIscsiInitiatorChap(ctx context.Context, initiator, chapName, chapPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIscsiInitiatorChap", ctx, initiator, chapName, chapPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetIscsiInitiatorChap indicates an expected call of SetIscsiInitiatorChap.
+func (mr *MockOceandiskClientInterfaceMockRecorder) SetIscsiInitiatorChap(ctx, initiator, chapName, chapPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIscsiInitiatorChap", reflect.TypeOf((*MockOceandiskClientInterface)(nil).SetIscsiInitiatorChap), ctx, initiator, chapName, chapPassword)
+}
+
 // SetSystemInfo mocks base method.
 func (m *MockOceandiskClientInterface) SetSystemInfo(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -29,6 +29,7 @@ import (
 	context "context"
 	http "net/http"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 
@@ -216,6 +217,25 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) AllowNfsShareAccess(ctx, req
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllowNfsShareAccess", reflect.TypeOf((*MockOceanstorClientInterface)(nil).AllowNfsShareAccess), ctx, req)
 }
 
+// BackendNetworkReport mocks base method.
+func (m *MockOceanstorClientInterface) BackendNetworkReport(ctx context.Context, portals ...string) *client.NetworkReport {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range portals {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BackendNetworkReport", varargs...)
+	ret0, _ := ret[0].(*client.NetworkReport)
+	return ret0
+}
+
+// BackendNetworkReport indicates an expected call of BackendNetworkReport.
+func (mr *MockOceanstorClientInterfaceMockRecorder) BackendNetworkReport(ctx any, portals ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, portals...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackendNetworkReport", reflect.TypeOf((*MockOceanstorClientInterface)(nil).BackendNetworkReport), varargs...)
+}
+
 // BaseCall mocks base method.
 func (m *MockOceanstorClientInterface) BaseCall(ctx context.Context, method, url string, data map[string]any) (base.Response, error) {
 	m.ctrl.T.Helper()
@@ -261,6 +281,50 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) Call(ctx, method, url, data
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockOceanstorClientInterface)(nil).Call), ctx, method, url, data)
 }
 
+// CallStream mocks base method.
+func (m *MockOceanstorClientInterface) CallStream(ctx context.Context, method, url string, data map[string]any, handler client.StreamHandler) (base.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallStream", ctx, method, url, data, handler)
+	ret0, _ := ret[0].(base.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallStream indicates an expected call of CallStream.
+func (mr *MockOceanstorClientInterfaceMockRecorder) CallStream(ctx, method, url, data, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallStream", reflect.TypeOf((*MockOceanstorClientInterface)(nil).CallStream), ctx, method, url, data, handler)
+}
+
+// Canary mocks base method.
+func (m *MockOceanstorClientInterface) Canary(ctx context.Context, param *client.CanaryParam) *client.CanaryReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Canary", ctx, param)
+	ret0, _ := ret[0].(*client.CanaryReport)
+	return ret0
+}
+
+// Canary indicates an expected call of Canary.
+func (mr *MockOceanstorClientInterfaceMockRecorder) Canary(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Canary", reflect.TypeOf((*MockOceanstorClientInterface)(nil).Canary), ctx, param)
+}
+
+// CleanStaleSessions mocks base method.
+func (m *MockOceanstorClientInterface) CleanStaleSessions(ctx context.Context, param *client.StaleSessionParam) ([]*client.StorageSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanStaleSessions", ctx, param)
+	ret0, _ := ret[0].([]*client.StorageSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanStaleSessions indicates an expected call of CleanStaleSessions.
+func (mr *MockOceanstorClientInterfaceMockRecorder) CleanStaleSessions(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanStaleSessions", reflect.TypeOf((*MockOceanstorClientInterface)(nil).CleanStaleSessions), ctx, param)
+}
+
 // CloneFileSystem mocks base method.
 func (m *MockOceanstorClientInterface) CloneFileSystem(ctx context.Context, name string, allocType int, parentID, parentSnapshotID string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -276,6 +340,18 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) CloneFileSystem(ctx, name, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneFileSystem", reflect.TypeOf((*MockOceanstorClientInterface)(nil).CloneFileSystem), ctx, name, allocType, parentID, parentSnapshotID)
 }
 
+// Close mocks base method.
+func (m *MockOceanstorClientInterface) Close(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close", ctx)
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockOceanstorClientInterfaceMockRecorder) Close(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockOceanstorClientInterface)(nil).Close), ctx)
+}
+
 // CreateClonePair mocks base method.
 func (m *MockOceanstorClientInterface) CreateClonePair(ctx context.Context, srcLunID, dstLunID string, cloneSpeed int) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -306,6 +382,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) CreateDTree(ctx, params any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDTree", reflect.TypeOf((*MockOceanstorClientInterface)(nil).CreateDTree), ctx, params)
 }
 
+// CreateDTreeWithInheritedQuota mocks base method.
+func (m *MockOceanstorClientInterface) CreateDTreeWithInheritedQuota(ctx context.Context, parentFsID, name string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDTreeWithInheritedQuota", ctx, parentFsID, name)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDTreeWithInheritedQuota indicates an expected call of CreateDTreeWithInheritedQuota.
+func (mr *MockOceanstorClientInterfaceMockRecorder) CreateDTreeWithInheritedQuota(ctx, parentFsID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDTreeWithInheritedQuota", reflect.TypeOf((*MockOceanstorClientInterface)(nil).CreateDTreeWithInheritedQuota), ctx, parentFsID, name)
+}
+
 // CreateFSSnapshot mocks base method.
 func (m *MockOceanstorClientInterface) CreateFSSnapshot(ctx context.Context, name, parentID string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -321,6 +412,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) CreateFSSnapshot(ctx, name,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFSSnapshot", reflect.TypeOf((*MockOceanstorClientInterface)(nil).CreateFSSnapshot), ctx, name, parentID)
 }
 
+// CreateFSSnapshotConsistencyGroup mocks base method.
+func (m *MockOceanstorClientInterface) CreateFSSnapshotConsistencyGroup(ctx context.Context, fsIDs []string, name string) (*client.FSSnapshotConsistencyGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFSSnapshotConsistencyGroup", ctx, fsIDs, name)
+	ret0, _ := ret[0].(*client.FSSnapshotConsistencyGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFSSnapshotConsistencyGroup indicates an expected call of CreateFSSnapshotConsistencyGroup.
+func (mr *MockOceanstorClientInterfaceMockRecorder) CreateFSSnapshotConsistencyGroup(ctx, fsIDs, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFSSnapshotConsistencyGroup", reflect.TypeOf((*MockOceanstorClientInterface)(nil).CreateFSSnapshotConsistencyGroup), ctx, fsIDs, name)
+}
+
 // CreateFileSystem mocks base method.
 func (m *MockOceanstorClientInterface) CreateFileSystem(ctx context.Context, params map[string]any) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -615,6 +721,20 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) DeleteFSSnapshot(ctx, snapsh
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFSSnapshot", reflect.TypeOf((*MockOceanstorClientInterface)(nil).DeleteFSSnapshot), ctx, snapshotID)
 }
 
+// DeleteFSSnapshotConsistencyGroup mocks base method.
+func (m *MockOceanstorClientInterface) DeleteFSSnapshotConsistencyGroup(ctx context.Context, groupID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFSSnapshotConsistencyGroup", ctx, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFSSnapshotConsistencyGroup indicates an expected call of DeleteFSSnapshotConsistencyGroup.
+func (mr *MockOceanstorClientInterfaceMockRecorder) DeleteFSSnapshotConsistencyGroup(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFSSnapshotConsistencyGroup", reflect.TypeOf((*MockOceanstorClientInterface)(nil).DeleteFSSnapshotConsistencyGroup), ctx, groupID)
+}
+
 // DeleteFileSystem mocks base method.
 func (m *MockOceanstorClientInterface) DeleteFileSystem(ctx context.Context, params map[string]any) error {
 	m.ctrl.T.Helper()
@@ -671,6 +791,20 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) DeleteHyperMetroPair(ctx, pa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHyperMetroPair", reflect.TypeOf((*MockOceanstorClientInterface)(nil).DeleteHyperMetroPair), ctx, pairID, onlineDelete)
 }
 
+// DeleteIfExists mocks base method.
+func (m *MockOceanstorClientInterface) DeleteIfExists(ctx context.Context, url string, data map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIfExists", ctx, url, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIfExists indicates an expected call of DeleteIfExists.
+func (mr *MockOceanstorClientInterfaceMockRecorder) DeleteIfExists(ctx, url, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIfExists", reflect.TypeOf((*MockOceanstorClientInterface)(nil).DeleteIfExists), ctx, url, data)
+}
+
 // DeleteLun mocks base method.
 func (m *MockOceanstorClientInterface) DeleteLun(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
@@ -811,6 +945,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) DeleteReplicationPair(ctx, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReplicationPair", reflect.TypeOf((*MockOceanstorClientInterface)(nil).DeleteReplicationPair), ctx, pairID)
 }
 
+// DetectHyperMetroSplitBrain mocks base method.
+func (m *MockOceanstorClientInterface) DetectHyperMetroSplitBrain(ctx context.Context, pairID string) (*client.HyperMetroSplitBrainStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectHyperMetroSplitBrain", ctx, pairID)
+	ret0, _ := ret[0].(*client.HyperMetroSplitBrainStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectHyperMetroSplitBrain indicates an expected call of DetectHyperMetroSplitBrain.
+func (mr *MockOceanstorClientInterfaceMockRecorder) DetectHyperMetroSplitBrain(ctx, pairID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectHyperMetroSplitBrain", reflect.TypeOf((*MockOceanstorClientInterface)(nil).DetectHyperMetroSplitBrain), ctx, pairID)
+}
+
 // DuplicateClient mocks base method.
 func (m *MockOceanstorClientInterface) DuplicateClient() *client.OceanstorClient {
 	m.ctrl.T.Helper()
@@ -868,6 +1017,35 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) Get(ctx, url, data any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOceanstorClientInterface)(nil).Get), ctx, url, data)
 }
 
+// GetActiveAlarms mocks base method.
+func (m *MockOceanstorClientInterface) GetActiveAlarms(ctx context.Context) ([]client.AlarmInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveAlarms", ctx)
+	ret0, _ := ret[0].([]client.AlarmInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveAlarms indicates an expected call of GetActiveAlarms.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetActiveAlarms(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveAlarms", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetActiveAlarms), ctx)
+}
+
+// GetActiveURL mocks base method.
+func (m *MockOceanstorClientInterface) GetActiveURL() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveURL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetActiveURL indicates an expected call of GetActiveURL.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetActiveURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveURL", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetActiveURL))
+}
+
 // GetAllPools mocks base method.
 func (m *MockOceanstorClientInterface) GetAllPools(ctx context.Context) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -1328,6 +1506,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetIscsiTgtPort(ctx any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIscsiTgtPort", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetIscsiTgtPort), ctx)
 }
 
+// GetLicenseExpiry mocks base method.
+func (m *MockOceanstorClientInterface) GetLicenseExpiry(ctx context.Context) (map[string]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLicenseExpiry", ctx)
+	ret0, _ := ret[0].(map[string]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLicenseExpiry indicates an expected call of GetLicenseExpiry.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetLicenseExpiry(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLicenseExpiry", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetLicenseExpiry), ctx)
+}
+
 // GetLicenseFeature mocks base method.
 func (m *MockOceanstorClientInterface) GetLicenseFeature(ctx context.Context) (map[string]int, error) {
 	m.ctrl.T.Helper()
@@ -1388,6 +1581,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetLunByName(ctx, name any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLunByName", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetLunByName), ctx, name)
 }
 
+// GetLunByWWN mocks base method.
+func (m *MockOceanstorClientInterface) GetLunByWWN(ctx context.Context, wwn string) (*client.LunInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLunByWWN", ctx, wwn)
+	ret0, _ := ret[0].(*client.LunInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLunByWWN indicates an expected call of GetLunByWWN.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetLunByWWN(ctx, wwn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLunByWWN", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetLunByWWN), ctx, wwn)
+}
+
 // GetLunCopyByID mocks base method.
 func (m *MockOceanstorClientInterface) GetLunCopyByID(ctx context.Context, lunCopyID string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -1463,6 +1671,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetLunGroupByName(ctx, name
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLunGroupByName", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetLunGroupByName), ctx, name)
 }
 
+// GetLunOwnerController mocks base method.
+func (m *MockOceanstorClientInterface) GetLunOwnerController(ctx context.Context, lunID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLunOwnerController", ctx, lunID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLunOwnerController indicates an expected call of GetLunOwnerController.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetLunOwnerController(ctx, lunID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLunOwnerController", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetLunOwnerController), ctx, lunID)
+}
+
 // GetLunSnapshotByName mocks base method.
 func (m *MockOceanstorClientInterface) GetLunSnapshotByName(ctx context.Context, name string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -1478,6 +1701,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetLunSnapshotByName(ctx, na
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLunSnapshotByName", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetLunSnapshotByName), ctx, name)
 }
 
+// GetLunSnapshotCount mocks base method.
+func (m *MockOceanstorClientInterface) GetLunSnapshotCount(ctx context.Context, lunID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLunSnapshotCount", ctx, lunID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLunSnapshotCount indicates an expected call of GetLunSnapshotCount.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetLunSnapshotCount(ctx, lunID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLunSnapshotCount", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetLunSnapshotCount), ctx, lunID)
+}
+
 // GetMappingByName mocks base method.
 func (m *MockOceanstorClientInterface) GetMappingByName(ctx context.Context, name string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -1493,6 +1731,35 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetMappingByName(ctx, name a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMappingByName", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetMappingByName), ctx, name)
 }
 
+// GetMaxNameLength mocks base method.
+func (m *MockOceanstorClientInterface) GetMaxNameLength(objType string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxNameLength", objType)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMaxNameLength indicates an expected call of GetMaxNameLength.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetMaxNameLength(objType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxNameLength", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetMaxNameLength), objType)
+}
+
+// GetMaxSnapshotsPerVolume mocks base method.
+func (m *MockOceanstorClientInterface) GetMaxSnapshotsPerVolume(ctx context.Context) int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxSnapshotsPerVolume", ctx)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// GetMaxSnapshotsPerVolume indicates an expected call of GetMaxSnapshotsPerVolume.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetMaxSnapshotsPerVolume(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxSnapshotsPerVolume", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetMaxSnapshotsPerVolume), ctx)
+}
+
 // GetNFSServiceSetting mocks base method.
 func (m *MockOceanstorClientInterface) GetNFSServiceSetting(ctx context.Context) (map[string]bool, error) {
 	m.ctrl.T.Helper()
@@ -1673,6 +1940,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetReplicationPairByResID(ct
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationPairByResID", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetReplicationPairByResID), ctx, resID, resType)
 }
 
+// GetReplicationProgress mocks base method.
+func (m *MockOceanstorClientInterface) GetReplicationProgress(ctx context.Context, pairID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicationProgress", ctx, pairID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplicationProgress indicates an expected call of GetReplicationProgress.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetReplicationProgress(ctx, pairID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationProgress", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetReplicationProgress), ctx, pairID)
+}
+
 // GetReplicationvStorePairByvStore mocks base method.
 func (m *MockOceanstorClientInterface) GetReplicationvStorePairByvStore(ctx context.Context, vStoreID string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -1748,6 +2030,36 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetRoCEPortalByIP(ctx, tgtPo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoCEPortalByIP", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetRoCEPortalByIP), ctx, tgtPortal)
 }
 
+// GetShareSquashConfig mocks base method.
+func (m *MockOceanstorClientInterface) GetShareSquashConfig(ctx context.Context, accessID, vStoreID string) (*base.NfsShareSquashConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShareSquashConfig", ctx, accessID, vStoreID)
+	ret0, _ := ret[0].(*base.NfsShareSquashConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShareSquashConfig indicates an expected call of GetShareSquashConfig.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetShareSquashConfig(ctx, accessID, vStoreID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareSquashConfig", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetShareSquashConfig), ctx, accessID, vStoreID)
+}
+
+// GetStorageTime mocks base method.
+func (m *MockOceanstorClientInterface) GetStorageTime(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageTime", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageTime indicates an expected call of GetStorageTime.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetStorageTime(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageTime", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetStorageTime), ctx)
+}
+
 // GetStorageVersion mocks base method.
 func (m *MockOceanstorClientInterface) GetStorageVersion() string {
 	m.ctrl.T.Helper()
@@ -1762,6 +2074,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetStorageVersion() *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageVersion", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetStorageVersion))
 }
 
+// GetSupportedProtocols mocks base method.
+func (m *MockOceanstorClientInterface) GetSupportedProtocols(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupportedProtocols", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupportedProtocols indicates an expected call of GetSupportedProtocols.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetSupportedProtocols(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupportedProtocols", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetSupportedProtocols), ctx)
+}
+
 // GetSystem mocks base method.
 func (m *MockOceanstorClientInterface) GetSystem(ctx context.Context) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -1777,6 +2104,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetSystem(ctx any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystem", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetSystem), ctx)
 }
 
+// GetSystemInfo mocks base method.
+func (m *MockOceanstorClientInterface) GetSystemInfo(ctx context.Context) (*client.StorageSystemInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSystemInfo", ctx)
+	ret0, _ := ret[0].(*client.StorageSystemInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSystemInfo indicates an expected call of GetSystemInfo.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetSystemInfo(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemInfo", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetSystemInfo), ctx)
+}
+
 // GetSystemUTCTime mocks base method.
 func (m *MockOceanstorClientInterface) GetSystemUTCTime(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1792,6 +2134,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetSystemUTCTime(ctx any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemUTCTime", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetSystemUTCTime), ctx)
 }
 
+// GetTieringPolicy mocks base method.
+func (m *MockOceanstorClientInterface) GetTieringPolicy(ctx context.Context, fsID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTieringPolicy", ctx, fsID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTieringPolicy indicates an expected call of GetTieringPolicy.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetTieringPolicy(ctx, fsID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTieringPolicy", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetTieringPolicy), ctx, fsID)
+}
+
 // GetVStorePairs mocks base method.
 func (m *MockOceanstorClientInterface) GetVStorePairs(ctx context.Context) ([]any, error) {
 	m.ctrl.T.Helper()
@@ -1807,6 +2164,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetVStorePairs(ctx any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVStorePairs", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetVStorePairs), ctx)
 }
 
+// GetVStoreQuota mocks base method.
+func (m *MockOceanstorClientInterface) GetVStoreQuota(ctx context.Context, vStoreID string) (*client.VStoreQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVStoreQuota", ctx, vStoreID)
+	ret0, _ := ret[0].(*client.VStoreQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVStoreQuota indicates an expected call of GetVStoreQuota.
+func (mr *MockOceanstorClientInterfaceMockRecorder) GetVStoreQuota(ctx, vStoreID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVStoreQuota", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetVStoreQuota), ctx, vStoreID)
+}
+
 // GetvStoreByName mocks base method.
 func (m *MockOceanstorClientInterface) GetvStoreByName(ctx context.Context, name string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -1865,6 +2237,78 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) GetvStorePairByID(ctx, pairI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetvStorePairByID", reflect.TypeOf((*MockOceanstorClientInterface)(nil).GetvStorePairByID), ctx, pairID)
 }
 
+// InvalidatePoolCache mocks base method.
+func (m *MockOceanstorClientInterface) InvalidatePoolCache() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InvalidatePoolCache")
+}
+
+// InvalidatePoolCache indicates an expected call of InvalidatePoolCache.
+func (mr *MockOceanstorClientInterfaceMockRecorder) InvalidatePoolCache() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidatePoolCache", reflect.TypeOf((*MockOceanstorClientInterface)(nil).InvalidatePoolCache))
+}
+
+// IsTokenValid mocks base method.
+func (m *MockOceanstorClientInterface) IsTokenValid(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenValid", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenValid indicates an expected call of IsTokenValid.
+func (mr *MockOceanstorClientInterfaceMockRecorder) IsTokenValid(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenValid", reflect.TypeOf((*MockOceanstorClientInterface)(nil).IsTokenValid), ctx)
+}
+
+// IsVolumeMapped mocks base method.
+func (m *MockOceanstorClientInterface) IsVolumeMapped(ctx context.Context, volumeID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsVolumeMapped", ctx, volumeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsVolumeMapped indicates an expected call of IsVolumeMapped.
+func (mr *MockOceanstorClientInterfaceMockRecorder) IsVolumeMapped(ctx, volumeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsVolumeMapped", reflect.TypeOf((*MockOceanstorClientInterface)(nil).IsVolumeMapped), ctx, volumeID)
+}
+
+// ListFilesystemsByPrefix mocks base method.
+func (m *MockOceanstorClientInterface) ListFilesystemsByPrefix(ctx context.Context, prefix string) ([]client.FilesystemInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFilesystemsByPrefix", ctx, prefix)
+	ret0, _ := ret[0].([]client.FilesystemInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFilesystemsByPrefix indicates an expected call of ListFilesystemsByPrefix.
+func (mr *MockOceanstorClientInterfaceMockRecorder) ListFilesystemsByPrefix(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFilesystemsByPrefix", reflect.TypeOf((*MockOceanstorClientInterface)(nil).ListFilesystemsByPrefix), ctx, prefix)
+}
+
+// ListSessions mocks base method.
+func (m *MockOceanstorClientInterface) ListSessions(ctx context.Context) ([]*client.StorageSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx)
+	ret0, _ := ret[0].([]*client.StorageSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockOceanstorClientInterfaceMockRecorder) ListSessions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockOceanstorClientInterface)(nil).ListSessions), ctx)
+}
+
 // Login mocks base method.
 func (m *MockOceanstorClientInterface) Login(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -2036,6 +2480,34 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) RemoveLunFromGroup(ctx, lunI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLunFromGroup", reflect.TypeOf((*MockOceanstorClientInterface)(nil).RemoveLunFromGroup), ctx, lunID, groupID)
 }
 
+// RenameFilesystem mocks base method.
+func (m *MockOceanstorClientInterface) RenameFilesystem(ctx context.Context, id, newName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameFilesystem", ctx, id, newName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameFilesystem indicates an expected call of RenameFilesystem.
+func (mr *MockOceanstorClientInterfaceMockRecorder) RenameFilesystem(ctx, id, newName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameFilesystem", reflect.TypeOf((*MockOceanstorClientInterface)(nil).RenameFilesystem), ctx, id, newName)
+}
+
+// RenameLun mocks base method.
+func (m *MockOceanstorClientInterface) RenameLun(ctx context.Context, id, newName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameLun", ctx, id, newName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameLun indicates an expected call of RenameLun.
+func (mr *MockOceanstorClientInterfaceMockRecorder) RenameLun(ctx, id, newName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameLun", reflect.TypeOf((*MockOceanstorClientInterface)(nil).RenameLun), ctx, id, newName)
+}
+
 // SafeBaseCall mocks base method.
 func (m *MockOceanstorClientInterface) SafeBaseCall(ctx context.Context, method, url string, data map[string]any) (base.Response, error) {
 	m.ctrl.T.Helper()
@@ -2109,6 +2581,48 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) SafeDeleteNfsShare(ctx, id,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SafeDeleteNfsShare", reflect.TypeOf((*MockOceanstorClientInterface)(nil).SafeDeleteNfsShare), ctx, id, vStoreID)
 }
 
+// SetIscsiInitiatorChap mocks base method.
+func (m *MockOceanstorClientInterface) SetIscsiInitiatorChap(ctx context.Context, initiator, chapName, chapPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIscsiInitiatorChap", ctx, initiator, chapName, chapPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetIscsiInitiatorChap indicates an expected call of SetIscsiInitiatorChap.
+func (mr *MockOceanstorClientInterfaceMockRecorder) SetIscsiInitiatorChap(ctx, initiator, chapName, chapPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIscsiInitiatorChap", reflect.TypeOf((*MockOceanstorClientInterface)(nil).SetIscsiInitiatorChap), ctx, initiator, chapName, chapPassword)
+}
+
+// SetLunOwnerController mocks base method.
+func (m *MockOceanstorClientInterface) SetLunOwnerController(ctx context.Context, lunID, controllerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLunOwnerController", ctx, lunID, controllerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLunOwnerController indicates an expected call of SetLunOwnerController.
+func (mr *MockOceanstorClientInterfaceMockRecorder) SetLunOwnerController(ctx, lunID, controllerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLunOwnerController", reflect.TypeOf((*MockOceanstorClientInterface)(nil).SetLunOwnerController), ctx, lunID, controllerID)
+}
+
+// SetShareSquashConfig mocks base method.
+func (m *MockOceanstorClientInterface) SetShareSquashConfig(ctx context.Context, accessID, vStoreID string, config *base.NfsShareSquashConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetShareSquashConfig", ctx, accessID, vStoreID, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetShareSquashConfig indicates an expected call of SetShareSquashConfig.
+func (mr *MockOceanstorClientInterfaceMockRecorder) SetShareSquashConfig(ctx, accessID, vStoreID, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShareSquashConfig", reflect.TypeOf((*MockOceanstorClientInterface)(nil).SetShareSquashConfig), ctx, accessID, vStoreID, config)
+}
+
 // SetSystemInfo mocks base method.
 func (m *MockOceanstorClientInterface) SetSystemInfo(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -2123,6 +2637,20 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) SetSystemInfo(ctx any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSystemInfo", reflect.TypeOf((*MockOceanstorClientInterface)(nil).SetSystemInfo), ctx)
 }
 
+// SetTieringPolicy mocks base method.
+func (m *MockOceanstorClientInterface) SetTieringPolicy(ctx context.Context, fsID, policy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTieringPolicy", ctx, fsID, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTieringPolicy indicates an expected call of SetTieringPolicy.
+func (mr *MockOceanstorClientInterfaceMockRecorder) SetTieringPolicy(ctx, fsID, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTieringPolicy", reflect.TypeOf((*MockOceanstorClientInterface)(nil).SetTieringPolicy), ctx, fsID, policy)
+}
+
 // SplitCloneFS mocks base method.
 func (m *MockOceanstorClientInterface) SplitCloneFS(ctx context.Context, fsID, vStoreId string, splitSpeed int, isDeleteParentSnapshot bool) error {
 	m.ctrl.T.Helper()
@@ -2207,6 +2735,21 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) StopLunCopy(ctx, lunCopyID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopLunCopy", reflect.TypeOf((*MockOceanstorClientInterface)(nil).StopLunCopy), ctx, lunCopyID)
 }
 
+// SwitchVStore mocks base method.
+func (m *MockOceanstorClientInterface) SwitchVStore(ctx context.Context, vStoreName string) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwitchVStore", ctx, vStoreName)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwitchVStore indicates an expected call of SwitchVStore.
+func (mr *MockOceanstorClientInterfaceMockRecorder) SwitchVStore(ctx, vStoreName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwitchVStore", reflect.TypeOf((*MockOceanstorClientInterface)(nil).SwitchVStore), ctx, vStoreName)
+}
+
 // SyncClonePair mocks base method.
 func (m *MockOceanstorClientInterface) SyncClonePair(ctx context.Context, clonePairID string) error {
 	m.ctrl.T.Helper()
@@ -2360,3 +2903,31 @@ func (mr *MockOceanstorClientInterfaceMockRecorder) ValidateLogin(ctx any) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateLogin", reflect.TypeOf((*MockOceanstorClientInterface)(nil).ValidateLogin), ctx)
 }
+
+// WaitForFilesystemDeleted mocks base method.
+func (m *MockOceanstorClientInterface) WaitForFilesystemDeleted(ctx context.Context, fsID string, timeout time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForFilesystemDeleted", ctx, fsID, timeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForFilesystemDeleted indicates an expected call of WaitForFilesystemDeleted.
+func (mr *MockOceanstorClientInterfaceMockRecorder) WaitForFilesystemDeleted(ctx, fsID, timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForFilesystemDeleted", reflect.TypeOf((*MockOceanstorClientInterface)(nil).WaitForFilesystemDeleted), ctx, fsID, timeout)
+}
+
+// WaitReplicationPairState mocks base method.
+func (m *MockOceanstorClientInterface) WaitReplicationPairState(ctx context.Context, pairID, targetStatus string, timeout time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitReplicationPairState", ctx, pairID, targetStatus, timeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitReplicationPairState indicates an expected call of WaitReplicationPairState.
+func (mr *MockOceanstorClientInterfaceMockRecorder) WaitReplicationPairState(ctx, pairID, targetStatus, timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitReplicationPairState", reflect.TypeOf((*MockOceanstorClientInterface)(nil).WaitReplicationPairState), ctx, pairID, targetStatus, timeout)
+}
@@ -16,6 +16,15 @@
 
 package utils
 
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrSemaphoreAcquireCanceled is returned when the context is done before a permit is acquired
+var ErrSemaphoreAcquireCanceled = errors.New("semaphore acquire canceled")
+
 type Semaphore struct {
 	permits int
 	channel chan int
@@ -32,6 +41,21 @@ func (s *Semaphore) Acquire() {
 	s.channel <- 0
 }
 
+// AcquireCtx acquires a permit like Acquire, but gives up when the ctx is canceled or its deadline is exceeded,
+// the returned error wraps both ErrSemaphoreAcquireCanceled and the error of the ctx
+func (s *Semaphore) AcquireCtx(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrSemaphoreAcquireCanceled, err)
+	}
+
+	select {
+	case s.channel <- 0:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", ErrSemaphoreAcquireCanceled, ctx.Err())
+	}
+}
+
 func (s *Semaphore) Release() {
 	<-s.channel
 }
@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemaphore_AcquireCtx_Success(t *testing.T) {
+	// arrange
+	sem := NewSemaphore(1)
+
+	// act
+	err := sem.AcquireCtx(context.Background())
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, 0, sem.AvailablePermits())
+	sem.Release()
+	require.Equal(t, 1, sem.AvailablePermits())
+}
+
+func TestSemaphore_AcquireCtx_DeadlineExceeded(t *testing.T) {
+	// arrange
+	sem := NewSemaphore(1)
+	sem.Acquire()
+	defer sem.Release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// act
+	err := sem.AcquireCtx(ctx)
+
+	// assert
+	require.ErrorIs(t, err, ErrSemaphoreAcquireCanceled)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 0, sem.AvailablePermits())
+}
+
+func TestSemaphore_AcquireCtx_CanceledWhileWaiting(t *testing.T) {
+	// arrange
+	sem := NewSemaphore(1)
+	sem.Acquire()
+	defer sem.Release()
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+
+	// act
+	go func() { result <- sem.AcquireCtx(ctx) }()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	// assert
+	select {
+	case err := <-result:
+		require.True(t, errors.Is(err, ErrSemaphoreAcquireCanceled))
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("AcquireCtx is not released after the context is canceled")
+	}
+}
+
+func TestSemaphore_AcquireCtx_CanceledWithFreePermit(t *testing.T) {
+	// arrange
+	sem := NewSemaphore(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// act
+	err := sem.AcquireCtx(ctx)
+
+	// assert
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, sem.AvailablePermits())
+}
@@ -325,28 +325,55 @@ func (logger *loggerImpl) AddContext(ctx context.Context) Logger {
 func EnsureGRPCContext(ctx context.Context, req interface{},
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler) (interface{}, error) {
-	var requestID string
-	md, ok := metadata.FromIncomingContext(ctx)
 	// if no metadata, generate one
+	if _, ok := metadata.FromIncomingContext(ctx); !ok {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs())
+	}
+
+	return handler(EnsureRequestID(ctx), req)
+}
+
+// EnsureRequestID returns the ctx carrying a request id to correlate the logs of an operation,
+// the request id in the ctx or in the incoming metadata of the ctx is reused, otherwise a new one is generated
+func EnsureRequestID(ctx context.Context) context.Context {
+	if id, ok := ctx.Value(CsiRequestID).(string); ok && id != "" {
+		return ctx
+	}
+
+	if id := requestIDFromMetadata(ctx); id != "" {
+		return context.WithValue(ctx, CsiRequestID, id)
+	}
+
+	id, err := newRequestID()
+	if err != nil {
+		Errorf("Failed in random ID generation for request ID logging: %v", err)
+		return ctx
+	}
+
+	return context.WithValue(ctx, CsiRequestID, id)
+}
+
+// requestIDFromMetadata returns the request id in the incoming metadata of the ctx, empty if it is not set
+func requestIDFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		md = metadata.Pairs()
-		ctx = metadata.NewIncomingContext(ctx, md)
+		return ""
 	}
 
-	if reqIDs, ok := md[string(CsiRequestID)]; ok && len(reqIDs) > 0 {
-		requestID = reqIDs[0]
+	if reqIDs := md[string(CsiRequestID)]; len(reqIDs) > 0 {
+		return reqIDs[0]
 	}
 
-	if requestID == "" {
-		randomID, err := rand.Prime(rand.Reader, 32)
-		if err != nil {
-			Errorf("Failed in random ID generation for GRPC request ID logging: %v", err)
-			return handler(ctx, req)
-		}
-		requestID = randomID.String()
+	return ""
+}
+
+func newRequestID() (string, error) {
+	randomID, err := rand.Prime(rand.Reader, 32)
+	if err != nil {
+		return "", err
 	}
 
-	return handler(context.WithValue(ctx, CsiRequestID, requestID), req)
+	return randomID.String(), nil
 }
 
 // Flush ensures to commit current content of logging stream
@@ -374,25 +401,19 @@ func FilteredLog(ctx context.Context, isSkip, isDebug bool, msg string) {
 
 // SetRequestInfo used to set the context with value
 func SetRequestInfo(ctx context.Context) (context.Context, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
 	// if no metadata, generate one
-	if !ok {
-		md = metadata.Pairs()
-		ctx = metadata.NewIncomingContext(ctx, md)
-	}
-
-	var requestID string
-	if reqIDs, ok := md[string(CsiRequestID)]; ok && len(reqIDs) > 0 {
-		requestID = reqIDs[0]
+	if _, ok := metadata.FromIncomingContext(ctx); !ok {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs())
 	}
 
+	requestID := requestIDFromMetadata(ctx)
 	if requestID == "" {
-		randomID, err := rand.Prime(rand.Reader, 32)
+		var err error
+		requestID, err = newRequestID()
 		if err != nil {
 			Errorf("Failed in random ID generation for GRPC request ID logging: %v", err)
 			return ctx, err
 		}
-		requestID = randomID.String()
 	}
 	return context.WithValue(ctx, CsiRequestID, requestID), nil
 }
@@ -0,0 +1,64 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestEnsureRequestID(t *testing.T) {
+	// arrange
+	withID := context.WithValue(context.Background(), CsiRequestID, "1001")
+	withMetadata := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(string(CsiRequestID), "1002"))
+
+	// action
+	gotWithID := EnsureRequestID(withID)
+	gotWithMetadata := EnsureRequestID(withMetadata)
+	generated := EnsureRequestID(context.Background())
+
+	// assert
+	require.Equal(t, "1001", gotWithID.Value(CsiRequestID))
+	require.Equal(t, "1002", gotWithMetadata.Value(CsiRequestID))
+	require.NotEmpty(t, generated.Value(CsiRequestID))
+	require.Equal(t, generated, EnsureRequestID(generated))
+}
+
+func TestEnsureGRPCContext(t *testing.T) {
+	// arrange
+	withMetadata := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(string(CsiRequestID), "1003"))
+	var gotIDs []interface{}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		gotIDs = append(gotIDs, ctx.Value(CsiRequestID))
+		return nil, nil
+	}
+
+	// action
+	_, metadataErr := EnsureGRPCContext(withMetadata, nil, nil, handler)
+	_, generatedErr := EnsureGRPCContext(context.Background(), nil, nil, handler)
+
+	// assert
+	require.NoError(t, metadataErr)
+	require.NoError(t, generatedErr)
+	require.Equal(t, "1003", gotIDs[0])
+	require.NotEmpty(t, gotIDs[1])
+}
@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrPollTimeout means the condition is not satisfied before the timeout of polling
+	ErrPollTimeout = errors.New("poll timeout")
+	// ErrPollCondition means the condition returns an error, the polling is stopped
+	ErrPollCondition = errors.New("poll condition failed")
+)
+
+// PollUntil calls cond until it returns true, an error, the timeout elapses or ctx is done.
+// The interval between the calls starts from initialInterval and doubles up to maxInterval.
+// A non-positive timeout means the polling is only stopped by cond or ctx.
+// The error returned by cond is wrapped with ErrPollCondition, the timeout is reported as ErrPollTimeout
+// and the cancellation of ctx is reported as the error of ctx.
+func PollUntil(ctx context.Context, initialInterval, maxInterval, timeout time.Duration,
+	cond func() (bool, error)) error {
+	if initialInterval <= 0 || maxInterval < initialInterval {
+		return fmt.Errorf("invalid poll intervals, initial: %v, max: %v", initialInterval, maxInterval)
+	}
+
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	interval := initialInterval
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("poll canceled: %w", err)
+		}
+
+		done, err := cond()
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrPollCondition, err)
+		}
+		if done {
+			return nil
+		}
+
+		wait := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			wait.Stop()
+			return fmt.Errorf("poll canceled: %w", ctx.Err())
+		case <-deadline:
+			wait.Stop()
+			return fmt.Errorf("%w after %v", ErrPollTimeout, timeout)
+		case <-wait.C:
+		}
+
+		interval = min(interval*2, maxInterval)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollUntil_Done(t *testing.T) {
+	// arrange
+	var calls int
+	var lastCall time.Time
+	var intervals []time.Duration
+	cond := func() (bool, error) {
+		if calls > 0 {
+			intervals = append(intervals, time.Since(lastCall))
+		}
+		lastCall = time.Now()
+		calls++
+		return calls == 4, nil
+	}
+
+	// action
+	err := PollUntil(context.Background(), time.Millisecond, 3*time.Millisecond, time.Minute, cond)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, 4, calls)
+	require.GreaterOrEqual(t, intervals[1], 2*time.Millisecond)
+	require.GreaterOrEqual(t, intervals[2], 3*time.Millisecond)
+}
+
+func TestPollUntil_Timeout(t *testing.T) {
+	// action
+	err := PollUntil(context.Background(), time.Millisecond, time.Millisecond, 10*time.Millisecond,
+		func() (bool, error) { return false, nil })
+
+	// assert
+	require.ErrorIs(t, err, ErrPollTimeout)
+}
+
+func TestPollUntil_ConditionError(t *testing.T) {
+	// arrange
+	condErr := errors.New("condition error")
+
+	// action
+	err := PollUntil(context.Background(), time.Millisecond, time.Millisecond, time.Minute,
+		func() (bool, error) { return false, condErr })
+
+	// assert
+	require.ErrorIs(t, err, ErrPollCondition)
+	require.ErrorIs(t, err, condErr)
+	require.NotErrorIs(t, err, ErrPollTimeout)
+}
+
+func TestPollUntil_Canceled(t *testing.T) {
+	// arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int
+
+	// action
+	err := PollUntil(ctx, time.Hour, time.Hour, 0, func() (bool, error) {
+		calls++
+		cancel()
+		return false, nil
+	})
+
+	// assert
+	require.ErrorIs(t, err, context.Canceled)
+	require.NotErrorIs(t, err, ErrPollTimeout)
+	require.Equal(t, 1, calls)
+}
+
+func TestPollUntil_InvalidInterval(t *testing.T) {
+	// action
+	err := PollUntil(context.Background(), time.Second, time.Millisecond, 0,
+		func() (bool, error) { return true, nil })
+
+	// assert
+	require.ErrorContains(t, err, "invalid poll intervals")
+}
+
+func TestWaitUntil_Errors(t *testing.T) {
+	// arrange
+	condErr := errors.New("mock condition error")
+
+	// action
+	timeoutErr := WaitUntil(func() (bool, error) { return false, nil }, 10*time.Millisecond, time.Millisecond)
+	gotCondErr := WaitUntil(func() (bool, error) { return false, condErr }, time.Minute, time.Millisecond)
+	doneErr := WaitUntil(func() (bool, error) { return true, nil }, time.Minute, time.Millisecond)
+
+	// assert
+	require.EqualError(t, timeoutErr, "Wait timeout")
+	require.Equal(t, condErr, gotCondErr)
+	require.NoError(t, doneErr)
+}
@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// RetryBudget is a token bucket over retries. Each retry consumes a token and the tokens are refilled
+// at a fixed interval, so a persistently failing backend stops retrying once the budget is depleted.
+type RetryBudget struct {
+	capacity       int
+	refillInterval time.Duration
+	tokens         int
+	lastRefill     time.Time
+	mutex          sync.Mutex
+	now            func() time.Time
+}
+
+// NewRetryBudget returns a retry budget that allows capacity retries in a burst and refills one token
+// every refillInterval, the budget is disabled if the capacity or the refillInterval is not positive
+func NewRetryBudget(capacity int, refillInterval time.Duration) *RetryBudget {
+	return &RetryBudget{
+		capacity:       capacity,
+		refillInterval: refillInterval,
+		tokens:         capacity,
+		lastRefill:     time.Now(),
+		now:            time.Now,
+	}
+}
+
+// TryAcquire consumes a token of the budget, false is returned if the budget is depleted
+func (b *RetryBudget) TryAcquire() bool {
+	if b.disabled() {
+		return true
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.refill()
+	if b.tokens <= 0 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// Available returns the available tokens of the budget, -1 means the budget is disabled
+func (b *RetryBudget) Available() int {
+	if b.disabled() {
+		return -1
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.refill()
+	return b.tokens
+}
+
+func (b *RetryBudget) disabled() bool {
+	return b == nil || b.capacity <= 0 || b.refillInterval <= 0
+}
+
+func (b *RetryBudget) refill() {
+	now := b.now()
+	refilled := int(now.Sub(b.lastRefill) / b.refillInterval)
+	if refilled <= 0 {
+		return
+	}
+
+	b.tokens += refilled
+	if b.tokens >= b.capacity {
+		b.tokens = b.capacity
+		b.lastRefill = now
+		return
+	}
+
+	b.lastRefill = b.lastRefill.Add(time.Duration(refilled) * b.refillInterval)
+}
@@ -0,0 +1,78 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryBudget_DepleteAndRefill(t *testing.T) {
+	// arrange
+	now := time.Now()
+	budget := NewRetryBudget(3, time.Second)
+	budget.now = func() time.Time { return now }
+	budget.lastRefill = now
+
+	// action
+	var acquired []bool
+	for i := 0; i < 5; i++ {
+		acquired = append(acquired, budget.TryAcquire())
+	}
+
+	// assert
+	require.Equal(t, []bool{true, true, true, false, false}, acquired)
+	require.Equal(t, 0, budget.Available())
+
+	// action
+	now = now.Add(1500 * time.Millisecond)
+
+	// assert
+	require.Equal(t, 1, budget.Available())
+	require.True(t, budget.TryAcquire())
+	require.False(t, budget.TryAcquire())
+
+	// action
+	now = now.Add(500 * time.Millisecond)
+
+	// assert
+	require.Equal(t, 1, budget.Available())
+
+	// action
+	now = now.Add(time.Hour)
+
+	// assert
+	require.Equal(t, 3, budget.Available())
+}
+
+func TestRetryBudget_Disabled(t *testing.T) {
+	// arrange
+	var nilBudget *RetryBudget
+	budgets := []*RetryBudget{nilBudget, NewRetryBudget(0, time.Second), NewRetryBudget(1, 0)}
+
+	for _, budget := range budgets {
+		// action
+		for i := 0; i < 3; i++ {
+			require.True(t, budget.TryAcquire())
+		}
+
+		// assert
+		require.Equal(t, -1, budget.Available())
+	}
+}
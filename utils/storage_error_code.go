@@ -0,0 +1,48 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import "fmt"
+
+// storageErrorCode is the human readable name and description of a storage error code
+type storageErrorCode struct {
+	name        string
+	description string
+}
+
+// storageErrorCodes are the well-known error codes of the storage which are handled specially by the plugin,
+// they are registered by the storage clients defining them
+var storageErrorCodes = map[int64]storageErrorCode{}
+
+// RegisterErrorCodes registers the name and description of the well-known error codes to annotate them in the logs,
+// it is not safe for concurrent use and must be called in the init of the package defining the codes
+func RegisterErrorCodes(name, description string, codes ...int64) {
+	for _, code := range codes {
+		storageErrorCodes[code] = storageErrorCode{name: name, description: description}
+	}
+}
+
+// FormatErrorCode returns the error code annotated with its name and description if it is a well-known one,
+// e.g. "1077949061 (WrongPassword: the user name or password is incorrect)", otherwise only the number is returned
+func FormatErrorCode(code int64) string {
+	errorCode, ok := storageErrorCodes[code]
+	if !ok {
+		return fmt.Sprintf("%d", code)
+	}
+
+	return fmt.Sprintf("%d (%s: %s)", code, errorCode.name, errorCode.description)
+}
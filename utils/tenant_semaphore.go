@@ -0,0 +1,152 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"sync"
+)
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx with the tenant label, the label is used to isolate the request concurrency
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	if tenant == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// GetTenant returns the tenant label of ctx, empty string will be returned if the ctx is not tagged
+func GetTenant(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// TenantSemaphore limits the concurrency of each tenant, so that a single tenant can not occupy all the
+// permits of the shared semaphore. It should be acquired before the shared semaphore.
+// The semaphore of a tenant is evicted once none of its requests is running or waiting,
+// so the number of the kept semaphores is bounded by the concurrent requests instead of the tenants ever seen.
+type TenantSemaphore struct {
+	permits    int
+	mutex      sync.Mutex
+	semaphores map[string]*tenantSemaphoreEntry
+}
+
+// tenantSemaphoreEntry is the semaphore of a tenant with the number of its requests holding or waiting for a permit
+type tenantSemaphoreEntry struct {
+	sem  *Semaphore
+	refs int
+}
+
+// NewTenantSemaphore returns a semaphore that allows permits concurrent requests of each tenant,
+// the limit is disabled if the permits is not positive
+func NewTenantSemaphore(permits int) *TenantSemaphore {
+	return &TenantSemaphore{
+		permits:    permits,
+		semaphores: make(map[string]*tenantSemaphoreEntry),
+	}
+}
+
+// Acquire acquires a permit of the tenant, it does nothing if the tenant is empty or the limit is disabled
+func (s *TenantSemaphore) Acquire(tenant string) {
+	// the background ctx is never done, so the error is always nil
+	_ = s.AcquireCtx(context.Background(), tenant)
+}
+
+// AcquireCtx acquires a permit of the tenant like Acquire, but gives up when the ctx is done
+func (s *TenantSemaphore) AcquireCtx(ctx context.Context, tenant string) error {
+	if s.disabled(tenant) {
+		return nil
+	}
+
+	s.mutex.Lock()
+	entry, ok := s.semaphores[tenant]
+	if !ok {
+		entry = &tenantSemaphoreEntry{sem: NewSemaphore(s.permits)}
+		s.semaphores[tenant] = entry
+	}
+	entry.refs++
+	s.mutex.Unlock()
+
+	if err := entry.sem.AcquireCtx(ctx); err != nil {
+		s.unref(tenant, entry)
+		return err
+	}
+
+	return nil
+}
+
+// Release releases a permit of the tenant
+func (s *TenantSemaphore) Release(tenant string) {
+	if s.disabled(tenant) {
+		return
+	}
+
+	s.mutex.Lock()
+	entry, ok := s.semaphores[tenant]
+	s.mutex.Unlock()
+	if !ok {
+		return
+	}
+
+	entry.sem.Release()
+	s.unref(tenant, entry)
+}
+
+// Permits returns the permits of each tenant, 0 means the limit is disabled
+func (s *TenantSemaphore) Permits() int {
+	if s == nil || s.permits <= 0 {
+		return 0
+	}
+
+	return s.permits
+}
+
+// AvailablePermits returns the available permits of the tenant
+func (s *TenantSemaphore) AvailablePermits(tenant string) int {
+	if s.disabled(tenant) {
+		return s.Permits()
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if entry, ok := s.semaphores[tenant]; ok {
+		return entry.sem.AvailablePermits()
+	}
+
+	return s.permits
+}
+
+func (s *TenantSemaphore) disabled(tenant string) bool {
+	return s == nil || s.permits <= 0 || tenant == ""
+}
+
+// unref drops a reference of the entry and evicts it if none of the requests of the tenant is running or waiting
+func (s *TenantSemaphore) unref(tenant string, entry *tenantSemaphoreEntry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	entry.refs--
+	if entry.refs <= 0 && s.semaphores[tenant] == entry {
+		delete(s.semaphores, tenant)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantSemaphore_LimitEachTenantUnderContention(t *testing.T) {
+	// arrange
+	tenantPermits := 2
+	requests := 10
+	shared := NewSemaphore(4)
+	tenantSem := NewTenantSemaphore(tenantPermits)
+	var running, maxRunning sync.Map
+	for _, tenant := range []string{"tenant-a", "tenant-b"} {
+		running.Store(tenant, new(int32))
+		maxRunning.Store(tenant, new(int32))
+	}
+
+	call := func(ctx context.Context) {
+		tenant := GetTenant(ctx)
+		tenantSem.Acquire(tenant)
+		defer tenantSem.Release(tenant)
+		shared.Acquire()
+		defer shared.Release()
+
+		counter, _ := running.Load(tenant)
+		maxCounter, _ := maxRunning.Load(tenant)
+		current := atomic.AddInt32(counter.(*int32), 1)
+		for {
+			old := atomic.LoadInt32(maxCounter.(*int32))
+			if current <= old || atomic.CompareAndSwapInt32(maxCounter.(*int32), old, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(counter.(*int32), -1)
+	}
+
+	// action
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		for _, tenant := range []string{"tenant-a", "tenant-b"} {
+			wg.Add(1)
+			go func(ctx context.Context) {
+				defer wg.Done()
+				call(ctx)
+			}(WithTenant(context.Background(), tenant))
+		}
+	}
+	wg.Wait()
+
+	// assert
+	for _, tenant := range []string{"tenant-a", "tenant-b"} {
+		maxCounter, _ := maxRunning.Load(tenant)
+		require.LessOrEqual(t, atomic.LoadInt32(maxCounter.(*int32)), int32(tenantPermits))
+		require.Equal(t, tenantPermits, tenantSem.AvailablePermits(tenant))
+	}
+}
+
+func TestTenantSemaphore_OtherTenantNotBlocked(t *testing.T) {
+	// arrange
+	tenantSem := NewTenantSemaphore(1)
+	tenantSem.Acquire("tenant-a")
+	defer tenantSem.Release("tenant-a")
+	done := make(chan struct{})
+
+	// action
+	go func() {
+		tenantSem.Acquire("tenant-b")
+		tenantSem.Release("tenant-b")
+		close(done)
+	}()
+
+	// assert
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("tenant-b is blocked by tenant-a")
+	}
+	require.Equal(t, 0, tenantSem.AvailablePermits("tenant-a"))
+}
+
+func TestTenantSemaphore_Disabled(t *testing.T) {
+	// arrange
+	tenantSem := NewTenantSemaphore(0)
+
+	// action
+	tenantSem.Acquire("tenant-a")
+	tenantSem.Acquire("tenant-a")
+	tenantSem.Acquire("")
+
+	// assert
+	require.Equal(t, "", GetTenant(WithTenant(context.Background(), "")))
+	require.Equal(t, 0, tenantSem.AvailablePermits("tenant-a"))
+}
+
+func TestTenantSemaphore_EvictIdleTenant(t *testing.T) {
+	// arrange
+	tenantSem := NewTenantSemaphore(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// action
+	tenantSem.Acquire("tenant-a")
+	heldLen := len(tenantSem.semaphores)
+	waitErr := tenantSem.AcquireCtx(ctx, "tenant-a")
+	tenantSem.Release("tenant-a")
+	for i := 0; i < 100; i++ {
+		tenantSem.Acquire(fmt.Sprintf("tenant-%d", i))
+		tenantSem.Release(fmt.Sprintf("tenant-%d", i))
+	}
+
+	// assert
+	require.Equal(t, 1, heldLen)
+	require.ErrorIs(t, waitErr, context.DeadlineExceeded)
+	require.Empty(t, tenantSem.semaphores)
+	require.Equal(t, 1, tenantSem.AvailablePermits("tenant-a"))
+}
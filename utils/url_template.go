@@ -0,0 +1,96 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	urlTemplateIDPlaceholder = "{id}"
+	// a segment longer than this and containing digits is considered as an object id, such as device sn
+	urlTemplateMinIDLength = 8
+)
+
+// URLTemplateRule defines how to templatize the restful url to avoid the cardinality of metrics and logs
+type URLTemplateRule struct {
+	keptQueryParams map[string]bool
+}
+
+// DefaultURLTemplateRule keeps the query parameters which change the semantic of the request,
+// all the other query parameters such as filter=ID::xx are stripped.
+var DefaultURLTemplateRule = NewURLTemplateRule("REPTYPE", "RUNNINGSTATUS")
+
+// NewURLTemplateRule returns a rule which keeps the given query parameters in the templated url
+func NewURLTemplateRule(keptQueryParams ...string) *URLTemplateRule {
+	rule := &URLTemplateRule{keptQueryParams: make(map[string]bool, len(keptQueryParams))}
+	for _, param := range keptQueryParams {
+		rule.keptQueryParams[param] = true
+	}
+
+	return rule
+}
+
+// Template returns the templated url, the id segments of the path are replaced by "{id}",
+// and only the kept query parameters remain.
+// Example:
+//
+//	/lun/12?filter=ID::12    -> /lun/{id}
+//	/vstore_pair?REPTYPE=1   -> /vstore_pair?REPTYPE=1
+func (r *URLTemplateRule) Template(url string) string {
+	path, query, _ := strings.Cut(url, "?")
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if isURLIDSegment(segment) {
+			segments[i] = urlTemplateIDPlaceholder
+		}
+	}
+
+	templated := strings.Join(segments, "/")
+	var keptParams []string
+	for _, param := range strings.Split(query, "&") {
+		key, _, _ := strings.Cut(param, "=")
+		if key != "" && r.keptQueryParams[key] {
+			keptParams = append(keptParams, param)
+		}
+	}
+
+	if len(keptParams) == 0 {
+		return templated
+	}
+
+	return templated + "?" + strings.Join(keptParams, "&")
+}
+
+func isURLIDSegment(segment string) bool {
+	if segment == "" {
+		return false
+	}
+
+	var hasDigit, allDigits = false, true
+	for _, c := range segment {
+		if unicode.IsDigit(c) {
+			hasDigit = true
+		} else {
+			allDigits = false
+		}
+	}
+
+	return allDigits || (hasDigit && len(segment) >= urlTemplateMinIDLength)
+}
@@ -0,0 +1,51 @@
+/*
+ *  Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLTemplateRule_Template(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *URLTemplateRule
+		url  string
+		want string
+	}{
+		{"KeepREPTYPE", DefaultURLTemplateRule, "/vstore_pair?REPTYPE=1", "/vstore_pair?REPTYPE=1"},
+		{"StripIDFilter", DefaultURLTemplateRule, "/vstore_pair?filter=ID::3", "/vstore_pair"},
+		{"StripFilterKeepREPTYPE", DefaultURLTemplateRule, "/vstore_pair?filter=ID::3&REPTYPE=1",
+			"/vstore_pair?REPTYPE=1"},
+		{"ReplaceIDSegment", DefaultURLTemplateRule, "/HyperMetroPair/12", "/HyperMetroPair/{id}"},
+		{"ReplaceDeviceSN", DefaultURLTemplateRule, "/2102351NPT10J3000001/lun?filter=NAME::pvc-1",
+			"/{id}/lun"},
+		{"KeepActionSegment", DefaultURLTemplateRule, "/HyperMetroPair/synchronize_hcpair",
+			"/HyperMetroPair/synchronize_hcpair"},
+		{"KeepVersionSegment", DefaultURLTemplateRule, "/api/v2/pool", "/api/v2/pool"},
+		{"CustomRule", NewURLTemplateRule("filter"), "/lun?filter=NAME::pvc-1&range=[0-100]",
+			"/lun?filter=NAME::pvc-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.rule.Template(tt.url))
+		})
+	}
+}
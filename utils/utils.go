@@ -19,7 +19,6 @@ package utils
 
 import (
 	"context"
-	crand "crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -53,6 +52,11 @@ const (
 	DoradoV6Prefix    = "V600"
 	OceanStorV5Prefix = "V500"
 
+	// ProgressMin is the minimum progress percentage of the storage task
+	ProgressMin = 0
+	// ProgressMax is the maximum progress percentage of the storage task
+	ProgressMax = 100
+
 	longTimeout = 60
 )
 
@@ -332,39 +336,23 @@ func MergeMap(args ...map[string]interface{}) map[string]interface{} {
 }
 
 // WaitUntil executes the func until timeout, it also breaks while the func return true or an err.
+// The func is polled by PollUntil with a fixed interval, the error of the func is returned as it is.
 func WaitUntil(f func() (bool, error), timeout time.Duration, interval time.Duration) error {
-	done := make(chan error)
-	defer close(done)
-
-	go func() {
-		timeout := time.After(timeout)
-
-		for {
-			condition, err := f()
-			if err != nil {
-				done <- err
-				return
-			}
-
-			if condition {
-				done <- nil
-				return
-			}
-
-			select {
-			case <-timeout:
-				done <- fmt.Errorf("Wait timeout")
-				return
-			default:
-				time.Sleep(interval)
-			}
-		}
-	}()
+	var condErr error
+	err := PollUntil(context.Background(), interval, interval, timeout, func() (bool, error) {
+		var done bool
+		done, condErr = f()
+		return done, condErr
+	})
+	if condErr != nil {
+		return condErr
+	}
 
-	select {
-	case err := <-done:
-		return err
+	if errors.Is(err, ErrPollTimeout) {
+		return errors.New("Wait timeout")
 	}
+
+	return err
 }
 
 func RandomInt(n int) int {
@@ -461,6 +449,27 @@ func RoundUpSize(volumeSizeBytes int64, allocationUnitBytes int64) int64 {
 	return roundedUp
 }
 
+// GetExpandCapacity rounds up both the current and the requested size to the same allocation unit and
+// returns the smallest valid size to expand to. needExpand is false if the requested size is not larger than
+// the current size but rounds to the same size, and an error is returned only on a genuine shrink.
+func GetExpandCapacity(curSize, newSize, allocationUnit int64) (int64, bool, error) {
+	if allocationUnit <= 0 {
+		return 0, false, fmt.Errorf("allocation unit %d must be greater than 0", allocationUnit)
+	}
+
+	roundedCurSize := RoundUpSize(curSize, allocationUnit) * allocationUnit
+	roundedNewSize := RoundUpSize(newSize, allocationUnit) * allocationUnit
+	if roundedNewSize < roundedCurSize {
+		return 0, false, fmt.Errorf("newSize %d must be greater than or equal to curSize %d", newSize, curSize)
+	}
+
+	if newSize <= curSize {
+		return curSize, false, nil
+	}
+
+	return roundedNewSize, true, nil
+}
+
 // TransK8SCapacity trans volume size from Sector to Bytes
 func TransK8SCapacity(volumeSizeSectors, allocationUnitBytes int64) int64 {
 	return volumeSizeSectors * allocationUnitBytes
@@ -942,30 +951,7 @@ func AtoiWithDefault(s string, defaultResult int) int {
 
 // NewContextWithRequestID new a context
 func NewContextWithRequestID() context.Context {
-	ctx := context.Background()
-
-	var requestID string
-	md, ok := metadata.FromIncomingContext(ctx)
-	// if no metadata, generate one
-	if !ok {
-		md = metadata.Pairs()
-		ctx = metadata.NewIncomingContext(ctx, md)
-	}
-
-	if reqIDs, ok := md[string(log.CsiRequestID)]; ok && len(reqIDs) > 0 {
-		requestID = reqIDs[0]
-	}
-
-	if requestID == "" {
-		randomID, err := crand.Prime(crand.Reader, 32)
-		if err != nil {
-			log.Errorf("Failed in random ID generation for topo request ID logging: %v", err)
-			return ctx
-		}
-		requestID = randomID.String()
-	}
-
-	return context.WithValue(ctx, log.CsiRequestID, requestID)
+	return log.EnsureRequestID(metadata.NewIncomingContext(context.Background(), metadata.Pairs()))
 }
 
 // Contains sources contains target
@@ -1007,28 +993,56 @@ func RemoveString(slice []string, s string) []string {
 
 // FormatRespErr formats resp error and returns code and msg.
 func FormatRespErr(respErr map[string]interface{}) (int64, string, error) {
-	code, ok := respErr["code"]
-	if !ok {
-		return 0, "", fmt.Errorf("can not get code from respErr %v", respErr)
+	storageErr, err := ParseRespErr(respErr)
+	if err != nil {
+		return 0, "", err
 	}
 
-	floatCode, ok := code.(float64)
-	if !ok {
-		return 0, "", fmt.Errorf("can not convert resp code %v to float64", code)
+	return storageErr.Code, storageErr.Description, nil
+}
+
+// StorageError is the error in the response of the storage, the suggestion and the detail are the
+// human guidance returned by the storage, they are empty if the storage does not return them
+type StorageError struct {
+	Code        int64
+	Description string
+	Suggestion  string
+	Detail      string
+}
+
+// Error returns the code and the description, the suggestion and the detail are appended if they exist,
+// the well-known code is annotated with its name
+func (e *StorageError) Error() string {
+	msg := fmt.Sprintf("error code %s: [%s]", FormatErrorCode(e.Code), e.Description)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(", suggestion: [%s]", e.Suggestion)
+	}
+
+	if e.Detail != "" {
+		msg += fmt.Sprintf(", detail: [%s]", e.Detail)
 	}
 
-	intCode := int64(floatCode)
-	msg, ok := respErr["description"]
+	return msg
+}
+
+// ParseRespErr parses resp error to StorageError, the fields other than the code are optional
+func ParseRespErr(respErr map[string]interface{}) (*StorageError, error) {
+	code, ok := respErr["code"]
 	if !ok {
-		return intCode, "", nil
+		return nil, fmt.Errorf("can not get code from respErr %v", respErr)
 	}
 
-	strMsg, ok := msg.(string)
+	floatCode, ok := code.(float64)
 	if !ok {
-		return intCode, "", nil
+		return nil, fmt.Errorf("can not convert resp code %v to float64", code)
 	}
 
-	return intCode, strMsg, nil
+	return &StorageError{
+		Code:        int64(floatCode),
+		Description: GetValueOrFallback(respErr, "description", ""),
+		Suggestion:  GetValueOrFallback(respErr, "suggestion", ""),
+		Detail:      GetValueOrFallback(respErr, "detail", ""),
+	}, nil
 }
 
 // GetValueOrFallback returns the value of the given key
@@ -1062,6 +1076,21 @@ func GetValue[T any](m map[string]any, k string) (T, bool) {
 	return val, true
 }
 
+// ParseProgress parses the progress percentage returned by the storage,
+// the value is limited between ProgressMin and ProgressMax
+func ParseProgress(progress any) int {
+	progressStr, ok := progress.(string)
+	if !ok {
+		return ProgressMin
+	}
+
+	value, err := strconv.Atoi(progressStr)
+	if err != nil || value < ProgressMin {
+		return ProgressMin
+	}
+	return min(value, ProgressMax)
+}
+
 // zeroValue returns zero value of the given type.
 func zeroValue[T any]() T {
 	var zero T
@@ -325,3 +325,99 @@ func TestIsNil(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExpandCapacity(t *testing.T) {
+	// arrange
+	tests := []struct {
+		name           string
+		curSize        int64
+		newSize        int64
+		wantSize       int64
+		wantNeedExpand bool
+		wantErr        bool
+	}{
+		{name: "expand to rounded size", curSize: 2048, newSize: 2049, wantSize: 4096, wantNeedExpand: true},
+		{name: "same size", curSize: 2048, newSize: 2048, wantSize: 2048, wantNeedExpand: false},
+		{name: "rounds to current size", curSize: 4000, newSize: 3000, wantSize: 4000, wantNeedExpand: false},
+		{name: "genuine shrink", curSize: 4096, newSize: 2048, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// action
+			size, needExpand, err := GetExpandCapacity(tt.curSize, tt.newSize, 2048)
+
+			// assert
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantSize, size)
+			assert.Equal(t, tt.wantNeedExpand, needExpand)
+		})
+	}
+}
+
+func TestParseRespErr(t *testing.T) {
+	// arrange
+	RegisterErrorCodes("WrongPassword", "the user name or password is incorrect", 1077949061)
+	tests := []struct {
+		name    string
+		respErr map[string]interface{}
+		want    *StorageError
+		wantMsg string
+		wantErr bool
+	}{
+		{name: "code only", respErr: map[string]interface{}{"code": float64(1077949001)},
+			want: &StorageError{Code: 1077949001}, wantMsg: "error code 1077949001: []"},
+		{name: "with suggestion and detail", respErr: map[string]interface{}{"code": float64(1077949001),
+			"description": "mock description", "suggestion": "mock suggestion", "detail": "mock detail"},
+			want: &StorageError{Code: 1077949001, Description: "mock description", Suggestion: "mock suggestion",
+				Detail: "mock detail"},
+			wantMsg: "error code 1077949001: [mock description], suggestion: [mock suggestion], detail: [mock detail]"},
+		{name: "well-known code", respErr: map[string]interface{}{"code": float64(1077949061),
+			"description": "mock description"},
+			want: &StorageError{Code: 1077949061, Description: "mock description"},
+			wantMsg: "error code 1077949061 (WrongPassword: the user name or password is incorrect): " +
+				"[mock description]"},
+		{name: "code not exist", respErr: map[string]interface{}{"description": "mock description"},
+			wantErr: true},
+		{name: "code is not number", respErr: map[string]interface{}{"code": "0"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			got, err := ParseRespErr(tt.respErr)
+
+			// assert
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantMsg, got.Error())
+		})
+	}
+}
+
+func TestParseProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress any
+		want     int
+	}{
+		{name: "in range", progress: "42", want: 42},
+		{name: "above max", progress: "120", want: ProgressMax},
+		{name: "below min", progress: "-1", want: ProgressMin},
+		{name: "not number", progress: "abc", want: ProgressMin},
+		{name: "not string", progress: 42, want: ProgressMin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			got := ParseProgress(tt.progress)
+
+			// assert
+			require.Equal(t, tt.want, got)
+		})
+	}
+}